@@ -14,6 +14,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -24,8 +25,14 @@ const (
 )
 
 type ShortenURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Optional password protecting the shortened URL.
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Optional limit of redirects, zero means unlimited.
+	MaxClicks int64 `protobuf:"varint,3,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`
+	// Optional activation time, the shortened URL is not redirected before it.
+	ActiveFrom    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -67,6 +74,27 @@ func (x *ShortenURLRequest) GetUrl() string {
 	return ""
 }
 
+func (x *ShortenURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ShortenURLRequest) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
+func (x *ShortenURLRequest) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
 type ShortenURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
@@ -112,8 +140,10 @@ func (x *ShortenURLResponse) GetSlug() string {
 }
 
 type GetOriginalURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Slug  string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Password of a password protected URL.
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -155,6 +185,13 @@ func (x *GetOriginalURLRequest) GetSlug() string {
 	return ""
 }
 
+func (x *GetOriginalURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 type GetOriginalURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -199,24 +236,692 @@ func (x *GetOriginalURLResponse) GetUrl() string {
 	return ""
 }
 
+// ScheduleURLRequest replaces the activation window of a shortened URL owned by the caller.
+// Unset times stand for immediate activation and no expiration.
+type ScheduleURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	ActiveFrom    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleURLRequest) Reset() {
+	*x = ScheduleURLRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleURLRequest) ProtoMessage() {}
+
+func (x *ScheduleURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleURLRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleURLRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScheduleURLRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ScheduleURLRequest) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
+func (x *ScheduleURLRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ScheduleURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleURLResponse) Reset() {
+	*x = ScheduleURLResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleURLResponse) ProtoMessage() {}
+
+func (x *ScheduleURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleURLResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleURLResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{5}
+}
+
+// GetStatsRequest requests the service statistics, it is only served to peers of trusted subnets.
+// Unset days and top users stand for the configured defaults.
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	TopUsers      int32                  `protobuf:"varint,2,opt,name=top_users,json=topUsers,proto3" json:"top_users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStatsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetStatsRequest) GetTopUsers() int32 {
+	if x != nil {
+		return x.TopUsers
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Urls         int64                  `protobuf:"varint,1,opt,name=urls,proto3" json:"urls,omitempty"`
+	Users        int64                  `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+	Active       int64                  `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Deleted      int64                  `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Expired      int64                  `protobuf:"varint,5,opt,name=expired,proto3" json:"expired,omitempty"`
+	StorageBytes int64                  `protobuf:"varint,6,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes,omitempty"`
+	// URLs created per day of the last days, oldest first.
+	Daily []*DailyStats `protobuf:"bytes,7,rep,name=daily,proto3" json:"daily,omitempty"`
+	// Users with most URLs, most first.
+	TopUsers      []*UserStats `protobuf:"bytes,8,rep,name=top_users,json=topUsers,proto3" json:"top_users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetStatsResponse) GetUrls() int64 {
+	if x != nil {
+		return x.Urls
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetActive() int64 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetExpired() int64 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetDaily() []*DailyStats {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetTopUsers() []*UserStats {
+	if x != nil {
+		return x.TopUsers
+	}
+	return nil
+}
+
+type DailyStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The day in YYYY-MM-DD format.
+	Date          string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Urls          int64  `protobuf:"varint,2,opt,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyStats) Reset() {
+	*x = DailyStats{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyStats) ProtoMessage() {}
+
+func (x *DailyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyStats.ProtoReflect.Descriptor instead.
+func (*DailyStats) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DailyStats) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyStats) GetUrls() int64 {
+	if x != nil {
+		return x.Urls
+	}
+	return 0
+}
+
+type UserStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Urls          int64                  `protobuf:"varint,2,opt,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStats) Reset() {
+	*x = UserStats{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStats) ProtoMessage() {}
+
+func (x *UserStats) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStats.ProtoReflect.Descriptor instead.
+func (*UserStats) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UserStats) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserStats) GetUrls() int64 {
+	if x != nil {
+		return x.Urls
+	}
+	return 0
+}
+
+// ShortenURLStreamRequest is a URL to shorten within a stream, identified by the correlation ID.
+type ShortenURLStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShortenURLStreamRequest) Reset() {
+	*x = ShortenURLStreamRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShortenURLStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenURLStreamRequest) ProtoMessage() {}
+
+func (x *ShortenURLStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortenURLStreamRequest.ProtoReflect.Descriptor instead.
+func (*ShortenURLStreamRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ShortenURLStreamRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ShortenURLStreamRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// ShortenURLStreamResponse lists the shortened URLs in the order of the stream.
+type ShortenURLStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*CorrelatedURL       `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShortenURLStreamResponse) Reset() {
+	*x = ShortenURLStreamResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShortenURLStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenURLStreamResponse) ProtoMessage() {}
+
+func (x *ShortenURLStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortenURLStreamResponse.ProtoReflect.Descriptor instead.
+func (*ShortenURLStreamResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ShortenURLStreamResponse) GetUrls() []*CorrelatedURL {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+type CorrelatedURL struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorrelatedURL) Reset() {
+	*x = CorrelatedURL{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrelatedURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrelatedURL) ProtoMessage() {}
+
+func (x *CorrelatedURL) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrelatedURL.ProtoReflect.Descriptor instead.
+func (*CorrelatedURL) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CorrelatedURL) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *CorrelatedURL) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+type ListUserURLsStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserURLsStreamRequest) Reset() {
+	*x = ListUserURLsStreamRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserURLsStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserURLsStreamRequest) ProtoMessage() {}
+
+func (x *ListUserURLsStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserURLsStreamRequest.ProtoReflect.Descriptor instead.
+func (*ListUserURLsStreamRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{13}
+}
+
+type ListUserURLsStreamResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Clicks      int64                  `protobuf:"varint,3,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	// The redirects limit, zero means unlimited.
+	MaxClicks     int64 `protobuf:"varint,4,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserURLsStreamResponse) Reset() {
+	*x = ListUserURLsStreamResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserURLsStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserURLsStreamResponse) ProtoMessage() {}
+
+func (x *ListUserURLsStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserURLsStreamResponse.ProtoReflect.Descriptor instead.
+func (*ListUserURLsStreamResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListUserURLsStreamResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ListUserURLsStreamResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ListUserURLsStreamResponse) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *ListUserURLsStreamResponse) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
 var File_shortener_v1_shortener_proto protoreflect.FileDescriptor
 
 const file_shortener_v1_shortener_proto_rawDesc = "" +
 	"\n" +
-	"\x1cshortener/v1/shortener.proto\x12\fshortener.v1\x1a\x1bbuf/validate/validate.proto\"2\n" +
+	"\x1cshortener/v1/shortener.proto\x12\fshortener.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbc\x01\n" +
 	"\x11ShortenURLRequest\x12\x1d\n" +
-	"\x03url\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x88\x01\x01R\x03url\"(\n" +
+	"\x03url\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x88\x01\x01R\x03url\x12#\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xbaH\x04r\x02(HR\bpassword\x12&\n" +
+	"\n" +
+	"max_clicks\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\tmaxClicks\x12;\n" +
+	"\vactive_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\"(\n" +
 	"\x12ShortenURLResponse\x12\x12\n" +
-	"\x04slug\x18\x01 \x01(\tR\x04slug\"7\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"S\n" +
 	"\x15GetOriginalURLRequest\x12\x1e\n" +
 	"\x04slug\x18\x01 \x01(\tB\n" +
-	"\xbaH\a\xc8\x01\x01r\x02\x10\x06R\x04slug\"*\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x10\x06R\x04slug\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"*\n" +
 	"\x16GetOriginalURLResponse\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url2\xc3\x01\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"\xac\x01\n" +
+	"\x12ScheduleURLRequest\x12\x1e\n" +
+	"\x04slug\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x10\x06R\x04slug\x12;\n" +
+	"\vactive_from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x15\n" +
+	"\x13ScheduleURLResponse\"Y\n" +
+	"\x0fGetStatsRequest\x12\x1e\n" +
+	"\x04days\x18\x01 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xee\x02(\x00R\x04days\x12&\n" +
+	"\ttop_users\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\btopUsers\"\x93\x02\n" +
+	"\x10GetStatsResponse\x12\x12\n" +
+	"\x04urls\x18\x01 \x01(\x03R\x04urls\x12\x14\n" +
+	"\x05users\x18\x02 \x01(\x03R\x05users\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\x03R\x06active\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\x03R\adeleted\x12\x18\n" +
+	"\aexpired\x18\x05 \x01(\x03R\aexpired\x12#\n" +
+	"\rstorage_bytes\x18\x06 \x01(\x03R\fstorageBytes\x12.\n" +
+	"\x05daily\x18\a \x03(\v2\x18.shortener.v1.DailyStatsR\x05daily\x124\n" +
+	"\ttop_users\x18\b \x03(\v2\x17.shortener.v1.UserStatsR\btopUsers\"4\n" +
+	"\n" +
+	"DailyStats\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x12\n" +
+	"\x04urls\x18\x02 \x01(\x03R\x04urls\"8\n" +
+	"\tUserStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04urls\x18\x02 \x01(\x03R\x04urls\"k\n" +
+	"\x17ShortenURLStreamRequest\x121\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x18@R\rcorrelationId\x12\x1d\n" +
+	"\x03url\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x88\x01\x01R\x03url\"K\n" +
+	"\x18ShortenURLStreamResponse\x12/\n" +
+	"\x04urls\x18\x01 \x03(\v2\x1b.shortener.v1.CorrelatedURLR\x04urls\"S\n" +
+	"\rCorrelatedURL\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"\x1b\n" +
+	"\x19ListUserURLsStreamRequest\"\x93\x01\n" +
+	"\x1aListUserURLsStreamResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\x04 \x01(\x03R\tmaxClicks2\xb2\x04\n" +
 	"\x13URLShortenerService\x12O\n" +
 	"\n" +
 	"ShortenURL\x12\x1f.shortener.v1.ShortenURLRequest\x1a .shortener.v1.ShortenURLResponse\x12[\n" +
-	"\x0eGetOriginalURL\x12#.shortener.v1.GetOriginalURLRequest\x1a$.shortener.v1.GetOriginalURLResponseB\xa4\x01\n" +
+	"\x0eGetOriginalURL\x12#.shortener.v1.GetOriginalURLRequest\x1a$.shortener.v1.GetOriginalURLResponse\x12R\n" +
+	"\vScheduleURL\x12 .shortener.v1.ScheduleURLRequest\x1a!.shortener.v1.ScheduleURLResponse\x12I\n" +
+	"\bGetStats\x12\x1d.shortener.v1.GetStatsRequest\x1a\x1e.shortener.v1.GetStatsResponse\x12c\n" +
+	"\x10ShortenURLStream\x12%.shortener.v1.ShortenURLStreamRequest\x1a&.shortener.v1.ShortenURLStreamResponse(\x01\x12i\n" +
+	"\x12ListUserURLsStream\x12'.shortener.v1.ListUserURLsStreamRequest\x1a(.shortener.v1.ListUserURLsStreamResponse0\x01B\xa4\x01\n" +
 	"\x10com.shortener.v1B\x0eShortenerProtoP\x01Z/github.com/patraden/ya-practicum-go-shortly/api\xa2\x02\x03SXX\xaa\x02\fShortener.V1\xca\x02\fShortener\\V1\xe2\x02\x18Shortener\\V1\\GPBMetadata\xea\x02\rShortener::V1b\x06proto3"
 
 var (
@@ -231,23 +936,49 @@ func file_shortener_v1_shortener_proto_rawDescGZIP() []byte {
 	return file_shortener_v1_shortener_proto_rawDescData
 }
 
-var file_shortener_v1_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_shortener_v1_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_shortener_v1_shortener_proto_goTypes = []any{
-	(*ShortenURLRequest)(nil),      // 0: shortener.v1.ShortenURLRequest
-	(*ShortenURLResponse)(nil),     // 1: shortener.v1.ShortenURLResponse
-	(*GetOriginalURLRequest)(nil),  // 2: shortener.v1.GetOriginalURLRequest
-	(*GetOriginalURLResponse)(nil), // 3: shortener.v1.GetOriginalURLResponse
+	(*ShortenURLRequest)(nil),          // 0: shortener.v1.ShortenURLRequest
+	(*ShortenURLResponse)(nil),         // 1: shortener.v1.ShortenURLResponse
+	(*GetOriginalURLRequest)(nil),      // 2: shortener.v1.GetOriginalURLRequest
+	(*GetOriginalURLResponse)(nil),     // 3: shortener.v1.GetOriginalURLResponse
+	(*ScheduleURLRequest)(nil),         // 4: shortener.v1.ScheduleURLRequest
+	(*ScheduleURLResponse)(nil),        // 5: shortener.v1.ScheduleURLResponse
+	(*GetStatsRequest)(nil),            // 6: shortener.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),           // 7: shortener.v1.GetStatsResponse
+	(*DailyStats)(nil),                 // 8: shortener.v1.DailyStats
+	(*UserStats)(nil),                  // 9: shortener.v1.UserStats
+	(*ShortenURLStreamRequest)(nil),    // 10: shortener.v1.ShortenURLStreamRequest
+	(*ShortenURLStreamResponse)(nil),   // 11: shortener.v1.ShortenURLStreamResponse
+	(*CorrelatedURL)(nil),              // 12: shortener.v1.CorrelatedURL
+	(*ListUserURLsStreamRequest)(nil),  // 13: shortener.v1.ListUserURLsStreamRequest
+	(*ListUserURLsStreamResponse)(nil), // 14: shortener.v1.ListUserURLsStreamResponse
+	(*timestamppb.Timestamp)(nil),      // 15: google.protobuf.Timestamp
 }
 var file_shortener_v1_shortener_proto_depIdxs = []int32{
-	0, // 0: shortener.v1.URLShortenerService.ShortenURL:input_type -> shortener.v1.ShortenURLRequest
-	2, // 1: shortener.v1.URLShortenerService.GetOriginalURL:input_type -> shortener.v1.GetOriginalURLRequest
-	1, // 2: shortener.v1.URLShortenerService.ShortenURL:output_type -> shortener.v1.ShortenURLResponse
-	3, // 3: shortener.v1.URLShortenerService.GetOriginalURL:output_type -> shortener.v1.GetOriginalURLResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	15, // 0: shortener.v1.ShortenURLRequest.active_from:type_name -> google.protobuf.Timestamp
+	15, // 1: shortener.v1.ScheduleURLRequest.active_from:type_name -> google.protobuf.Timestamp
+	15, // 2: shortener.v1.ScheduleURLRequest.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: shortener.v1.GetStatsResponse.daily:type_name -> shortener.v1.DailyStats
+	9,  // 4: shortener.v1.GetStatsResponse.top_users:type_name -> shortener.v1.UserStats
+	12, // 5: shortener.v1.ShortenURLStreamResponse.urls:type_name -> shortener.v1.CorrelatedURL
+	0,  // 6: shortener.v1.URLShortenerService.ShortenURL:input_type -> shortener.v1.ShortenURLRequest
+	2,  // 7: shortener.v1.URLShortenerService.GetOriginalURL:input_type -> shortener.v1.GetOriginalURLRequest
+	4,  // 8: shortener.v1.URLShortenerService.ScheduleURL:input_type -> shortener.v1.ScheduleURLRequest
+	6,  // 9: shortener.v1.URLShortenerService.GetStats:input_type -> shortener.v1.GetStatsRequest
+	10, // 10: shortener.v1.URLShortenerService.ShortenURLStream:input_type -> shortener.v1.ShortenURLStreamRequest
+	13, // 11: shortener.v1.URLShortenerService.ListUserURLsStream:input_type -> shortener.v1.ListUserURLsStreamRequest
+	1,  // 12: shortener.v1.URLShortenerService.ShortenURL:output_type -> shortener.v1.ShortenURLResponse
+	3,  // 13: shortener.v1.URLShortenerService.GetOriginalURL:output_type -> shortener.v1.GetOriginalURLResponse
+	5,  // 14: shortener.v1.URLShortenerService.ScheduleURL:output_type -> shortener.v1.ScheduleURLResponse
+	7,  // 15: shortener.v1.URLShortenerService.GetStats:output_type -> shortener.v1.GetStatsResponse
+	11, // 16: shortener.v1.URLShortenerService.ShortenURLStream:output_type -> shortener.v1.ShortenURLStreamResponse
+	14, // 17: shortener.v1.URLShortenerService.ListUserURLsStream:output_type -> shortener.v1.ListUserURLsStreamResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_shortener_v1_shortener_proto_init() }
@@ -261,7 +992,7 @@ func file_shortener_v1_shortener_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shortener_v1_shortener_proto_rawDesc), len(file_shortener_v1_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
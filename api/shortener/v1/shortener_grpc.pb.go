@@ -20,8 +20,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	URLShortenerService_ShortenURL_FullMethodName     = "/shortener.v1.URLShortenerService/ShortenURL"
-	URLShortenerService_GetOriginalURL_FullMethodName = "/shortener.v1.URLShortenerService/GetOriginalURL"
+	URLShortenerService_ShortenURL_FullMethodName         = "/shortener.v1.URLShortenerService/ShortenURL"
+	URLShortenerService_GetOriginalURL_FullMethodName     = "/shortener.v1.URLShortenerService/GetOriginalURL"
+	URLShortenerService_ScheduleURL_FullMethodName        = "/shortener.v1.URLShortenerService/ScheduleURL"
+	URLShortenerService_GetStats_FullMethodName           = "/shortener.v1.URLShortenerService/GetStats"
+	URLShortenerService_ShortenURLStream_FullMethodName   = "/shortener.v1.URLShortenerService/ShortenURLStream"
+	URLShortenerService_ListUserURLsStream_FullMethodName = "/shortener.v1.URLShortenerService/ListUserURLsStream"
 )
 
 // URLShortenerServiceClient is the client API for URLShortenerService service.
@@ -30,6 +34,14 @@ const (
 type URLShortenerServiceClient interface {
 	ShortenURL(ctx context.Context, in *ShortenURLRequest, opts ...grpc.CallOption) (*ShortenURLResponse, error)
 	GetOriginalURL(ctx context.Context, in *GetOriginalURLRequest, opts ...grpc.CallOption) (*GetOriginalURLResponse, error)
+	ScheduleURL(ctx context.Context, in *ScheduleURLRequest, opts ...grpc.CallOption) (*ScheduleURLResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// ShortenURLStream shortens the streamed URLs in chunks as they are received,
+	// the shortened URLs are returned once the client closes the stream.
+	// Streams exceeding the URLs limit fail with the URLs shortened so far in the error details.
+	ShortenURLStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ShortenURLStreamRequest, ShortenURLStreamResponse], error)
+	// ListUserURLsStream streams the URLs shortened by the caller.
+	ListUserURLsStream(ctx context.Context, in *ListUserURLsStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListUserURLsStreamResponse], error)
 }
 
 type uRLShortenerServiceClient struct {
@@ -60,12 +72,72 @@ func (c *uRLShortenerServiceClient) GetOriginalURL(ctx context.Context, in *GetO
 	return out, nil
 }
 
+func (c *uRLShortenerServiceClient) ScheduleURL(ctx context.Context, in *ScheduleURLRequest, opts ...grpc.CallOption) (*ScheduleURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleURLResponse)
+	err := c.cc.Invoke(ctx, URLShortenerService_ScheduleURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLShortenerServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, URLShortenerService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLShortenerServiceClient) ShortenURLStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ShortenURLStreamRequest, ShortenURLStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &URLShortenerService_ServiceDesc.Streams[0], URLShortenerService_ShortenURLStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ShortenURLStreamRequest, ShortenURLStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type URLShortenerService_ShortenURLStreamClient = grpc.ClientStreamingClient[ShortenURLStreamRequest, ShortenURLStreamResponse]
+
+func (c *uRLShortenerServiceClient) ListUserURLsStream(ctx context.Context, in *ListUserURLsStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListUserURLsStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &URLShortenerService_ServiceDesc.Streams[1], URLShortenerService_ListUserURLsStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListUserURLsStreamRequest, ListUserURLsStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type URLShortenerService_ListUserURLsStreamClient = grpc.ServerStreamingClient[ListUserURLsStreamResponse]
+
 // URLShortenerServiceServer is the server API for URLShortenerService service.
 // All implementations must embed UnimplementedURLShortenerServiceServer
 // for forward compatibility.
 type URLShortenerServiceServer interface {
 	ShortenURL(context.Context, *ShortenURLRequest) (*ShortenURLResponse, error)
 	GetOriginalURL(context.Context, *GetOriginalURLRequest) (*GetOriginalURLResponse, error)
+	ScheduleURL(context.Context, *ScheduleURLRequest) (*ScheduleURLResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// ShortenURLStream shortens the streamed URLs in chunks as they are received,
+	// the shortened URLs are returned once the client closes the stream.
+	// Streams exceeding the URLs limit fail with the URLs shortened so far in the error details.
+	ShortenURLStream(grpc.ClientStreamingServer[ShortenURLStreamRequest, ShortenURLStreamResponse]) error
+	// ListUserURLsStream streams the URLs shortened by the caller.
+	ListUserURLsStream(*ListUserURLsStreamRequest, grpc.ServerStreamingServer[ListUserURLsStreamResponse]) error
 	mustEmbedUnimplementedURLShortenerServiceServer()
 }
 
@@ -82,6 +154,18 @@ func (UnimplementedURLShortenerServiceServer) ShortenURL(context.Context, *Short
 func (UnimplementedURLShortenerServiceServer) GetOriginalURL(context.Context, *GetOriginalURLRequest) (*GetOriginalURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOriginalURL not implemented")
 }
+func (UnimplementedURLShortenerServiceServer) ScheduleURL(context.Context, *ScheduleURLRequest) (*ScheduleURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleURL not implemented")
+}
+func (UnimplementedURLShortenerServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedURLShortenerServiceServer) ShortenURLStream(grpc.ClientStreamingServer[ShortenURLStreamRequest, ShortenURLStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ShortenURLStream not implemented")
+}
+func (UnimplementedURLShortenerServiceServer) ListUserURLsStream(*ListUserURLsStreamRequest, grpc.ServerStreamingServer[ListUserURLsStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListUserURLsStream not implemented")
+}
 func (UnimplementedURLShortenerServiceServer) mustEmbedUnimplementedURLShortenerServiceServer() {}
 func (UnimplementedURLShortenerServiceServer) testEmbeddedByValue()                             {}
 
@@ -139,6 +223,60 @@ func _URLShortenerService_GetOriginalURL_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _URLShortenerService_ScheduleURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLShortenerServiceServer).ScheduleURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLShortenerService_ScheduleURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLShortenerServiceServer).ScheduleURL(ctx, req.(*ScheduleURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLShortenerService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLShortenerServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLShortenerService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLShortenerServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLShortenerService_ShortenURLStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(URLShortenerServiceServer).ShortenURLStream(&grpc.GenericServerStream[ShortenURLStreamRequest, ShortenURLStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type URLShortenerService_ShortenURLStreamServer = grpc.ClientStreamingServer[ShortenURLStreamRequest, ShortenURLStreamResponse]
+
+func _URLShortenerService_ListUserURLsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUserURLsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(URLShortenerServiceServer).ListUserURLsStream(m, &grpc.GenericServerStream[ListUserURLsStreamRequest, ListUserURLsStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type URLShortenerService_ListUserURLsStreamServer = grpc.ServerStreamingServer[ListUserURLsStreamResponse]
+
 // URLShortenerService_ServiceDesc is the grpc.ServiceDesc for URLShortenerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,7 +292,26 @@ var URLShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOriginalURL",
 			Handler:    _URLShortenerService_GetOriginalURL_Handler,
 		},
+		{
+			MethodName: "ScheduleURL",
+			Handler:    _URLShortenerService_ScheduleURL_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _URLShortenerService_GetStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ShortenURLStream",
+			Handler:       _URLShortenerService_ShortenURLStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ListUserURLsStream",
+			Handler:       _URLShortenerService_ListUserURLsStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "shortener/v1/shortener.proto",
 }
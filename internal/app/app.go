@@ -14,17 +14,26 @@ import (
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/server"
 	grpcsrv "github.com/patraden/ya-practicum-go-shortly/internal/app/server/grpc"
 	httpsrv "github.com/patraden/ya-practicum-go-shortly/internal/app/server/http"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/accounts"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/apikeys"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/events"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/moderation"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/remover"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/shortener"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/statsprovider"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlgenerator"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/version"
 )
@@ -41,40 +50,151 @@ func App(appCfg *config.Config, logLevel zerolog.Level) *fx.App {
 		fx.Provide(func(l *logger.Logger) *zerolog.Logger { return l.GetLogger() }),
 		fx.Provide(postgres.New),
 		fx.Provide(fx.Annotate(urlgenerator.New, fx.As(new(urlgenerator.URLGenerator)))),
+		fx.Provide(geoip.NewLocator),
+		fx.Provide(urlpolicy.NewBlocklist, urlpolicy.New),
 		fx.Provide(
-			func(db *postgres.Database, l *zerolog.Logger, c *config.Config) (repository.URLRepository, error) {
+			middleware.NewJWTKeySet,
+			func(k *middleware.JWTKeySet) middleware.JWTKeys { return k },
+			func(t repository.TokenRepository) middleware.TokenDenylist { return t },
+			func(
+				k middleware.JWTKeys,
+				d middleware.TokenDenylist,
+				c *config.Config,
+				l *zerolog.Logger,
+			) *middleware.JWTMiddleware {
+				return middleware.NewKeysJWTMiddleware(k, l, middleware.WithJWTConfig(c), middleware.WithTokenDenylist(d))
+			},
+		),
+		fx.Provide(
+			func(
+				db *postgres.Database,
+				l *zerolog.Logger,
+				c *config.Config,
+			) (
+				repository.URLRepository,
+				repository.UserRepository,
+				repository.APIKeyRepository,
+				repository.TokenRepository,
+				repository.ModerationRepository,
+				repository.AuditRepository,
+				repository.WebhookRepository,
+				repository.OutboxRepository,
+				error,
+			) {
 				if c.DatabaseDSN != `` {
 					ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 					defer cancel()
 
 					if err := db.Init(ctx); err != nil {
-						return nil, err
+						return nil, nil, nil, nil, nil, nil, nil, nil, err
+					}
+
+					urlRepo := repository.NewDBURLRepository(db.ConnPool, l)
+
+					// urls stored before canonicalization get their canonical form before the app serves any.
+					if err := urlRepo.CanonicalizeURLMappings(context.Background()); err != nil {
+						return nil, nil, nil, nil, nil, nil, nil, nil, err
+					}
+
+					return urlRepo,
+						repository.NewDBUserRepository(db.ConnPool, l),
+						repository.NewDBAPIKeyRepository(db.ConnPool, l),
+						repository.NewDBTokenRepository(db.ConnPool, l),
+						urlRepo,
+						repository.NewDBAuditRepository(db.ConnPool, l),
+						repository.NewDBWebhookRepository(db.ConnPool, l),
+						urlRepo,
+						nil
+				}
+
+				urlRepo := repository.NewInMemoryURLRepository()
+
+				// empty repositories are not preserved, neither is their audit log
+				var auditRepo repository.AuditRepository = repository.NewInMemoryAuditRepository()
+				if !c.ForceEmptyRepo {
+					fileRepo, err := repository.NewFileAuditRepository(c.AuditLogPath)
+					if err != nil {
+						return nil, nil, nil, nil, nil, nil, nil, nil, err
 					}
 
-					return repository.NewDBURLRepository(db.ConnPool, l), nil
+					auditRepo = fileRepo
 				}
 
-				return repository.NewInMemoryURLRepository(), nil
+				return urlRepo,
+					repository.NewInMemoryUserRepository(),
+					repository.NewInMemoryAPIKeyRepository(),
+					repository.NewInMemoryTokenRepository(),
+					urlRepo,
+					auditRepo,
+					repository.NewInMemoryWebhookRepository(),
+					urlRepo,
+					nil
 			}),
 		fx.Provide(
 			shortener.NewInsistentShortener,
 			remover.NewBatchRemover,
 			statsprovider.NewRepoStatsProvider,
+			accounts.NewRepoAccounts,
+			apikeys.NewRepoAPIKeys,
+			moderation.NewRepoModerator,
+			audit.NewRepoAuditor,
+			webhooks.NewRepoWebhooks,
+			webhooks.NewDispatcher,
 			func(r *remover.BatchRemover) remover.URLRemover { return r },
 			func(p *statsprovider.RepoStatsProvider) statsprovider.StatsProvider { return p },
+			func(a *accounts.RepoAccounts) accounts.Accounts { return a },
+			func(k *apikeys.RepoAPIKeys) apikeys.APIKeys { return k },
+			func(k *apikeys.RepoAPIKeys) middleware.APIKeyResolver { return k },
+			func(m *moderation.RepoModerator) moderation.Moderator { return m },
+			func(a *audit.RepoAuditor) audit.Auditor { return a },
+			func(w *webhooks.RepoWebhooks) webhooks.Webhooks { return w },
+			func(w *webhooks.RepoWebhooks) webhooks.Notifier { return w },
+		),
+		fx.Provide(
+			func(lc fx.Lifecycle, c *config.Config, l *zerolog.Logger) (events.EventPublisher, error) {
+				if c.EventsNATSURL == `` {
+					l.Info().Msg("events: no broker configured, URL mapping events are dropped")
+
+					return events.NopPublisher{}, nil
+				}
+
+				publisher, err := events.NewNATSPublisher(c, l)
+				if err != nil {
+					return nil, err
+				}
+
+				lc.Append(fx.StopHook(publisher.Close))
+
+				return publisher, nil
+			},
+			events.NewRelay,
 		),
 		fx.Provide(
 			fx.Annotate(handler.NewPingHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
 			fx.Annotate(handler.NewDeleteHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
 			fx.Annotate(handler.InsistentShortenerHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
 			fx.Annotate(handler.NewStatsProviderHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
-			fx.Annotate(handler.NewRouter, fx.ParamTags(``, `group:"handlers"`)),
+			fx.Annotate(handler.NewAccountsHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
+			fx.Annotate(handler.NewAPIKeysHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
+			fx.Annotate(handler.NewAdminHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
+			fx.Annotate(handler.NewAuditHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
+			fx.Annotate(handler.NewWebhooksHandler, fx.As(new(handler.Handler)), fx.ResultTags(`group:"handlers"`)),
+			fx.Annotate(handler.NewRouter, fx.ParamTags(``, ``, ``, `group:"handlers"`)),
 		),
 		fx.Provide(
-			func(r repository.URLRepository) memento.Originator { return r },
+			func(
+				r repository.URLRepository,
+				u repository.UserRepository,
+				k repository.APIKeyRepository,
+				t repository.TokenRepository,
+				w repository.WebhookRepository,
+			) memento.Originator {
+				return memento.NewOriginators(r, u, k, t, w)
+			},
 			memento.NewStateManager,
 		),
-		fx.Provide(handler.NewGRPCShortenerHandler),
+		fx.Provide(handler.NewGRPCShortenerHandler, handler.NewGRPCHealthHandler),
+		fx.Provide(server.NewCertManager),
 		fx.Provide(httpsrv.NewServer),
 		fx.Provide(grpcsrv.NewServer),
 		fx.Invoke(fxAppInvoke),
@@ -87,12 +207,22 @@ func fxAppInvoke(
 	log *zerolog.Logger,
 	config *config.Config,
 	remover *remover.BatchRemover,
+	dispatcher *webhooks.Dispatcher,
+	relay *events.Relay,
+	blocklist *urlpolicy.Blocklist,
+	jwtKeys *middleware.JWTKeySet,
+	certs *server.CertManager,
 	stateManager *memento.StateManager,
 	serverHTTP *httpsrv.Server,
 	serverGRPC *grpcsrv.Server,
 	shutdowner fx.Shutdowner,
 ) {
 	ctxRemover, removerCancel := context.WithCancel(context.Background())
+	ctxDispatcher, dispatcherCancel := context.WithCancel(context.Background())
+	ctxRelay, relayCancel := context.WithCancel(context.Background())
+	ctxBlocklist, blocklistCancel := context.WithCancel(context.Background())
+	ctxJWTKeys, jwtKeysCancel := context.WithCancel(context.Background())
+	ctxCerts, certsCancel := context.WithCancel(context.Background())
 
 	lc.Append(fx.Hook{
 		OnStart: func(_ context.Context) error {
@@ -108,6 +238,15 @@ func fxAppInvoke(
 			appServerStart(shutdowner, serverHTTP, log)
 			appServerStart(shutdowner, serverGRPC, log)
 			remover.Start(ctxRemover)
+			dispatcher.Start(ctxDispatcher)
+			relay.Start(ctxRelay)
+
+			go blocklist.Watch(ctxBlocklist)
+			go jwtKeys.Watch(ctxJWTKeys)
+			go certs.Watch(ctxCerts)
+
+			appHandleReload(ctxJWTKeys, jwtKeys, certs, log)
+
 			version := version.NewVersion(log)
 			version.Log()
 			logStart(log, config)
@@ -115,8 +254,15 @@ func fxAppInvoke(
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
+			blocklistCancel()
+			jwtKeysCancel()
+			certsCancel()
 			removerCancel()
 			remover.Stop(ctx)
+			dispatcherCancel()
+			dispatcher.Stop(ctx)
+			relayCancel()
+			relay.Stop(ctx)
 
 			err := appServerStop(ctx, serverHTTP)
 			if err != nil {
@@ -164,6 +310,44 @@ func appHandleSignals(shutdowner fx.Shutdowner, log *zerolog.Logger) {
 	}()
 }
 
+// appHandleReload reloads JWT keys and TLS certificates on SIGHUP until the context is done.
+func appHandleReload(
+	ctx context.Context,
+	jwtKeys *middleware.JWTKeySet,
+	certs *server.CertManager,
+	log *zerolog.Logger,
+) {
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(reloadChan)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-reloadChan:
+				log.Info().
+					Str("Signal", sig.String()).
+					Msg("Reload signal received")
+
+				if err := jwtKeys.Reload(); err != nil {
+					log.Error().Err(err).
+						Str("Signal", sig.String()).
+						Msg("Failed to reload jwt keys")
+				}
+
+				if err := certs.Reload(); err != nil {
+					log.Error().Err(err).
+						Str("Signal", sig.String()).
+						Msg("Failed to reload tls certificate")
+				}
+			}
+		}
+	}()
+}
+
 func appServerStart(shutdowner fx.Shutdowner, server server.AppServer, log *zerolog.Logger) {
 	go func() {
 		err := server.Run()
@@ -189,9 +373,16 @@ func logStart(log *zerolog.Logger, config *config.Config) {
 		Str("SERVER_ADDRESS", config.ServerAddr).
 		Str("BASE_URL", config.BaseURL).
 		Bool("ENABLE_HTTPS", config.EnableHTTPS).
+		Bool("ENABLE_GRPC_TLS", config.EnableGRPCTLS).
+		Str("TLS_CERT_MODE", config.TLSCertMode).
 		Bool("FORCE_EMPTY", config.ForceEmptyRepo).
 		Str("FILE_STORAGE_PATH", config.FileStoragePath).
+		Str("AUDIT_LOG_PATH", config.AuditLogPath).
 		Str("TRUSTED_SUBNET", config.TrustedSubnet).
+		Strs("TRUSTED_SUBNETS", config.TrustedSubnets).
+		Strs("TRUSTED_PROXIES", config.TrustedProxies).
+		Str("CLIENT_IP_HEADER", config.ClientIPHeader).
+		Strs("ADMIN_USERS", config.AdminUsers).
 		Str("SERVER_GRPC_ADDRESS", config.ServerGRPCAddr).
 		Msg("App started")
 }
@@ -201,9 +392,16 @@ func logStop(log *zerolog.Logger, config *config.Config) {
 		Str("SERVER_ADDRESS", config.ServerAddr).
 		Str("BASE_URL", config.BaseURL).
 		Bool("ENABLE_HTTPS", config.EnableHTTPS).
+		Bool("ENABLE_GRPC_TLS", config.EnableGRPCTLS).
+		Str("TLS_CERT_MODE", config.TLSCertMode).
 		Bool("FORCE_EMPTY", config.ForceEmptyRepo).
 		Str("FILE_STORAGE_PATH", config.FileStoragePath).
+		Str("AUDIT_LOG_PATH", config.AuditLogPath).
 		Str("TRUSTED_SUBNET", config.TrustedSubnet).
+		Strs("TRUSTED_SUBNETS", config.TrustedSubnets).
+		Strs("TRUSTED_PROXIES", config.TrustedProxies).
+		Str("CLIENT_IP_HEADER", config.ClientIPHeader).
+		Strs("ADMIN_USERS", config.AdminUsers).
 		Str("SERVER_GRPC_ADDRESS", config.ServerGRPCAddr).
 		Msg("App stopped")
 }
@@ -3,12 +3,15 @@ package config
 import (
 	"flag"
 	"log"
+	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/caarlos0/env/v6"
 	easyjson "github.com/mailru/easyjson"
 
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
 )
@@ -67,7 +70,7 @@ func (b *builder) loadFlags() {
 	flag.StringVar(&b.cfg.DatabaseDSN, "d", b.cfg.DatabaseDSN, "database DSN")
 	flag.StringVar(&b.cfg.TrustedSubnet, "t", b.cfg.TrustedSubnet, "trusted subnet")
 	flag.BoolVar(&b.cfg.EnableHTTPS, "s", b.cfg.EnableHTTPS, "enable https")
-	flag.BoolVar(&b.cfg.ForceEmptyRepo, "force-empty", b.cfg.ForceEmptyRepo, "skip repository load from disk")
+	flag.BoolVar(&b.cfg.ForceEmptyRepo, "force-empty", b.cfg.ForceEmptyRepo, "keep repository and audit log in memory")
 	flag.Parse()
 }
 
@@ -76,6 +79,99 @@ func (b *builder) getConfig() *Config {
 		log.Fatal(e.ErrInvalidConfig)
 	}
 
+	if b.cfg.DefaultRedirectType == domain.RedirectDefault || !b.cfg.DefaultRedirectType.IsValid() {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if b.cfg.PasswordMaxAttempts <= 0 {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	fallback := domain.OriginalURL(b.cfg.InactiveFallbackURL)
+	if fallback != "" && !fallback.IsValid() {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if b.cfg.StatsDays <= 0 || b.cfg.StatsDays > MaxStatsDays ||
+		b.cfg.StatsTopUsers < 0 || b.cfg.StatsTopUsers > MaxStatsTopUsers {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if len(b.cfg.URLAllowedSchemes) == 0 {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if !slices.Contains([]string{TLSCertModeFile, TLSCertModeSelfSigned, TLSCertModeACME}, b.cfg.TLSCertMode) {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	// certificates are only issued for known domains
+	if b.cfg.TLSCertMode == TLSCertModeACME && len(b.cfg.ACMEDomains) == 0 {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	// client IPs are only read from the one header trusted proxies set
+	clientIPHeaders := []string{ClientIPHeaderForwarded, ClientIPHeaderXForwardedFor, ClientIPHeaderXRealIP}
+	if !slices.Contains(clientIPHeaders, b.cfg.ClientIPHeader) {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	subnets := slices.Concat(
+		[]string{b.cfg.TrustedSubnet},
+		b.cfg.TrustedSubnets,
+		b.cfg.DeniedSubnets,
+		b.cfg.TrustedProxies,
+	)
+	if _, err := utils.ParseSubnets(subnets...); err != nil {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	// audit events are appended to the file unless stored in the database
+	if b.cfg.DatabaseDSN == `` && b.cfg.AuditLogPath == `` {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	for _, user := range b.cfg.AdminUsers {
+		if _, err := domain.ParseUserID(user); err != nil {
+			log.Fatal(e.ErrInvalidConfig)
+		}
+	}
+
+	// client certificates are verified with the client CAs
+	if b.cfg.TLSRequireClientCert && b.cfg.TLSClientCAPath == `` && b.cfg.StatsClientCAPath == `` {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	// client certificates are only presented over https
+	if b.cfg.StatsClientCAPath != `` && !b.cfg.EnableHTTPS {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	// browsers reject SameSite=None cookies without the Secure attribute
+	sameSite, ok := b.cfg.AuthCookieSameSiteMode()
+	if !ok || (sameSite == http.SameSiteNoneMode && !b.cfg.AuthCookieSecure) {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if b.cfg.WebhookMaxAttempts <= 0 ||
+		slices.ContainsFunc(b.cfg.WebhookMilestones, func(clicks int64) bool { return clicks <= 0 }) {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	// events are published to subjects of the subject prefix followed by the event type
+	if b.cfg.EventsSubject == `` || strings.ContainsAny(b.cfg.EventsSubject, " \t*>") ||
+		strings.HasSuffix(b.cfg.EventsSubject, ".") {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if b.cfg.GRPCStreamChunk <= 0 || b.cfg.GRPCStreamMaxURLs < b.cfg.GRPCStreamChunk {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
+	if b.cfg.JWTTokenTTL <= 0 || b.cfg.JWTRenewBefore < 0 {
+		log.Fatal(e.ErrInvalidConfig)
+	}
+
 	if !strings.HasSuffix(b.cfg.BaseURL, "/") {
 		b.cfg.BaseURL += "/"
 	}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"net/http"
+	"strings"
 	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 )
 
 // Default app config constants.
@@ -13,23 +17,107 @@ const (
 	defaultWriteTimeout        = 10 * time.Second  // Maximum duration to write response
 	defaultIdleTimeout         = 120 * time.Second // Maximum duration for idle connections
 	defaultURLSize             = 8
+	defaultPasswordMaxAttempts = 5
+	defaultPasswordLockout     = 15 * time.Minute     // Window of failed password attempts per slug
+	defaultURLResolveTimeout   = 2 * time.Second      // Maximum duration to resolve a destination host
+	defaultURLBlocklistReload  = 30 * time.Second     // Interval of destination blocklist file changes checks
+	defaultJWTKeysReload       = 30 * time.Second     // Interval of JWT keys directory changes checks
+	defaultJWTTokenTTL         = 365 * 24 * time.Hour // Lifetime of issued JWT tokens
+	defaultJWTRenewBefore      = 30 * 24 * time.Hour  // Tokens closer to their expiry are renewed
+	defaultTLSCertReload       = 30 * time.Second     // Interval of TLS certificate files changes checks
+	defaultStatsDays           = 30                   // Days of daily stats
+	defaultStatsTopUsers       = 10                   // Users of top users stats
+	defaultWebhookMaxAttempts  = 8                    // Failed deliveries of a webhook event before dead-lettering
+	defaultWebhookTimeout      = 5 * time.Second      // Maximum duration of a webhook delivery
+	defaultWebhookPoll         = time.Second          // Interval of webhook outbox polls
+	defaultWebhookRetry        = 10 * time.Second     // Delay of the first webhook delivery retry
+	defaultWebhookRetryMax     = time.Hour            // Maximum delay of webhook delivery retries
+	defaultEventsPoll          = time.Second          // Interval of events outbox polls
+	defaultEventsLease         = 30 * time.Second     // Lease of claimed outbox events, bounds a relay publishing round
+	defaultGRPCStreamChunk     = 100                  // URLs of gRPC streams shortened at once
+	defaultGRPCStreamMaxURLs   = 10000                // URLs shortened per gRPC stream
+)
+
+// Stats parameters limits.
+const (
+	MaxStatsDays     = 366
+	MaxStatsTopUsers = 100
+)
+
+// DefaultJWTSecret is the JWT secret of the default config, it is refused in production mode.
+const DefaultJWTSecret = `d1a58c288a0226998149277b14993f6c73cf44ff9df3de548df4df25a13b251a`
+
+// TLS certificate modes.
+const (
+	TLSCertModeFile       = `file`        // Certificate and key files are loaded and reloaded on change
+	TLSCertModeSelfSigned = `self-signed` // Same as file, missing files are generated with a self-signed certificate
+	TLSCertModeACME       = `acme`        // Certificates are obtained from an ACME directory
+)
+
+// Client IP headers set by trusted proxies.
+const (
+	ClientIPHeaderForwarded     = `Forwarded`       // Standard forwarding header (RFC 7239)
+	ClientIPHeaderXForwardedFor = `X-Forwarded-For` // De facto forwarding header, appended by most proxies
+	ClientIPHeaderXRealIP       = `X-Real-IP`       // Client IP header, set by nginx real IP configurations
 )
 
 // Config holds the app configuration settings, which can be set through environment variables or flags.
 //
 //easyjson:json
 type Config struct {
-	ServerAddr              string `env:"SERVER_ADDRESS" json:"server_address"`
-	ServerGRPCAddr          string `env:"SERVER_GRPC_ADDRESS" json:"server_grpc_address"`
-	BaseURL                 string `env:"BASE_URL" json:"base_url"`
-	FileStoragePath         string `env:"FILE_STORAGE_PATH" json:"file_storage_path"`
-	DatabaseDSN             string `env:"DATABASE_DSN" json:"database_dsn"`
-	EnableHTTPS             bool   `env:"ENABLE_HTTPS" json:"enable_https"`
-	JWTSecret               string `env:"JWT_SECRET" json:"jwt_secret"`
-	TLSKeyPath              string `env:"TLC_KEY_PATH" json:"tlc_key_path"`
-	TLSCertPath             string `env:"TLC_CERT_PATH" json:"tlc_cert_path"`
-	TrustedSubnet           string `env:"TRUSTED_SUBNET" json:"trusted_subnet"`
-	ConfigJSON              string `env:"CONFIG"`
+	ServerAddr              string              `env:"SERVER_ADDRESS" json:"server_address"`
+	ServerGRPCAddr          string              `env:"SERVER_GRPC_ADDRESS" json:"server_grpc_address"`
+	BaseURL                 string              `env:"BASE_URL" json:"base_url"`
+	FileStoragePath         string              `env:"FILE_STORAGE_PATH" json:"file_storage_path"`
+	AuditLogPath            string              `env:"AUDIT_LOG_PATH" json:"audit_log_path"`
+	DatabaseDSN             string              `env:"DATABASE_DSN" json:"database_dsn"`
+	EnableHTTPS             bool                `env:"ENABLE_HTTPS" json:"enable_https"`
+	JWTSecret               string              `env:"JWT_SECRET" json:"jwt_secret"`
+	JWTKeysPath             string              `env:"JWT_KEYS_PATH" json:"jwt_keys_path"`
+	JWTSigningKeyID         string              `env:"JWT_SIGNING_KEY_ID" json:"jwt_signing_key_id"`
+	JWTVerifyHMAC           bool                `env:"JWT_VERIFY_HMAC" json:"jwt_verify_hmac"`
+	Production              bool                `env:"PRODUCTION" json:"production"`
+	AuthCookieDomain        string              `env:"AUTH_COOKIE_DOMAIN" json:"auth_cookie_domain"`
+	AuthCookieSecure        bool                `env:"AUTH_COOKIE_SECURE" json:"auth_cookie_secure"`
+	AuthCookieHTTPOnly      bool                `env:"AUTH_COOKIE_HTTP_ONLY" json:"auth_cookie_http_only"`
+	AuthCookieSameSite      string              `env:"AUTH_COOKIE_SAME_SITE" json:"auth_cookie_same_site"`
+	TLSKeyPath              string              `env:"TLC_KEY_PATH" json:"tlc_key_path"`
+	TLSCertPath             string              `env:"TLC_CERT_PATH" json:"tlc_cert_path"`
+	TLSCertMode             string              `env:"TLS_CERT_MODE" json:"tls_cert_mode"`
+	ACMEDomains             []string            `env:"ACME_DOMAINS" envSeparator:"," json:"acme_domains"`
+	ACMEEmail               string              `env:"ACME_EMAIL" json:"acme_email"`
+	ACMEDirectoryURL        string              `env:"ACME_DIRECTORY_URL" json:"acme_directory_url"`
+	ACMECAPath              string              `env:"ACME_CA_PATH" json:"acme_ca_path"`
+	ACMECacheDir            string              `env:"ACME_CACHE_DIR" json:"acme_cache_dir"`
+	EnableGRPCTLS           bool                `env:"ENABLE_GRPC_TLS" json:"enable_grpc_tls"`
+	EnableGRPCReflection    bool                `env:"ENABLE_GRPC_REFLECTION" json:"enable_grpc_reflection"`
+	TLSClientCAPath         string              `env:"TLS_CLIENT_CA_PATH" json:"tls_client_ca_path"`
+	TLSRequireClientCert    bool                `env:"TLS_REQUIRE_CLIENT_CERT" json:"tls_require_client_cert"`
+	StatsClientCAPath       string              `env:"STATS_CLIENT_CA_PATH" json:"stats_client_ca_path"`
+	StatsDays               int                 `env:"STATS_DAYS" json:"stats_days"`
+	StatsTopUsers           int                 `env:"STATS_TOP_USERS" json:"stats_top_users"`
+	TrustedSubnet           string              `env:"TRUSTED_SUBNET" json:"trusted_subnet"`
+	TrustedSubnets          []string            `env:"TRUSTED_SUBNETS" envSeparator:"," json:"trusted_subnets"`
+	DeniedSubnets           []string            `env:"DENIED_SUBNETS" envSeparator:"," json:"denied_subnets"`
+	TrustedProxies          []string            `env:"TRUSTED_PROXIES" envSeparator:"," json:"trusted_proxies"`
+	ClientIPHeader          string              `env:"CLIENT_IP_HEADER" json:"client_ip_header"`
+	AdminUsers              []string            `env:"ADMIN_USERS" envSeparator:"," json:"admin_users"`
+	DefaultRedirectType     domain.RedirectType `env:"DEFAULT_REDIRECT_TYPE" json:"default_redirect_type"`
+	PasswordMaxAttempts     int                 `env:"PASSWORD_MAX_ATTEMPTS" json:"password_max_attempts"`
+	InactiveFallbackURL     string              `env:"INACTIVE_FALLBACK_URL" json:"inactive_fallback_url"`
+	GeoIPDatabasePath       string              `env:"GEOIP_DATABASE_PATH" json:"geoip_database_path"`
+	URLAllowedSchemes       []string            `env:"URL_ALLOWED_SCHEMES" envSeparator:"," json:"url_allowed_schemes"`
+	URLAllowPrivate         bool                `env:"URL_ALLOW_PRIVATE" json:"url_allow_private"`
+	URLBlocklistPath        string              `env:"URL_BLOCKLIST_PATH" json:"url_blocklist_path"`
+	URLStripTracking        bool                `env:"URL_STRIP_TRACKING" json:"url_strip_tracking"`
+	PerUserURLs             bool                `env:"PER_USER_URLS" json:"per_user_urls"`
+	WebhookMaxAttempts      int                 `env:"WEBHOOK_MAX_ATTEMPTS" json:"webhook_max_attempts"`
+	WebhookMilestones       []int64             `env:"WEBHOOK_MILESTONES" envSeparator:"," json:"webhook_milestones"`
+	EventsNATSURL           string              `env:"EVENTS_NATS_URL" json:"events_nats_url"`
+	EventsSubject           string              `env:"EVENTS_SUBJECT" json:"events_subject"`
+	GRPCStreamChunk         int                 `env:"GRPC_STREAM_CHUNK" json:"grpc_stream_chunk"`
+	GRPCStreamMaxURLs       int                 `env:"GRPC_STREAM_MAX_URLS" json:"grpc_stream_max_urls"`
+	ConfigJSON              string              `env:"CONFIG"`
 	URLGenTimeout           time.Duration
 	URLGenRetryInterval     time.Duration
 	URLsize                 int
@@ -37,6 +125,19 @@ type Config struct {
 	ServerReadHeaderTimeout time.Duration
 	ServerWriteTimeout      time.Duration
 	ServerIdleTimeout       time.Duration
+	PasswordLockout         time.Duration
+	URLResolveTimeout       time.Duration
+	URLBlocklistReload      time.Duration
+	JWTKeysReload           time.Duration
+	JWTTokenTTL             time.Duration
+	JWTRenewBefore          time.Duration
+	TLSCertReload           time.Duration
+	WebhookTimeout          time.Duration
+	WebhookPollInterval     time.Duration
+	WebhookRetryInterval    time.Duration
+	WebhookRetryMaxInterval time.Duration
+	EventsPollInterval      time.Duration
+	EventsLease             time.Duration
 	ForceEmptyRepo          bool
 }
 
@@ -47,12 +148,54 @@ func DefaultConfig() *Config {
 		ServerGRPCAddr:          `localhost:3200`,
 		BaseURL:                 `http://localhost:8080/`,
 		FileStoragePath:         `data/service_storage.json`,
+		AuditLogPath:            `data/audit_log.jsonl`,
 		DatabaseDSN:             ``,
 		EnableHTTPS:             false,
-		JWTSecret:               `d1a58c288a0226998149277b14993f6c73cf44ff9df3de548df4df25a13b251a`,
+		JWTSecret:               DefaultJWTSecret,
+		JWTKeysPath:             ``,
+		JWTSigningKeyID:         ``,
+		JWTVerifyHMAC:           false,
+		Production:              false,
+		AuthCookieDomain:        ``,
+		AuthCookieSecure:        false,
+		AuthCookieHTTPOnly:      true,
+		AuthCookieSameSite:      `lax`,
 		TLSKeyPath:              `/etc/ssl/private/shortener-key.pem`,
 		TLSCertPath:             `/etc/ssl/certs/shortener-cert.pem`,
+		TLSCertMode:             TLSCertModeFile,
+		ACMEDomains:             []string{},
+		ACMEEmail:               ``,
+		ACMEDirectoryURL:        ``,
+		ACMECAPath:              ``,
+		ACMECacheDir:            `data/acme`,
+		EnableGRPCTLS:           false,
+		EnableGRPCReflection:    false,
+		TLSClientCAPath:         ``,
+		TLSRequireClientCert:    false,
+		StatsClientCAPath:       ``,
+		StatsDays:               defaultStatsDays,
+		StatsTopUsers:           defaultStatsTopUsers,
 		TrustedSubnet:           ``,
+		TrustedSubnets:          []string{},
+		DeniedSubnets:           []string{},
+		TrustedProxies:          []string{},
+		ClientIPHeader:          ClientIPHeaderXForwardedFor,
+		AdminUsers:              []string{},
+		DefaultRedirectType:     domain.RedirectTemporary,
+		PasswordMaxAttempts:     defaultPasswordMaxAttempts,
+		InactiveFallbackURL:     ``,
+		GeoIPDatabasePath:       ``,
+		URLAllowedSchemes:       []string{`http`, `https`},
+		URLAllowPrivate:         false,
+		URLBlocklistPath:        ``,
+		URLStripTracking:        false,
+		PerUserURLs:             false,
+		WebhookMaxAttempts:      defaultWebhookMaxAttempts,
+		WebhookMilestones:       []int64{100, 1000, 10000},
+		EventsNATSURL:           ``,
+		EventsSubject:           `shortener.events`,
+		GRPCStreamChunk:         defaultGRPCStreamChunk,
+		GRPCStreamMaxURLs:       defaultGRPCStreamMaxURLs,
 		ConfigJSON:              ``,
 		URLGenTimeout:           defaultURLGenTimeout,
 		URLGenRetryInterval:     defaultURLGenRetryInterval,
@@ -61,10 +204,40 @@ func DefaultConfig() *Config {
 		ServerReadHeaderTimeout: defaultReadHeaderTimeout,
 		ServerWriteTimeout:      defaultWriteTimeout,
 		ServerIdleTimeout:       defaultIdleTimeout,
+		PasswordLockout:         defaultPasswordLockout,
+		URLResolveTimeout:       defaultURLResolveTimeout,
+		URLBlocklistReload:      defaultURLBlocklistReload,
+		JWTKeysReload:           defaultJWTKeysReload,
+		JWTTokenTTL:             defaultJWTTokenTTL,
+		JWTRenewBefore:          defaultJWTRenewBefore,
+		TLSCertReload:           defaultTLSCertReload,
+		WebhookTimeout:          defaultWebhookTimeout,
+		WebhookPollInterval:     defaultWebhookPoll,
+		WebhookRetryInterval:    defaultWebhookRetry,
+		WebhookRetryMaxInterval: defaultWebhookRetryMax,
+		EventsPollInterval:      defaultEventsPoll,
+		EventsLease:             defaultEventsLease,
 		ForceEmptyRepo:          false,
 	}
 }
 
+// AuthCookieSameSiteMode returns the SameSite mode of the auth cookie: lax, strict, none or the browser default.
+// It reports false for unknown modes.
+func (c *Config) AuthCookieSameSiteMode() (http.SameSite, bool) {
+	switch strings.ToLower(c.AuthCookieSameSite) {
+	case ``:
+		return http.SameSiteDefaultMode, true
+	case `lax`:
+		return http.SameSiteLaxMode, true
+	case `strict`:
+		return http.SameSiteStrictMode, true
+	case `none`:
+		return http.SameSiteNoneMode, true
+	default:
+		return http.SameSiteDefaultMode, false
+	}
+}
+
 // LoadConfig initializes and returns a Config instance.
 func LoadConfig() *Config {
 	builder := newBuilder()
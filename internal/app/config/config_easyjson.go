@@ -4,11 +4,11 @@ package config
 
 import (
 	json "encoding/json"
-	time "time"
-
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	time "time"
 )
 
 // suppress unused package warning
@@ -46,18 +46,249 @@ func easyjson6615c02eDecodeGithubComPatradenYaPracticumGoShortlyInternalAppConfi
 			out.BaseURL = string(in.String())
 		case "file_storage_path":
 			out.FileStoragePath = string(in.String())
+		case "audit_log_path":
+			out.AuditLogPath = string(in.String())
 		case "database_dsn":
 			out.DatabaseDSN = string(in.String())
 		case "enable_https":
 			out.EnableHTTPS = bool(in.Bool())
 		case "jwt_secret":
 			out.JWTSecret = string(in.String())
+		case "jwt_keys_path":
+			out.JWTKeysPath = string(in.String())
+		case "jwt_signing_key_id":
+			out.JWTSigningKeyID = string(in.String())
+		case "jwt_verify_hmac":
+			out.JWTVerifyHMAC = bool(in.Bool())
+		case "production":
+			out.Production = bool(in.Bool())
+		case "auth_cookie_domain":
+			out.AuthCookieDomain = string(in.String())
+		case "auth_cookie_secure":
+			out.AuthCookieSecure = bool(in.Bool())
+		case "auth_cookie_http_only":
+			out.AuthCookieHTTPOnly = bool(in.Bool())
+		case "auth_cookie_same_site":
+			out.AuthCookieSameSite = string(in.String())
 		case "tlc_key_path":
 			out.TLSKeyPath = string(in.String())
 		case "tlc_cert_path":
 			out.TLSCertPath = string(in.String())
+		case "tls_cert_mode":
+			out.TLSCertMode = string(in.String())
+		case "acme_domains":
+			if in.IsNull() {
+				in.Skip()
+				out.ACMEDomains = nil
+			} else {
+				in.Delim('[')
+				if out.ACMEDomains == nil {
+					if !in.IsDelim(']') {
+						out.ACMEDomains = make([]string, 0, 4)
+					} else {
+						out.ACMEDomains = []string{}
+					}
+				} else {
+					out.ACMEDomains = (out.ACMEDomains)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.ACMEDomains = append(out.ACMEDomains, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "acme_email":
+			out.ACMEEmail = string(in.String())
+		case "acme_directory_url":
+			out.ACMEDirectoryURL = string(in.String())
+		case "acme_ca_path":
+			out.ACMECAPath = string(in.String())
+		case "acme_cache_dir":
+			out.ACMECacheDir = string(in.String())
+		case "enable_grpc_tls":
+			out.EnableGRPCTLS = bool(in.Bool())
+		case "enable_grpc_reflection":
+			out.EnableGRPCReflection = bool(in.Bool())
+		case "tls_client_ca_path":
+			out.TLSClientCAPath = string(in.String())
+		case "tls_require_client_cert":
+			out.TLSRequireClientCert = bool(in.Bool())
+		case "stats_client_ca_path":
+			out.StatsClientCAPath = string(in.String())
+		case "stats_days":
+			out.StatsDays = int(in.Int())
+		case "stats_top_users":
+			out.StatsTopUsers = int(in.Int())
 		case "trusted_subnet":
 			out.TrustedSubnet = string(in.String())
+		case "trusted_subnets":
+			if in.IsNull() {
+				in.Skip()
+				out.TrustedSubnets = nil
+			} else {
+				in.Delim('[')
+				if out.TrustedSubnets == nil {
+					if !in.IsDelim(']') {
+						out.TrustedSubnets = make([]string, 0, 4)
+					} else {
+						out.TrustedSubnets = []string{}
+					}
+				} else {
+					out.TrustedSubnets = (out.TrustedSubnets)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.TrustedSubnets = append(out.TrustedSubnets, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "denied_subnets":
+			if in.IsNull() {
+				in.Skip()
+				out.DeniedSubnets = nil
+			} else {
+				in.Delim('[')
+				if out.DeniedSubnets == nil {
+					if !in.IsDelim(']') {
+						out.DeniedSubnets = make([]string, 0, 4)
+					} else {
+						out.DeniedSubnets = []string{}
+					}
+				} else {
+					out.DeniedSubnets = (out.DeniedSubnets)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 string
+					v3 = string(in.String())
+					out.DeniedSubnets = append(out.DeniedSubnets, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "trusted_proxies":
+			if in.IsNull() {
+				in.Skip()
+				out.TrustedProxies = nil
+			} else {
+				in.Delim('[')
+				if out.TrustedProxies == nil {
+					if !in.IsDelim(']') {
+						out.TrustedProxies = make([]string, 0, 4)
+					} else {
+						out.TrustedProxies = []string{}
+					}
+				} else {
+					out.TrustedProxies = (out.TrustedProxies)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.TrustedProxies = append(out.TrustedProxies, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "client_ip_header":
+			out.ClientIPHeader = string(in.String())
+		case "admin_users":
+			if in.IsNull() {
+				in.Skip()
+				out.AdminUsers = nil
+			} else {
+				in.Delim('[')
+				if out.AdminUsers == nil {
+					if !in.IsDelim(']') {
+						out.AdminUsers = make([]string, 0, 4)
+					} else {
+						out.AdminUsers = []string{}
+					}
+				} else {
+					out.AdminUsers = (out.AdminUsers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.AdminUsers = append(out.AdminUsers, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "default_redirect_type":
+			out.DefaultRedirectType = domain.RedirectType(in.Int())
+		case "password_max_attempts":
+			out.PasswordMaxAttempts = int(in.Int())
+		case "inactive_fallback_url":
+			out.InactiveFallbackURL = string(in.String())
+		case "geoip_database_path":
+			out.GeoIPDatabasePath = string(in.String())
+		case "url_allowed_schemes":
+			if in.IsNull() {
+				in.Skip()
+				out.URLAllowedSchemes = nil
+			} else {
+				in.Delim('[')
+				if out.URLAllowedSchemes == nil {
+					if !in.IsDelim(']') {
+						out.URLAllowedSchemes = make([]string, 0, 4)
+					} else {
+						out.URLAllowedSchemes = []string{}
+					}
+				} else {
+					out.URLAllowedSchemes = (out.URLAllowedSchemes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 string
+					v6 = string(in.String())
+					out.URLAllowedSchemes = append(out.URLAllowedSchemes, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "url_allow_private":
+			out.URLAllowPrivate = bool(in.Bool())
+		case "url_blocklist_path":
+			out.URLBlocklistPath = string(in.String())
+		case "url_strip_tracking":
+			out.URLStripTracking = bool(in.Bool())
+		case "per_user_urls":
+			out.PerUserURLs = bool(in.Bool())
+		case "webhook_max_attempts":
+			out.WebhookMaxAttempts = int(in.Int())
+		case "webhook_milestones":
+			if in.IsNull() {
+				in.Skip()
+				out.WebhookMilestones = nil
+			} else {
+				in.Delim('[')
+				if out.WebhookMilestones == nil {
+					if !in.IsDelim(']') {
+						out.WebhookMilestones = make([]int64, 0, 8)
+					} else {
+						out.WebhookMilestones = []int64{}
+					}
+				} else {
+					out.WebhookMilestones = (out.WebhookMilestones)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 int64
+					v7 = int64(in.Int64())
+					out.WebhookMilestones = append(out.WebhookMilestones, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "events_nats_url":
+			out.EventsNATSURL = string(in.String())
+		case "events_subject":
+			out.EventsSubject = string(in.String())
+		case "grpc_stream_chunk":
+			out.GRPCStreamChunk = int(in.Int())
+		case "grpc_stream_max_urls":
+			out.GRPCStreamMaxURLs = int(in.Int())
 		case "ConfigJSON":
 			out.ConfigJSON = string(in.String())
 		case "URLGenTimeout":
@@ -74,6 +305,32 @@ func easyjson6615c02eDecodeGithubComPatradenYaPracticumGoShortlyInternalAppConfi
 			out.ServerWriteTimeout = time.Duration(in.Int64())
 		case "ServerIdleTimeout":
 			out.ServerIdleTimeout = time.Duration(in.Int64())
+		case "PasswordLockout":
+			out.PasswordLockout = time.Duration(in.Int64())
+		case "URLResolveTimeout":
+			out.URLResolveTimeout = time.Duration(in.Int64())
+		case "URLBlocklistReload":
+			out.URLBlocklistReload = time.Duration(in.Int64())
+		case "JWTKeysReload":
+			out.JWTKeysReload = time.Duration(in.Int64())
+		case "JWTTokenTTL":
+			out.JWTTokenTTL = time.Duration(in.Int64())
+		case "JWTRenewBefore":
+			out.JWTRenewBefore = time.Duration(in.Int64())
+		case "TLSCertReload":
+			out.TLSCertReload = time.Duration(in.Int64())
+		case "WebhookTimeout":
+			out.WebhookTimeout = time.Duration(in.Int64())
+		case "WebhookPollInterval":
+			out.WebhookPollInterval = time.Duration(in.Int64())
+		case "WebhookRetryInterval":
+			out.WebhookRetryInterval = time.Duration(in.Int64())
+		case "WebhookRetryMaxInterval":
+			out.WebhookRetryMaxInterval = time.Duration(in.Int64())
+		case "EventsPollInterval":
+			out.EventsPollInterval = time.Duration(in.Int64())
+		case "EventsLease":
+			out.EventsLease = time.Duration(in.Int64())
 		case "ForceEmptyRepo":
 			out.ForceEmptyRepo = bool(in.Bool())
 		default:
@@ -110,6 +367,11 @@ func easyjson6615c02eEncodeGithubComPatradenYaPracticumGoShortlyInternalAppConfi
 		out.RawString(prefix)
 		out.String(string(in.FileStoragePath))
 	}
+	{
+		const prefix string = ",\"audit_log_path\":"
+		out.RawString(prefix)
+		out.String(string(in.AuditLogPath))
+	}
 	{
 		const prefix string = ",\"database_dsn\":"
 		out.RawString(prefix)
@@ -125,6 +387,46 @@ func easyjson6615c02eEncodeGithubComPatradenYaPracticumGoShortlyInternalAppConfi
 		out.RawString(prefix)
 		out.String(string(in.JWTSecret))
 	}
+	{
+		const prefix string = ",\"jwt_keys_path\":"
+		out.RawString(prefix)
+		out.String(string(in.JWTKeysPath))
+	}
+	{
+		const prefix string = ",\"jwt_signing_key_id\":"
+		out.RawString(prefix)
+		out.String(string(in.JWTSigningKeyID))
+	}
+	{
+		const prefix string = ",\"jwt_verify_hmac\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.JWTVerifyHMAC))
+	}
+	{
+		const prefix string = ",\"production\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Production))
+	}
+	{
+		const prefix string = ",\"auth_cookie_domain\":"
+		out.RawString(prefix)
+		out.String(string(in.AuthCookieDomain))
+	}
+	{
+		const prefix string = ",\"auth_cookie_secure\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AuthCookieSecure))
+	}
+	{
+		const prefix string = ",\"auth_cookie_http_only\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AuthCookieHTTPOnly))
+	}
+	{
+		const prefix string = ",\"auth_cookie_same_site\":"
+		out.RawString(prefix)
+		out.String(string(in.AuthCookieSameSite))
+	}
 	{
 		const prefix string = ",\"tlc_key_path\":"
 		out.RawString(prefix)
@@ -135,11 +437,253 @@ func easyjson6615c02eEncodeGithubComPatradenYaPracticumGoShortlyInternalAppConfi
 		out.RawString(prefix)
 		out.String(string(in.TLSCertPath))
 	}
+	{
+		const prefix string = ",\"tls_cert_mode\":"
+		out.RawString(prefix)
+		out.String(string(in.TLSCertMode))
+	}
+	{
+		const prefix string = ",\"acme_domains\":"
+		out.RawString(prefix)
+		if in.ACMEDomains == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.ACMEDomains {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"acme_email\":"
+		out.RawString(prefix)
+		out.String(string(in.ACMEEmail))
+	}
+	{
+		const prefix string = ",\"acme_directory_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ACMEDirectoryURL))
+	}
+	{
+		const prefix string = ",\"acme_ca_path\":"
+		out.RawString(prefix)
+		out.String(string(in.ACMECAPath))
+	}
+	{
+		const prefix string = ",\"acme_cache_dir\":"
+		out.RawString(prefix)
+		out.String(string(in.ACMECacheDir))
+	}
+	{
+		const prefix string = ",\"enable_grpc_tls\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.EnableGRPCTLS))
+	}
+	{
+		const prefix string = ",\"enable_grpc_reflection\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.EnableGRPCReflection))
+	}
+	{
+		const prefix string = ",\"tls_client_ca_path\":"
+		out.RawString(prefix)
+		out.String(string(in.TLSClientCAPath))
+	}
+	{
+		const prefix string = ",\"tls_require_client_cert\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.TLSRequireClientCert))
+	}
+	{
+		const prefix string = ",\"stats_client_ca_path\":"
+		out.RawString(prefix)
+		out.String(string(in.StatsClientCAPath))
+	}
+	{
+		const prefix string = ",\"stats_days\":"
+		out.RawString(prefix)
+		out.Int(int(in.StatsDays))
+	}
+	{
+		const prefix string = ",\"stats_top_users\":"
+		out.RawString(prefix)
+		out.Int(int(in.StatsTopUsers))
+	}
 	{
 		const prefix string = ",\"trusted_subnet\":"
 		out.RawString(prefix)
 		out.String(string(in.TrustedSubnet))
 	}
+	{
+		const prefix string = ",\"trusted_subnets\":"
+		out.RawString(prefix)
+		if in.TrustedSubnets == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.TrustedSubnets {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v11))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"denied_subnets\":"
+		out.RawString(prefix)
+		if in.DeniedSubnets == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.DeniedSubnets {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v13))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"trusted_proxies\":"
+		out.RawString(prefix)
+		if in.TrustedProxies == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.TrustedProxies {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"client_ip_header\":"
+		out.RawString(prefix)
+		out.String(string(in.ClientIPHeader))
+	}
+	{
+		const prefix string = ",\"admin_users\":"
+		out.RawString(prefix)
+		if in.AdminUsers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.AdminUsers {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v17))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"default_redirect_type\":"
+		out.RawString(prefix)
+		out.Int(int(in.DefaultRedirectType))
+	}
+	{
+		const prefix string = ",\"password_max_attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.PasswordMaxAttempts))
+	}
+	{
+		const prefix string = ",\"inactive_fallback_url\":"
+		out.RawString(prefix)
+		out.String(string(in.InactiveFallbackURL))
+	}
+	{
+		const prefix string = ",\"geoip_database_path\":"
+		out.RawString(prefix)
+		out.String(string(in.GeoIPDatabasePath))
+	}
+	{
+		const prefix string = ",\"url_allowed_schemes\":"
+		out.RawString(prefix)
+		if in.URLAllowedSchemes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.URLAllowedSchemes {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v19))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"url_allow_private\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.URLAllowPrivate))
+	}
+	{
+		const prefix string = ",\"url_blocklist_path\":"
+		out.RawString(prefix)
+		out.String(string(in.URLBlocklistPath))
+	}
+	{
+		const prefix string = ",\"url_strip_tracking\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.URLStripTracking))
+	}
+	{
+		const prefix string = ",\"per_user_urls\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PerUserURLs))
+	}
+	{
+		const prefix string = ",\"webhook_max_attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.WebhookMaxAttempts))
+	}
+	{
+		const prefix string = ",\"webhook_milestones\":"
+		out.RawString(prefix)
+		if in.WebhookMilestones == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.WebhookMilestones {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v21))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"events_nats_url\":"
+		out.RawString(prefix)
+		out.String(string(in.EventsNATSURL))
+	}
+	{
+		const prefix string = ",\"events_subject\":"
+		out.RawString(prefix)
+		out.String(string(in.EventsSubject))
+	}
+	{
+		const prefix string = ",\"grpc_stream_chunk\":"
+		out.RawString(prefix)
+		out.Int(int(in.GRPCStreamChunk))
+	}
+	{
+		const prefix string = ",\"grpc_stream_max_urls\":"
+		out.RawString(prefix)
+		out.Int(int(in.GRPCStreamMaxURLs))
+	}
 	{
 		const prefix string = ",\"ConfigJSON\":"
 		out.RawString(prefix)
@@ -180,6 +724,71 @@ func easyjson6615c02eEncodeGithubComPatradenYaPracticumGoShortlyInternalAppConfi
 		out.RawString(prefix)
 		out.Int64(int64(in.ServerIdleTimeout))
 	}
+	{
+		const prefix string = ",\"PasswordLockout\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.PasswordLockout))
+	}
+	{
+		const prefix string = ",\"URLResolveTimeout\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.URLResolveTimeout))
+	}
+	{
+		const prefix string = ",\"URLBlocklistReload\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.URLBlocklistReload))
+	}
+	{
+		const prefix string = ",\"JWTKeysReload\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.JWTKeysReload))
+	}
+	{
+		const prefix string = ",\"JWTTokenTTL\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.JWTTokenTTL))
+	}
+	{
+		const prefix string = ",\"JWTRenewBefore\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.JWTRenewBefore))
+	}
+	{
+		const prefix string = ",\"TLSCertReload\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TLSCertReload))
+	}
+	{
+		const prefix string = ",\"WebhookTimeout\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WebhookTimeout))
+	}
+	{
+		const prefix string = ",\"WebhookPollInterval\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WebhookPollInterval))
+	}
+	{
+		const prefix string = ",\"WebhookRetryInterval\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WebhookRetryInterval))
+	}
+	{
+		const prefix string = ",\"WebhookRetryMaxInterval\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WebhookRetryMaxInterval))
+	}
+	{
+		const prefix string = ",\"EventsPollInterval\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.EventsPollInterval))
+	}
+	{
+		const prefix string = ",\"EventsLease\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.EventsLease))
+	}
 	{
 		const prefix string = ",\"ForceEmptyRepo\":"
 		out.RawString(prefix)
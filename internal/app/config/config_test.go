@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"flag"
+	"net/http"
 	"os"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 )
 
 func resetFlags(t *testing.T) {
@@ -88,6 +90,7 @@ func TestLoadConfigPriorityOrder(t *testing.T) {
 
 	t.Setenv("SERVER_ADDRESS", "env-config:8080")
 	t.Setenv("ENABLE_HTTPS", "true")
+	t.Setenv("DEFAULT_REDIRECT_TYPE", "308")
 
 	resetFlags(t)
 
@@ -105,6 +108,10 @@ func TestLoadConfigPriorityOrder(t *testing.T) {
 	if !cfg.EnableHTTPS {
 		t.Errorf("Expected EnableHTTPS to be true, got false")
 	}
+
+	if cfg.DefaultRedirectType != domain.RedirectPermanent {
+		t.Errorf("Expected DefaultRedirectType 308, got %d", cfg.DefaultRedirectType)
+	}
 }
 
 func TestLoadConfigSaveAsFile(t *testing.T) {
@@ -134,3 +141,25 @@ func TestLoadConfigSaveAsFile(t *testing.T) {
 
 	assert.Equal(t, *originalCfg, loadedCfg, "config mismatch after unmarshaling")
 }
+
+func TestAuthCookieSameSiteMode(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+
+	for mode, expected := range map[string]http.SameSite{
+		"":       http.SameSiteDefaultMode,
+		"Lax":    http.SameSiteLaxMode,
+		"strict": http.SameSiteStrictMode,
+		"none":   http.SameSiteNoneMode,
+	} {
+		cfg.AuthCookieSameSite = mode
+		sameSite, ok := cfg.AuthCookieSameSiteMode()
+		assert.True(t, ok, mode)
+		assert.Equal(t, expected, sameSite, mode)
+	}
+
+	cfg.AuthCookieSameSite = "sometimes"
+	_, ok := cfg.AuthCookieSameSiteMode()
+	assert.False(t, ok)
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// API key format constants, keys look like sk_<id>_<secret>.
+const (
+	APIKeyPrefix      = "sk_"
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+	MaxAPIKeyName     = 100
+)
+
+// APIKeyScope represents a permission granted to an API key.
+type APIKeyScope string
+
+// API key scopes.
+const (
+	ScopeRead   APIKeyScope = "read"   // Reading URLs and their settings.
+	ScopeWrite  APIKeyScope = "write"  // Shortening URLs and changing their settings.
+	ScopeDelete APIKeyScope = "delete" // Deleting URLs.
+)
+
+// IsValid checks whether the APIKeyScope is supported.
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case ScopeRead, ScopeWrite, ScopeDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKeyScopes represents the set of permissions granted to an API key.
+type APIKeyScopes []APIKeyScope
+
+// Validate checks that there is at least one scope and all scopes are supported.
+func (ss APIKeyScopes) Validate() error {
+	if len(ss) == 0 {
+		return e.ErrAPIKeyInvalid
+	}
+
+	for _, s := range ss {
+		if !s.IsValid() {
+			return e.ErrAPIKeyInvalid
+		}
+	}
+
+	return nil
+}
+
+// Has checks whether the scope is granted.
+func (ss APIKeyScopes) Has(scope APIKeyScope) bool {
+	for _, s := range ss {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APIKey represents a long-lived credential of a user for server-to-server clients.
+// Only the SHA-256 hash of the key is kept, the key itself is shown to the user once on creation.
+type APIKey struct {
+	ID        string       `json:"id"`
+	UserID    UserID       `json:"user_id"`
+	Name      string       `json:"name"`
+	Hash      string       `json:"hash"`
+	Scopes    APIKeyScopes `json:"scopes"`
+	CreatedAt time.Time    `json:"created_at"`
+	Revoked   bool         `json:"is_revoked"`
+}
+
+// NewAPIKey creates an API key of the user with the given scopes and returns it along with the key itself.
+func NewAPIKey(userID UserID, name string, scopes APIKeyScopes) (*APIKey, string, error) {
+	if len(name) > MaxAPIKeyName {
+		return nil, "", e.ErrAPIKeyInvalid
+	}
+
+	if err := scopes.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	id := make([]byte, apiKeyIDBytes)
+	secret := make([]byte, apiKeySecretBytes)
+
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", e.Wrap("failed to generate api key", err, errLabel)
+	}
+
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", e.Wrap("failed to generate api key", err, errLabel)
+	}
+
+	key := hex.EncodeToString(id)
+	token := APIKeyPrefix + key + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        key,
+		UserID:    userID,
+		Name:      name,
+		Hash:      hashAPIKey(token),
+		Scopes:    append(APIKeyScopes(nil), scopes...),
+		CreatedAt: time.Now(),
+		Revoked:   false,
+	}, token, nil
+}
+
+// IsAPIKey checks whether the token looks like an API key rather than a JWT token.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// ParseAPIKeyID returns the ID part of an API key used to look it up.
+func ParseAPIKeyID(token string) (string, error) {
+	if !IsAPIKey(token) {
+		return "", e.ErrAPIKeyInvalid
+	}
+
+	// secrets may contain underscores, ids are hex encoded.
+	id, secret, found := strings.Cut(strings.TrimPrefix(token, APIKeyPrefix), "_")
+	if !found || len(id) != 2*apiKeyIDBytes || secret == "" {
+		return "", e.ErrAPIKeyInvalid
+	}
+
+	return id, nil
+}
+
+// Matches checks in constant time whether the token is the key.
+func (k *APIKey) Matches(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.Hash), []byte(hashAPIKey(token))) == 1
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash of an API key.
+// Keys are random enough for a fast hash, unlike user chosen passwords.
+func hashAPIKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
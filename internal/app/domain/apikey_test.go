@@ -0,0 +1,68 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	scopes := domain.APIKeyScopes{domain.ScopeRead, domain.ScopeWrite}
+
+	key, token, err := domain.NewAPIKey(userID, "ci", scopes)
+	require.NoError(t, err)
+	assert.True(t, domain.IsAPIKey(token))
+	assert.Equal(t, userID, key.UserID)
+	assert.NotContains(t, key.Hash, token)
+	assert.True(t, key.Matches(token))
+	assert.False(t, key.Matches(token+"x"))
+	assert.True(t, key.Scopes.Has(domain.ScopeWrite))
+	assert.False(t, key.Scopes.Has(domain.ScopeDelete))
+
+	id, err := domain.ParseAPIKeyID(token)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, id)
+
+	other, otherToken, err := domain.NewAPIKey(userID, "ci", scopes)
+	require.NoError(t, err)
+	assert.NotEqual(t, key.ID, other.ID)
+	assert.NotEqual(t, token, otherToken)
+
+	_, _, err = domain.NewAPIKey(userID, "ci", nil)
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	_, _, err = domain.NewAPIKey(userID, "ci", domain.APIKeyScopes{"admin"})
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	_, _, err = domain.NewAPIKey(userID, strings.Repeat("n", domain.MaxAPIKeyName+1), scopes)
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+}
+
+func TestParseAPIKeyID(t *testing.T) {
+	t.Parallel()
+
+	for _, token := range []string{
+		"",
+		"sk_",
+		"sk_0123456789abcdef",
+		"sk_0123456789abcdef_",
+		"sk_0123_secret",
+		"pk_0123456789abcdef_secret",
+		"eyJhbGciOiJIUzI1NiJ9.e30.sig",
+	} {
+		_, err := domain.ParseAPIKeyID(token)
+		require.ErrorIs(t, err, e.ErrAPIKeyInvalid, token)
+	}
+
+	id, err := domain.ParseAPIKeyID("sk_0123456789abcdef_se_cret")
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", id)
+}
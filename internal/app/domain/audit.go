@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+)
+
+// AuditAction represents a link lifecycle event recorded in the audit log.
+type AuditAction string
+
+// Audit actions.
+const (
+	AuditCreated AuditAction = "created" // Shortening of a link.
+	AuditUpdated AuditAction = "updated" // Change of the schedule or the rules of a link.
+	AuditDeleted AuditAction = "deleted" // Deletion of a link by its owner.
+)
+
+// Protocol represents the protocol a request has been received with.
+type Protocol string
+
+// Request protocols.
+const (
+	ProtocolHTTP Protocol = "http"
+	ProtocolGRPC Protocol = "grpc"
+)
+
+// RequestSource represents where a request came from.
+type RequestSource struct {
+	Protocol Protocol
+	ClientIP string
+}
+
+// AuditEvent represents an immutable record of the audit log.
+// The UserID is the actor, Details qualify the action, e.g. what has been updated.
+type AuditEvent struct {
+	ID        int64
+	Action    AuditAction
+	Slug      Slug
+	UserID    UserID
+	Protocol  Protocol
+	ClientIP  string
+	Details   string
+	CreatedAt time.Time
+}
+
+// NewAuditEvent creates an audit log record of an action on a slug taken by the actor from the source.
+func NewAuditEvent(action AuditAction, slug Slug, actor UserID, source RequestSource, details string) AuditEvent {
+	return AuditEvent{
+		ID:        0,
+		Action:    action,
+		Slug:      slug,
+		UserID:    actor,
+		Protocol:  source.Protocol,
+		ClientIP:  source.ClientIP,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+}
@@ -7,35 +7,80 @@ import (
 
 // Static errors.
 var (
-	ErrStateNotmplemented     = errors.New("[memento] state store/restore not implemented")
-	ErrPGEmptyPool            = errors.New("[postgres] empty database conn pool")
-	ErrOriginalExists         = errors.New("[repository] original url exists")
-	ErrSlugExists             = errors.New("[repository] slug exists")
-	ErrSlugNotFound           = errors.New("[repository] slug not found")
-	ErrUserNotFound           = errors.New("[repository] user not found")
-	ErrMissedJob              = errors.New("[batcher] missed output job")
-	ErrMissedTask             = errors.New("[batcher] missed input task")
-	ErrFailedCast             = errors.New("[batcher] failed to cast")
-	ErrSlugInvalid            = errors.New("[shortener] invalid slug")
-	ErrSlugDeleted            = errors.New("[shortener] slug deleted")
-	ErrSlugCollision          = errors.New("[shortener] slug collision")
-	ErrShortenerInternal      = errors.New("[shortener] internal error")
-	ErrStatsProviderInternal  = errors.New("[statsprovider] internal error")
-	ErrRemoverInternal        = errors.New("[remover] internal error")
-	ErrRemoverInitBatcher     = errors.New("[remover] init batcher error")
-	ErrInvalidConfig          = errors.New("[config] bad config parameters")
-	ErrEnvConfigParse         = errors.New("[config] env vars parsing error")
-	ErrUtilsCompEncoding      = errors.New("[utils] bad compression encoding")
-	ErrUtilsDecompionEncoding = errors.New("[utils] bad decompression encoding")
-	ErrUtilsEncoderOpen       = errors.New("[utils] compression encoder open error")
-	ErrUtilsEncoderCast       = errors.New("[utils] compression encoder cast error")
-	ErrURLGenGenerateSlug     = errors.New("[urlgenerator] slug(s) generation error")
-	ErrAuthInvalidToken       = errors.New("[middleware] invalid jwt token")
-	ErrAuthUnexpectedSign     = errors.New("[middleware] unexpected sign method")
-	ErrAuthNoCookie           = errors.New("[middleware] no auth cookie")
-	ErrAuthNoMD               = errors.New("[middleware] no metadata")
-	ErrServerShutdown         = errors.New("[server] server shutdown error")
-	ErrTestGeneral            = errors.New("[test] test error")
+	ErrStateNotmplemented      = errors.New("[memento] state store/restore not implemented")
+	ErrPGEmptyPool             = errors.New("[postgres] empty database conn pool")
+	ErrOriginalExists          = errors.New("[repository] original url exists")
+	ErrSlugExists              = errors.New("[repository] slug exists")
+	ErrSlugNotFound            = errors.New("[repository] slug not found")
+	ErrSlugExhausted           = errors.New("[repository] slug clicks exhausted")
+	ErrUserNotFound            = errors.New("[repository] user not found")
+	ErrUserExists              = errors.New("[repository] user exists")
+	ErrAPIKeyNotFound          = errors.New("[repository] api key not found")
+	ErrWebhookNotFound         = errors.New("[repository] webhook not found")
+	ErrMissedJob               = errors.New("[batcher] missed output job")
+	ErrMissedTask              = errors.New("[batcher] missed input task")
+	ErrFailedCast              = errors.New("[batcher] failed to cast")
+	ErrPassthroughInvalid      = errors.New("[domain] invalid passthrough")
+	ErrPasswordInvalid         = errors.New("[domain] invalid password")
+	ErrActivationWindowInvalid = errors.New("[domain] invalid activation window")
+	ErrRedirectRuleInvalid     = errors.New("[domain] invalid redirect rule")
+	ErrVariantsInvalid         = errors.New("[domain] invalid variants")
+	ErrUserCredentialsInvalid  = errors.New("[domain] invalid email or password")
+	ErrAPIKeyInvalid           = errors.New("[domain] invalid api key")
+	ErrModerationReasonInvalid = errors.New("[domain] invalid moderation reason")
+	ErrModerationActionInvalid = errors.New("[domain] invalid moderation action")
+	ErrWebhookInvalid          = errors.New("[domain] invalid webhook")
+	ErrPasswordRequired        = errors.New("[shortener] password required")
+	ErrPasswordThrottled       = errors.New("[shortener] too many password attempts")
+	ErrSlugInvalid             = errors.New("[shortener] invalid slug")
+	ErrSlugDeleted             = errors.New("[shortener] slug deleted")
+	ErrSlugNotActive           = errors.New("[shortener] slug not yet active")
+	ErrSlugExpired             = errors.New("[shortener] slug expired")
+	ErrSlugDisabled            = errors.New("[shortener] slug disabled")
+	ErrUserBanned              = errors.New("[shortener] user banned")
+	ErrRedirectRuleNotFound    = errors.New("[shortener] redirect rule not found")
+	ErrSlugCollision           = errors.New("[shortener] slug collision")
+	ErrOriginalOptions         = errors.New("[shortener] original url exists with other options")
+	ErrShortenerInternal       = errors.New("[shortener] internal error")
+	ErrLoginFailed             = errors.New("[accounts] wrong email or password")
+	ErrAccountsInternal        = errors.New("[accounts] internal error")
+	ErrAPIKeysInternal         = errors.New("[apikeys] internal error")
+	ErrModerationInternal      = errors.New("[moderation] internal error")
+	ErrModerationParams        = errors.New("[moderation] invalid moderation parameters")
+	ErrAuditInternal           = errors.New("[audit] internal error")
+	ErrAuditParams             = errors.New("[audit] invalid audit parameters")
+	ErrWebhooksInternal        = errors.New("[webhooks] internal error")
+	ErrWebhookRejected         = errors.New("[webhooks] delivery rejected by receiver")
+	ErrStatsProviderInternal   = errors.New("[statsprovider] internal error")
+	ErrStatsProviderParams     = errors.New("[statsprovider] invalid stats parameters")
+	ErrRemoverInternal         = errors.New("[remover] internal error")
+	ErrRemoverInitBatcher      = errors.New("[remover] init batcher error")
+	ErrInvalidConfig           = errors.New("[config] bad config parameters")
+	ErrEnvConfigParse          = errors.New("[config] env vars parsing error")
+	ErrDefaultJWTSecret        = errors.New("[config] default jwt secret in production mode")
+	ErrUtilsCompEncoding       = errors.New("[utils] bad compression encoding")
+	ErrUtilsDecompionEncoding  = errors.New("[utils] bad decompression encoding")
+	ErrUtilsEncoderOpen        = errors.New("[utils] compression encoder open error")
+	ErrUtilsEncoderCast        = errors.New("[utils] compression encoder cast error")
+	ErrUtilsCertPool           = errors.New("[utils] no certificates in bundle")
+	ErrUtilsSubnet             = errors.New("[utils] invalid subnet")
+	ErrURLGenGenerateSlug      = errors.New("[urlgenerator] slug(s) generation error")
+	ErrAuthInvalidToken        = errors.New("[middleware] invalid jwt token")
+	ErrAuthUnexpectedSign      = errors.New("[middleware] unexpected sign method")
+	ErrAuthNoCookie            = errors.New("[middleware] no auth cookie")
+	ErrAuthNoMD                = errors.New("[middleware] no metadata")
+	ErrAuthScope               = errors.New("[middleware] api key scope not granted")
+	ErrAuthKeyNotFound         = errors.New("[middleware] jwt key not found")
+	ErrAuthKeyInvalid          = errors.New("[middleware] invalid jwt key")
+	ErrAuthTokenRevoked        = errors.New("[middleware] jwt token revoked")
+	ErrAuthDenylist            = errors.New("[middleware] jwt token denylist error")
+	ErrGeoIPDatabase           = errors.New("[geoip] invalid database")
+	ErrURLSchemeNotAllowed     = errors.New("[urlpolicy] url scheme not allowed")
+	ErrURLPrivateAddress       = errors.New("[urlpolicy] url points to a private address")
+	ErrURLBlocked              = errors.New("[urlpolicy] url domain blocked")
+	ErrServerShutdown          = errors.New("[server] server shutdown error")
+	ErrServerNoCert            = errors.New("[server] no tls certificate")
+	ErrTestGeneral             = errors.New("[test] test error")
 )
 
 // Wrap formats and wraps an error message with a specific label.
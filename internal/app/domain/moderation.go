@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// MaxModerationReason is the maximum length of a moderation reason.
+const MaxModerationReason = 500
+
+// ModerationAction represents an action of an administrator moderating links and users.
+type ModerationAction string
+
+// Moderation actions.
+const (
+	ActionDisableURL ModerationAction = "disable_url" // Disabling a link from redirecting.
+	ActionEnableURL  ModerationAction = "enable_url"  // Re-enabling a disabled link.
+	ActionBanUser    ModerationAction = "ban_user"    // Banning a user from creating links.
+	ActionUnbanUser  ModerationAction = "unban_user"  // Lifting the ban of a user.
+)
+
+// ModerationEntry represents a record of the moderation log.
+// Link actions target the Slug and its owner, user actions target the UserID with an empty Slug.
+// The Actor is the administrator who took the action.
+type ModerationEntry struct {
+	ID        int64            `json:"id"`
+	Action    ModerationAction `json:"action"`
+	Slug      Slug             `json:"slug"`
+	UserID    UserID           `json:"user_id"`
+	Actor     string           `json:"actor"`
+	Reason    string           `json:"reason"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewModerationEntry creates a moderation log record of an action with a required reason.
+func NewModerationEntry(action ModerationAction, actor, reason string) (*ModerationEntry, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > MaxModerationReason {
+		return nil, e.ErrModerationReasonInvalid
+	}
+
+	return &ModerationEntry{
+		ID:        0,
+		Action:    action,
+		Slug:      "",
+		UserID:    UserID{},
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Moderate applies a link moderation action to the URLMapping, user actions are ignored.
+// Disabled links keep the reason they were disabled for.
+func (m *URLMapping) Moderate(action ModerationAction, reason string) {
+	switch action {
+	case ActionDisableURL:
+		m.Disabled, m.DisabledReason = true, reason
+	case ActionEnableURL:
+		m.Disabled, m.DisabledReason = false, ""
+	case ActionBanUser, ActionUnbanUser:
+		return
+	}
+}
@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+func TestNewModerationEntry(t *testing.T) {
+	t.Parallel()
+
+	entry, err := domain.NewModerationEntry(domain.ActionDisableURL, "admin", "  phishing\n")
+	require.NoError(t, err)
+	assert.Equal(t, "phishing", entry.Reason)
+	assert.Equal(t, "admin", entry.Actor)
+	assert.False(t, entry.CreatedAt.IsZero())
+
+	_, err = domain.NewModerationEntry(domain.ActionBanUser, "admin", " ")
+	require.ErrorIs(t, err, e.ErrModerationReasonInvalid)
+
+	_, err = domain.NewModerationEntry(domain.ActionBanUser, "admin", strings.Repeat("r", domain.MaxModerationReason+1))
+	require.ErrorIs(t, err, e.ErrModerationReasonInvalid)
+}
+
+func TestModerate(t *testing.T) {
+	t.Parallel()
+
+	m := domain.NewURLMapping("slug1", "http://example.com", domain.NewUserID())
+
+	m.Moderate(domain.ActionDisableURL, "phishing")
+	assert.True(t, m.Disabled)
+	assert.Equal(t, "phishing", m.DisabledReason)
+
+	m.Moderate(domain.ActionBanUser, "spam")
+	assert.True(t, m.Disabled, "user actions do not affect links")
+
+	m.Moderate(domain.ActionEnableURL, "false positive")
+	assert.False(t, m.Disabled)
+	assert.Empty(t, m.DisabledReason)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"golang.org/x/crypto/bcrypt"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// PasswordHash represents a bcrypt hash of a password protecting an OriginalURL or a User account.
+type PasswordHash string
+
+// NewPasswordHash hashes the given password.
+func NewPasswordHash(password string) (PasswordHash, error) {
+	if password == "" {
+		return "", e.ErrPasswordInvalid
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", e.Wrap("failed to hash password", e.ErrPasswordInvalid, errLabel)
+	}
+
+	return PasswordHash(hash), nil
+}
+
+// IsSet checks whether the PasswordHash protects anything.
+func (h PasswordHash) IsSet() bool {
+	return h != ""
+}
+
+// Matches checks whether the given password matches the PasswordHash.
+func (h PasswordHash) Matches(password string) bool {
+	if !h.IsSet() {
+		return true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(h), []byte(password)) == nil
+}
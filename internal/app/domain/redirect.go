@@ -0,0 +1,44 @@
+package domain
+
+import "net/http"
+
+// RedirectType represents the HTTP status code used to redirect to the OriginalURL.
+type RedirectType int
+
+// Supported redirect types.
+const (
+	RedirectDefault          RedirectType = 0 // Use the service default redirect type.
+	RedirectMovedPermanently RedirectType = http.StatusMovedPermanently
+	RedirectFound            RedirectType = http.StatusFound
+	RedirectTemporary        RedirectType = http.StatusTemporaryRedirect
+	RedirectPermanent        RedirectType = http.StatusPermanentRedirect
+)
+
+// IsValid checks whether the RedirectType is supported.
+func (t RedirectType) IsValid() bool {
+	switch t {
+	case RedirectDefault, RedirectMovedPermanently, RedirectFound, RedirectTemporary, RedirectPermanent:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPermanent checks whether the RedirectType allows clients to cache the redirect.
+func (t RedirectType) IsPermanent() bool {
+	return t == RedirectMovedPermanently || t == RedirectPermanent
+}
+
+// OrDefault returns the RedirectType or the given default if it is not set.
+func (t RedirectType) OrDefault(def RedirectType) RedirectType {
+	if t == RedirectDefault {
+		return def
+	}
+
+	return t
+}
+
+// StatusCode returns the HTTP status code of the RedirectType.
+func (t RedirectType) StatusCode() int {
+	return int(t)
+}
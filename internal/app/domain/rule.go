@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"net/netip"
+	"strings"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// MaxRedirectRules is the maximum number of redirect rules per URLMapping.
+const MaxRedirectRules = 20
+
+// Platform represents the operating system of a visitor device.
+type Platform string
+
+// Supported visitor platforms.
+const (
+	PlatformAny     Platform = ""
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+	PlatformWindows Platform = "windows"
+	PlatformMacOS   Platform = "macos"
+	PlatformLinux   Platform = "linux"
+)
+
+// IsValid checks whether the Platform is supported.
+func (p Platform) IsValid() bool {
+	switch p {
+	case PlatformAny, PlatformIOS, PlatformAndroid, PlatformWindows, PlatformMacOS, PlatformLinux:
+		return true
+	default:
+		return false
+	}
+}
+
+// PlatformFromUserAgent detects the visitor Platform from a User-Agent header value.
+// Unknown agents result in PlatformAny.
+func PlatformFromUserAgent(userAgent string) Platform {
+	switch {
+	case strings.Contains(userAgent, "iPhone"),
+		strings.Contains(userAgent, "iPad"),
+		strings.Contains(userAgent, "iPod"):
+		return PlatformIOS
+	case strings.Contains(userAgent, "Android"):
+		return PlatformAndroid
+	case strings.Contains(userAgent, "Windows"):
+		return PlatformWindows
+	case strings.Contains(userAgent, "Macintosh"):
+		return PlatformMacOS
+	case strings.Contains(userAgent, "Linux"):
+		return PlatformLinux
+	default:
+		return PlatformAny
+	}
+}
+
+// Client describes the visitor of a shortened URL for redirect rules matching.
+type Client struct {
+	Platform  Platform   // The visitor device platform.
+	Languages []string   // The visitor preferred languages, most preferred first.
+	IP        netip.Addr // The visitor IP address.
+	Country   string     // The visitor ISO country code, empty if unknown.
+}
+
+// RedirectRule sends visitors matching all of its conditions to its own target.
+// Empty conditions match any visitor.
+//
+//easyjson:json
+type RedirectRule struct {
+	Platform Platform    `json:"platform,omitempty"`
+	Language string      `json:"language,omitempty"`
+	CIDR     string      `json:"cidr,omitempty"`
+	Country  string      `json:"country,omitempty"`
+	Target   OriginalURL `json:"target"`
+}
+
+// Validate checks that the RedirectRule has a valid target and at least one valid condition.
+func (r *RedirectRule) Validate() error {
+	if !r.Target.IsValid() || !r.Platform.IsValid() {
+		return e.ErrRedirectRuleInvalid
+	}
+
+	if r.Platform == PlatformAny && r.Language == "" && r.CIDR == "" && r.Country == "" {
+		return e.ErrRedirectRuleInvalid
+	}
+
+	if r.CIDR != "" {
+		if _, err := netip.ParsePrefix(r.CIDR); err != nil {
+			return e.Wrap("failed to parse cidr", e.ErrRedirectRuleInvalid, errLabel)
+		}
+	}
+
+	if r.Country != "" && len(r.Country) != 2 {
+		return e.ErrRedirectRuleInvalid
+	}
+
+	return nil
+}
+
+// Matches checks whether the Client satisfies all conditions of the RedirectRule.
+func (r *RedirectRule) Matches(client *Client) bool {
+	if r.Platform != PlatformAny && r.Platform != client.Platform {
+		return false
+	}
+
+	if r.Country != "" && !strings.EqualFold(r.Country, client.Country) {
+		return false
+	}
+
+	if r.CIDR != "" {
+		prefix, err := netip.ParsePrefix(r.CIDR)
+		if err != nil || !client.IP.IsValid() || !prefix.Contains(client.IP.Unmap()) {
+			return false
+		}
+	}
+
+	if r.Language != "" && !r.matchesLanguage(client.Languages) {
+		return false
+	}
+
+	return true
+}
+
+// matchesLanguage matches the rule language against a language or any of its subtags,
+// so that "en" matches "en-US" while "en-US" does not match "en".
+func (r *RedirectRule) matchesLanguage(languages []string) bool {
+	subtags := strings.ToLower(r.Language) + "-"
+
+	for _, lang := range languages {
+		if strings.EqualFold(lang, r.Language) || strings.HasPrefix(strings.ToLower(lang), subtags) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedirectRules is an ordered list of redirect rules, the first matching rule wins.
+//
+//easyjson:json
+type RedirectRules []RedirectRule
+
+// Validate checks the number of rules and each rule.
+func (rs RedirectRules) Validate() error {
+	if len(rs) > MaxRedirectRules {
+		return e.ErrRedirectRuleInvalid
+	}
+
+	for i := range rs {
+		if err := rs[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match returns the target of the first rule matching the Client.
+func (rs RedirectRules) Match(client *Client) (OriginalURL, bool) {
+	for i := range rs {
+		if rs[i].Matches(client) {
+			return rs[i].Target, true
+		}
+	}
+
+	return "", false
+}
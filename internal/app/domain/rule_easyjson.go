@@ -0,0 +1,322 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package domain
+
+import (
+	json "encoding/json"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(in *jlexer.Lexer, out *RedirectRules) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RedirectRules, 0, 0)
+			} else {
+				*out = RedirectRules{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 RedirectRule
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(out *jwriter.Writer, in RedirectRules) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RedirectRules) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RedirectRules) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RedirectRules) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RedirectRules) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(l, v)
+}
+func easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(in *jlexer.Lexer, out *RedirectRule) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "platform":
+			out.Platform = Platform(in.String())
+		case "language":
+			out.Language = string(in.String())
+		case "cidr":
+			out.CIDR = string(in.String())
+		case "country":
+			out.Country = string(in.String())
+		case "target":
+			out.Target = OriginalURL(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(out *jwriter.Writer, in RedirectRule) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Platform != "" {
+		const prefix string = ",\"platform\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Platform))
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Language))
+	}
+	if in.CIDR != "" {
+		const prefix string = ",\"cidr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CIDR))
+	}
+	if in.Country != "" {
+		const prefix string = ",\"country\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Country))
+	}
+	{
+		const prefix string = ",\"target\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Target))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RedirectRule) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RedirectRule) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RedirectRule) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RedirectRule) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(l, v)
+}
+func easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(in *jlexer.Lexer, out *Client) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Platform":
+			out.Platform = Platform(in.String())
+		case "Languages":
+			if in.IsNull() {
+				in.Skip()
+				out.Languages = nil
+			} else {
+				in.Delim('[')
+				if out.Languages == nil {
+					if !in.IsDelim(']') {
+						out.Languages = make([]string, 0, 4)
+					} else {
+						out.Languages = []string{}
+					}
+				} else {
+					out.Languages = (out.Languages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Languages = append(out.Languages, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "IP":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.IP).UnmarshalText(data))
+			}
+		case "Country":
+			out.Country = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(out *jwriter.Writer, in Client) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Platform\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Platform))
+	}
+	{
+		const prefix string = ",\"Languages\":"
+		out.RawString(prefix)
+		if in.Languages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Languages {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"IP\":"
+		out.RawString(prefix)
+		out.RawText((in.IP).MarshalText())
+	}
+	{
+		const prefix string = ",\"Country\":"
+		out.RawString(prefix)
+		out.String(string(in.Country))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Client) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Client) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF1a92ff2EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Client) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Client) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF1a92ff2DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(l, v)
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+func TestPlatformFromUserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		userAgent string
+		platform  domain.Platform
+	}{
+		{"iPhone", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15", domain.PlatformIOS},
+		{"iPad", "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X)", domain.PlatformIOS},
+		{"Android", "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36", domain.PlatformAndroid},
+		{"Windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", domain.PlatformWindows},
+		{"MacOS", "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0)", domain.PlatformMacOS},
+		{"Linux", "Mozilla/5.0 (X11; Linux x86_64)", domain.PlatformLinux},
+		{"Unknown", "curl/8.4.0", domain.PlatformAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.platform, domain.PlatformFromUserAgent(tt.userAgent))
+		})
+	}
+}
+
+func TestRedirectRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    domain.RedirectRule
+		wantErr bool
+	}{
+		{"Platform", domain.RedirectRule{Platform: domain.PlatformIOS, Target: "https://apps.apple.com/app"}, false},
+		{"Language", domain.RedirectRule{Language: "de", Target: "https://example.de"}, false},
+		{"CIDR", domain.RedirectRule{CIDR: "10.0.0.0/8", Target: "https://intranet.example.com"}, false},
+		{"Country", domain.RedirectRule{Country: "FR", Target: "https://example.fr"}, false},
+		{"No Condition", domain.RedirectRule{Target: "https://example.com"}, true},
+		{"Invalid Target", domain.RedirectRule{Language: "de", Target: "example"}, true},
+		{"Unknown Platform", domain.RedirectRule{Platform: "symbian", Target: "https://example.com"}, true},
+		{"Invalid CIDR", domain.RedirectRule{CIDR: "10.0.0.0/33", Target: "https://example.com"}, true},
+		{"Invalid Country", domain.RedirectRule{Country: "FRA", Target: "https://example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, e.ErrRedirectRuleInvalid)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	rules := make(domain.RedirectRules, domain.MaxRedirectRules+1)
+	for i := range rules {
+		rules[i] = domain.RedirectRule{Language: "en", Target: "https://example.com"}
+	}
+
+	require.NoError(t, rules[:domain.MaxRedirectRules].Validate())
+	require.ErrorIs(t, rules.Validate(), e.ErrRedirectRuleInvalid)
+}
+
+func TestRedirectRulesMatch(t *testing.T) {
+	t.Parallel()
+
+	rules := domain.RedirectRules{
+		{Platform: domain.PlatformIOS, Target: "https://apps.apple.com/app"},
+		{Platform: domain.PlatformAndroid, Target: "https://play.google.com/app"},
+		{CIDR: "10.0.0.0/8", Target: "https://intranet.example.com"},
+		{Language: "de", Country: "CH", Target: "https://example.ch"},
+		{Language: "de", Target: "https://example.de"},
+	}
+
+	tests := []struct {
+		name   string
+		client domain.Client
+		target domain.OriginalURL
+		ok     bool
+	}{
+		{"iOS", domain.Client{Platform: domain.PlatformIOS, Languages: []string{"de"}}, "https://apps.apple.com/app", true},
+		{"Android", domain.Client{Platform: domain.PlatformAndroid}, "https://play.google.com/app", true},
+		{"Intranet", domain.Client{IP: netip.MustParseAddr("10.1.2.3")}, "https://intranet.example.com", true},
+		{"Swiss German", domain.Client{Languages: []string{"de-CH"}, Country: "ch"}, "https://example.ch", true},
+		{"German", domain.Client{Languages: []string{"fr", "de-DE"}, Country: "DE"}, "https://example.de", true},
+		{"Default", domain.Client{Platform: domain.PlatformWindows, Languages: []string{"en"}}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			target, ok := rules.Match(&tt.client)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.target, target)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// URLMappingEventType represents a change of URL mappings streamed to downstream consumers.
+type URLMappingEventType string
+
+// URL mapping event types.
+const (
+	URLMappingCreated URLMappingEventType = "urlmapping.created" // Creation of a URL mapping.
+	URLMappingDeleted URLMappingEventType = "urlmapping.deleted" // Deletion of a URL mapping by its owner.
+)
+
+// URLMappingEvent represents a change of a URL mapping kept in the outbox until published.
+// The original URL is only set for created URL mappings.
+type URLMappingEvent struct {
+	ID          int64
+	EventID     string
+	Type        URLMappingEventType
+	Slug        Slug
+	UserID      UserID
+	OriginalURL OriginalURL
+	CreatedAt   time.Time
+}
+
+// NewURLMappingEvent creates a uniquely identified event of a URL mapping owned by the user.
+func NewURLMappingEvent(eventType URLMappingEventType, slug Slug, owner UserID) URLMappingEvent {
+	return URLMappingEvent{
+		ID:          0,
+		EventID:     uuid.NewString(),
+		Type:        eventType,
+		Slug:        slug,
+		UserID:      owner,
+		OriginalURL: "",
+		CreatedAt:   time.Now(),
+	}
+}
+
+// URLMappingCreatedEvent returns the event of a new URL mapping.
+func URLMappingCreatedEvent(m *URLMapping) URLMappingEvent {
+	event := NewURLMappingEvent(URLMappingCreated, m.Slug, m.UserID)
+	event.OriginalURL = m.OriginalURL
+
+	return event
+}
@@ -0,0 +1,27 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+func TestURLMappingEvents(t *testing.T) {
+	t.Parallel()
+
+	urlm := domain.NewURLMapping("slug1", "https://example.com/", domain.NewUserID())
+
+	created := domain.URLMappingCreatedEvent(urlm)
+	assert.Equal(t, domain.URLMappingCreated, created.Type)
+	assert.Equal(t, urlm.Slug, created.Slug)
+	assert.Equal(t, urlm.UserID, created.UserID)
+	assert.Equal(t, urlm.OriginalURL, created.OriginalURL)
+	assert.NotEmpty(t, created.EventID)
+
+	deleted := domain.NewURLMappingEvent(domain.URLMappingDeleted, urlm.Slug, urlm.UserID)
+	assert.Empty(t, deleted.OriginalURL)
+	assert.Zero(t, deleted.ID)
+	assert.NotEqual(t, created.EventID, deleted.EventID)
+}
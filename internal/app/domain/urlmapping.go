@@ -2,7 +2,12 @@ package domain
 
 import (
 	"net/url"
+	"strings"
 	"time"
+
+	"golang.org/x/net/idna"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 )
 
 const (
@@ -49,14 +54,212 @@ func (u OriginalURL) IsValid() bool {
 	return true
 }
 
+// defaultPorts lists ports implied by URL schemes.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// trackingParams lists query parameters which only serve visitor tracking, utm_ prefixed ones aside.
+var trackingParams = map[string]struct{}{
+	"fbclid":  {},
+	"gclid":   {},
+	"dclid":   {},
+	"gbraid":  {},
+	"wbraid":  {},
+	"msclkid": {},
+	"yclid":   {},
+	"igshid":  {},
+	"mc_cid":  {},
+	"mc_eid":  {},
+}
+
+// Canonical returns the normalized form of the OriginalURL used to detect duplicate URLs.
+//
+// Scheme and host are lowercased, internationalized hosts are converted to punycode,
+// default ports, trailing path slashes and redundant percent-encoding are removed
+// and query parameters are sorted. Tracking query parameters are removed on request.
+// URLs which cannot be parsed are returned as is.
+func (u OriginalURL) Canonical(stripTracking bool) OriginalURL {
+	parsedURL, err := url.Parse(u.String())
+	if err != nil || parsedURL.Host == "" {
+		return u
+	}
+
+	parsedURL.Scheme = strings.ToLower(parsedURL.Scheme)
+	parsedURL.Host = canonicalHost(parsedURL)
+
+	// encoded slashes are the only escapes which change the path meaning.
+	if !strings.Contains(strings.ToLower(parsedURL.RawPath), "%2f") {
+		parsedURL.RawPath = ""
+	}
+
+	if len(parsedURL.Path) > 1 {
+		parsedURL.Path = strings.TrimSuffix(parsedURL.Path, "/")
+		parsedURL.RawPath = strings.TrimSuffix(parsedURL.RawPath, "/")
+	}
+
+	if parsedURL.Path == "" {
+		parsedURL.Path = "/"
+	}
+
+	if query, errQuery := url.ParseQuery(parsedURL.RawQuery); errQuery == nil {
+		for key := range query {
+			if stripTracking && isTrackingParam(key) {
+				query.Del(key)
+			}
+		}
+
+		parsedURL.RawQuery = query.Encode()
+	}
+
+	parsedURL.ForceQuery = false
+	parsedURL.RawFragment = ""
+
+	return OriginalURL(parsedURL.String())
+}
+
+// isTrackingParam checks whether a query parameter only serves visitor tracking.
+func isTrackingParam(key string) bool {
+	key = strings.ToLower(key)
+	_, tracking := trackingParams[key]
+
+	return tracking || strings.HasPrefix(key, "utm_")
+}
+
+// canonicalHost returns the lowercased punycode host of a URL without its default port.
+func canonicalHost(parsedURL *url.URL) string {
+	host := strings.TrimSuffix(strings.ToLower(parsedURL.Hostname()), ".")
+	if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+		host = ascii
+	}
+
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	port := parsedURL.Port()
+	if port == "" || port == defaultPorts[parsedURL.Scheme] {
+		return host
+	}
+
+	return host + ":" + port
+}
+
+// WithPassthrough returns the OriginalURL extended with a visitor sub-path and raw query.
+//
+// The sub-path is joined to the original path and must not contain dot segments.
+// Visitor query parameters are appended after the original ones, while parameters
+// already present in the OriginalURL take precedence over visitor ones with the same name.
+func (u OriginalURL) WithPassthrough(rawQuery string, subPath string) (OriginalURL, error) {
+	if rawQuery == "" && subPath == "" {
+		return u, nil
+	}
+
+	parsedURL, err := url.Parse(u.String())
+	if err != nil {
+		return "", e.Wrap("failed to parse original url", err, errLabel)
+	}
+
+	if subPath != "" {
+		for _, segment := range strings.Split(subPath, "/") {
+			if segment == "." || segment == ".." {
+				return "", e.ErrPassthroughInvalid
+			}
+		}
+
+		parsedURL = parsedURL.JoinPath(subPath)
+	}
+
+	if rawQuery != "" {
+		visitorQuery, errQuery := url.ParseQuery(rawQuery)
+		if errQuery != nil {
+			return "", e.Wrap("failed to parse query", e.ErrPassthroughInvalid, errLabel)
+		}
+
+		for key := range parsedURL.Query() {
+			visitorQuery.Del(key)
+		}
+
+		if extra := visitorQuery.Encode(); extra != "" {
+			if parsedURL.RawQuery != "" {
+				parsedURL.RawQuery += "&"
+			}
+
+			parsedURL.RawQuery += extra
+		}
+	}
+
+	return OriginalURL(parsedURL.String()), nil
+}
+
 // URLMapping represents a mapping between a shortened URL (Slug) and its OriginalURL.
+//
+// Original URLs are unique by their Canonical form within a Namespace, which is either
+// the zero UserID shared by all users or the UserID of the owner in per-user mode.
 type URLMapping struct {
-	Slug        Slug        `json:"short_url"`
-	OriginalURL OriginalURL `json:"original_url"`
-	UserID      UserID      `json:"user_id"`
-	CreatedAt   time.Time   `json:"created_at"`
-	ExpiresAt   time.Time   `json:"expires_at"`
-	Deleted     bool        `json:"is_deleted"`
+	Slug           Slug          `json:"short_url"`
+	OriginalURL    OriginalURL   `json:"original_url"`
+	Canonical      OriginalURL   `json:"canonical_url"`
+	Namespace      UserID        `json:"namespace"`
+	UserID         UserID        `json:"user_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	ExpiresAt      time.Time     `json:"expires_at"`
+	Deleted        bool          `json:"is_deleted"`
+	Disabled       bool          `json:"is_disabled"`
+	DisabledReason string        `json:"disabled_reason"`
+	Clicks         int64         `json:"clicks"`
+	RedirectType   RedirectType  `json:"redirect_type"`
+	PassQuery      bool          `json:"pass_query"`
+	PassPath       bool          `json:"pass_path"`
+	PasswordHash   PasswordHash  `json:"password_hash"`
+	MaxClicks      int64         `json:"max_clicks"`
+	ActiveFrom     time.Time     `json:"active_from"`
+	Rules          RedirectRules `json:"rules"`
+	Variants       Variants      `json:"variants"`
+}
+
+// URLMappingOption configures optional settings of a URLMapping.
+type URLMappingOption func(m *URLMapping)
+
+// WithRedirectType sets the redirect type of the URLMapping.
+func WithRedirectType(redirectType RedirectType) URLMappingOption {
+	return func(m *URLMapping) {
+		m.RedirectType = redirectType
+	}
+}
+
+// Exhausted checks whether the URLMapping reached its click limit.
+// A zero MaxClicks means the URLMapping has no click limit.
+func (m *URLMapping) Exhausted() bool {
+	return m.MaxClicks > 0 && m.Clicks >= m.MaxClicks
+}
+
+// IsActive checks whether the URLMapping activation window has started at the given time.
+// A zero ActiveFrom means the URLMapping is active since its creation.
+func (m *URLMapping) IsActive(now time.Time) bool {
+	return m.ActiveFrom.IsZero() || !now.Before(m.ActiveFrom)
+}
+
+// IsExpired checks whether the URLMapping activation window has ended at the given time.
+// A zero ExpiresAt means the URLMapping never expires.
+func (m *URLMapping) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// HasOptions checks whether any optional setting of the URLMapping differs from its default.
+func (m *URLMapping) HasOptions() bool {
+	return m.RedirectType != RedirectDefault || m.PassQuery || m.PassPath || m.PasswordHash.IsSet() ||
+		m.MaxClicks > 0 || m.Scheduled() || len(m.Rules) > 0 || len(m.Variants) > 0
+}
+
+// Scheduled checks whether the URLMapping has an activation window other than the default one.
+func (m *URLMapping) Scheduled() bool {
+	if !m.ActiveFrom.IsZero() {
+		return true
+	}
+
+	return !m.ExpiresAt.IsZero() && m.ExpiresAt.Sub(m.CreatedAt) != defaultExpiration
 }
 
 // ExpiresAfter sets the expiration time of the URLMapping based on a given duration.
@@ -64,17 +267,74 @@ func (m *URLMapping) ExpiresAfter(duration time.Duration) {
 	m.ExpiresAt = m.CreatedAt.Add(duration)
 }
 
+// WithPassthrough enables merging of the visitor query and sub-path into the OriginalURL on redirect.
+func WithPassthrough(query bool, path bool) URLMappingOption {
+	return func(m *URLMapping) {
+		m.PassQuery = query
+		m.PassPath = path
+	}
+}
+
+// WithPasswordHash protects the URLMapping with a password.
+func WithPasswordHash(hash PasswordHash) URLMappingOption {
+	return func(m *URLMapping) {
+		m.PasswordHash = hash
+	}
+}
+
+// WithMaxClicks limits the number of redirects of the URLMapping, zero means no limit.
+func WithMaxClicks(maxClicks int64) URLMappingOption {
+	return func(m *URLMapping) {
+		m.MaxClicks = maxClicks
+	}
+}
+
+// WithActiveFrom postpones the activation of the URLMapping until the given time.
+func WithActiveFrom(activeFrom time.Time) URLMappingOption {
+	return func(m *URLMapping) {
+		m.ActiveFrom = activeFrom.UTC()
+	}
+}
+
+// ValidateActivationWindow checks that an activation window ends after it starts.
+// Zero times stand for an open start or end of the window.
+func ValidateActivationWindow(activeFrom time.Time, expiresAt time.Time) error {
+	if !activeFrom.IsZero() && !expiresAt.IsZero() && !expiresAt.After(activeFrom) {
+		return e.ErrActivationWindowInvalid
+	}
+
+	return nil
+}
+
 // NewURLMapping creates a new URLMapping instance with the given Slug, OriginalURL, and UserID.
-func NewURLMapping(slug Slug, original OriginalURL, userID UserID) *URLMapping {
+// Optional settings are applied in order after defaults.
+func NewURLMapping(slug Slug, original OriginalURL, userID UserID, opts ...URLMappingOption) *URLMapping {
 	m := &URLMapping{
-		Slug:        slug,
-		OriginalURL: original,
-		UserID:      userID,
-		CreatedAt:   time.Now(),
-		Deleted:     false,
+		Slug:           slug,
+		OriginalURL:    original,
+		Canonical:      original.Canonical(false),
+		Namespace:      UserID{},
+		UserID:         userID,
+		CreatedAt:      time.Now(),
+		Deleted:        false,
+		Disabled:       false,
+		DisabledReason: "",
+		Clicks:         0,
+		RedirectType:   RedirectDefault,
+		PassQuery:      false,
+		PassPath:       false,
+		PasswordHash:   "",
+		MaxClicks:      0,
+		ActiveFrom:     time.Time{},
+		Rules:          nil,
+		Variants:       nil,
 	}
 
 	m.ExpiresAfter(defaultExpiration)
 
+	for _, opt := range opts {
+		opt(m)
+	}
+
 	return m
 }
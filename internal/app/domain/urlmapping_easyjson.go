@@ -41,6 +41,14 @@ func easyjson57a14e87DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomai
 			out.Slug = Slug(in.String())
 		case "original_url":
 			out.OriginalURL = OriginalURL(in.String())
+		case "canonical_url":
+			out.Canonical = OriginalURL(in.String())
+		case "namespace":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.Namespace[:], in.Bytes())
+			}
 		case "user_id":
 			if in.IsNull() {
 				in.Skip()
@@ -57,6 +65,30 @@ func easyjson57a14e87DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomai
 			}
 		case "is_deleted":
 			out.Deleted = bool(in.Bool())
+		case "is_disabled":
+			out.Disabled = bool(in.Bool())
+		case "disabled_reason":
+			out.DisabledReason = string(in.String())
+		case "clicks":
+			out.Clicks = int64(in.Int64())
+		case "redirect_type":
+			out.RedirectType = RedirectType(in.Int())
+		case "pass_query":
+			out.PassQuery = bool(in.Bool())
+		case "pass_path":
+			out.PassPath = bool(in.Bool())
+		case "password_hash":
+			out.PasswordHash = PasswordHash(in.String())
+		case "max_clicks":
+			out.MaxClicks = int64(in.Int64())
+		case "active_from":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ActiveFrom).UnmarshalJSON(data))
+			}
+		case "rules":
+			(out.Rules).UnmarshalEasyJSON(in)
+		case "variants":
+			(out.Variants).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -81,6 +113,16 @@ func easyjson57a14e87EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomai
 		out.RawString(prefix)
 		out.String(string(in.OriginalURL))
 	}
+	{
+		const prefix string = ",\"canonical_url\":"
+		out.RawString(prefix)
+		out.String(string(in.Canonical))
+	}
+	{
+		const prefix string = ",\"namespace\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Namespace[:])
+	}
 	{
 		const prefix string = ",\"user_id\":"
 		out.RawString(prefix)
@@ -101,6 +143,61 @@ func easyjson57a14e87EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomai
 		out.RawString(prefix)
 		out.Bool(bool(in.Deleted))
 	}
+	{
+		const prefix string = ",\"is_disabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Disabled))
+	}
+	{
+		const prefix string = ",\"disabled_reason\":"
+		out.RawString(prefix)
+		out.String(string(in.DisabledReason))
+	}
+	{
+		const prefix string = ",\"clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Clicks))
+	}
+	{
+		const prefix string = ",\"redirect_type\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectType))
+	}
+	{
+		const prefix string = ",\"pass_query\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassQuery))
+	}
+	{
+		const prefix string = ",\"pass_path\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassPath))
+	}
+	{
+		const prefix string = ",\"password_hash\":"
+		out.RawString(prefix)
+		out.String(string(in.PasswordHash))
+	}
+	{
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxClicks))
+	}
+	{
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((in.ActiveFrom).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"rules\":"
+		out.RawString(prefix)
+		(in.Rules).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"variants\":"
+		out.RawString(prefix)
+		(in.Variants).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
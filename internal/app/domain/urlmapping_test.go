@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 )
 
 func TestSlugString(t *testing.T) {
@@ -94,3 +95,246 @@ func TestNewURLMapping(t *testing.T) {
 	assert.False(t, mapping.Deleted)
 	assert.WithinDuration(t, time.Now().Add(time.Hour*24*730), mapping.ExpiresAt, time.Second*2)
 }
+
+func TestNewURLMappingWithRedirectType(t *testing.T) {
+	t.Parallel()
+
+	mapping := domain.NewURLMapping("short123", "https://example.com", domain.NewUserID())
+	assert.Equal(t, domain.RedirectDefault, mapping.RedirectType)
+
+	mapping = domain.NewURLMapping(
+		"short123",
+		"https://example.com",
+		domain.NewUserID(),
+		domain.WithRedirectType(domain.RedirectPermanent),
+	)
+	assert.Equal(t, domain.RedirectPermanent, mapping.RedirectType)
+}
+
+func TestURLMappingExhausted(t *testing.T) {
+	t.Parallel()
+
+	mapping := domain.NewURLMapping("short123", "https://example.com", domain.NewUserID())
+	mapping.Clicks = 100
+	assert.False(t, mapping.Exhausted())
+
+	mapping = domain.NewURLMapping("short123", "https://example.com", domain.NewUserID(), domain.WithMaxClicks(2))
+	mapping.Clicks = 1
+	assert.False(t, mapping.Exhausted())
+
+	mapping.Clicks = 2
+	assert.True(t, mapping.Exhausted())
+}
+
+func TestURLMappingActivationWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	mapping := domain.NewURLMapping("short123", "https://example.com", domain.NewUserID())
+	assert.True(t, mapping.IsActive(now))
+	assert.False(t, mapping.IsExpired(now))
+
+	mapping = domain.NewURLMapping(
+		"short123",
+		"https://example.com",
+		domain.NewUserID(),
+		domain.WithActiveFrom(now.Add(time.Hour)),
+	)
+	assert.False(t, mapping.IsActive(now))
+	assert.True(t, mapping.IsActive(now.Add(time.Hour)))
+	assert.True(t, mapping.IsExpired(mapping.ExpiresAt))
+
+	mapping.ExpiresAt = time.Time{}
+	assert.False(t, mapping.IsExpired(now.AddDate(100, 0, 0)))
+}
+
+func TestURLMappingHasOptions(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+
+	assert.False(t, domain.NewURLMapping("short123", "https://example.com", userID).HasOptions())
+	assert.False(t, domain.NewURLMapping(
+		"short123",
+		"https://example.com",
+		userID,
+		domain.WithRedirectType(domain.RedirectDefault),
+		domain.WithPassthrough(false, false),
+		domain.WithMaxClicks(0),
+		domain.WithActiveFrom(time.Time{}),
+	).HasOptions())
+	assert.True(t, domain.NewURLMapping("short123", "https://example.com", userID, domain.WithMaxClicks(1)).HasOptions())
+	assert.True(t, domain.NewURLMapping("short123", "https://example.com", userID, domain.WithPassthrough(true, false)).
+		HasOptions())
+}
+
+func TestURLMappingScheduled(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	mapping := domain.NewURLMapping("short123", "https://example.com", domain.NewUserID())
+	assert.False(t, mapping.Scheduled())
+
+	mapping.ExpiresAt = time.Time{}
+	assert.False(t, mapping.Scheduled())
+
+	mapping.ExpiresAt = now.Add(time.Hour)
+	assert.True(t, mapping.Scheduled())
+
+	mapping = domain.NewURLMapping(
+		"short123",
+		"https://example.com",
+		domain.NewUserID(),
+		domain.WithActiveFrom(now.Add(time.Hour)),
+	)
+	assert.True(t, mapping.Scheduled())
+}
+
+func TestValidateActivationWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	require.NoError(t, domain.ValidateActivationWindow(time.Time{}, time.Time{}))
+	require.NoError(t, domain.ValidateActivationWindow(now, time.Time{}))
+	require.NoError(t, domain.ValidateActivationWindow(time.Time{}, now))
+	require.NoError(t, domain.ValidateActivationWindow(now, now.Add(time.Second)))
+	require.ErrorIs(t, domain.ValidateActivationWindow(now, now), e.ErrActivationWindowInvalid)
+	require.ErrorIs(t, domain.ValidateActivationWindow(now, now.Add(-time.Second)), e.ErrActivationWindowInvalid)
+}
+
+func TestRedirectType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		rtype     domain.RedirectType
+		valid     bool
+		permanent bool
+		resolved  domain.RedirectType
+	}{
+		{"Default", domain.RedirectDefault, true, false, domain.RedirectTemporary},
+		{"Moved permanently", domain.RedirectMovedPermanently, true, true, domain.RedirectMovedPermanently},
+		{"Found", domain.RedirectFound, true, false, domain.RedirectFound},
+		{"Temporary", domain.RedirectTemporary, true, false, domain.RedirectTemporary},
+		{"Permanent", domain.RedirectPermanent, true, true, domain.RedirectPermanent},
+		{"Unsupported", domain.RedirectType(200), false, false, domain.RedirectType(200)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.rtype.IsValid())
+			assert.Equal(t, tt.permanent, tt.rtype.IsPermanent())
+			assert.Equal(t, tt.resolved, tt.rtype.OrDefault(domain.RedirectTemporary))
+		})
+	}
+}
+
+func TestOriginalURLWithPassthrough(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		original domain.OriginalURL
+		query    string
+		subPath  string
+		expected domain.OriginalURL
+		wantErr  error
+	}{
+		{"Nothing to pass", "https://example.com/a?x=1", "", "", "https://example.com/a?x=1", nil},
+		{"Query appended", "https://example.com/a", "utm_source=x", "", "https://example.com/a?utm_source=x", nil},
+		{
+			"Original parameters take precedence",
+			"https://example.com/a?utm_source=orig&b=2",
+			"utm_source=x&utm_medium=y",
+			"",
+			"https://example.com/a?utm_source=orig&b=2&utm_medium=y",
+			nil,
+		},
+		{"Sub-path joined", "https://example.com/a/", "", "b/c", "https://example.com/a/b/c", nil},
+		{"Sub-path on bare host", "https://example.com", "", "b", "https://example.com/b", nil},
+		{
+			"Sub-path and query",
+			"https://example.com/a?x=1#top",
+			"y=2",
+			"b",
+			"https://example.com/a/b?x=1&y=2#top",
+			nil,
+		},
+		{"Dot segments rejected", "https://example.com/a", "", "../b", "", e.ErrPassthroughInvalid},
+		{"Malformed query rejected", "https://example.com/a", "x=%zz", "", "", e.ErrPassthroughInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.original.WithPassthrough(tt.query, tt.subPath)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestOriginalURLCanonical(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		original      domain.OriginalURL
+		stripTracking bool
+		expected      domain.OriginalURL
+	}{
+		{"Lowercase host", "HTTP://Example.COM/a", false, "http://example.com/a"},
+		{"Trailing slash", "http://example.com/a/", false, "http://example.com/a"},
+		{"Root path", "http://example.com", false, "http://example.com/"},
+		{"Default port", "http://example.com:80/a", false, "http://example.com/a"},
+		{"Default https port", "https://example.com:443/a", false, "https://example.com/a"},
+		{"Custom port", "http://example.com:8080/a", false, "http://example.com:8080/a"},
+		{"Trailing host dot", "http://example.com./a", false, "http://example.com/a"},
+		{"Unreserved escapes", "http://example.com/%7Euser/a%2db", false, "http://example.com/~user/a-b"},
+		{"Escaped slash kept", "http://example.com/a%2Fb", false, "http://example.com/a%2Fb"},
+		{"Sorted query", "http://example.com/a?b=2&a=1", false, "http://example.com/a?a=1&b=2"},
+		{"Empty query", "http://example.com/a?", false, "http://example.com/a"},
+		{"Tracking kept", "http://example.com/a?utm_source=x&id=1", false, "http://example.com/a?id=1&utm_source=x"},
+		{"Tracking stripped", "http://example.com/a?UTM_source=x&fbclid=y&id=1", true, "http://example.com/a?id=1"},
+		{"IDN host", "https://пример.рф/a", false, "https://xn--e1afmkfd.xn--p1ai/a"},
+		{"IPv6 host", "http://[::1]:80/a", false, "http://[::1]/a"},
+		{"Fragment kept", "http://example.com/a#Top", false, "http://example.com/a#Top"},
+		{"Unparsable", "http://example.com/%zz", false, "http://example.com/%zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.original.Canonical(tt.stripTracking))
+		})
+	}
+
+	// variants of the same url share the canonical form.
+	canonical := domain.OriginalURL("http://Example.com/a").Canonical(false)
+	assert.Equal(t, canonical, domain.OriginalURL("http://example.com/a/").Canonical(false))
+	assert.Equal(t, canonical, domain.OriginalURL("http://example.com:80/a").Canonical(false))
+}
+
+func TestPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	_, err := domain.NewPasswordHash("")
+	require.ErrorIs(t, err, e.ErrPasswordInvalid)
+
+	hash, err := domain.NewPasswordHash("secret")
+	require.NoError(t, err)
+	assert.True(t, hash.IsSet())
+	assert.NotEqual(t, domain.PasswordHash("secret"), hash)
+	assert.True(t, hash.Matches("secret"))
+	assert.False(t, hash.Matches("wrong"))
+
+	mapping := domain.NewURLMapping("short123", "https://example.com", domain.NewUserID(), domain.WithPasswordHash(hash))
+	assert.Equal(t, hash, mapping.PasswordHash)
+	assert.True(t, domain.PasswordHash("").Matches("anything"))
+}
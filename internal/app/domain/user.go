@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// User account password limits.
+const (
+	MinUserPasswordLength = 8
+	MaxUserPasswordLength = 72 // bcrypt ignores anything beyond 72 bytes.
+)
+
+// Email represents a normalized email address of a registered user.
+type Email string
+
+// String returns the string representation of the Email.
+func (m Email) String() string {
+	return string(m)
+}
+
+// ParseEmail validates a bare email address and normalizes it to lower case.
+func ParseEmail(address string) (Email, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address {
+		return "", e.ErrUserCredentialsInvalid
+	}
+
+	return Email(address), nil
+}
+
+// User represents a registered user account owning the URL mappings of its UserID.
+type User struct {
+	ID           UserID       `json:"id"`
+	Email        Email        `json:"email"`
+	PasswordHash PasswordHash `json:"password_hash"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// NewUser creates a user account with a new UserID for the given email and password.
+func NewUser(email, password string) (*User, error) {
+	address, err := ParseEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(password) < MinUserPasswordLength || len(password) > MaxUserPasswordLength {
+		return nil, e.ErrUserCredentialsInvalid
+	}
+
+	hash, err := NewPasswordHash(password)
+	if err != nil {
+		return nil, e.Wrap("failed to hash user password", e.ErrUserCredentialsInvalid, errLabel)
+	}
+
+	return &User{
+		ID:           NewUserID(),
+		Email:        address,
+		PasswordHash: hash,
+		CreatedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+func TestParseEmail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		email   domain.Email
+		wantErr bool
+	}{
+		{"user@example.com", "user@example.com", false},
+		{" User@Example.COM ", "user@example.com", false},
+		{"", "", true},
+		{"user", "", true},
+		{"User <user@example.com>", "", true},
+		{"user@example.com, other@example.com", "", true},
+	}
+
+	for _, tt := range tests {
+		email, err := domain.ParseEmail(tt.address)
+		if tt.wantErr {
+			require.ErrorIs(t, err, e.ErrUserCredentialsInvalid, tt.address)
+
+			continue
+		}
+
+		require.NoError(t, err, tt.address)
+		assert.Equal(t, tt.email, email)
+	}
+}
+
+func TestNewUser(t *testing.T) {
+	t.Parallel()
+
+	user, err := domain.NewUser("User@Example.com", "s3cret-pass")
+	require.NoError(t, err)
+	assert.False(t, user.ID.IsNil())
+	assert.Equal(t, domain.Email("user@example.com"), user.Email)
+	assert.True(t, user.PasswordHash.Matches("s3cret-pass"))
+	assert.False(t, user.PasswordHash.Matches("wrong-pass"))
+
+	_, err = domain.NewUser("not an email", "s3cret-pass")
+	require.ErrorIs(t, err, e.ErrUserCredentialsInvalid)
+
+	_, err = domain.NewUser("user@example.com", "short")
+	require.ErrorIs(t, err, e.ErrUserCredentialsInvalid)
+
+	_, err = domain.NewUser("user@example.com", strings.Repeat("p", domain.MaxUserPasswordLength+1))
+	require.ErrorIs(t, err, e.ErrUserCredentialsInvalid)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// Limits of A/B split redirect targets per URLMapping.
+const (
+	MinVariants      = 2
+	MaxVariants      = 10
+	MaxVariantWeight = 1000
+)
+
+// Variant is one of the weighted redirect targets of an A/B split URLMapping.
+//
+//easyjson:json
+type Variant struct {
+	Target OriginalURL `json:"target"`
+	Weight int         `json:"weight"`
+	Clicks int64       `json:"clicks"`
+}
+
+// Variants is a list of weighted redirect targets.
+// Variants are referred to by their one-based number, zero stands for no variant.
+//
+//easyjson:json
+type Variants []Variant
+
+// Validate checks the number of variants, their targets and weights.
+// An empty list is valid and means the URLMapping is not split.
+func (vs Variants) Validate() error {
+	if len(vs) == 0 {
+		return nil
+	}
+
+	if len(vs) < MinVariants || len(vs) > MaxVariants {
+		return e.ErrVariantsInvalid
+	}
+
+	for _, v := range vs {
+		if !v.Target.IsValid() || v.Weight <= 0 || v.Weight > MaxVariantWeight {
+			return e.ErrVariantsInvalid
+		}
+	}
+
+	return nil
+}
+
+// TotalWeight returns the sum of variant weights.
+func (vs Variants) TotalWeight() int {
+	total := 0
+	for _, v := range vs {
+		total += v.Weight
+	}
+
+	return total
+}
+
+// Has checks whether a variant with the given number exists.
+func (vs Variants) Has(number int) bool {
+	return number > 0 && number <= len(vs)
+}
+
+// Pick returns the number of the variant owning the given point of the cumulative weights range,
+// so that a point drawn uniformly from [0, TotalWeight) selects variants proportionally to their weights.
+// Zero is returned for points out of range.
+func (vs Variants) Pick(point int) int {
+	if point < 0 {
+		return 0
+	}
+
+	for i, v := range vs {
+		if point < v.Weight {
+			return i + 1
+		}
+
+		point -= v.Weight
+	}
+
+	return 0
+}
+
+// Target returns the redirect target of the variant with the given number.
+func (vs Variants) Target(number int) OriginalURL {
+	if !vs.Has(number) {
+		return ""
+	}
+
+	return vs[number-1].Target
+}
+
+// WithVariants splits redirects of the URLMapping between weighted targets.
+// Click counts of the given variants are reset.
+func WithVariants(variants Variants) URLMappingOption {
+	return func(m *URLMapping) {
+		if len(variants) == 0 {
+			m.Variants = nil
+
+			return
+		}
+
+		m.Variants = make(Variants, len(variants))
+		for i, v := range variants {
+			m.Variants[i] = Variant{Target: v.Target, Weight: v.Weight, Clicks: 0}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package domain
+
+import (
+	json "encoding/json"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson28164ed1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(in *jlexer.Lexer, out *Variants) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Variants, 0, 2)
+			} else {
+				*out = Variants{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Variant
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson28164ed1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(out *jwriter.Writer, in Variants) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Variants) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson28164ed1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Variants) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson28164ed1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Variants) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson28164ed1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Variants) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson28164ed1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(l, v)
+}
+func easyjson28164ed1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(in *jlexer.Lexer, out *Variant) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "target":
+			out.Target = OriginalURL(in.String())
+		case "weight":
+			out.Weight = int(in.Int())
+		case "clicks":
+			out.Clicks = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson28164ed1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(out *jwriter.Writer, in Variant) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"weight\":"
+		out.RawString(prefix)
+		out.Int(int(in.Weight))
+	}
+	{
+		const prefix string = ",\"clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Clicks))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Variant) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson28164ed1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Variant) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson28164ed1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Variant) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson28164ed1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Variant) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson28164ed1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(l, v)
+}
@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+func TestVariantsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		variants domain.Variants
+		wantErr  bool
+	}{
+		{"Empty", nil, false},
+		{"Split", domain.Variants{
+			{Target: "https://a.example.com", Weight: 70},
+			{Target: "https://b.example.com", Weight: 30},
+		}, false},
+		{"Single", domain.Variants{{Target: "https://a.example.com", Weight: 100}}, true},
+		{"Zero Weight", domain.Variants{
+			{Target: "https://a.example.com", Weight: 1},
+			{Target: "https://b.example.com", Weight: 0},
+		}, true},
+		{"Heavy Weight", domain.Variants{
+			{Target: "https://a.example.com", Weight: 1},
+			{Target: "https://b.example.com", Weight: 1001},
+		}, true},
+		{"Invalid Target", domain.Variants{
+			{Target: "https://a.example.com", Weight: 1},
+			{Target: "b.example.com", Weight: 1},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.variants.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, e.ErrVariantsInvalid)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	variants := make(domain.Variants, domain.MaxVariants+1)
+	for i := range variants {
+		variants[i] = domain.Variant{Target: "https://example.com", Weight: 1, Clicks: 0}
+	}
+
+	require.NoError(t, variants[:domain.MaxVariants].Validate())
+	require.ErrorIs(t, variants.Validate(), e.ErrVariantsInvalid)
+}
+
+func TestVariantsPick(t *testing.T) {
+	t.Parallel()
+
+	variants := domain.Variants{
+		{Target: "https://a.example.com", Weight: 70, Clicks: 0},
+		{Target: "https://b.example.com", Weight: 30, Clicks: 0},
+	}
+
+	assert.Equal(t, 100, variants.TotalWeight())
+	assert.Equal(t, 1, variants.Pick(0))
+	assert.Equal(t, 1, variants.Pick(69))
+	assert.Equal(t, 2, variants.Pick(70))
+	assert.Equal(t, 2, variants.Pick(99))
+	assert.Equal(t, 0, variants.Pick(100))
+	assert.Equal(t, 0, variants.Pick(-1))
+
+	assert.True(t, variants.Has(2))
+	assert.False(t, variants.Has(0))
+	assert.False(t, variants.Has(3))
+	assert.Equal(t, domain.OriginalURL("https://b.example.com"), variants.Target(2))
+	assert.Empty(t, variants.Target(3))
+}
+
+func TestWithVariants(t *testing.T) {
+	t.Parallel()
+
+	variants := domain.Variants{
+		{Target: "https://a.example.com", Weight: 1, Clicks: 5},
+		{Target: "https://b.example.com", Weight: 2, Clicks: 7},
+	}
+
+	m := domain.NewURLMapping("slug", "https://example.com", domain.NewUserID(), domain.WithVariants(variants))
+	require.Len(t, m.Variants, 2)
+	assert.Equal(t, domain.Variant{Target: "https://b.example.com", Weight: 2, Clicks: 0}, m.Variants[1])
+	assert.Equal(t, int64(7), variants[1].Clicks)
+
+	m = domain.NewURLMapping("slug", "https://example.com", domain.NewUserID(), domain.WithVariants(nil))
+	assert.Nil(t, m.Variants)
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// Webhook format constants.
+const (
+	MaxWebhookURL          = 2048
+	MinWebhookSecret       = 16
+	MaxWebhookSecret       = 256
+	WebhookSignaturePrefix = "sha256="
+	webhookIDBytes         = 8
+	webhookSecretBytes     = 32
+)
+
+// WebhookEventType represents a link event users can be notified about.
+type WebhookEventType string
+
+// Webhook event types.
+const (
+	WebhookLinkCreated   WebhookEventType = "link.created"   // Shortening of a link.
+	WebhookLinkDeleted   WebhookEventType = "link.deleted"   // Deletion of a link by its owner.
+	WebhookLinkMilestone WebhookEventType = "link.milestone" // A link reaching a click milestone.
+)
+
+// WebhookDeliveryStatus represents the state of a webhook delivery in the outbox.
+type WebhookDeliveryStatus string
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for the next attempt.
+	DeliveryDelivered WebhookDeliveryStatus = "delivered" // Accepted by the receiver.
+	DeliveryDead      WebhookDeliveryStatus = "dead"      // Given up after too many failed attempts.
+)
+
+// Webhook represents a subscription of a user to events of their links.
+// Events are posted to the URL and signed with the secret.
+type Webhook struct {
+	ID        string      `json:"id"`
+	UserID    UserID      `json:"user_id"`
+	URL       OriginalURL `json:"url"`
+	Secret    string      `json:"secret"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// NewWebhook creates a webhook of the user posting to an http(s) URL.
+// A random secret is generated unless one is given.
+func NewWebhook(userID UserID, target OriginalURL, secret string) (*Webhook, error) {
+	if len(target) > MaxWebhookURL || !target.IsValid() {
+		return nil, e.ErrWebhookInvalid
+	}
+
+	parsed, err := url.Parse(target.String())
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, e.ErrWebhookInvalid
+	}
+
+	if secret != "" && (len(secret) < MinWebhookSecret || len(secret) > MaxWebhookSecret) {
+		return nil, e.ErrWebhookInvalid
+	}
+
+	id := make([]byte, webhookIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return nil, e.Wrap("failed to generate webhook", err, errLabel)
+	}
+
+	if secret == "" {
+		random := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(random); err != nil {
+			return nil, e.Wrap("failed to generate webhook", err, errLabel)
+		}
+
+		secret = base64.RawURLEncoding.EncodeToString(random)
+	}
+
+	return &Webhook{
+		ID:        hex.EncodeToString(id),
+		UserID:    userID,
+		URL:       target,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Sign returns the signature of a payload: the hex encoded HMAC-SHA256 of the payload keyed with the secret.
+func (w *Webhook) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write(payload)
+
+	return WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookEvent represents an event of a link sent to webhooks of the link owner.
+// The original URL is only set for created links and clicks for milestones.
+type WebhookEvent struct {
+	ID          string
+	Type        WebhookEventType
+	Slug        Slug
+	OriginalURL OriginalURL
+	UserID      UserID
+	Clicks      int64
+	CreatedAt   time.Time
+}
+
+// NewWebhookEvent creates a uniquely identified event of a link owned by the user.
+func NewWebhookEvent(eventType WebhookEventType, slug Slug, owner UserID) WebhookEvent {
+	return WebhookEvent{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		Slug:        slug,
+		OriginalURL: "",
+		UserID:      owner,
+		Clicks:      0,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// WebhookDelivery represents a delivery of an event payload to a webhook kept in the outbox until delivered.
+// Deliveries failing too many times are dead-lettered.
+type WebhookDelivery struct {
+	ID            int64
+	Webhook       Webhook
+	EventID       string
+	EventType     WebhookEventType
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// NewWebhookDelivery creates a pending delivery of the event payload to the webhook due immediately.
+func NewWebhookDelivery(hook *Webhook, event *WebhookEvent, payload []byte) WebhookDelivery {
+	return WebhookDelivery{
+		ID:            0,
+		Webhook:       *hook,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        DeliveryPending,
+		Attempts:      0,
+		NextAttemptAt: event.CreatedAt,
+		LastError:     "",
+		CreatedAt:     event.CreatedAt,
+	}
+}
+
+// Fail records a failed attempt, the delivery is retried after the delay or dead-lettered after maxAttempts.
+func (d *WebhookDelivery) Fail(reason string, delay time.Duration, maxAttempts int) {
+	d.Attempts++
+	d.LastError = reason
+
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryDead
+
+		return
+	}
+
+	d.NextAttemptAt = time.Now().Add(delay)
+}
+
+// Succeed records a successful attempt.
+func (d *WebhookDelivery) Succeed() {
+	d.Attempts++
+	d.LastError = ""
+	d.Status = DeliveryDelivered
+}
@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+func TestNewWebhook(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+
+	hook, err := domain.NewWebhook(userID, "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+	assert.Equal(t, userID, hook.UserID)
+	assert.NotEmpty(t, hook.ID)
+	assert.GreaterOrEqual(t, len(hook.Secret), domain.MinWebhookSecret)
+
+	other, err := domain.NewWebhook(userID, "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+	assert.NotEqual(t, hook.ID, other.ID)
+	assert.NotEqual(t, hook.Secret, other.Secret)
+
+	secret := strings.Repeat("s", domain.MinWebhookSecret)
+	hook, err = domain.NewWebhook(userID, "http://crm.example.com/hooks", secret)
+	require.NoError(t, err)
+	assert.Equal(t, secret, hook.Secret)
+
+	invalid := []struct {
+		target domain.OriginalURL
+		secret string
+	}{
+		{"ftp://crm.example.com/hooks", ""},
+		{"not a url", ""},
+		{domain.OriginalURL("https://crm.example.com/" + strings.Repeat("a", domain.MaxWebhookURL)), ""},
+		{"https://crm.example.com/hooks", "short"},
+		{"https://crm.example.com/hooks", strings.Repeat("s", domain.MaxWebhookSecret+1)},
+	}
+
+	for _, tt := range invalid {
+		_, err = domain.NewWebhook(userID, tt.target, tt.secret)
+		require.ErrorIs(t, err, e.ErrWebhookInvalid, tt.target)
+	}
+}
+
+func TestWebhookSign(t *testing.T) {
+	t.Parallel()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	payload := []byte(`{"type":"link.created"}`)
+	mac := hmac.New(sha256.New, []byte(hook.Secret))
+	mac.Write(payload)
+
+	assert.Equal(t, domain.WebhookSignaturePrefix+hex.EncodeToString(mac.Sum(nil)), hook.Sign(payload))
+	assert.NotEqual(t, hook.Sign(payload), hook.Sign([]byte(`{}`)))
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkDeleted, "slug", hook.UserID)
+	delivery := domain.NewWebhookDelivery(hook, &event, []byte(`{}`))
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Equal(t, event.ID, delivery.EventID)
+	assert.Equal(t, event.CreatedAt, delivery.NextAttemptAt)
+
+	delivery.Fail("status 500", time.Minute, 2)
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "status 500", delivery.LastError)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now().Add(time.Minute/2)))
+
+	retried := delivery
+	retried.Succeed()
+	assert.Equal(t, domain.DeliveryDelivered, retried.Status)
+	assert.Equal(t, 2, retried.Attempts)
+	assert.Empty(t, retried.LastError)
+
+	delivery.Fail("status 502", time.Minute, 2)
+	assert.Equal(t, domain.DeliveryDead, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, "status 502", delivery.LastError)
+}
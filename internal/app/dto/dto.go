@@ -1,12 +1,23 @@
 package dto
 
-import "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+import (
+	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
 
 // ShortenURLRequest represents a request payload to shorten a URL.
 //
 //easyjson:json
 type ShortenURLRequest struct {
-	LongURL string `json:"url"` // The original long URL to be shortened.
+	LongURL      string              `json:"url"`                     // The original long URL to be shortened.
+	RedirectType domain.RedirectType `json:"redirect_type,omitempty"` // The redirect HTTP status code (optional).
+	PassQuery    bool                `json:"pass_query,omitempty"`    // Whether to pass the visitor query on redirect.
+	PassPath     bool                `json:"pass_path,omitempty"`     // Whether to pass the visitor sub-path on redirect.
+	Password     string              `json:"password,omitempty"`      // The password protecting the URL (optional).
+	MaxClicks    int64               `json:"max_clicks,omitempty"`    // The redirects limit, zero is unlimited (optional).
+	ActiveFrom   time.Time           `json:"active_from,omitempty"`   // The activation time (optional).
+	Variants     domain.Variants     `json:"variants,omitempty"`      // The weighted A/B split targets (optional).
 }
 
 // ShortenedURLResponse represents the response containing a shortened URL.
@@ -20,8 +31,18 @@ type ShortenedURLResponse struct {
 //
 //easyjson:json
 type URLPair struct {
-	Slug        domain.Slug        `json:"short_url"`    // The shortened slug.
-	OriginalURL domain.OriginalURL `json:"original_url"` // The original full URL.
+	Slug        domain.Slug        `json:"short_url"`            // The shortened slug.
+	OriginalURL domain.OriginalURL `json:"original_url"`         // The original full URL.
+	Clicks      int64              `json:"clicks,omitempty"`     // The number of redirects made.
+	MaxClicks   int64              `json:"max_clicks,omitempty"` // The redirects limit, omitted when unlimited.
+}
+
+// URLRejection represents the response to a destination URL rejected by the URL policy.
+//
+//easyjson:json
+type URLRejection struct {
+	Error  string `json:"error"`  // The rejection error message.
+	Reason string `json:"reason"` // The rejection reason code.
 }
 
 // CorrelatedOriginalURL represents an original URL with a correlation ID.
@@ -42,12 +63,64 @@ type CorrelatedSlug struct {
 	Slug          domain.Slug `json:"short_url"`      // The generated short URL (slug).
 }
 
+// URLInfo represents the public metadata of a shortened URL.
+//
+// Clicks, as well as the original URL of password protected links, are only disclosed to the owner.
+//
+//easyjson:json
+type URLInfo struct {
+	ShortURL    string             `json:"short_url"`              // The full short URL.
+	OriginalURL domain.OriginalURL `json:"original_url,omitempty"` // The original full URL.
+	CreatedAt   time.Time          `json:"created_at"`             // The creation time.
+	ActiveFrom  time.Time          `json:"active_from"`            // The activation time.
+	ExpiresAt   time.Time          `json:"expires_at"`             // The expiration time.
+	Deleted     bool               `json:"is_deleted"`             // Whether the URL has been deleted by its owner.
+	Disabled    bool               `json:"is_disabled"`            // Whether the URL has been disabled by moderators.
+	Expired     bool               `json:"is_expired"`             // Whether the URL is past its activation window.
+	Exhausted   bool               `json:"is_exhausted"`           // Whether the URL reached its clicks limit.
+	Protected   bool               `json:"is_protected"`           // Whether the URL is password protected.
+	Clicks      *int64             `json:"clicks,omitempty"`       // The number of redirects (owner only).
+	Variants    domain.Variants    `json:"variants,omitempty"`     // The A/B split targets with clicks (owner only).
+}
+
+// Visit represents a request to follow a shortened URL.
+type Visit struct {
+	Slug     domain.Slug    // The shortened slug.
+	Probe    bool           // Whether the visit only resolves the URL without counting a click (e.g. HEAD requests).
+	Query    string         // The raw query of the short URL.
+	SubPath  string         // The path following the slug in the short URL.
+	Password string         // The password of a protected URL.
+	Variant  int            // The variant number the visitor was previously assigned, zero if none.
+	Client   *domain.Client // The visitor matched against redirect rules, nil if rules are not matched.
+}
+
+// Redirect represents the outcome of following a shortened URL.
+type Redirect struct {
+	Location  domain.OriginalURL   // The redirect target.
+	Type      domain.RedirectType  // The redirect HTTP status code.
+	Rules     domain.RedirectRules // The conditional redirect targets, Location is the matched one.
+	Variant   int                  // The served variant number of an A/B split URL, zero if not split.
+	Protected bool                 // The URL is protected with a password.
+	Limited   bool                 // The URL has a clicks limit.
+	Scheduled bool                 // The URL has an activation window other than the default one.
+}
+
 // UserSlug represents a mapping between a user and their shortened URL slug.
 type UserSlug struct {
 	Slug   domain.Slug   // The shortened slug.
 	UserID domain.UserID // The user who owns the slug.
 }
 
+// URLSchedule represents the activation window of a shortened URL.
+//
+// Zero ActiveFrom means the URL is active immediately, zero ExpiresAt means it never expires.
+//
+//easyjson:json
+type URLSchedule struct {
+	ActiveFrom time.Time `json:"active_from"` // The activation time.
+	ExpiresAt  time.Time `json:"expires_at"`  // The expiration time.
+}
+
 // OriginalURLBatch is a batch of correlated original URLs.
 //
 //easyjson:json
@@ -88,6 +161,8 @@ func NewURLPairBatch(maps *[]domain.URLMapping, baseURL string) *URLPairBatch {
 		res[i] = URLPair{
 			Slug:        domain.Slug(m.Slug.WithBaseURL(baseURL)), // Append base URL to slug.
 			OriginalURL: m.OriginalURL,
+			Clicks:      m.Clicks,
+			MaxClicks:   m.MaxClicks,
 		}
 	}
 
@@ -98,6 +173,401 @@ func NewURLPairBatch(maps *[]domain.URLMapping, baseURL string) *URLPairBatch {
 //
 //easyjson:json
 type RepoStats struct {
-	CountSlugs int64 `json:"urls"`
-	CountUsers int64 `json:"users"`
+	CountSlugs   int64        `json:"urls"`          // Number of all URLs.
+	CountUsers   int64        `json:"users"`         // Number of users owning URLs.
+	CountActive  int64        `json:"active"`        // Number of URLs neither deleted nor expired.
+	CountDeleted int64        `json:"deleted"`       // Number of deleted URLs.
+	CountExpired int64        `json:"expired"`       // Number of expired URLs which are not deleted.
+	StorageBytes int64        `json:"storage_bytes"` // Size of stored URLs.
+	Daily        []DailyStats `json:"daily"`         // URLs created per day of the last days.
+	TopUsers     []UserStats  `json:"top_users"`     // Users with most URLs.
+}
+
+// DailyStats represents the number of URLs created on a day.
+//
+//easyjson:json
+type DailyStats struct {
+	Date       string `json:"date"` // The day in YYYY-MM-DD format.
+	CountSlugs int64  `json:"urls"`
+}
+
+// UserStats represents the number of URLs of a user.
+//
+//easyjson:json
+type UserStats struct {
+	UserID     string `json:"user_id"`
+	CountSlugs int64  `json:"urls"`
+}
+
+// StatsParams represents parameters of repo statistics.
+type StatsParams struct {
+	Days     int       // Number of the last days of daily stats, today included.
+	TopUsers int       // Number of top users by URLs count.
+	Now      time.Time // Time the stats are computed at.
+}
+
+// Since returns the first day of daily stats.
+func (p StatsParams) Since() time.Time {
+	year, month, day := p.Now.Date()
+
+	return time.Date(year, month, day-p.Days+1, 0, 0, 0, 0, p.Now.Location())
+}
+
+// NewDailyStats creates daily stats of the last days from URLs counts by day, days without URLs included.
+func NewDailyStats(params StatsParams, counts map[string]int64) []DailyStats {
+	daily := make([]DailyStats, 0, params.Days)
+
+	for day := params.Since(); len(daily) < params.Days; day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		daily = append(daily, DailyStats{Date: date, CountSlugs: counts[date]})
+	}
+
+	return daily
+}
+
+// Credentials represents a request payload to register or log in a user account.
+//
+//easyjson:json
+type Credentials struct {
+	Email    string `json:"email"`           // The account email.
+	Password string `json:"password"`        // The account password.
+	Claim    bool   `json:"claim,omitempty"` // Whether to claim the URLs of the current anonymous identity on login.
+}
+
+// Account represents the response to a user account registration or login.
+//
+//easyjson:json
+type Account struct {
+	UserID  string `json:"user_id"` // The account user ID.
+	Email   string `json:"email"`   // The account email.
+	Token   string `json:"token"`   // The JWT token of the account, also set as the auth cookie.
+	Claimed int64  `json:"claimed"` // The number of claimed anonymous URLs.
+}
+
+// APIKeyRequest represents a request payload to create an API key.
+//
+//easyjson:json
+type APIKeyRequest struct {
+	Name   string              `json:"name"`   // The API key name to tell keys apart.
+	Scopes domain.APIKeyScopes `json:"scopes"` // The API key scopes: read, write and delete.
+}
+
+// APIKeyInfo represents an API key of a user.
+//
+//easyjson:json
+type APIKeyInfo struct {
+	ID        string              `json:"id"`            // The API key ID, the part of the key after the sk_ prefix.
+	Name      string              `json:"name"`          // The API key name.
+	Scopes    domain.APIKeyScopes `json:"scopes"`        // The API key scopes.
+	CreatedAt time.Time           `json:"created_at"`    // The creation time.
+	Revoked   bool                `json:"is_revoked"`    // Whether the API key has been revoked.
+	Key       string              `json:"key,omitempty"` // The API key itself, only disclosed on creation.
+}
+
+// NewAPIKeyInfo creates the APIKeyInfo of an API key.
+func NewAPIKeyInfo(key *domain.APIKey) APIKeyInfo {
+	return APIKeyInfo{
+		ID:        key.ID,
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+		Revoked:   key.Revoked,
+		Key:       "",
+	}
+}
+
+// APIKeyInfoBatch represents the API keys of a user.
+//
+//easyjson:json
+type APIKeyInfoBatch []APIKeyInfo
+
+// ModerationRequest represents a request payload of an administrator moderating a URL or a user.
+//
+//easyjson:json
+type ModerationRequest struct {
+	Reason string `json:"reason"` // The required reason of the moderation action.
+}
+
+// AdminURLInfo represents a shortened URL as seen by administrators.
+//
+//easyjson:json
+type AdminURLInfo struct {
+	ShortURL       string             `json:"short_url"`                 // The full short URL.
+	OriginalURL    domain.OriginalURL `json:"original_url"`              // The original full URL.
+	UserID         string             `json:"user_id"`                   // The owner user ID.
+	CreatedAt      time.Time          `json:"created_at"`                // The creation time.
+	ExpiresAt      time.Time          `json:"expires_at"`                // The expiration time.
+	Deleted        bool               `json:"is_deleted"`                // Whether the URL has been deleted by its owner.
+	Disabled       bool               `json:"is_disabled"`               // Whether the URL has been disabled by admins.
+	DisabledReason string             `json:"disabled_reason,omitempty"` // The reason the URL has been disabled for.
+	Protected      bool               `json:"is_protected"`              // Whether the URL is password protected.
+	Clicks         int64              `json:"clicks"`                    // The number of redirects.
+}
+
+// NewAdminURLInfo creates the AdminURLInfo of a URL mapping.
+func NewAdminURLInfo(m *domain.URLMapping, baseURL string) AdminURLInfo {
+	return AdminURLInfo{
+		ShortURL:       m.Slug.WithBaseURL(baseURL),
+		OriginalURL:    m.OriginalURL,
+		UserID:         m.UserID.String(),
+		CreatedAt:      m.CreatedAt,
+		ExpiresAt:      m.ExpiresAt,
+		Deleted:        m.Deleted,
+		Disabled:       m.Disabled,
+		DisabledReason: m.DisabledReason,
+		Protected:      m.PasswordHash.IsSet(),
+		Clicks:         m.Clicks,
+	}
+}
+
+// AdminURLInfoBatch represents shortened URLs as seen by administrators.
+//
+//easyjson:json
+type AdminURLInfoBatch []AdminURLInfo
+
+// ModerationLogEntry represents a record of the moderation log.
+//
+//easyjson:json
+type ModerationLogEntry struct {
+	ID        int64                   `json:"id"`             // The record ID, records are ordered by it.
+	Action    domain.ModerationAction `json:"action"`         // The moderation action.
+	Slug      domain.Slug             `json:"slug,omitempty"` // The moderated slug of URL actions.
+	UserID    string                  `json:"user_id"`        // The moderated user, the owner of the slug of URL actions.
+	Actor     string                  `json:"actor"`          // The administrator, an admin user ID or a client IP.
+	Reason    string                  `json:"reason"`         // The reason of the action.
+	CreatedAt time.Time               `json:"created_at"`     // The time of the action.
+}
+
+// NewModerationLogEntry creates the ModerationLogEntry of a moderation log record.
+func NewModerationLogEntry(entry *domain.ModerationEntry) ModerationLogEntry {
+	return ModerationLogEntry{
+		ID:        entry.ID,
+		Action:    entry.Action,
+		Slug:      entry.Slug,
+		UserID:    entry.UserID.String(),
+		Actor:     entry.Actor,
+		Reason:    entry.Reason,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+// ModerationLog represents records of the moderation log, newest first.
+//
+//easyjson:json
+type ModerationLog []ModerationLogEntry
+
+// AuditFilter represents a query of the audit log by slug, by user or by both.
+type AuditFilter struct {
+	Slug   domain.Slug   // Events of the slug, any slug when empty.
+	UserID domain.UserID // Events of the actor, any actor when nil.
+	Limit  int           // The maximum number of latest events.
+}
+
+// Matches reports whether the audit event matches the filter.
+func (f AuditFilter) Matches(event *domain.AuditEvent) bool {
+	return (f.Slug == "" || f.Slug == event.Slug) && (f.UserID.IsNil() || f.UserID == event.UserID)
+}
+
+// AuditEvent represents a record of the audit log.
+//
+//easyjson:json
+type AuditEvent struct {
+	ID        int64              `json:"id"`                // The record ID, records are ordered by it.
+	Action    domain.AuditAction `json:"action"`            // The link lifecycle event.
+	Slug      domain.Slug        `json:"slug"`              // The slug of the link.
+	UserID    string             `json:"user_id"`           // The actor.
+	Protocol  domain.Protocol    `json:"protocol"`          // The protocol of the request, http or grpc.
+	ClientIP  string             `json:"client_ip"`         // The client IP of the request.
+	Details   string             `json:"details,omitempty"` // What has been updated.
+	CreatedAt time.Time          `json:"created_at"`        // The time of the event.
+}
+
+// NewAuditEvent creates the AuditEvent of an audit log record.
+func NewAuditEvent(event *domain.AuditEvent) AuditEvent {
+	return AuditEvent{
+		ID:        event.ID,
+		Action:    event.Action,
+		Slug:      event.Slug,
+		UserID:    event.UserID.String(),
+		Protocol:  event.Protocol,
+		ClientIP:  event.ClientIP,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// Event converts the AuditEvent back into an audit log record.
+func (a *AuditEvent) Event() (domain.AuditEvent, error) {
+	userID, err := domain.ParseUserID(a.UserID)
+	if err != nil {
+		return domain.AuditEvent{}, err
+	}
+
+	return domain.AuditEvent{
+		ID:        a.ID,
+		Action:    a.Action,
+		Slug:      a.Slug,
+		UserID:    userID,
+		Protocol:  a.Protocol,
+		ClientIP:  a.ClientIP,
+		Details:   a.Details,
+		CreatedAt: a.CreatedAt,
+	}, nil
+}
+
+// AuditLog represents records of the audit log, newest first.
+//
+//easyjson:json
+type AuditLog []AuditEvent
+
+// WebhookRequest represents a request payload to subscribe a webhook.
+//
+//easyjson:json
+type WebhookRequest struct {
+	URL    domain.OriginalURL `json:"url"`    // The http(s) URL events are posted to.
+	Secret string             `json:"secret"` // The optional secret signing events, generated when empty.
+}
+
+// WebhookInfo represents a webhook of a user.
+//
+//easyjson:json
+type WebhookInfo struct {
+	ID        string             `json:"id"`               // The webhook ID.
+	URL       domain.OriginalURL `json:"url"`              // The URL events are posted to.
+	CreatedAt time.Time          `json:"created_at"`       // The subscription time.
+	Secret    string             `json:"secret,omitempty"` // The signing secret, only disclosed on creation.
+}
+
+// NewWebhookInfo creates the WebhookInfo of a webhook.
+func NewWebhookInfo(hook *domain.Webhook) WebhookInfo {
+	return WebhookInfo{
+		ID:        hook.ID,
+		URL:       hook.URL,
+		CreatedAt: hook.CreatedAt,
+		Secret:    "",
+	}
+}
+
+// WebhookInfoBatch represents the webhooks of a user.
+//
+//easyjson:json
+type WebhookInfoBatch []WebhookInfo
+
+// WebhookEvent represents the JSON payload of a webhook event.
+//
+//easyjson:json
+type WebhookEvent struct {
+	ID          string                  `json:"id"`                     // The event ID, the same for every retry.
+	Type        domain.WebhookEventType `json:"type"`                   // The link event.
+	Slug        domain.Slug             `json:"slug"`                   // The slug of the link.
+	ShortURL    string                  `json:"short_url"`              // The full short URL.
+	OriginalURL domain.OriginalURL      `json:"original_url,omitempty"` // The original URL of created links.
+	UserID      string                  `json:"user_id"`                // The link owner.
+	Clicks      int64                   `json:"clicks,omitempty"`       // The click milestone reached.
+	CreatedAt   time.Time               `json:"created_at"`             // The time of the event.
+}
+
+// NewWebhookEvent creates the WebhookEvent payload of a link event.
+func NewWebhookEvent(event *domain.WebhookEvent, baseURL string) WebhookEvent {
+	return WebhookEvent{
+		ID:          event.ID,
+		Type:        event.Type,
+		Slug:        event.Slug,
+		ShortURL:    baseURL + event.Slug.String(),
+		OriginalURL: event.OriginalURL,
+		UserID:      event.UserID.String(),
+		Clicks:      event.Clicks,
+		CreatedAt:   event.CreatedAt,
+	}
+}
+
+// WebhookDeliveryInfo represents a delivery of a webhook event.
+//
+//easyjson:json
+type WebhookDeliveryInfo struct {
+	ID            int64                        `json:"id"`              // The delivery ID.
+	EventID       string                       `json:"event_id"`        // The event ID.
+	EventType     domain.WebhookEventType      `json:"event_type"`      // The link event.
+	Status        domain.WebhookDeliveryStatus `json:"status"`          // The delivery status.
+	Attempts      int                          `json:"attempts"`        // The number of delivery attempts.
+	NextAttemptAt time.Time                    `json:"next_attempt_at"` // The time of the next attempt.
+	LastError     string                       `json:"last_error"`      // The error of the last failed attempt.
+	CreatedAt     time.Time                    `json:"created_at"`      // The time of the event.
+}
+
+// NewWebhookDeliveryInfo creates the WebhookDeliveryInfo of a delivery.
+func NewWebhookDeliveryInfo(delivery *domain.WebhookDelivery) WebhookDeliveryInfo {
+	return WebhookDeliveryInfo{
+		ID:            delivery.ID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+		CreatedAt:     delivery.CreatedAt,
+	}
+}
+
+// WebhookDeliveryBatch represents deliveries of a webhook, newest first.
+//
+//easyjson:json
+type WebhookDeliveryBatch []WebhookDeliveryInfo
+
+// URLMappingEvent represents the JSON payload of a URL mapping event streamed to the message broker.
+//
+//easyjson:json
+type URLMappingEvent struct {
+	ID          string                     `json:"id"`                     // The event ID, the same for every redelivery.
+	Type        domain.URLMappingEventType `json:"type"`                   // The URL mapping change.
+	Slug        domain.Slug                `json:"slug"`                   // The slug of the URL mapping.
+	ShortURL    string                     `json:"short_url"`              // The full short URL.
+	OriginalURL domain.OriginalURL         `json:"original_url,omitempty"` // The original URL of created mappings.
+	UserID      string                     `json:"user_id"`                // The URL mapping owner.
+	CreatedAt   time.Time                  `json:"created_at"`             // The time of the event.
+}
+
+// NewURLMappingEvent creates the URLMappingEvent payload of a URL mapping event.
+func NewURLMappingEvent(event *domain.URLMappingEvent, baseURL string) URLMappingEvent {
+	return URLMappingEvent{
+		ID:          event.EventID,
+		Type:        event.Type,
+		Slug:        event.Slug,
+		ShortURL:    baseURL + event.Slug.String(),
+		OriginalURL: event.OriginalURL,
+		UserID:      event.UserID.String(),
+		CreatedAt:   event.CreatedAt,
+	}
+}
+
+// StateRecordKind represents the kind of a state file record other than a URL mapping.
+type StateRecordKind string
+
+// State file record kinds.
+const (
+	StateRecordBan        StateRecordKind = "ban"        // A banned user.
+	StateRecordModeration StateRecordKind = "moderation" // A moderation log record.
+	StateRecordUser       StateRecordKind = "user"       // A user account.
+	StateRecordAPIKey     StateRecordKind = "api_key"    // An API key.
+	StateRecordToken      StateRecordKind = "token"      // A revoked JWT token.
+	StateRecordWebhook    StateRecordKind = "webhook"    // A webhook subscription.
+)
+
+// RevokedToken represents a JWT token revoked until its expiration.
+type RevokedToken struct {
+	JTI       string    `json:"jti"`        // The token ID.
+	ExpiresAt time.Time `json:"expires_at"` // The token expiration time.
+}
+
+// StateRecord represents a record of the state file other than a URL mapping, URL mappings are stored as is.
+//
+//easyjson:json
+type StateRecord struct {
+	Kind       StateRecordKind         `json:"record"`               // The record kind.
+	Ban        domain.UserID           `json:"ban,omitempty"`        // The banned user of ban records.
+	Moderation *domain.ModerationEntry `json:"moderation,omitempty"` // The log record of moderation records.
+	User       *domain.User            `json:"user,omitempty"`       // The account of user records.
+	APIKey     *domain.APIKey          `json:"api_key,omitempty"`    // The key of API key records.
+	Token      *RevokedToken           `json:"token,omitempty"`      // The revoked token of token records.
+	Webhook    *domain.Webhook         `json:"webhook,omitempty"`    // The subscription of webhook records.
 }
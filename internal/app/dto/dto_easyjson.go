@@ -4,11 +4,9 @@ package dto
 
 import (
 	json "encoding/json"
-
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
-
 	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 )
 
@@ -20,7 +18,80 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(in *jlexer.Lexer, out *UserSlugBatch) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(in *jlexer.Lexer, out *WebhookRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.URL = domain.OriginalURL(in.String())
+		case "secret":
+			out.Secret = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(out *jwriter.Writer, in WebhookRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"secret\":"
+		out.RawString(prefix)
+		out.String(string(in.Secret))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(in *jlexer.Lexer, out *WebhookInfoBatch) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -29,16 +100,16 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(i
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(UserSlugBatch, 0, 4)
+				*out = make(WebhookInfoBatch, 0, 0)
 			} else {
-				*out = UserSlugBatch{}
+				*out = WebhookInfoBatch{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v1 domain.Slug
-			v1 = domain.Slug(in.String())
+			var v1 WebhookInfo
+			(v1).UnmarshalEasyJSON(in)
 			*out = append(*out, v1)
 			in.WantComma()
 		}
@@ -48,7 +119,7 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(i
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(out *jwriter.Writer, in UserSlugBatch) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(out *jwriter.Writer, in WebhookInfoBatch) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -57,36 +128,36 @@ func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(o
 			if v2 > 0 {
 				out.RawByte(',')
 			}
-			out.String(string(v3))
+			(v3).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UserSlugBatch) MarshalJSON() ([]byte, error) {
+func (v WebhookInfoBatch) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserSlugBatch) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(w, v)
+func (v WebhookInfoBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UserSlugBatch) UnmarshalJSON(data []byte) error {
+func (v *WebhookInfoBatch) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UserSlugBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto(l, v)
+func (v *WebhookInfoBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(in *jlexer.Lexer, out *UserSlug) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(in *jlexer.Lexer, out *WebhookInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -105,14 +176,16 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(
 			continue
 		}
 		switch key {
-		case "Slug":
-			out.Slug = domain.Slug(in.String())
-		case "UserID":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				copy(out.UserID[:], in.Bytes())
+		case "id":
+			out.ID = string(in.String())
+		case "url":
+			out.URL = domain.OriginalURL(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
 			}
+		case "secret":
+			out.Secret = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -123,113 +196,3224 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(out *jwriter.Writer, in UserSlug) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(out *jwriter.Writer, in WebhookInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Slug\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Slug))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"UserID\":"
+		const prefix string = ",\"url\":"
 		out.RawString(prefix)
-		out.Base64Bytes(in.UserID[:])
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	if in.Secret != "" {
+		const prefix string = ",\"secret\":"
+		out.RawString(prefix)
+		out.String(string(in.Secret))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UserSlug) MarshalJSON() ([]byte, error) {
+func (v WebhookInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserSlug) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(w, v)
+func (v WebhookInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UserSlug) UnmarshalJSON(data []byte) error {
+func (v *WebhookInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UserSlug) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto1(l, v)
+func (v *WebhookInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(in *jlexer.Lexer, out *URLPairBatch) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(in *jlexer.Lexer, out *WebhookEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
-		in.Skip()
-		*out = nil
-	} else {
-		in.Delim('[')
-		if *out == nil {
-			if !in.IsDelim(']') {
-				*out = make(URLPairBatch, 0, 2)
-			} else {
-				*out = URLPairBatch{}
-			}
-		} else {
-			*out = (*out)[:0]
+		if isTopLevel {
+			in.Consumed()
 		}
-		for !in.IsDelim(']') {
-			var v6 URLPair
-			(v6).UnmarshalEasyJSON(in)
-			*out = append(*out, v6)
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
 			in.WantComma()
+			continue
 		}
-		in.Delim(']')
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "type":
+			out.Type = domain.WebhookEventType(in.String())
+		case "slug":
+			out.Slug = domain.Slug(in.String())
+		case "short_url":
+			out.ShortURL = string(in.String())
+		case "original_url":
+			out.OriginalURL = domain.OriginalURL(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "clicks":
+			out.Clicks = int64(in.Int64())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
+	in.Delim('}')
 	if isTopLevel {
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(out *jwriter.Writer, in URLPairBatch) {
-	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-		out.RawString("null")
-	} else {
-		out.RawByte('[')
-		for v7, v8 := range in {
-			if v7 > 0 {
-				out.RawByte(',')
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(out *jwriter.Writer, in WebhookEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"short_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ShortURL))
+	}
+	if in.OriginalURL != "" {
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OriginalURL))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	if in.Clicks != 0 {
+		const prefix string = ",\"clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Clicks))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(in *jlexer.Lexer, out *WebhookDeliveryInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "event_id":
+			out.EventID = string(in.String())
+		case "event_type":
+			out.EventType = domain.WebhookEventType(in.String())
+		case "status":
+			out.Status = domain.WebhookDeliveryStatus(in.String())
+		case "attempts":
+			out.Attempts = int(in.Int())
+		case "next_attempt_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.NextAttemptAt).UnmarshalJSON(data))
+			}
+		case "last_error":
+			out.LastError = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
 			}
-			(v8).MarshalEasyJSON(out)
+		default:
+			in.SkipRecursive()
 		}
-		out.RawByte(']')
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(out *jwriter.Writer, in WebhookDeliveryInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"event_id\":"
+		out.RawString(prefix)
+		out.String(string(in.EventID))
+	}
+	{
+		const prefix string = ",\"event_type\":"
+		out.RawString(prefix)
+		out.String(string(in.EventType))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Attempts))
+	}
+	{
+		const prefix string = ",\"next_attempt_at\":"
+		out.RawString(prefix)
+		out.Raw((in.NextAttemptAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookDeliveryInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookDeliveryInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookDeliveryInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookDeliveryInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(in *jlexer.Lexer, out *WebhookDeliveryBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(WebhookDeliveryBatch, 0, 0)
+			} else {
+				*out = WebhookDeliveryBatch{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 WebhookDeliveryInfo
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(out *jwriter.Writer, in WebhookDeliveryBatch) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookDeliveryBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookDeliveryBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookDeliveryBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookDeliveryBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(in *jlexer.Lexer, out *Visit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Slug":
+			out.Slug = domain.Slug(in.String())
+		case "Probe":
+			out.Probe = bool(in.Bool())
+		case "Query":
+			out.Query = string(in.String())
+		case "SubPath":
+			out.SubPath = string(in.String())
+		case "Password":
+			out.Password = string(in.String())
+		case "Variant":
+			out.Variant = int(in.Int())
+		case "Client":
+			if in.IsNull() {
+				in.Skip()
+				out.Client = nil
+			} else {
+				if out.Client == nil {
+					out.Client = new(domain.Client)
+				}
+				(*out.Client).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(out *jwriter.Writer, in Visit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Slug\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"Probe\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Probe))
+	}
+	{
+		const prefix string = ",\"Query\":"
+		out.RawString(prefix)
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"SubPath\":"
+		out.RawString(prefix)
+		out.String(string(in.SubPath))
+	}
+	{
+		const prefix string = ",\"Password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	{
+		const prefix string = ",\"Variant\":"
+		out.RawString(prefix)
+		out.Int(int(in.Variant))
+	}
+	{
+		const prefix string = ",\"Client\":"
+		out.RawString(prefix)
+		if in.Client == nil {
+			out.RawString("null")
+		} else {
+			(*in.Client).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Visit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Visit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Visit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Visit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(in *jlexer.Lexer, out *UserStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = string(in.String())
+		case "urls":
+			out.CountSlugs = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(out *jwriter.Writer, in UserStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CountSlugs))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(in *jlexer.Lexer, out *UserSlugBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserSlugBatch, 0, 4)
+			} else {
+				*out = UserSlugBatch{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 domain.Slug
+			v7 = domain.Slug(in.String())
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(out *jwriter.Writer, in UserSlugBatch) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			out.String(string(v9))
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserSlugBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserSlugBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserSlugBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserSlugBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(in *jlexer.Lexer, out *UserSlug) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Slug":
+			out.Slug = domain.Slug(in.String())
+		case "UserID":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.UserID[:], in.Bytes())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(out *jwriter.Writer, in UserSlug) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Slug\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"UserID\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.UserID[:])
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserSlug) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserSlug) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserSlug) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserSlug) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(in *jlexer.Lexer, out *URLSchedule) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "active_from":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ActiveFrom).UnmarshalJSON(data))
+			}
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(out *jwriter.Writer, in URLSchedule) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.ActiveFrom).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v URLSchedule) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v URLSchedule) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *URLSchedule) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *URLSchedule) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto11(in *jlexer.Lexer, out *URLRejection) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "error":
+			out.Error = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto11(out *jwriter.Writer, in URLRejection) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"error\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Error))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v URLRejection) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v URLRejection) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *URLRejection) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *URLRejection) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto11(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto12(in *jlexer.Lexer, out *URLPairBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(URLPairBatch, 0, 1)
+			} else {
+				*out = URLPairBatch{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v12 URLPair
+			(v12).UnmarshalEasyJSON(in)
+			*out = append(*out, v12)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto12(out *jwriter.Writer, in URLPairBatch) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v13, v14 := range in {
+			if v13 > 0 {
+				out.RawByte(',')
+			}
+			(v14).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v URLPairBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v URLPairBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *URLPairBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *URLPairBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto12(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto13(in *jlexer.Lexer, out *URLPair) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "short_url":
+			out.Slug = domain.Slug(in.String())
+		case "original_url":
+			out.OriginalURL = domain.OriginalURL(in.String())
+		case "clicks":
+			out.Clicks = int64(in.Int64())
+		case "max_clicks":
+			out.MaxClicks = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto13(out *jwriter.Writer, in URLPair) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"short_url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OriginalURL))
+	}
+	if in.Clicks != 0 {
+		const prefix string = ",\"clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Clicks))
+	}
+	if in.MaxClicks != 0 {
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxClicks))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v URLPair) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v URLPair) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *URLPair) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *URLPair) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto13(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto14(in *jlexer.Lexer, out *URLMappingEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "type":
+			out.Type = domain.URLMappingEventType(in.String())
+		case "slug":
+			out.Slug = domain.Slug(in.String())
+		case "short_url":
+			out.ShortURL = string(in.String())
+		case "original_url":
+			out.OriginalURL = domain.OriginalURL(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto14(out *jwriter.Writer, in URLMappingEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"short_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ShortURL))
+	}
+	if in.OriginalURL != "" {
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OriginalURL))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v URLMappingEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v URLMappingEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *URLMappingEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *URLMappingEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto14(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto15(in *jlexer.Lexer, out *URLInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "short_url":
+			out.ShortURL = string(in.String())
+		case "original_url":
+			out.OriginalURL = domain.OriginalURL(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "active_from":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ActiveFrom).UnmarshalJSON(data))
+			}
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		case "is_deleted":
+			out.Deleted = bool(in.Bool())
+		case "is_disabled":
+			out.Disabled = bool(in.Bool())
+		case "is_expired":
+			out.Expired = bool(in.Bool())
+		case "is_exhausted":
+			out.Exhausted = bool(in.Bool())
+		case "is_protected":
+			out.Protected = bool(in.Bool())
+		case "clicks":
+			if in.IsNull() {
+				in.Skip()
+				out.Clicks = nil
+			} else {
+				if out.Clicks == nil {
+					out.Clicks = new(int64)
+				}
+				*out.Clicks = int64(in.Int64())
+			}
+		case "variants":
+			(out.Variants).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto15(out *jwriter.Writer, in URLInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"short_url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortURL))
+	}
+	if in.OriginalURL != "" {
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OriginalURL))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((in.ActiveFrom).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"is_deleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Deleted))
+	}
+	{
+		const prefix string = ",\"is_disabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Disabled))
+	}
+	{
+		const prefix string = ",\"is_expired\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Expired))
+	}
+	{
+		const prefix string = ",\"is_exhausted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Exhausted))
+	}
+	{
+		const prefix string = ",\"is_protected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Protected))
+	}
+	if in.Clicks != nil {
+		const prefix string = ",\"clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(*in.Clicks))
+	}
+	if len(in.Variants) != 0 {
+		const prefix string = ",\"variants\":"
+		out.RawString(prefix)
+		(in.Variants).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v URLInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v URLInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *URLInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *URLInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto15(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto16(in *jlexer.Lexer, out *StatsParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Days":
+			out.Days = int(in.Int())
+		case "TopUsers":
+			out.TopUsers = int(in.Int())
+		case "Now":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Now).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto16(out *jwriter.Writer, in StatsParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Days\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Days))
+	}
+	{
+		const prefix string = ",\"TopUsers\":"
+		out.RawString(prefix)
+		out.Int(int(in.TopUsers))
+	}
+	{
+		const prefix string = ",\"Now\":"
+		out.RawString(prefix)
+		out.Raw((in.Now).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StatsParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StatsParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StatsParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StatsParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto16(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto17(in *jlexer.Lexer, out *StateRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "record":
+			out.Kind = StateRecordKind(in.String())
+		case "ban":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.Ban[:], in.Bytes())
+			}
+		case "moderation":
+			if in.IsNull() {
+				in.Skip()
+				out.Moderation = nil
+			} else {
+				if out.Moderation == nil {
+					out.Moderation = new(domain.ModerationEntry)
+				}
+				easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(in, out.Moderation)
+			}
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+				out.User = nil
+			} else {
+				if out.User == nil {
+					out.User = new(domain.User)
+				}
+				easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(in, out.User)
+			}
+		case "api_key":
+			if in.IsNull() {
+				in.Skip()
+				out.APIKey = nil
+			} else {
+				if out.APIKey == nil {
+					out.APIKey = new(domain.APIKey)
+				}
+				easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(in, out.APIKey)
+			}
+		case "token":
+			if in.IsNull() {
+				in.Skip()
+				out.Token = nil
+			} else {
+				if out.Token == nil {
+					out.Token = new(RevokedToken)
+				}
+				(*out.Token).UnmarshalEasyJSON(in)
+			}
+		case "webhook":
+			if in.IsNull() {
+				in.Skip()
+				out.Webhook = nil
+			} else {
+				if out.Webhook == nil {
+					out.Webhook = new(domain.Webhook)
+				}
+				easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain3(in, out.Webhook)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto17(out *jwriter.Writer, in StateRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"record\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kind))
+	}
+	if true {
+		const prefix string = ",\"ban\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Ban[:])
+	}
+	if in.Moderation != nil {
+		const prefix string = ",\"moderation\":"
+		out.RawString(prefix)
+		easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(out, *in.Moderation)
+	}
+	if in.User != nil {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(out, *in.User)
+	}
+	if in.APIKey != nil {
+		const prefix string = ",\"api_key\":"
+		out.RawString(prefix)
+		easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(out, *in.APIKey)
+	}
+	if in.Token != nil {
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		(*in.Token).MarshalEasyJSON(out)
+	}
+	if in.Webhook != nil {
+		const prefix string = ",\"webhook\":"
+		out.RawString(prefix)
+		easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain3(out, *in.Webhook)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StateRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StateRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StateRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StateRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto17(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain3(in *jlexer.Lexer, out *domain.Webhook) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "user_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.UserID[:], in.Bytes())
+			}
+		case "url":
+			out.URL = domain.OriginalURL(in.String())
+		case "secret":
+			out.Secret = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain3(out *jwriter.Writer, in domain.Webhook) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.UserID[:])
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"secret\":"
+		out.RawString(prefix)
+		out.String(string(in.Secret))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(in *jlexer.Lexer, out *domain.APIKey) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "user_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.UserID[:], in.Bytes())
+			}
+		case "name":
+			out.Name = string(in.String())
+		case "hash":
+			out.Hash = string(in.String())
+		case "scopes":
+			if in.IsNull() {
+				in.Skip()
+				out.Scopes = nil
+			} else {
+				in.Delim('[')
+				if out.Scopes == nil {
+					if !in.IsDelim(']') {
+						out.Scopes = make(domain.APIKeyScopes, 0, 4)
+					} else {
+						out.Scopes = domain.APIKeyScopes{}
+					}
+				} else {
+					out.Scopes = (out.Scopes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v20 domain.APIKeyScope
+					v20 = domain.APIKeyScope(in.String())
+					out.Scopes = append(out.Scopes, v20)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "is_revoked":
+			out.Revoked = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain2(out *jwriter.Writer, in domain.APIKey) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.UserID[:])
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"hash\":"
+		out.RawString(prefix)
+		out.String(string(in.Hash))
+	}
+	{
+		const prefix string = ",\"scopes\":"
+		out.RawString(prefix)
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v22, v23 := range in.Scopes {
+				if v22 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v23))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"is_revoked\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Revoked))
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(in *jlexer.Lexer, out *domain.User) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.ID[:], in.Bytes())
+			}
+		case "email":
+			out.Email = domain.Email(in.String())
+		case "password_hash":
+			out.PasswordHash = domain.PasswordHash(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain1(out *jwriter.Writer, in domain.User) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.ID[:])
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"password_hash\":"
+		out.RawString(prefix)
+		out.String(string(in.PasswordHash))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(in *jlexer.Lexer, out *domain.ModerationEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "action":
+			out.Action = domain.ModerationAction(in.String())
+		case "slug":
+			out.Slug = domain.Slug(in.String())
+		case "user_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.UserID[:], in.Bytes())
+			}
+		case "actor":
+			out.Actor = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDomain(out *jwriter.Writer, in domain.ModerationEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.UserID[:])
+	}
+	{
+		const prefix string = ",\"actor\":"
+		out.RawString(prefix)
+		out.String(string(in.Actor))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto18(in *jlexer.Lexer, out *SlugBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(SlugBatch, 0, 2)
+			} else {
+				*out = SlugBatch{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v28 CorrelatedSlug
+			(v28).UnmarshalEasyJSON(in)
+			*out = append(*out, v28)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto18(out *jwriter.Writer, in SlugBatch) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v29, v30 := range in {
+			if v29 > 0 {
+				out.RawByte(',')
+			}
+			(v30).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SlugBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SlugBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SlugBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SlugBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto18(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto19(in *jlexer.Lexer, out *ShortenedURLResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "result":
+			out.ShortURL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto19(out *jwriter.Writer, in ShortenedURLResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortURL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ShortenedURLResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ShortenedURLResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ShortenedURLResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ShortenedURLResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto19(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto20(in *jlexer.Lexer, out *ShortenURLRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.LongURL = string(in.String())
+		case "redirect_type":
+			out.RedirectType = domain.RedirectType(in.Int())
+		case "pass_query":
+			out.PassQuery = bool(in.Bool())
+		case "pass_path":
+			out.PassPath = bool(in.Bool())
+		case "password":
+			out.Password = string(in.String())
+		case "max_clicks":
+			out.MaxClicks = int64(in.Int64())
+		case "active_from":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ActiveFrom).UnmarshalJSON(data))
+			}
+		case "variants":
+			(out.Variants).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto20(out *jwriter.Writer, in ShortenURLRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.LongURL))
+	}
+	if in.RedirectType != 0 {
+		const prefix string = ",\"redirect_type\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectType))
+	}
+	if in.PassQuery {
+		const prefix string = ",\"pass_query\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassQuery))
+	}
+	if in.PassPath {
+		const prefix string = ",\"pass_path\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassPath))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	if in.MaxClicks != 0 {
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxClicks))
+	}
+	if true {
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((in.ActiveFrom).MarshalJSON())
+	}
+	if len(in.Variants) != 0 {
+		const prefix string = ",\"variants\":"
+		out.RawString(prefix)
+		(in.Variants).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ShortenURLRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ShortenURLRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ShortenURLRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ShortenURLRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto20(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto21(in *jlexer.Lexer, out *RevokedToken) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "jti":
+			out.JTI = string(in.String())
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto21(out *jwriter.Writer, in RevokedToken) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"jti\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.JTI))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RevokedToken) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RevokedToken) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RevokedToken) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RevokedToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto21(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto22(in *jlexer.Lexer, out *RepoStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "urls":
+			out.CountSlugs = int64(in.Int64())
+		case "users":
+			out.CountUsers = int64(in.Int64())
+		case "active":
+			out.CountActive = int64(in.Int64())
+		case "deleted":
+			out.CountDeleted = int64(in.Int64())
+		case "expired":
+			out.CountExpired = int64(in.Int64())
+		case "storage_bytes":
+			out.StorageBytes = int64(in.Int64())
+		case "daily":
+			if in.IsNull() {
+				in.Skip()
+				out.Daily = nil
+			} else {
+				in.Delim('[')
+				if out.Daily == nil {
+					if !in.IsDelim(']') {
+						out.Daily = make([]DailyStats, 0, 2)
+					} else {
+						out.Daily = []DailyStats{}
+					}
+				} else {
+					out.Daily = (out.Daily)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 DailyStats
+					(v31).UnmarshalEasyJSON(in)
+					out.Daily = append(out.Daily, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "top_users":
+			if in.IsNull() {
+				in.Skip()
+				out.TopUsers = nil
+			} else {
+				in.Delim('[')
+				if out.TopUsers == nil {
+					if !in.IsDelim(']') {
+						out.TopUsers = make([]UserStats, 0, 2)
+					} else {
+						out.TopUsers = []UserStats{}
+					}
+				} else {
+					out.TopUsers = (out.TopUsers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v32 UserStats
+					(v32).UnmarshalEasyJSON(in)
+					out.TopUsers = append(out.TopUsers, v32)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto22(out *jwriter.Writer, in RepoStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.CountSlugs))
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CountUsers))
+	}
+	{
+		const prefix string = ",\"active\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CountActive))
+	}
+	{
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CountDeleted))
+	}
+	{
+		const prefix string = ",\"expired\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CountExpired))
+	}
+	{
+		const prefix string = ",\"storage_bytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.StorageBytes))
+	}
+	{
+		const prefix string = ",\"daily\":"
+		out.RawString(prefix)
+		if in.Daily == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v33, v34 := range in.Daily {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				(v34).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"top_users\":"
+		out.RawString(prefix)
+		if in.TopUsers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.TopUsers {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				(v36).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto22(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto23(in *jlexer.Lexer, out *Redirect) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Location":
+			out.Location = domain.OriginalURL(in.String())
+		case "Type":
+			out.Type = domain.RedirectType(in.Int())
+		case "Rules":
+			(out.Rules).UnmarshalEasyJSON(in)
+		case "Variant":
+			out.Variant = int(in.Int())
+		case "Protected":
+			out.Protected = bool(in.Bool())
+		case "Limited":
+			out.Limited = bool(in.Bool())
+		case "Scheduled":
+			out.Scheduled = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto23(out *jwriter.Writer, in Redirect) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Location\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Location))
+	}
+	{
+		const prefix string = ",\"Type\":"
+		out.RawString(prefix)
+		out.Int(int(in.Type))
+	}
+	{
+		const prefix string = ",\"Rules\":"
+		out.RawString(prefix)
+		(in.Rules).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"Variant\":"
+		out.RawString(prefix)
+		out.Int(int(in.Variant))
+	}
+	{
+		const prefix string = ",\"Protected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Protected))
+	}
+	{
+		const prefix string = ",\"Limited\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Limited))
+	}
+	{
+		const prefix string = ",\"Scheduled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Scheduled))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Redirect) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Redirect) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Redirect) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Redirect) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto23(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto24(in *jlexer.Lexer, out *OriginalURLBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(OriginalURLBatch, 0, 2)
+			} else {
+				*out = OriginalURLBatch{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v37 CorrelatedOriginalURL
+			(v37).UnmarshalEasyJSON(in)
+			*out = append(*out, v37)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto24(out *jwriter.Writer, in OriginalURLBatch) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v38, v39 := range in {
+			if v38 > 0 {
+				out.RawByte(',')
+			}
+			(v39).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OriginalURLBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OriginalURLBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OriginalURLBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OriginalURLBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto24(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto25(in *jlexer.Lexer, out *ModerationRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto25(out *jwriter.Writer, in ModerationRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ModerationRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ModerationRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ModerationRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ModerationRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto25(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto26(in *jlexer.Lexer, out *ModerationLogEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "action":
+			out.Action = domain.ModerationAction(in.String())
+		case "slug":
+			out.Slug = domain.Slug(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "actor":
+			out.Actor = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto26(out *jwriter.Writer, in ModerationLogEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	if in.Slug != "" {
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"actor\":"
+		out.RawString(prefix)
+		out.String(string(in.Actor))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ModerationLogEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ModerationLogEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ModerationLogEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ModerationLogEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto26(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto27(in *jlexer.Lexer, out *ModerationLog) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ModerationLog, 0, 0)
+			} else {
+				*out = ModerationLog{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v40 ModerationLogEntry
+			(v40).UnmarshalEasyJSON(in)
+			*out = append(*out, v40)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto27(out *jwriter.Writer, in ModerationLog) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v41, v42 := range in {
+			if v41 > 0 {
+				out.RawByte(',')
+			}
+			(v42).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ModerationLog) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ModerationLog) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ModerationLog) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ModerationLog) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto27(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto28(in *jlexer.Lexer, out *DailyStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "date":
+			out.Date = string(in.String())
+		case "urls":
+			out.CountSlugs = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto28(out *jwriter.Writer, in DailyStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Date))
+	}
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CountSlugs))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DailyStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DailyStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DailyStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DailyStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto28(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto29(in *jlexer.Lexer, out *Credentials) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "email":
+			out.Email = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		case "claim":
+			out.Claim = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto29(out *jwriter.Writer, in Credentials) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	if in.Claim {
+		const prefix string = ",\"claim\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Claim))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Credentials) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Credentials) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Credentials) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Credentials) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto29(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto30(in *jlexer.Lexer, out *CorrelatedSlug) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "correlation_id":
+			out.CorrelationID = string(in.String())
+		case "short_url":
+			out.Slug = domain.Slug(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto30(out *jwriter.Writer, in CorrelatedSlug) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"correlation_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CorrelationID))
+	}
+	{
+		const prefix string = ",\"short_url\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CorrelatedSlug) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CorrelatedSlug) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CorrelatedSlug) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CorrelatedSlug) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto30(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto31(in *jlexer.Lexer, out *CorrelatedOriginalURL) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "correlation_id":
+			out.CorrelationID = string(in.String())
+		case "original_url":
+			out.OriginalURL = domain.OriginalURL(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto31(out *jwriter.Writer, in CorrelatedOriginalURL) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"correlation_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CorrelationID))
+	}
+	{
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OriginalURL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CorrelatedOriginalURL) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CorrelatedOriginalURL) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CorrelatedOriginalURL) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CorrelatedOriginalURL) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto31(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto32(in *jlexer.Lexer, out *AuditLog) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(AuditLog, 0, 0)
+			} else {
+				*out = AuditLog{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v43 AuditEvent
+			(v43).UnmarshalEasyJSON(in)
+			*out = append(*out, v43)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto32(out *jwriter.Writer, in AuditLog) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v44, v45 := range in {
+			if v44 > 0 {
+				out.RawByte(',')
+			}
+			(v45).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditLog) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditLog) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditLog) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditLog) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto32(l, v)
+}
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto33(in *jlexer.Lexer, out *AuditFilter) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Slug":
+			out.Slug = domain.Slug(in.String())
+		case "UserID":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				copy(out.UserID[:], in.Bytes())
+			}
+		case "Limit":
+			out.Limit = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto33(out *jwriter.Writer, in AuditFilter) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Slug\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"UserID\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.UserID[:])
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
 	}
+	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v URLPairBatch) MarshalJSON() ([]byte, error) {
+func (v AuditFilter) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto33(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v URLPairBatch) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(w, v)
+func (v AuditFilter) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto33(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *URLPairBatch) UnmarshalJSON(data []byte) error {
+func (v *AuditFilter) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto33(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *URLPairBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto2(l, v)
+func (v *AuditFilter) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto33(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(in *jlexer.Lexer, out *URLPair) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto34(in *jlexer.Lexer, out *AuditEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -248,10 +3432,24 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(
 			continue
 		}
 		switch key {
-		case "short_url":
+		case "id":
+			out.ID = int64(in.Int64())
+		case "action":
+			out.Action = domain.AuditAction(in.String())
+		case "slug":
 			out.Slug = domain.Slug(in.String())
-		case "original_url":
-			out.OriginalURL = domain.OriginalURL(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "protocol":
+			out.Protocol = domain.Protocol(in.String())
+		case "client_ip":
+			out.ClientIP = string(in.String())
+		case "details":
+			out.Details = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -262,47 +3460,77 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(out *jwriter.Writer, in URLPair) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto34(out *jwriter.Writer, in AuditEvent) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"short_url\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
 		out.String(string(in.Slug))
 	}
 	{
-		const prefix string = ",\"original_url\":"
+		const prefix string = ",\"user_id\":"
 		out.RawString(prefix)
-		out.String(string(in.OriginalURL))
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"protocol\":"
+		out.RawString(prefix)
+		out.String(string(in.Protocol))
+	}
+	{
+		const prefix string = ",\"client_ip\":"
+		out.RawString(prefix)
+		out.String(string(in.ClientIP))
+	}
+	if in.Details != "" {
+		const prefix string = ",\"details\":"
+		out.RawString(prefix)
+		out.String(string(in.Details))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v URLPair) MarshalJSON() ([]byte, error) {
+func (v AuditEvent) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto34(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v URLPair) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(w, v)
+func (v AuditEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto34(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *URLPair) UnmarshalJSON(data []byte) error {
+func (v *AuditEvent) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto34(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *URLPair) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto3(l, v)
+func (v *AuditEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto34(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(in *jlexer.Lexer, out *SlugBatch) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto35(in *jlexer.Lexer, out *AdminURLInfoBatch) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -311,17 +3539,17 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(SlugBatch, 0, 2)
+				*out = make(AdminURLInfoBatch, 0, 0)
 			} else {
-				*out = SlugBatch{}
+				*out = AdminURLInfoBatch{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v9 CorrelatedSlug
-			(v9).UnmarshalEasyJSON(in)
-			*out = append(*out, v9)
+			var v48 AdminURLInfo
+			(v48).UnmarshalEasyJSON(in)
+			*out = append(*out, v48)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -330,45 +3558,45 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(out *jwriter.Writer, in SlugBatch) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto35(out *jwriter.Writer, in AdminURLInfoBatch) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v10, v11 := range in {
-			if v10 > 0 {
+		for v49, v50 := range in {
+			if v49 > 0 {
 				out.RawByte(',')
 			}
-			(v11).MarshalEasyJSON(out)
+			(v50).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v SlugBatch) MarshalJSON() ([]byte, error) {
+func (v AdminURLInfoBatch) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto35(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v SlugBatch) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(w, v)
+func (v AdminURLInfoBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto35(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *SlugBatch) UnmarshalJSON(data []byte) error {
+func (v *AdminURLInfoBatch) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto35(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *SlugBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto4(l, v)
+func (v *AdminURLInfoBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto35(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(in *jlexer.Lexer, out *ShortenedURLResponse) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto36(in *jlexer.Lexer, out *AdminURLInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -387,8 +3615,30 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(
 			continue
 		}
 		switch key {
-		case "result":
+		case "short_url":
 			out.ShortURL = string(in.String())
+		case "original_url":
+			out.OriginalURL = domain.OriginalURL(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		case "is_deleted":
+			out.Deleted = bool(in.Bool())
+		case "is_disabled":
+			out.Disabled = bool(in.Bool())
+		case "disabled_reason":
+			out.DisabledReason = string(in.String())
+		case "is_protected":
+			out.Protected = bool(in.Bool())
+		case "clicks":
+			out.Clicks = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -399,42 +3649,87 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(out *jwriter.Writer, in ShortenedURLResponse) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto36(out *jwriter.Writer, in AdminURLInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"result\":"
+		const prefix string = ",\"short_url\":"
 		out.RawString(prefix[1:])
 		out.String(string(in.ShortURL))
 	}
+	{
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OriginalURL))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"is_deleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Deleted))
+	}
+	{
+		const prefix string = ",\"is_disabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Disabled))
+	}
+	if in.DisabledReason != "" {
+		const prefix string = ",\"disabled_reason\":"
+		out.RawString(prefix)
+		out.String(string(in.DisabledReason))
+	}
+	{
+		const prefix string = ",\"is_protected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Protected))
+	}
+	{
+		const prefix string = ",\"clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Clicks))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ShortenedURLResponse) MarshalJSON() ([]byte, error) {
+func (v AdminURLInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto36(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ShortenedURLResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(w, v)
+func (v AdminURLInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto36(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ShortenedURLResponse) UnmarshalJSON(data []byte) error {
+func (v *AdminURLInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto36(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ShortenedURLResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto5(l, v)
+func (v *AdminURLInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto36(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(in *jlexer.Lexer, out *ShortenURLRequest) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto37(in *jlexer.Lexer, out *Account) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -453,8 +3748,14 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(
 			continue
 		}
 		switch key {
-		case "url":
-			out.LongURL = string(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "claimed":
+			out.Claimed = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -465,42 +3766,57 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(out *jwriter.Writer, in ShortenURLRequest) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto37(out *jwriter.Writer, in Account) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"url\":"
+		const prefix string = ",\"user_id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.LongURL))
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"claimed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Claimed))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ShortenURLRequest) MarshalJSON() ([]byte, error) {
+func (v Account) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto37(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ShortenURLRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(w, v)
+func (v Account) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto37(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ShortenURLRequest) UnmarshalJSON(data []byte) error {
+func (v *Account) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto37(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ShortenURLRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto6(l, v)
+func (v *Account) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto37(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(in *jlexer.Lexer, out *RepoStats) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto38(in *jlexer.Lexer, out *APIKeyRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -519,10 +3835,31 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(
 			continue
 		}
 		switch key {
-		case "urls":
-			out.CountSlugs = int64(in.Int64())
-		case "users":
-			out.CountUsers = int64(in.Int64())
+		case "name":
+			out.Name = string(in.String())
+		case "scopes":
+			if in.IsNull() {
+				in.Skip()
+				out.Scopes = nil
+			} else {
+				in.Delim('[')
+				if out.Scopes == nil {
+					if !in.IsDelim(']') {
+						out.Scopes = make(domain.APIKeyScopes, 0, 4)
+					} else {
+						out.Scopes = domain.APIKeyScopes{}
+					}
+				} else {
+					out.Scopes = (out.Scopes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 domain.APIKeyScope
+					v51 = domain.APIKeyScope(in.String())
+					out.Scopes = append(out.Scopes, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -533,47 +3870,58 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(out *jwriter.Writer, in RepoStats) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto38(out *jwriter.Writer, in APIKeyRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"urls\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix[1:])
-		out.Int64(int64(in.CountSlugs))
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"users\":"
+		const prefix string = ",\"scopes\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.CountUsers))
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v52, v53 := range in.Scopes {
+				if v52 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v53))
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RepoStats) MarshalJSON() ([]byte, error) {
+func (v APIKeyRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto38(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RepoStats) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(w, v)
+func (v APIKeyRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto38(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RepoStats) UnmarshalJSON(data []byte) error {
+func (v *APIKeyRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto38(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RepoStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto7(l, v)
+func (v *APIKeyRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto38(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(in *jlexer.Lexer, out *OriginalURLBatch) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto39(in *jlexer.Lexer, out *APIKeyInfoBatch) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -582,17 +3930,17 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(OriginalURLBatch, 0, 2)
+				*out = make(APIKeyInfoBatch, 0, 0)
 			} else {
-				*out = OriginalURLBatch{}
+				*out = APIKeyInfoBatch{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v12 CorrelatedOriginalURL
-			(v12).UnmarshalEasyJSON(in)
-			*out = append(*out, v12)
+			var v54 APIKeyInfo
+			(v54).UnmarshalEasyJSON(in)
+			*out = append(*out, v54)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -601,45 +3949,45 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(out *jwriter.Writer, in OriginalURLBatch) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto39(out *jwriter.Writer, in APIKeyInfoBatch) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v13, v14 := range in {
-			if v13 > 0 {
+		for v55, v56 := range in {
+			if v55 > 0 {
 				out.RawByte(',')
 			}
-			(v14).MarshalEasyJSON(out)
+			(v56).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OriginalURLBatch) MarshalJSON() ([]byte, error) {
+func (v APIKeyInfoBatch) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto39(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OriginalURLBatch) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(w, v)
+func (v APIKeyInfoBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto39(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OriginalURLBatch) UnmarshalJSON(data []byte) error {
+func (v *APIKeyInfoBatch) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto39(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OriginalURLBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto8(l, v)
+func (v *APIKeyInfoBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto39(l, v)
 }
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(in *jlexer.Lexer, out *CorrelatedSlug) {
+func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto40(in *jlexer.Lexer, out *APIKeyInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -658,10 +4006,41 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(
 			continue
 		}
 		switch key {
-		case "correlation_id":
-			out.CorrelationID = string(in.String())
-		case "short_url":
-			out.Slug = domain.Slug(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "scopes":
+			if in.IsNull() {
+				in.Skip()
+				out.Scopes = nil
+			} else {
+				in.Delim('[')
+				if out.Scopes == nil {
+					if !in.IsDelim(']') {
+						out.Scopes = make(domain.APIKeyScopes, 0, 4)
+					} else {
+						out.Scopes = domain.APIKeyScopes{}
+					}
+				} else {
+					out.Scopes = (out.Scopes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v57 domain.APIKeyScope
+					v57 = domain.APIKeyScope(in.String())
+					out.Scopes = append(out.Scopes, v57)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "is_revoked":
+			out.Revoked = bool(in.Bool())
+		case "key":
+			out.Key = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -672,116 +4051,74 @@ func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(out *jwriter.Writer, in CorrelatedSlug) {
+func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto40(out *jwriter.Writer, in APIKeyInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"correlation_id\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.CorrelationID))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"short_url\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.String(string(in.Slug))
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v CorrelatedSlug) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CorrelatedSlug) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *CorrelatedSlug) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CorrelatedSlug) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto9(l, v)
-}
-func easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(in *jlexer.Lexer, out *CorrelatedOriginalURL) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
+		out.String(string(in.Name))
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "correlation_id":
-			out.CorrelationID = string(in.String())
-		case "original_url":
-			out.OriginalURL = domain.OriginalURL(in.String())
-		default:
-			in.SkipRecursive()
+	{
+		const prefix string = ",\"scopes\":"
+		out.RawString(prefix)
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v58, v59 := range in.Scopes {
+				if v58 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v59))
+			}
+			out.RawByte(']')
 		}
-		in.WantComma()
 	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(out *jwriter.Writer, in CorrelatedOriginalURL) {
-	out.RawByte('{')
-	first := true
-	_ = first
 	{
-		const prefix string = ",\"correlation_id\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.CorrelationID))
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
 	}
 	{
-		const prefix string = ",\"original_url\":"
+		const prefix string = ",\"is_revoked\":"
 		out.RawString(prefix)
-		out.String(string(in.OriginalURL))
+		out.Bool(bool(in.Revoked))
+	}
+	if in.Key != "" {
+		const prefix string = ",\"key\":"
+		out.RawString(prefix)
+		out.String(string(in.Key))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CorrelatedOriginalURL) MarshalJSON() ([]byte, error) {
+func (v APIKeyInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(&w, v)
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto40(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CorrelatedOriginalURL) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(w, v)
+func (v APIKeyInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComPatradenYaPracticumGoShortlyInternalAppDto40(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CorrelatedOriginalURL) UnmarshalJSON(data []byte) error {
+func (v *APIKeyInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(&r, v)
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto40(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CorrelatedOriginalURL) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto10(l, v)
+func (v *APIKeyInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComPatradenYaPracticumGoShortlyInternalAppDto40(l, v)
 }
@@ -0,0 +1,142 @@
+// Package geoip resolves countries of client IP addresses from an offline database file.
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+const (
+	errLabel      = "geoip"
+	networkColumn = 0
+	countryColumn = 1
+)
+
+// Locator resolves the ISO country code of an IP address, an empty code means unknown.
+type Locator interface {
+	Country(ip netip.Addr) string
+}
+
+// NopLocator is a Locator which knows no countries.
+type NopLocator struct{}
+
+// Country always returns an unknown country.
+func (NopLocator) Country(_ netip.Addr) string {
+	return ""
+}
+
+type block struct {
+	prefix  netip.Prefix
+	country string
+}
+
+// Database is an in-memory Locator loaded from a CSV file of non-overlapping networks.
+//
+// Each record holds a network in CIDR notation followed by an ISO country code,
+// e.g. "81.2.69.0/24,GB". A header record and further columns are ignored.
+type Database struct {
+	blocks []block
+}
+
+// NewLocator creates a Locator from the GeoIP database file configured in GeoIPDatabasePath.
+// Without a configured file a NopLocator is returned.
+func NewLocator(config *config.Config, log *zerolog.Logger) (Locator, error) {
+	if config.GeoIPDatabasePath == "" {
+		return NopLocator{}, nil
+	}
+
+	file, err := os.Open(config.GeoIPDatabasePath)
+	if err != nil {
+		return nil, e.Wrap("failed to open database", err, errLabel)
+	}
+	defer file.Close()
+
+	db, err := NewDatabase(file)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("path", config.GeoIPDatabasePath).
+		Int("networks", len(db.blocks)).
+		Msg("geoip database loaded")
+
+	return db, nil
+}
+
+// NewDatabase reads a GeoIP Database from CSV records.
+func NewDatabase(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	blocks := make([]block, 0)
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, e.Wrap("failed to read database", err, errLabel)
+		}
+
+		if len(record) <= countryColumn {
+			return nil, e.ErrGeoIPDatabase
+		}
+
+		prefix, err := netip.ParsePrefix(record[networkColumn])
+		if err != nil {
+			// header record.
+			if line == 1 {
+				continue
+			}
+
+			return nil, e.Wrap("failed to parse network", e.ErrGeoIPDatabase, errLabel)
+		}
+
+		country := strings.ToUpper(strings.TrimSpace(record[countryColumn]))
+		if country == "" {
+			continue
+		}
+
+		blocks = append(blocks, block{prefix: prefix.Masked(), country: country})
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].prefix.Addr().Less(blocks[j].prefix.Addr())
+	})
+
+	return &Database{blocks: blocks}, nil
+}
+
+// Country returns the country of the network containing the IP address.
+func (db *Database) Country(ip netip.Addr) string {
+	if !ip.IsValid() {
+		return ""
+	}
+
+	ip = ip.Unmap()
+
+	// the last network starting at or before ip is the only candidate to contain it.
+	idx := sort.Search(len(db.blocks), func(i int) bool {
+		return ip.Less(db.blocks[i].prefix.Addr())
+	}) - 1
+
+	if idx < 0 || !db.blocks[idx].prefix.Contains(ip) {
+		return ""
+	}
+
+	return db.blocks[idx].country
+}
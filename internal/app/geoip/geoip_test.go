@@ -0,0 +1,85 @@
+package geoip_test
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
+)
+
+const testDatabase = `network,country_iso_code
+81.2.69.0/24,GB
+2.125.160.216/29,gb
+89.160.20.112/28,SE
+2001:218::/32,JP
+216.160.83.56/29,
+`
+
+func TestDatabaseCountry(t *testing.T) {
+	t.Parallel()
+
+	db, err := geoip.NewDatabase(strings.NewReader(testDatabase))
+	require.NoError(t, err)
+
+	tests := []struct {
+		ip      string
+		country string
+	}{
+		{"81.2.69.142", "GB"},
+		{"2.125.160.217", "GB"},
+		{"89.160.20.127", "SE"},
+		{"89.160.20.128", ""},
+		{"::ffff:81.2.69.1", "GB"},
+		{"2001:218:1::1", "JP"},
+		{"216.160.83.57", ""},
+		{"1.1.1.1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.country, db.Country(netip.MustParseAddr(tt.ip)))
+		})
+	}
+
+	assert.Empty(t, db.Country(netip.Addr{}))
+}
+
+func TestNewDatabaseInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := geoip.NewDatabase(strings.NewReader("81.2.69.0/24,GB\nnot a network,SE\n"))
+	require.ErrorIs(t, err, e.ErrGeoIPDatabase)
+
+	_, err = geoip.NewDatabase(strings.NewReader("81.2.69.0/24\n"))
+	require.ErrorIs(t, err, e.ErrGeoIPDatabase)
+}
+
+func TestNewLocator(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	cfg := config.DefaultConfig()
+
+	locator, err := geoip.NewLocator(cfg, &log)
+	require.NoError(t, err)
+	assert.Equal(t, geoip.NopLocator{}, locator)
+
+	cfg.GeoIPDatabasePath = filepath.Join(t.TempDir(), "geoip.csv")
+	_, err = geoip.NewLocator(cfg, &log)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(cfg.GeoIPDatabasePath, []byte(testDatabase), 0o600))
+	locator, err = geoip.NewLocator(cfg, &log)
+	require.NoError(t, err)
+	assert.Equal(t, "SE", locator.Country(netip.MustParseAddr("89.160.20.113")))
+}
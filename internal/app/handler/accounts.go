@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/accounts"
+)
+
+// AccountsHandler handles requests related to user accounts.
+type AccountsHandler struct {
+	service accounts.Accounts
+	auth    *middleware.JWTMiddleware
+	config  *config.Config
+	log     *zerolog.Logger
+}
+
+// NewAccountsHandler creates and returns a new AccountsHandler instance.
+func NewAccountsHandler(
+	service accounts.Accounts,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *AccountsHandler {
+	return &AccountsHandler{
+		service: service,
+		auth:    auth,
+		config:  config,
+		log:     log,
+	}
+}
+
+// RegisterRoutes register all handler routes within http router.
+func (h *AccountsHandler) RegisterRoutes(router chi.Router) {
+	router.Post("/api/user/register", h.HandleRegister)
+	router.Post("/api/user/login", h.HandleLogin)
+	router.Post("/api/user/logout", h.HandleLogout)
+}
+
+// HandleRegister handles requests to register a new user account.
+// URLs of the current anonymous identity are claimed into the new account.
+func (h *AccountsHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
+	var credentials dto.Credentials
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &credentials); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	user, err := h.service.Register(r.Context(), &credentials)
+
+	switch {
+	case errors.Is(err, e.ErrUserCredentialsInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, e.ErrUserExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		h.signIn(w, r, user, true, http.StatusCreated)
+	}
+}
+
+// HandleLogin handles requests to log in a user account.
+// URLs of the current anonymous identity are claimed into the account on request.
+func (h *AccountsHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	var credentials dto.Credentials
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &credentials); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	user, err := h.service.Login(r.Context(), &credentials)
+
+	switch {
+	case errors.Is(err, e.ErrLoginFailed):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		h.signIn(w, r, user, credentials.Claim, http.StatusOK)
+	}
+}
+
+// HandleLogout handles requests to log out, the token of the request is revoked and the auth cookie is removed.
+func (h *AccountsHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	err := h.auth.RevokeToken(r)
+
+	switch {
+	case errors.Is(err, e.ErrAuthDenylist):
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case err != nil:
+		http.Error(w, `unauthorized`, http.StatusUnauthorized)
+	default:
+		h.auth.ClearTokenCookie(w)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// signIn claims the URLs of the current identity if asked to,
+// issues the account token and writes it both as the auth cookie and in the response.
+// Claims of banned identities are refused without signing in.
+func (h *AccountsHandler) signIn(w http.ResponseWriter, r *http.Request, user *domain.User, claim bool, status int) {
+	var claimed int64
+
+	if anonymous, ok := h.auth.Identify(r); ok && claim {
+		n, err := h.service.ClaimURLs(r.Context(), anonymous, user.ID)
+		if errors.Is(err, e.ErrUserBanned) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		claimed = n
+	}
+
+	token, err := h.auth.GenerateToken(user.ID)
+	if err != nil {
+		http.Error(w, "failed to generate token", http.StatusInternalServerError)
+
+		return
+	}
+
+	h.auth.SetTokenCookie(w, token)
+	w.Header().Set(ContentType, ContentTypeJSON)
+	w.WriteHeader(status)
+
+	account := dto.Account{
+		UserID:  user.ID.String(),
+		Email:   user.Email.String(),
+		Token:   token,
+		Claimed: claimed,
+	}
+
+	if _, err := easyjson.MarshalToWriter(account, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write account response")
+	}
+}
@@ -0,0 +1,288 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func setupAccountsHandler(t *testing.T) (
+	*gomock.Controller,
+	*mock.MockAccounts,
+	*handler.AccountsHandler,
+	*middleware.JWTMiddleware,
+) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockSrv := mock.NewMockAccounts(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	config := config.DefaultConfig()
+	auth := newJWTMiddleware(t, log, config)
+	h := handler.NewAccountsHandler(mockSrv, auth, config, log)
+
+	return ctrl, mockSrv, h, auth
+}
+
+func TestHandleRegister(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h, auth := setupAccountsHandler(t)
+	defer ctrl.Finish()
+
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+
+	anonymous := domain.NewUserID()
+	anonymousToken, err := auth.GenerateToken(anonymous)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		body   string
+		setup  func()
+		cookie string
+		status int
+	}{
+		{
+			name: "registered with claimed urls",
+			body: `{"email":"user@example.com","password":"s3cret-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Register(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockSrv.EXPECT().ClaimURLs(gomock.Any(), anonymous, user.ID).Return(int64(2), nil)
+			},
+			cookie: anonymousToken,
+			status: http.StatusCreated,
+		},
+		{
+			name: "registered without identity",
+			body: `{"email":"user@example.com","password":"s3cret-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Register(gomock.Any(), gomock.Any()).Return(user, nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "invalid credentials",
+			body: `{"email":"user","password":"s3cret-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Register(gomock.Any(), gomock.Any()).Return(nil, e.ErrUserCredentialsInvalid)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "existing user",
+			body: `{"email":"user@example.com","password":"s3cret-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Register(gomock.Any(), gomock.Any()).Return(nil, e.ErrUserExists)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "invalid json",
+			body:   `{"email":`,
+			setup:  func() {},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/user/register", strings.NewReader(tt.body))
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: middleware.AuthCookieName, Value: tt.cookie})
+			}
+
+			w := httptest.NewRecorder()
+			h.HandleRegister(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.status, res.StatusCode)
+
+			if tt.status != http.StatusCreated {
+				return
+			}
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"user_id":"`+user.ID.String()+`"`)
+
+			var token string
+
+			for _, cookie := range res.Cookies() {
+				if cookie.Name == middleware.AuthCookieName {
+					token = cookie.Value
+				}
+			}
+
+			claims, err := auth.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID.String(), claims.UserID)
+		})
+	}
+}
+
+func TestHandleLogin(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h, auth := setupAccountsHandler(t)
+	defer ctrl.Finish()
+
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+
+	anonymousToken, err := auth.GenerateToken(domain.NewUserID())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		body   string
+		setup  func()
+		status int
+		result string
+	}{
+		{
+			name: "logged in",
+			body: `{"email":"user@example.com","password":"s3cret-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Login(gomock.Any(), gomock.Any()).Return(user, nil)
+			},
+			status: http.StatusOK,
+			result: `"claimed":0`,
+		},
+		{
+			name: "logged in with claimed urls",
+			body: `{"email":"user@example.com","password":"s3cret-pass","claim":true}`,
+			setup: func() {
+				mockSrv.EXPECT().Login(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockSrv.EXPECT().ClaimURLs(gomock.Any(), gomock.Any(), user.ID).Return(int64(3), nil)
+			},
+			status: http.StatusOK,
+			result: `"claimed":3`,
+		},
+		{
+			name: "failed claim",
+			body: `{"email":"user@example.com","password":"s3cret-pass","claim":true}`,
+			setup: func() {
+				mockSrv.EXPECT().Login(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockSrv.EXPECT().ClaimURLs(gomock.Any(), gomock.Any(), user.ID).Return(int64(0), e.ErrAccountsInternal)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "banned claim",
+			body: `{"email":"user@example.com","password":"s3cret-pass","claim":true}`,
+			setup: func() {
+				mockSrv.EXPECT().Login(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockSrv.EXPECT().ClaimURLs(gomock.Any(), gomock.Any(), user.ID).Return(int64(0), e.ErrUserBanned)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name: "wrong password",
+			body: `{"email":"user@example.com","password":"wrong-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, e.ErrLoginFailed)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "internal error",
+			body: `{"email":"user@example.com","password":"s3cret-pass"}`,
+			setup: func() {
+				mockSrv.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, e.ErrAccountsInternal)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/user/login", strings.NewReader(tt.body))
+			req.AddCookie(&http.Cookie{Name: middleware.AuthCookieName, Value: anonymousToken})
+
+			w := httptest.NewRecorder()
+			h.HandleLogin(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			assert.Contains(t, string(body), tt.result)
+		})
+	}
+}
+
+func TestHandleLogout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	config := config.DefaultConfig()
+	auth := middleware.NewJWTMiddleware(
+		func(*jwt.Token) (any, error) { return []byte(config.JWTSecret), nil },
+		log,
+		middleware.WithJWTConfig(config),
+		middleware.WithTokenDenylist(repository.NewInMemoryTokenRepository()),
+	)
+	h := handler.NewAccountsHandler(mock.NewMockAccounts(ctrl), auth, config, log)
+
+	token, err := auth.GenerateToken(domain.NewUserID())
+	require.NoError(t, err)
+
+	logout := func(token string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/api/user/logout", nil)
+		if token != "" {
+			req.AddCookie(&http.Cookie{Name: middleware.AuthCookieName, Value: token})
+		}
+
+		w := httptest.NewRecorder()
+		h.HandleLogout(w, req)
+
+		return w.Result()
+	}
+
+	res := logout(token)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	require.Len(t, res.Cookies(), 1)
+	assert.Negative(t, res.Cookies()[0].MaxAge)
+
+	revoked := logout(token)
+	defer revoked.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, revoked.StatusCode)
+
+	anonymous := logout("")
+	defer anonymous.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, anonymous.StatusCode)
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/moderation"
+)
+
+// AdminHandler handles requests of administrators moderating links and users.
+type AdminHandler struct {
+	service moderation.Moderator
+	auth    *middleware.JWTMiddleware
+	config  *config.Config
+	log     *zerolog.Logger
+}
+
+// NewAdminHandler creates and returns a new AdminHandler instance.
+func NewAdminHandler(
+	service moderation.Moderator,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+		auth:    auth,
+		config:  config,
+		log:     log,
+	}
+}
+
+// RegisterRoutes register all handler routes within http router.
+// Admin routes are restricted to admin users and to the trusted subnets, API keys are forbidden.
+func (h *AdminHandler) RegisterRoutes(router chi.Router) {
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.DenyAPIKeys())
+		r.Use(middleware.AdminMiddleware(h.log, h.config, h.auth))
+		r.Get("/api/admin/urls/{slug}", h.HandleGetURL)
+		r.Post("/api/admin/urls/{slug}/disable", h.HandleDisableURL)
+		r.Post("/api/admin/urls/{slug}/enable", h.HandleEnableURL)
+		r.Get("/api/admin/users/{user}/urls", h.HandleGetUserURLs)
+		r.Post("/api/admin/users/{user}/ban", h.HandleBanUser)
+		r.Post("/api/admin/users/{user}/unban", h.HandleUnbanUser)
+		r.Get("/api/admin/moderation", h.HandleGetModerationLog)
+	})
+}
+
+// HandleGetURL handles requests to look up any slug, including deleted and disabled ones.
+func (h *AdminHandler) HandleGetURL(w http.ResponseWriter, r *http.Request) {
+	m, err := h.service.GetURL(r.Context(), domain.Slug(chi.URLParam(r, "slug")))
+	h.writeURL(w, m, err)
+}
+
+// HandleDisableURL handles requests to disable redirects of a slug with a reason.
+func (h *AdminHandler) HandleDisableURL(w http.ResponseWriter, r *http.Request) {
+	var modReq dto.ModerationRequest
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &modReq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	m, err := h.service.DisableURL(r.Context(), domain.Slug(chi.URLParam(r, "slug")), modReq.Reason)
+	h.writeURL(w, m, err)
+}
+
+// HandleEnableURL handles requests to re-enable redirects of a disabled slug with a reason.
+func (h *AdminHandler) HandleEnableURL(w http.ResponseWriter, r *http.Request) {
+	var modReq dto.ModerationRequest
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &modReq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	m, err := h.service.EnableURL(r.Context(), domain.Slug(chi.URLParam(r, "slug")), modReq.Reason)
+	h.writeURL(w, m, err)
+}
+
+// HandleGetUserURLs handles requests to list links of any user.
+func (h *AdminHandler) HandleGetUserURLs(w http.ResponseWriter, r *http.Request) {
+	userID, err := domain.ParseUserID(chi.URLParam(r, "user"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	urls, err := h.service.GetUserURLs(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	infos := make(dto.AdminURLInfoBatch, len(urls))
+	for i := range urls {
+		infos[i] = dto.NewAdminURLInfo(&urls[i], h.config.BaseURL)
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(infos, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write admin urls response")
+	}
+}
+
+// HandleBanUser handles requests to ban a user from creating links with a reason.
+func (h *AdminHandler) HandleBanUser(w http.ResponseWriter, r *http.Request) {
+	h.moderateUser(w, r, h.service.BanUser)
+}
+
+// HandleUnbanUser handles requests to lift the ban of a user with a reason.
+func (h *AdminHandler) HandleUnbanUser(w http.ResponseWriter, r *http.Request) {
+	h.moderateUser(w, r, h.service.UnbanUser)
+}
+
+// HandleGetModerationLog handles requests to view the latest records of the moderation log.
+// Optional limit query parameter sets the number of records.
+func (h *AdminHandler) HandleGetModerationLog(w http.ResponseWriter, r *http.Request) {
+	limit := moderation.DefaultLogLimit
+
+	if value := r.URL.Query().Get("limit"); value != `` {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, e.ErrModerationParams.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		limit = parsed
+	}
+
+	entries, err := h.service.GetModerationLog(r.Context(), limit)
+
+	switch {
+	case errors.Is(err, e.ErrModerationParams):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	log := make(dto.ModerationLog, len(entries))
+	for i := range entries {
+		log[i] = dto.NewModerationLogEntry(&entries[i])
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(log, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write moderation log response")
+	}
+}
+
+func (h *AdminHandler) moderateUser(
+	w http.ResponseWriter,
+	r *http.Request,
+	moderate func(ctx context.Context, user domain.UserID, reason string) error,
+) {
+	userID, err := domain.ParseUserID(chi.URLParam(r, "user"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var modReq dto.ModerationRequest
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &modReq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = moderate(r.Context(), userID, modReq.Reason)
+
+	switch {
+	case errors.Is(err, e.ErrModerationReasonInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *AdminHandler) writeURL(w http.ResponseWriter, m *domain.URLMapping, err error) {
+	switch {
+	case errors.Is(err, e.ErrModerationReasonInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	case errors.Is(err, e.ErrSlugNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(dto.NewAdminURLInfo(m, h.config.BaseURL), w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write admin url response")
+	}
+}
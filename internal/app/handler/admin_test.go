@@ -0,0 +1,214 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+)
+
+// setupAdminRouter returns a router of the admin handler along with a token of its admin user.
+func setupAdminRouter(t *testing.T) (*gomock.Controller, *mock.MockModerator, http.Handler, string) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockSrv := mock.NewMockModerator(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	adminID := domain.NewUserID()
+
+	config := config.DefaultConfig()
+	config.AdminUsers = []string{adminID.String()}
+	auth := newJWTMiddleware(t, log, config)
+
+	token, err := auth.GenerateToken(adminID)
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	handler.NewAdminHandler(mockSrv, auth, config, log).RegisterRoutes(router)
+
+	return ctrl, mockSrv, router, token
+}
+
+func serveAdmin(t *testing.T, router http.Handler, token, method, target, body string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res.StatusCode, string(resBody)
+}
+
+func TestAdminAccess(t *testing.T) {
+	t.Parallel()
+
+	ctrl, _, router, _ := setupAdminRouter(t)
+	defer ctrl.Finish()
+
+	status, _ := serveAdmin(t, router, "", http.MethodGet, "/api/admin/moderation", "")
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestHandleModerateURL(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, router, token := setupAdminRouter(t)
+	defer ctrl.Finish()
+
+	urlm := domain.NewURLMapping("slug1", "http://example.com", domain.NewUserID())
+	urlm.Moderate(domain.ActionDisableURL, "phishing")
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		setup  func()
+		status int
+	}{
+		{
+			name:   "look up",
+			target: "/api/admin/urls/slug1",
+			body:   "",
+			setup: func() {
+				mockSrv.EXPECT().GetURL(gomock.Any(), domain.Slug("slug1")).Return(urlm, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "disabled",
+			target: "/api/admin/urls/slug1/disable",
+			body:   `{"reason":"phishing"}`,
+			setup: func() {
+				mockSrv.EXPECT().DisableURL(gomock.Any(), domain.Slug("slug1"), "phishing").Return(urlm, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "missing reason",
+			target: "/api/admin/urls/slug1/enable",
+			body:   `{}`,
+			setup: func() {
+				mockSrv.EXPECT().EnableURL(gomock.Any(), domain.Slug("slug1"), "").
+					Return(nil, e.ErrModerationReasonInvalid)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "not found",
+			target: "/api/admin/urls/slug2/enable",
+			body:   `{"reason":"appeal"}`,
+			setup: func() {
+				mockSrv.EXPECT().EnableURL(gomock.Any(), domain.Slug("slug2"), "appeal").Return(nil, e.ErrSlugNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "invalid json",
+			target: "/api/admin/urls/slug1/disable",
+			body:   `{"reason":`,
+			setup:  func() {},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			method := http.MethodPost
+			if tt.body == "" {
+				method = http.MethodGet
+			}
+
+			status, body := serveAdmin(t, router, token, method, tt.target, tt.body)
+			assert.Equal(t, tt.status, status)
+
+			if tt.status == http.StatusOK {
+				assert.Contains(t, body, `"is_disabled":true,"disabled_reason":"phishing"`)
+			}
+		})
+	}
+}
+
+func TestHandleModerateUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, router, token := setupAdminRouter(t)
+	defer ctrl.Finish()
+
+	userID := domain.NewUserID()
+	target := "/api/admin/users/" + userID.String()
+
+	mockSrv.EXPECT().BanUser(gomock.Any(), userID, "spam").Return(nil)
+
+	status, _ := serveAdmin(t, router, token, http.MethodPost, target+"/ban", `{"reason":"spam"}`)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	mockSrv.EXPECT().UnbanUser(gomock.Any(), userID, "").Return(e.ErrModerationReasonInvalid)
+
+	status, _ = serveAdmin(t, router, token, http.MethodPost, target+"/unban", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = serveAdmin(t, router, token, http.MethodPost, "/api/admin/users/someone/ban", `{"reason":"spam"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	mockSrv.EXPECT().GetUserURLs(gomock.Any(), userID).
+		Return([]domain.URLMapping{*domain.NewURLMapping("slug1", "http://example.com", userID)}, nil)
+
+	status, body := serveAdmin(t, router, token, http.MethodGet, target+"/urls", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"user_id":"`+userID.String()+`"`)
+}
+
+func TestHandleGetModerationLog(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, router, token := setupAdminRouter(t)
+	defer ctrl.Finish()
+
+	entry, err := domain.NewModerationEntry(domain.ActionBanUser, "admin", "spam")
+	require.NoError(t, err)
+
+	mockSrv.EXPECT().GetModerationLog(gomock.Any(), 100).Return([]domain.ModerationEntry{*entry}, nil)
+
+	status, body := serveAdmin(t, router, token, http.MethodGet, "/api/admin/moderation", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"action":"ban_user"`)
+
+	mockSrv.EXPECT().GetModerationLog(gomock.Any(), 5000).Return(nil, e.ErrModerationParams)
+
+	status, _ = serveAdmin(t, router, token, http.MethodGet, "/api/admin/moderation?limit=5000", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = serveAdmin(t, router, token, http.MethodGet, "/api/admin/moderation?limit=many", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	mockSrv.EXPECT().GetModerationLog(gomock.Any(), 10).Return(nil, e.ErrModerationInternal)
+
+	status, _ = serveAdmin(t, router, token, http.MethodGet, "/api/admin/moderation?limit=10", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/apikeys"
+)
+
+// APIKeysHandler handles requests related to API keys of users.
+type APIKeysHandler struct {
+	service apikeys.APIKeys
+	auth    *middleware.JWTMiddleware
+	config  *config.Config
+	log     *zerolog.Logger
+}
+
+// NewAPIKeysHandler creates and returns a new APIKeysHandler instance.
+func NewAPIKeysHandler(
+	service apikeys.APIKeys,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *APIKeysHandler {
+	return &APIKeysHandler{
+		service: service,
+		auth:    auth,
+		config:  config,
+		log:     log,
+	}
+}
+
+// RegisterRoutes register all handler routes within http router.
+// API keys are managed with user tokens only, requests authenticated with API keys are forbidden.
+func (h *APIKeysHandler) RegisterRoutes(router chi.Router) {
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.DenyAPIKeys())
+		r.Use(h.auth.AuthorizeHandler)
+		r.Post("/api/user/keys", h.HandleCreateAPIKey)
+		r.Get("/api/user/keys", h.HandleGetAPIKeys)
+		r.Delete("/api/user/keys/{id}", h.HandleRevokeAPIKey)
+	})
+}
+
+// HandleCreateAPIKey handles requests to create an API key of the user.
+// The key itself is only disclosed in the response.
+func (h *APIKeysHandler) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var keyReq dto.APIKeyRequest
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &keyReq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	key, token, err := h.service.CreateAPIKey(r.Context(), keyReq.Name, keyReq.Scopes)
+	if errors.Is(err, e.ErrAPIKeyInvalid) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	info := dto.NewAPIKeyInfo(key)
+	info.Key = token
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+	w.Header().Set(CacheControl, CacheControlNoStore)
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(info, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write api key response")
+	}
+}
+
+// HandleGetAPIKeys handles requests to list API keys of the user.
+func (h *APIKeysHandler) HandleGetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.GetUserAPIKeys(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	infos := make(dto.APIKeyInfoBatch, len(keys))
+	for i := range keys {
+		infos[i] = dto.NewAPIKeyInfo(&keys[i])
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(infos, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write api keys response")
+	}
+}
+
+// HandleRevokeAPIKey handles requests to revoke an API key of the user.
+func (h *APIKeysHandler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	err := h.service.RevokeAPIKey(r.Context(), chi.URLParam(r, "id"))
+	if errors.Is(err, e.ErrAPIKeyNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
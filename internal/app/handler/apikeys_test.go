@@ -0,0 +1,170 @@
+package handler_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+)
+
+func setupAPIKeysHandler(t *testing.T) (*gomock.Controller, *mock.MockAPIKeys, *handler.APIKeysHandler) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockSrv := mock.NewMockAPIKeys(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+
+	config := config.DefaultConfig()
+
+	return ctrl, mockSrv, handler.NewAPIKeysHandler(mockSrv, newJWTMiddleware(t, log, config), config, log)
+}
+
+func TestHandleCreateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupAPIKeysHandler(t)
+	defer ctrl.Finish()
+
+	key, token, err := domain.NewAPIKey(domain.NewUserID(), "ci", domain.APIKeyScopes{domain.ScopeRead})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		body   string
+		setup  func()
+		status int
+	}{
+		{
+			name: "created",
+			body: `{"name":"ci","scopes":["read"]}`,
+			setup: func() {
+				mockSrv.EXPECT().CreateAPIKey(gomock.Any(), "ci", domain.APIKeyScopes{domain.ScopeRead}).
+					Return(key, token, nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "invalid scopes",
+			body: `{"name":"ci","scopes":["admin"]}`,
+			setup: func() {
+				mockSrv.EXPECT().CreateAPIKey(gomock.Any(), "ci", gomock.Any()).Return(nil, "", e.ErrAPIKeyInvalid)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			body: `{"name":"ci","scopes":["read"]}`,
+			setup: func() {
+				mockSrv.EXPECT().CreateAPIKey(gomock.Any(), "ci", gomock.Any()).Return(nil, "", e.ErrAPIKeysInternal)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "invalid json",
+			body:   `{"name":`,
+			setup:  func() {},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/user/keys", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.HandleCreateAPIKey(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.status, res.StatusCode)
+
+			if tt.status != http.StatusCreated {
+				return
+			}
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"key":"`+token+`"`)
+			assert.Equal(t, handler.CacheControlNoStore, res.Header.Get(handler.CacheControl))
+		})
+	}
+}
+
+func TestHandleGetAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupAPIKeysHandler(t)
+	defer ctrl.Finish()
+
+	key, token, err := domain.NewAPIKey(domain.NewUserID(), "ci", domain.APIKeyScopes{domain.ScopeRead})
+	require.NoError(t, err)
+
+	mockSrv.EXPECT().GetUserAPIKeys(gomock.Any()).Return([]domain.APIKey{*key}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/keys", nil)
+	w := httptest.NewRecorder()
+	h.HandleGetAPIKeys(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), `"id":"`+key.ID+`"`)
+	assert.NotContains(t, string(body), token)
+	assert.NotContains(t, string(body), key.Hash)
+}
+
+func TestHandleRevokeAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupAPIKeysHandler(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "revoked", err: nil, status: http.StatusNoContent},
+		{name: "not found", err: e.ErrAPIKeyNotFound, status: http.StatusNotFound},
+		{name: "internal error", err: e.ErrAPIKeysInternal, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSrv.EXPECT().RevokeAPIKey(gomock.Any(), "0123456789abcdef").Return(tt.err)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "0123456789abcdef")
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/user/keys/0123456789abcdef", nil)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.HandleRevokeAPIKey(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
+)
+
+// AuditHandler provides HTTP request handling for the audit log of link lifecycle events.
+type AuditHandler struct {
+	service audit.Auditor
+	auth    *middleware.JWTMiddleware
+	config  *config.Config
+	log     *zerolog.Logger
+}
+
+// NewAuditHandler creates new instance of AuditHandler.
+func NewAuditHandler(
+	service audit.Auditor,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		auth:    auth,
+		config:  config,
+		log:     log,
+	}
+}
+
+// RegisterRoutes register all handler routes within http router.
+// The audit log is restricted to administrators the same way as admin routes are, it is never open
+// unlike stats without a configured subnet. Client certificates are required as for stats if configured.
+func (h *AuditHandler) RegisterRoutes(router chi.Router) {
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.ClientCAMiddleware(h.log, h.config.StatsClientCAPath))
+		r.Use(middleware.DenyAPIKeys())
+		r.Use(middleware.AdminMiddleware(h.log, h.config, h.auth))
+		r.Get("/api/internal/audit", h.HandleGetAuditLog)
+	})
+}
+
+// HandleGetAuditLog handles requests to retrieve the latest audit events of a slug, of a user or of both.
+// At least one of slug and user query parameters is required, optional limit sets the number of events.
+func (h *AuditHandler) HandleGetAuditLog(w http.ResponseWriter, r *http.Request) {
+	filter, err := auditFilter(r)
+	if err != nil {
+		http.Error(w, e.ErrAuditParams.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	events, err := h.service.GetEvents(r.Context(), filter)
+
+	switch {
+	case errors.Is(err, e.ErrAuditParams):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	log := make(dto.AuditLog, len(events))
+	for i := range events {
+		log[i] = dto.NewAuditEvent(&events[i])
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(log, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write audit log response")
+	}
+}
+
+// auditFilter parses audit log query parameters.
+func auditFilter(r *http.Request) (dto.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := dto.AuditFilter{
+		Slug:   domain.Slug(query.Get("slug")),
+		UserID: domain.UserID{},
+		Limit:  audit.DefaultLimit,
+	}
+
+	if value := query.Get("user"); value != `` {
+		userID, err := domain.ParseUserID(value)
+		if err != nil {
+			return filter, err
+		}
+
+		filter.UserID = userID
+	}
+
+	if value := query.Get("limit"); value != `` {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, err
+		}
+
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,190 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
+)
+
+func setupAuditHandler(t *testing.T) (*gomock.Controller, *mock.MockAuditor, *handler.AuditHandler) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockSrv := mock.NewMockAuditor(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	config := config.DefaultConfig()
+	h := handler.NewAuditHandler(mockSrv, newJWTMiddleware(t, log, config), config, log)
+
+	return ctrl, mockSrv, h
+}
+
+func TestHandleGetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, handler := setupAuditHandler(t)
+	defer ctrl.Finish()
+
+	userID := domain.NewUserID()
+	events := []domain.AuditEvent{{
+		ID:        1,
+		Action:    domain.AuditCreated,
+		Slug:      "slug1",
+		UserID:    userID,
+		Protocol:  domain.ProtocolHTTP,
+		ClientIP:  "127.0.0.1",
+		Details:   "",
+		CreatedAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+	}}
+
+	t.Run("successful request", func(t *testing.T) {
+		mockSrv.EXPECT().
+			GetEvents(gomock.Any(), dto.AuditFilter{Slug: "slug1", UserID: userID, Limit: 5}).
+			Return(events, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/audit?slug=slug1&user="+userID.String()+"&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGetAuditLog(w, req)
+		res := w.Result()
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `[{"id":1,"action":"created","slug":"slug1","user_id":"`+userID.String()+
+			`","protocol":"http","client_ip":"127.0.0.1","created_at":"2024-05-02T00:00:00Z"}]`, string(body))
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		mockSrv.EXPECT().
+			GetEvents(gomock.Any(), dto.AuditFilter{Slug: "slug1", UserID: domain.UserID{}, Limit: audit.DefaultLimit}).
+			Return([]domain.AuditEvent{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/audit?slug=slug1", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGetAuditLog(w, req)
+		res := w.Result()
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `[]`, string(body))
+	})
+
+	t.Run("bad query params", func(t *testing.T) {
+		for _, query := range []string{"user=abc", "slug=slug1&limit=1.5"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/audit?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleGetAuditLog(w, req)
+			res := w.Result()
+			res.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		}
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		mockSrv.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Return(nil, e.ErrAuditParams)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/audit", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGetAuditLog(w, req)
+		res := w.Result()
+
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("failed service", func(t *testing.T) {
+		mockSrv.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Return(nil, e.ErrAuditInternal)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/audit?slug=slug1", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGetAuditLog(w, req)
+		res := w.Result()
+
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestAuditRoutes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mock.NewMockAuditor(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	adminID := domain.NewUserID()
+
+	tests := []struct {
+		name     string
+		admins   []string
+		subnets  []string
+		userID   domain.UserID
+		expected int
+	}{
+		{"Unconfigured", []string{}, []string{}, domain.UserID{}, http.StatusForbidden},
+		{"Not admin", []string{adminID.String()}, []string{}, domain.NewUserID(), http.StatusForbidden},
+		{"Admin", []string{adminID.String()}, []string{}, adminID, http.StatusOK},
+		{"Trusted subnet", []string{}, []string{"192.0.2.0/24"}, domain.UserID{}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := config.DefaultConfig()
+			config.AdminUsers = tt.admins
+			config.TrustedSubnets = tt.subnets
+			auth := newJWTMiddleware(t, log, config)
+
+			router := chi.NewRouter()
+			handler.NewAuditHandler(mockSrv, auth, config, log).RegisterRoutes(router)
+
+			if tt.expected == http.StatusOK {
+				mockSrv.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Return([]domain.AuditEvent{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/audit?slug=slug1", nil)
+
+			if tt.userID != (domain.UserID{}) {
+				token, err := auth.GenerateToken(tt.userID)
+				require.NoError(t, err)
+
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/patraden/ya-practicum-go-shortly/api/shortener/v1"
+)
+
+// ErrorDomain is the domain of the reasons of gRPC error details.
+const ErrorDomain = "shortener.v1"
+
+// Reasons of gRPC errors, returned as error info details along with the status code.
+const (
+	ReasonURLRejected       = "URL_REJECTED"       // The URL is rejected by the URL policy
+	ReasonURLCollision      = "URL_COLLISION"      // The URL is already shortened
+	ReasonURLOptions        = "URL_OPTIONS"        // The URL is already shortened with other options
+	ReasonTooManyURLs       = "TOO_MANY_URLS"      // The stream exceeds the URLs limit
+	ReasonUserBanned        = "USER_BANNED"        // The caller is banned
+	ReasonScheduleInvalid   = "SCHEDULE_INVALID"   // The activation window is invalid
+	ReasonPasswordRequired  = "PASSWORD_REQUIRED"  // The slug is protected with a password
+	ReasonPasswordInvalid   = "PASSWORD_INVALID"   // The password does not match
+	ReasonPasswordThrottled = "PASSWORD_THROTTLED" // Too many wrong passwords are tried
+	ReasonSlugInvalid       = "SLUG_INVALID"       // The slug is malformed
+	ReasonSlugNotFound      = "SLUG_NOT_FOUND"     // The slug does not exist or is not owned by the caller
+	ReasonSlugDeleted       = "SLUG_DELETED"       // The slug is deleted by its owner
+	ReasonSlugExhausted     = "SLUG_EXHAUSTED"     // The slug reached its clicks limit
+	ReasonSlugExpired       = "SLUG_EXPIRED"       // The slug is past its activation window
+	ReasonSlugDisabled      = "SLUG_DISABLED"      // The slug is disabled by moderators
+	ReasonSlugNotActive     = "SLUG_NOT_ACTIVE"    // The slug is before its activation window
+)
+
+// errorInfo returns a status error with error info details of the reason.
+// The metadata is optional.
+func errorInfo(code codes.Code, msg string, reason string, metadata map[string]string) error {
+	st, err := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(code, msg)
+	}
+
+	return st.Err()
+}
+
+// badRequest returns an invalid argument status error with the field violations of a protovalidate error.
+func badRequest(err error) error {
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return status.Error(codes.InvalidArgument, "Bad Request")
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(verr.Violations))
+	for i, violation := range verr.Violations {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(violation.Proto.GetField()),
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetConstraintId(),
+		}
+	}
+
+	st, err := status.New(codes.InvalidArgument, "Bad Request").WithDetails(&errdetails.BadRequest{
+		FieldViolations: violations,
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "Bad Request")
+	}
+
+	return st.Err()
+}
+
+// tooManyURLs returns the status error of streams exceeding the URLs limit.
+// The URLs shortened before the limit was exceeded are added to the details, so that clients can reconcile them.
+func tooManyURLs(limit int, shortened *pb.ShortenURLStreamResponse) error {
+	msg := "Too Many URLs"
+
+	st, err := status.New(codes.ResourceExhausted, msg).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   ReasonTooManyURLs,
+			Domain:   ErrorDomain,
+			Metadata: map[string]string{"limit": strconv.Itoa(limit)},
+		},
+		shortened,
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+
+	return st.Err()
+}
+
+// urlRejected returns the status error of URLs rejected by the URL policy for the reason.
+func urlRejected(reason string) error {
+	return errorInfo(codes.InvalidArgument, "URL Rejected: "+reason, ReasonURLRejected, map[string]string{
+		"policy": reason,
+	})
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/patraden/ya-practicum-go-shortly/api/shortener/v1"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
+)
+
+// HealthWatchInterval is the interval of repository health checks of health watch streams.
+const HealthWatchInterval = 5 * time.Second
+
+// GRPCHealthHandler implements the grpc health checking protocol.
+// The server and the shortener service are serving while the repository is healthy,
+// same as the database ping of PingHandler, the in-memory repository is always healthy.
+// Both are not serving anymore once the server is shutting down.
+type GRPCHealthHandler struct {
+	db       *postgres.Database
+	config   *config.Config
+	log      *zerolog.Logger
+	interval time.Duration
+	shutdown chan struct{}
+	once     sync.Once
+	healthpb.UnimplementedHealthServer
+}
+
+// NewGRPCHealthHandler creates a new instance of GRPCHealthHandler.
+func NewGRPCHealthHandler(db *postgres.Database, config *config.Config, log *zerolog.Logger) *GRPCHealthHandler {
+	return &GRPCHealthHandler{
+		db:       db,
+		config:   config,
+		log:      log,
+		interval: HealthWatchInterval,
+		shutdown: make(chan struct{}),
+		once:     sync.Once{},
+	}
+}
+
+// WithWatchInterval sets the interval of repository health checks of health watch streams.
+func (h *GRPCHealthHandler) WithWatchInterval(interval time.Duration) *GRPCHealthHandler {
+	h.interval = interval
+
+	return h
+}
+
+// Shutdown sets the server and the shortener service not serving, so that clients stop sending requests
+// before the server stops. Health watch streams are sent the status and closed, not to hold back the server.
+func (h *GRPCHealthHandler) Shutdown() {
+	h.once.Do(func() {
+		close(h.shutdown)
+	})
+}
+
+// Check handles requests of the health status of the server or the shortener service.
+func (h *GRPCHealthHandler) Check(
+	ctx context.Context,
+	r *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	if !h.known(r.GetService()) {
+		return nil, status.Error(codes.NotFound, "Unknown Service")
+	}
+
+	return &healthpb.HealthCheckResponse{Status: h.status(ctx)}, nil
+}
+
+// Watch handles requests to stream the health status of the server or the shortener service.
+// The status is sent once and then every time it changes, until the client goes away or the server shuts down.
+func (h *GRPCHealthHandler) Watch(
+	r *healthpb.HealthCheckRequest,
+	stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse],
+) error {
+	ctx := stream.Context()
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	ticker := time.NewTicker(h.interval)
+
+	defer ticker.Stop()
+
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if h.known(r.GetService()) {
+			current = h.status(ctx)
+		}
+
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-h.shutdown:
+			// the stream is closed once the not serving status is sent
+			if current != healthpb.HealthCheckResponse_SERVING {
+				return nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// known reports whether the service is health checked, the empty service is the server.
+func (h *GRPCHealthHandler) known(service string) bool {
+	return service == "" || service == pb.URLShortenerService_ServiceDesc.ServiceName
+}
+
+// status returns the serving status of the repository, not serving while shutting down.
+func (h *GRPCHealthHandler) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	select {
+	case <-h.shutdown:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+	}
+
+	if h.config.DatabaseDSN == `` {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	if err := h.db.Ping(ctx); err != nil {
+		h.log.
+			Error().
+			Err(err).
+			Str("DSN", h.config.DatabaseDSN).
+			Msg("database is not reachable")
+
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -3,24 +3,37 @@ package handler
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/patraden/ya-practicum-go-shortly/api/shortener/v1"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/shortener"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/statsprovider"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
 )
 
 // GRPCShortenerHandler provides gRPC request handling for URL shortening operations.
 type GRPCShortenerHandler struct {
 	service   shortener.URLShortener
+	stats     statsprovider.StatsProvider
+	geo       geoip.Locator
+	keys      middleware.APIKeyResolver
+	auth      *middleware.JWTMiddleware
 	config    *config.Config
 	log       *zerolog.Logger
 	validator protovalidate.Validator
@@ -30,6 +43,10 @@ type GRPCShortenerHandler struct {
 // NewGRPCURLShortenerHandler creates a new instance of GRPCShortenerHandler using service interface.
 func NewGRPCURLShortenerHandler(
 	service shortener.URLShortener,
+	stats statsprovider.StatsProvider,
+	geo geoip.Locator,
+	keys middleware.APIKeyResolver,
+	auth *middleware.JWTMiddleware,
 	config *config.Config,
 	log *zerolog.Logger,
 ) (*GRPCShortenerHandler, error) {
@@ -40,6 +57,10 @@ func NewGRPCURLShortenerHandler(
 
 	return &GRPCShortenerHandler{
 		service:   service,
+		stats:     stats,
+		geo:       geo,
+		keys:      keys,
+		auth:      auth,
 		config:    config,
 		log:       log,
 		validator: validator,
@@ -50,6 +71,10 @@ func NewGRPCURLShortenerHandler(
 func NewGRPCShortenerHandler(
 	config *config.Config,
 	service *shortener.InsistentShortener,
+	stats statsprovider.StatsProvider,
+	geo geoip.Locator,
+	keys middleware.APIKeyResolver,
+	auth *middleware.JWTMiddleware,
 	log *zerolog.Logger,
 ) (*GRPCShortenerHandler, error) {
 	validator, err := protovalidate.New()
@@ -59,6 +84,10 @@ func NewGRPCShortenerHandler(
 
 	return &GRPCShortenerHandler{
 		service:   service,
+		stats:     stats,
+		geo:       geo,
+		keys:      keys,
+		auth:      auth,
 		config:    config,
 		log:       log,
 		validator: validator,
@@ -71,16 +100,57 @@ func (h *GRPCShortenerHandler) ShortenURL(
 	r *pb.ShortenURLRequest,
 ) (*pb.ShortenURLResponse, error) {
 	if err := h.validator.Validate(r); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "Bad Request")
+		return nil, badRequest(err)
+	}
+
+	var opts []domain.URLMappingOption
+
+	if r.GetPassword() != "" {
+		hash, err := domain.NewPasswordHash(r.GetPassword())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Bad Request")
+		}
+
+		opts = append(opts, domain.WithPasswordHash(hash))
+	}
+
+	if r.GetMaxClicks() > 0 {
+		opts = append(opts, domain.WithMaxClicks(r.GetMaxClicks()))
+	}
+
+	if r.GetActiveFrom() != nil {
+		opts = append(opts, domain.WithActiveFrom(timeFromProto(r.GetActiveFrom())))
+	}
+
+	slug, err := h.service.ShortenURL(ctx, domain.OriginalURL(r.GetUrl()), opts...)
+	if reason, rejected := urlpolicy.Reason(err); rejected {
+		return nil, urlRejected(reason)
+	}
+
+	if errors.Is(err, e.ErrActivationWindowInvalid) {
+		return nil, errorInfo(codes.InvalidArgument, "Bad Request", ReasonScheduleInvalid, nil)
+	}
+
+	if errors.Is(err, e.ErrUserBanned) {
+		return nil, errorInfo(codes.PermissionDenied, "User Banned", ReasonUserBanned, nil)
+	}
+
+	if isContextError(err) {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	if errors.Is(err, e.ErrOriginalOptions) {
+		return nil, errorInfo(codes.AlreadyExists, "Conflict", ReasonURLOptions, nil)
 	}
 
-	slug, err := h.service.ShortenURL(ctx, domain.OriginalURL(r.GetUrl()))
 	if err != nil && !errors.Is(err, e.ErrOriginalExists) {
 		return nil, status.Error(codes.Internal, "Internal Server Error")
 	}
 
 	if errors.Is(err, e.ErrOriginalExists) {
-		return nil, status.Error(codes.AlreadyExists, "Conflict")
+		return nil, errorInfo(codes.AlreadyExists, "Conflict", ReasonURLCollision, map[string]string{
+			"short_url": slug.WithBaseURL(h.config.BaseURL),
+		})
 	}
 
 	return &pb.ShortenURLResponse{Slug: slug.WithBaseURL(h.config.BaseURL)}, nil
@@ -92,27 +162,273 @@ func (h *GRPCShortenerHandler) GetOriginalURL(
 	r *pb.GetOriginalURLRequest,
 ) (*pb.GetOriginalURLResponse, error) {
 	if err := h.validator.Validate(r); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "Bad Request")
+		return nil, badRequest(err)
 	}
 
-	slug := domain.Slug(r.GetSlug())
-	original, err := h.service.GetOriginalURL(ctx, slug)
+	visit := &dto.Visit{
+		Slug:     domain.Slug(r.GetSlug()),
+		Probe:    false,
+		Query:    "",
+		SubPath:  "",
+		Password: r.GetPassword(),
+		Variant:  0,
+		Client:   h.peerClient(ctx),
+	}
+	redirect, err := h.service.FollowURL(ctx, visit)
 
 	switch {
+	case errors.Is(err, e.ErrPasswordRequired):
+		return nil, errorInfo(codes.Unauthenticated, "Password Required", ReasonPasswordRequired, nil)
+
+	case errors.Is(err, e.ErrPasswordInvalid):
+		return nil, errorInfo(codes.PermissionDenied, "Wrong Password", ReasonPasswordInvalid, nil)
+
+	case errors.Is(err, e.ErrPasswordThrottled):
+		return nil, errorInfo(codes.ResourceExhausted, "Too Many Attempts", ReasonPasswordThrottled, nil)
+
 	case errors.Is(err, e.ErrSlugInvalid):
-		return nil, status.Error(codes.InvalidArgument, "Bad Request")
+		return nil, errorInfo(codes.InvalidArgument, "Bad Request", ReasonSlugInvalid, nil)
 
 	case errors.Is(err, e.ErrSlugNotFound):
-		return nil, status.Error(codes.NotFound, "Not Found")
+		return nil, errorInfo(codes.NotFound, "Not Found", ReasonSlugNotFound, nil)
 
 	case errors.Is(err, e.ErrSlugDeleted):
-		return nil, status.Error(codes.NotFound, "Deleted")
+		return nil, errorInfo(codes.NotFound, "Deleted", ReasonSlugDeleted, nil)
+
+	case errors.Is(err, e.ErrSlugExhausted):
+		return nil, errorInfo(codes.NotFound, "Exhausted", ReasonSlugExhausted, nil)
+
+	case errors.Is(err, e.ErrSlugExpired):
+		return nil, errorInfo(codes.NotFound, "Expired", ReasonSlugExpired, nil)
+
+	case errors.Is(err, e.ErrSlugDisabled):
+		return nil, errorInfo(codes.NotFound, "Disabled", ReasonSlugDisabled, nil)
+
+	case errors.Is(err, e.ErrSlugNotActive) && h.config.InactiveFallbackURL != "":
+		return &pb.GetOriginalURLResponse{Url: h.config.InactiveFallbackURL}, nil
+
+	case errors.Is(err, e.ErrSlugNotActive):
+		return nil, errorInfo(codes.NotFound, "Not Yet Active", ReasonSlugNotActive, nil)
 
 	case errors.Is(err, e.ErrShortenerInternal) || err != nil:
 		return nil, status.Error(codes.Internal, "Internal Server Error")
 	}
 
-	return &pb.GetOriginalURLResponse{Url: original.String()}, nil
+	return &pb.GetOriginalURLResponse{Url: redirect.Location.String()}, nil
+}
+
+// ScheduleURL handles requests to replace the activation window of a shortened URL owned by the caller.
+func (h *GRPCShortenerHandler) ScheduleURL(
+	ctx context.Context,
+	r *pb.ScheduleURLRequest,
+) (*pb.ScheduleURLResponse, error) {
+	if err := h.validator.Validate(r); err != nil {
+		return nil, badRequest(err)
+	}
+
+	schedule := &dto.URLSchedule{
+		ActiveFrom: timeFromProto(r.GetActiveFrom()),
+		ExpiresAt:  timeFromProto(r.GetExpiresAt()),
+	}
+	err := h.service.ScheduleURL(ctx, domain.Slug(r.GetSlug()), schedule)
+
+	switch {
+	case errors.Is(err, e.ErrSlugInvalid):
+		return nil, errorInfo(codes.InvalidArgument, "Bad Request", ReasonSlugInvalid, nil)
+
+	case errors.Is(err, e.ErrActivationWindowInvalid):
+		return nil, errorInfo(codes.InvalidArgument, "Bad Request", ReasonScheduleInvalid, nil)
+
+	case errors.Is(err, e.ErrSlugNotFound):
+		return nil, errorInfo(codes.NotFound, "Not Found", ReasonSlugNotFound, nil)
+
+	case err != nil:
+		return nil, status.Error(codes.Internal, "Internal Server Error")
+	}
+
+	return &pb.ScheduleURLResponse{}, nil
+}
+
+// GetStats handles requests to retrieve repository statistics.
+func (h *GRPCShortenerHandler) GetStats(ctx context.Context, r *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	if err := h.validator.Validate(r); err != nil {
+		return nil, badRequest(err)
+	}
+
+	params := dto.StatsParams{Days: int(r.GetDays()), TopUsers: int(r.GetTopUsers()), Now: time.Time{}}
+	stats, err := h.stats.GetStats(ctx, params)
+
+	switch {
+	case errors.Is(err, e.ErrStatsProviderParams):
+		return nil, status.Error(codes.InvalidArgument, "Bad Request")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "Internal Server Error")
+	}
+
+	resp := &pb.GetStatsResponse{
+		Urls:         stats.CountSlugs,
+		Users:        stats.CountUsers,
+		Active:       stats.CountActive,
+		Deleted:      stats.CountDeleted,
+		Expired:      stats.CountExpired,
+		StorageBytes: stats.StorageBytes,
+		Daily:        make([]*pb.DailyStats, len(stats.Daily)),
+		TopUsers:     make([]*pb.UserStats, len(stats.TopUsers)),
+	}
+
+	for i, day := range stats.Daily {
+		resp.Daily[i] = &pb.DailyStats{Date: day.Date, Urls: day.CountSlugs}
+	}
+
+	for i, user := range stats.TopUsers {
+		resp.TopUsers[i] = &pb.UserStats{UserId: user.UserID, Urls: user.CountSlugs}
+	}
+
+	return resp, nil
+}
+
+// ShortenURLStream handles client streams of URLs to shorten.
+// URLs are validated as they are received and shortened in chunks of the configured size,
+// the stream is not read while a chunk is shortened, so the client is held back by the stream flow control.
+// Chunks shortened before a failure are kept, they are listed by ListUserURLsStream.
+// When the stream exceeds the URLs limit, the URLs shortened so far are returned in the error details.
+func (h *GRPCShortenerHandler) ShortenURLStream(stream pb.URLShortenerService_ShortenURLStreamServer) error {
+	ctx := stream.Context()
+	chunk := make(dto.OriginalURLBatch, 0, h.config.GRPCStreamChunk)
+	resp := &pb.ShortenURLStreamResponse{Urls: make([]*pb.CorrelatedURL, 0, h.config.GRPCStreamChunk)}
+
+	for {
+		r, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := h.validator.Validate(r); err != nil {
+			return badRequest(err)
+		}
+
+		if len(resp.GetUrls())+len(chunk) == h.config.GRPCStreamMaxURLs {
+			return tooManyURLs(h.config.GRPCStreamMaxURLs, resp)
+		}
+
+		chunk = append(chunk, dto.CorrelatedOriginalURL{
+			CorrelationID: r.GetCorrelationId(),
+			OriginalURL:   domain.OriginalURL(r.GetUrl()),
+		})
+
+		if len(chunk) == h.config.GRPCStreamChunk {
+			if err := h.shortenChunk(ctx, chunk, resp); err != nil {
+				return err
+			}
+
+			chunk = chunk[:0]
+		}
+	}
+
+	if len(chunk) > 0 {
+		if err := h.shortenChunk(ctx, chunk, resp); err != nil {
+			return err
+		}
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// shortenChunk shortens a chunk of streamed URLs and appends the short URLs to the response.
+func (h *GRPCShortenerHandler) shortenChunk(
+	ctx context.Context,
+	chunk dto.OriginalURLBatch,
+	resp *pb.ShortenURLStreamResponse,
+) error {
+	slugs, err := h.service.ShortenURLBatch(ctx, &chunk)
+	if reason, rejected := urlpolicy.Reason(err); rejected {
+		return urlRejected(reason)
+	}
+
+	switch {
+	case errors.Is(err, e.ErrUserBanned):
+		return errorInfo(codes.PermissionDenied, "User Banned", ReasonUserBanned, nil)
+	case isContextError(err):
+		return status.FromContextError(err).Err()
+	case err != nil:
+		return status.Error(codes.Internal, "Internal Server Error")
+	}
+
+	for _, slug := range *slugs {
+		resp.Urls = append(resp.Urls, &pb.CorrelatedURL{
+			CorrelationId: slug.CorrelationID,
+			ShortUrl:      slug.Slug.WithBaseURL(h.config.BaseURL),
+		})
+	}
+
+	return nil
+}
+
+// ListUserURLsStream handles requests to stream the URLs shortened by the caller.
+// Sending blocks while the client does not keep up with the stream, until the client goes away.
+func (h *GRPCShortenerHandler) ListUserURLsStream(
+	r *pb.ListUserURLsStreamRequest,
+	stream pb.URLShortenerService_ListUserURLsStreamServer,
+) error {
+	if err := h.validator.Validate(r); err != nil {
+		return badRequest(err)
+	}
+
+	urls, err := h.service.GetUserURLs(stream.Context())
+
+	switch {
+	case errors.Is(err, e.ErrUserNotFound):
+		return nil
+	case err != nil:
+		return status.Error(codes.Internal, "Internal Server Error")
+	}
+
+	for _, pair := range *urls {
+		err := stream.Send(&pb.ListUserURLsStreamResponse{
+			ShortUrl:    pair.Slug.String(),
+			OriginalUrl: pair.OriginalURL.String(),
+			Clicks:      pair.Clicks,
+			MaxClicks:   pair.MaxClicks,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// peerClient describes the caller for redirect rules matching with its metadata,
+// the IP address is the client IP resolved by the SourceInterceptor.
+func (h *GRPCShortenerHandler) peerClient(ctx context.Context) *domain.Client {
+	var userAgent, acceptLanguage string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		userAgent = strings.Join(md.Get("user-agent"), " ")
+		acceptLanguage = strings.Join(md.Get("accept-language"), ",")
+	}
+
+	source, _ := middleware.GetSource(ctx)
+
+	return visitorClient(h.geo, userAgent, acceptLanguage, source.ClientIP)
+}
+
+// isContextError reports whether the error is caused by a cancelled or timed out request.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// timeFromProto converts an optional protobuf timestamp, unset timestamps result in zero time.
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
 }
 
 // Interceptors returns interceptors that should be used with the handler.
@@ -123,12 +439,50 @@ func (h *GRPCShortenerHandler) Interceptors() []grpc.UnaryServerInterceptor {
 			return true
 		case pb.URLShortenerService_GetOriginalURL_FullMethodName:
 			return true
+		case pb.URLShortenerService_ScheduleURL_FullMethodName:
+			return true
+		case pb.URLShortenerService_ShortenURLStream_FullMethodName:
+			return true
 		default:
 			return false
 		}
 	}
 
+	// only existing users have URLs to list
+	authorized := func(method string) bool {
+		return method == pb.URLShortenerService_ListUserURLsStream_FullMethodName
+	}
+
+	scopes := map[string]domain.APIKeyScope{
+		pb.URLShortenerService_ShortenURL_FullMethodName:         domain.ScopeWrite,
+		pb.URLShortenerService_GetOriginalURL_FullMethodName:     domain.ScopeRead,
+		pb.URLShortenerService_ScheduleURL_FullMethodName:        domain.ScopeWrite,
+		pb.URLShortenerService_ShortenURLStream_FullMethodName:   domain.ScopeWrite,
+		pb.URLShortenerService_ListUserURLsStream_FullMethodName: domain.ScopeRead,
+	}
+
+	internal := func(method string) bool {
+		return method == pb.URLShortenerService_GetStats_FullMethodName
+	}
+
 	return []grpc.UnaryServerInterceptor{
-		middleware.AuthenticateGRPC(filter, h.log, h.config),
+		middleware.SourceInterceptor(),
+		middleware.SubnetInterceptor(h.log, h.config, internal),
+		middleware.APIKeyInterceptor(h.keys, scopes, h.log),
+		h.auth.JWTAuthenticateInterceptor(filter),
+		h.auth.JWTAuthorizeInterceptor(authorized),
+	}
+}
+
+// StreamInterceptors returns stream interceptors that should be used with the handler,
+// the interceptors of the handler are applied to stream contexts.
+func (h *GRPCShortenerHandler) StreamInterceptors() []grpc.StreamServerInterceptor {
+	interceptors := h.Interceptors()
+	res := make([]grpc.StreamServerInterceptor, len(interceptors))
+
+	for i, interceptor := range interceptors {
+		res[i] = middleware.StreamInterceptor(interceptor)
 	}
+
+	return res
 }
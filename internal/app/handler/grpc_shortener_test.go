@@ -2,20 +2,33 @@ package handler_test
 
 import (
 	"context"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/patraden/ya-practicum-go-shortly/api/shortener/v1"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
 )
 
@@ -28,9 +41,12 @@ func setupGRPCShortenerHandler(t *testing.T) (
 
 	ctrl := gomock.NewController(t)
 	mockSrv := mock.NewMockURLShortener(ctrl)
+	mockStats := mock.NewMockStatsProvider(ctrl)
 	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
-	config := &config.Config{BaseURL: "http://base.url"}
-	h, err := handler.NewGRPCURLShortenerHandler(mockSrv, config, log)
+	config := &config.Config{BaseURL: "http://base.url", JWTSecret: "test-secret"}
+	auth := newJWTMiddleware(t, log, config)
+	keys := mock.NewMockAPIKeyResolver(ctrl)
+	h, err := handler.NewGRPCURLShortenerHandler(mockSrv, mockStats, geoip.NopLocator{}, keys, auth, config, log)
 	require.NoError(t, err)
 
 	return ctrl, mockSrv, h
@@ -42,15 +58,19 @@ func TestGRPCSShortenURL(t *testing.T) {
 	tests := []struct {
 		name        string
 		url         string
+		password    string
 		mockReturn  domain.Slug
 		mockError   error
 		expectedErr codes.Code
 		expectedURL string
 	}{
-		{"Success", "https://example.com", domain.Slug("abcd1234"), nil, codes.OK, "http://base.url/abcd1234"},
-		{"Invalid URL format", "invalid-url", "", nil, codes.InvalidArgument, ""},
-		{"Already Exists", "https://duplicate.com", "", e.ErrOriginalExists, codes.AlreadyExists, ""},
-		{"Internal Error", "https://example.com", "", e.ErrTestGeneral, codes.Internal, ""},
+		{"Success", "https://example.com", "", domain.Slug("abcd1234"), nil, codes.OK, "http://base.url/abcd1234"},
+		{"Success With Password", "https://example.com", "secret", "abcd1234", nil, codes.OK, "http://base.url/abcd1234"},
+		{"Password Too Long", "https://example.com", strings.Repeat("p", 73), "", nil, codes.InvalidArgument, ""},
+		{"Invalid URL format", "invalid-url", "", "", nil, codes.InvalidArgument, ""},
+		{"Already Exists", "https://duplicate.com", "", "", e.ErrOriginalExists, codes.AlreadyExists, ""},
+		{"Exists With Options", "https://duplicate.com", "", "", e.ErrOriginalOptions, codes.AlreadyExists, ""},
+		{"Internal Error", "https://example.com", "", "", e.ErrTestGeneral, codes.Internal, ""},
 	}
 
 	for _, ttc := range tests {
@@ -60,14 +80,21 @@ func TestGRPCSShortenURL(t *testing.T) {
 			ctrl, mockSrv, h := setupGRPCShortenerHandler(t)
 			defer ctrl.Finish()
 
-			if ttc.mockError != nil || ttc.mockReturn != "" {
+			if (ttc.mockError != nil || ttc.mockReturn != "") && ttc.password == "" {
 				mockSrv.EXPECT().
 					ShortenURL(gomock.Any(), domain.OriginalURL(ttc.url)).
 					Return(ttc.mockReturn, ttc.mockError).
 					AnyTimes()
 			}
 
-			resp, err := h.ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: ttc.url})
+			if (ttc.mockError != nil || ttc.mockReturn != "") && ttc.password != "" {
+				mockSrv.EXPECT().
+					ShortenURL(gomock.Any(), domain.OriginalURL(ttc.url), gomock.Any()).
+					Return(ttc.mockReturn, ttc.mockError).
+					AnyTimes()
+			}
+
+			resp, err := h.ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: ttc.url, Password: ttc.password})
 
 			if ttc.expectedErr == codes.OK {
 				require.NoError(t, err)
@@ -81,23 +108,50 @@ func TestGRPCSShortenURL(t *testing.T) {
 	}
 }
 
+func TestGRPCSShortenURLMaxClicks(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupGRPCShortenerHandler(t)
+	defer ctrl.Finish()
+
+	mockSrv.EXPECT().
+		ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
+		Return(domain.Slug("abcd1234"), nil).
+		Times(1)
+
+	resp, err := h.ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: "https://example.com", MaxClicks: 3})
+	require.NoError(t, err)
+	require.Equal(t, "http://base.url/abcd1234", resp.GetSlug())
+
+	_, err = h.ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: "https://example.com", MaxClicks: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestGetOriginalURL(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name        string
 		slug        string
+		password    string
 		mockReturn  domain.OriginalURL
 		mockError   error
 		expectedErr codes.Code
 		expectedURL string
 	}{
-		{"Success", "abcd1234", domain.OriginalURL("https://example.com"), nil, codes.OK, "https://example.com"},
-		{"Slug Too Short", "abc", "", nil, codes.InvalidArgument, ""},
-		{"Invalid Slug", "invalid-slug", "", e.ErrSlugInvalid, codes.InvalidArgument, ""},
-		{"Slug Not Found", "notfound123", "", e.ErrSlugNotFound, codes.NotFound, ""},
-		{"Slug Deleted", "deleted123", "", e.ErrSlugDeleted, codes.NotFound, ""},
-		{"Internal Error", "error123", "", e.ErrShortenerInternal, codes.Internal, ""},
+		{"Success", "abcd1234", "", domain.OriginalURL("https://example.com"), nil, codes.OK, "https://example.com"},
+		{"Success With Password", "abcd1234", "secret", "https://example.com", nil, codes.OK, "https://example.com"},
+		{"Slug Too Short", "abc", "", "", nil, codes.InvalidArgument, ""},
+		{"Invalid Slug", "invalid-slug", "", "", e.ErrSlugInvalid, codes.InvalidArgument, ""},
+		{"Slug Not Found", "notfound123", "", "", e.ErrSlugNotFound, codes.NotFound, ""},
+		{"Slug Deleted", "deleted123", "", "", e.ErrSlugDeleted, codes.NotFound, ""},
+		{"Slug Exhausted", "exhausted1", "", "", e.ErrSlugExhausted, codes.NotFound, ""},
+		{"Slug Not Yet Active", "inactive1", "", "", e.ErrSlugNotActive, codes.NotFound, ""},
+		{"Slug Expired", "expired12", "", "", e.ErrSlugExpired, codes.NotFound, ""},
+		{"Password Required", "abcd1234", "", "", e.ErrPasswordRequired, codes.Unauthenticated, ""},
+		{"Wrong Password", "abcd1234", "wrong", "", e.ErrPasswordInvalid, codes.PermissionDenied, ""},
+		{"Password Throttled", "abcd1234", "wrong", "", e.ErrPasswordThrottled, codes.ResourceExhausted, ""},
+		{"Internal Error", "error123", "", "", e.ErrShortenerInternal, codes.Internal, ""},
 	}
 
 	for _, ttc := range tests {
@@ -108,13 +162,27 @@ func TestGetOriginalURL(t *testing.T) {
 			defer ctrl.Finish()
 
 			if ttc.mockError != nil || ttc.mockReturn != "" {
+				var redirect *dto.Redirect
+				if ttc.mockReturn != "" {
+					redirect = &dto.Redirect{Location: ttc.mockReturn, Type: domain.RedirectTemporary}
+				}
+
+				visit := &dto.Visit{
+					Slug:     domain.Slug(ttc.slug),
+					Probe:    false,
+					Query:    "",
+					SubPath:  "",
+					Password: ttc.password,
+					Client:   &domain.Client{Platform: domain.PlatformAny, Languages: []string{}, IP: netip.Addr{}, Country: ""},
+				}
 				mockSrv.EXPECT().
-					GetOriginalURL(gomock.Any(), domain.Slug(ttc.slug)).
-					Return(ttc.mockReturn, ttc.mockError).
+					FollowURL(gomock.Any(), visit).
+					Return(redirect, ttc.mockError).
 					AnyTimes()
 			}
 
-			resp, err := h.GetOriginalURL(context.Background(), &pb.GetOriginalURLRequest{Slug: ttc.slug})
+			req := &pb.GetOriginalURLRequest{Slug: ttc.slug, Password: ttc.password}
+			resp, err := h.GetOriginalURL(context.Background(), req)
 
 			if ttc.expectedErr == codes.OK {
 				require.NoError(t, err)
@@ -127,3 +195,371 @@ func TestGetOriginalURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOriginalURLClient(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupGRPCShortenerHandler(t)
+	defer ctrl.Finish()
+
+	md := metadata.Pairs("user-agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", "accept-language", "de")
+	source := domain.RequestSource{Protocol: domain.ProtocolGRPC, ClientIP: "81.2.69.10"}
+	ctx := middleware.WithSource(metadata.NewIncomingContext(context.Background(), md), source)
+	client := &domain.Client{
+		Platform:  domain.PlatformIOS,
+		Languages: []string{"de"},
+		IP:        netip.MustParseAddr("81.2.69.10"),
+		Country:   "",
+	}
+
+	mockSrv.EXPECT().
+		FollowURL(gomock.Any(), gomock.Cond(func(visit *dto.Visit) bool {
+			return assert.ObjectsAreEqual(client, visit.Client)
+		})).
+		Return(&dto.Redirect{Location: "https://apps.apple.com/app", Type: domain.RedirectTemporary}, nil)
+
+	resp, err := h.GetOriginalURL(ctx, &pb.GetOriginalURLRequest{Slug: "abcd1234"})
+	require.NoError(t, err)
+	require.Equal(t, "https://apps.apple.com/app", resp.GetUrl())
+}
+
+func TestGRPCScheduleURL(t *testing.T) {
+	t.Parallel()
+
+	activeFrom := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		slug        string
+		activeFrom  *timestamppb.Timestamp
+		mockError   error
+		expectedErr codes.Code
+	}{
+		{"Success", "abcd1234", timestamppb.New(activeFrom), nil, codes.OK},
+		{"Slug Too Short", "abc", nil, nil, codes.InvalidArgument},
+		{"Invalid Window", "abcd1234", timestamppb.New(activeFrom), e.ErrActivationWindowInvalid, codes.InvalidArgument},
+		{"Not Owned", "abcd1234", nil, e.ErrSlugNotFound, codes.NotFound},
+		{"Internal Error", "abcd1234", nil, e.ErrShortenerInternal, codes.Internal},
+	}
+
+	for _, ttc := range tests {
+		t.Run(ttc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl, mockSrv, h := setupGRPCShortenerHandler(t)
+			defer ctrl.Finish()
+
+			if ttc.expectedErr != codes.InvalidArgument || ttc.mockError != nil {
+				schedule := &dto.URLSchedule{ActiveFrom: time.Time{}, ExpiresAt: time.Time{}}
+				if ttc.activeFrom != nil {
+					schedule.ActiveFrom = activeFrom
+				}
+
+				mockSrv.EXPECT().
+					ScheduleURL(gomock.Any(), domain.Slug(ttc.slug), schedule).
+					Return(ttc.mockError).
+					Times(1)
+			}
+
+			req := &pb.ScheduleURLRequest{Slug: ttc.slug, ActiveFrom: ttc.activeFrom}
+			_, err := h.ScheduleURL(context.Background(), req)
+			require.Equal(t, ttc.expectedErr, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCGetOriginalURLInactiveFallback(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mock.NewMockURLShortener(ctrl)
+	mockStats := mock.NewMockStatsProvider(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	config := &config.Config{
+		BaseURL:             "http://base.url",
+		JWTSecret:           "test-secret",
+		InactiveFallbackURL: "https://example.com/soon",
+	}
+	auth := newJWTMiddleware(t, log, config)
+	keys := mock.NewMockAPIKeyResolver(ctrl)
+	h, err := handler.NewGRPCURLShortenerHandler(mockSrv, mockStats, geoip.NopLocator{}, keys, auth, config, log)
+	require.NoError(t, err)
+
+	mockSrv.EXPECT().FollowURL(gomock.Any(), gomock.Any()).Return(nil, e.ErrSlugNotActive)
+
+	resp, err := h.GetOriginalURL(context.Background(), &pb.GetOriginalURLRequest{Slug: "abcd1234"})
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/soon", resp.GetUrl())
+}
+
+func TestGRPCGetStats(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStats := mock.NewMockStatsProvider(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	config := &config.Config{BaseURL: "http://base.url", JWTSecret: "test-secret"}
+	auth := newJWTMiddleware(t, log, config)
+	keys := mock.NewMockAPIKeyResolver(ctrl)
+	h, err := handler.NewGRPCURLShortenerHandler(
+		mock.NewMockURLShortener(ctrl),
+		mockStats,
+		geoip.NopLocator{},
+		keys,
+		auth,
+		config,
+		log,
+	)
+	require.NoError(t, err)
+
+	user := domain.NewUserID()
+	stats := &dto.RepoStats{
+		CountSlugs:   3,
+		CountUsers:   2,
+		CountActive:  1,
+		CountDeleted: 1,
+		CountExpired: 1,
+		StorageBytes: 8192,
+		Daily:        []dto.DailyStats{{Date: "2024-05-02", CountSlugs: 3}},
+		TopUsers:     []dto.UserStats{{UserID: user.String(), CountSlugs: 2}},
+	}
+
+	mockStats.EXPECT().GetStats(gomock.Any(), dto.StatsParams{Days: 7, TopUsers: 1}).Return(stats, nil)
+
+	resp, err := h.GetStats(context.Background(), &pb.GetStatsRequest{Days: 7, TopUsers: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.GetUrls())
+	require.Equal(t, int64(2), resp.GetUsers())
+	require.Equal(t, int64(1), resp.GetActive())
+	require.Equal(t, int64(1), resp.GetDeleted())
+	require.Equal(t, int64(1), resp.GetExpired())
+	require.Equal(t, int64(8192), resp.GetStorageBytes())
+	require.Len(t, resp.GetDaily(), 1)
+	require.Equal(t, "2024-05-02", resp.GetDaily()[0].GetDate())
+	require.Len(t, resp.GetTopUsers(), 1)
+	require.Equal(t, user.String(), resp.GetTopUsers()[0].GetUserId())
+
+	_, err = h.GetStats(context.Background(), &pb.GetStatsRequest{Days: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mockStats.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, e.ErrStatsProviderParams)
+
+	_, err = h.GetStats(context.Background(), &pb.GetStatsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mockStats.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, e.ErrStatsProviderInternal)
+
+	_, err = h.GetStats(context.Background(), &pb.GetStatsRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+type shortenURLStream struct {
+	grpc.ServerStream
+	requests []*pb.ShortenURLStreamRequest
+	response *pb.ShortenURLStreamResponse
+}
+
+func (s *shortenURLStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *shortenURLStream) Recv() (*pb.ShortenURLStreamRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	r := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return r, nil
+}
+
+func (s *shortenURLStream) SendAndClose(resp *pb.ShortenURLStreamResponse) error {
+	s.response = resp
+
+	return nil
+}
+
+type listUserURLsStream struct {
+	grpc.ServerStream
+	responses []*pb.ListUserURLsStreamResponse
+}
+
+func (s *listUserURLsStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *listUserURLsStream) Send(resp *pb.ListUserURLsStreamResponse) error {
+	s.responses = append(s.responses, resp)
+
+	return nil
+}
+
+func setupGRPCStreamHandler(t *testing.T) (*mock.MockURLShortener, *handler.GRPCShortenerHandler) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockSrv := mock.NewMockURLShortener(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	config := &config.Config{
+		BaseURL:           "http://base.url",
+		JWTSecret:         "test-secret",
+		GRPCStreamChunk:   2,
+		GRPCStreamMaxURLs: 4,
+	}
+	auth := newJWTMiddleware(t, log, config)
+	keys := mock.NewMockAPIKeyResolver(ctrl)
+	h, err := handler.NewGRPCURLShortenerHandler(
+		mockSrv,
+		mock.NewMockStatsProvider(ctrl),
+		geoip.NopLocator{},
+		keys,
+		auth,
+		config,
+		log,
+	)
+	require.NoError(t, err)
+
+	return mockSrv, h
+}
+
+func shortenStreamRequests(count int) []*pb.ShortenURLStreamRequest {
+	requests := make([]*pb.ShortenURLStreamRequest, count)
+	for i := range requests {
+		id := strconv.Itoa(i)
+		requests[i] = &pb.ShortenURLStreamRequest{CorrelationId: id, Url: "https://example.com/" + id}
+	}
+
+	return requests
+}
+
+func batchLen(n int) gomock.Matcher {
+	return gomock.Cond(func(batch *dto.OriginalURLBatch) bool { return len(*batch) == n })
+}
+
+func TestGRPCShortenURLStream(t *testing.T) {
+	t.Parallel()
+
+	mockSrv, h := setupGRPCStreamHandler(t)
+	shorten := func(_ context.Context, batch *dto.OriginalURLBatch) (*dto.SlugBatch, error) {
+		slugs := make(dto.SlugBatch, len(*batch))
+		for i, url := range *batch {
+			slugs[i] = dto.CorrelatedSlug{CorrelationID: url.CorrelationID, Slug: domain.Slug("slug" + url.CorrelationID)}
+		}
+
+		return &slugs, nil
+	}
+
+	// three URLs are shortened in a full chunk and a remainder
+	mockSrv.EXPECT().ShortenURLBatch(gomock.Any(), batchLen(2)).DoAndReturn(shorten)
+	mockSrv.EXPECT().ShortenURLBatch(gomock.Any(), batchLen(1)).DoAndReturn(shorten)
+
+	stream := &shortenURLStream{requests: shortenStreamRequests(3)}
+	require.NoError(t, h.ShortenURLStream(stream))
+	require.Len(t, stream.response.GetUrls(), 3)
+	require.Equal(t, "2", stream.response.GetUrls()[2].GetCorrelationId())
+	require.Equal(t, "http://base.url/slug2", stream.response.GetUrls()[2].GetShortUrl())
+
+	stream = &shortenURLStream{requests: shortenStreamRequests(5)}
+	mockSrv.EXPECT().ShortenURLBatch(gomock.Any(), batchLen(2)).DoAndReturn(shorten).Times(2)
+
+	// URLs shortened before the limit is exceeded are returned in the details
+	st := status.Convert(h.ShortenURLStream(stream))
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 2)
+
+	shortened, ok := st.Details()[1].(*pb.ShortenURLStreamResponse)
+	require.True(t, ok)
+	require.Len(t, shortened.GetUrls(), 4)
+	require.Equal(t, "3", shortened.GetUrls()[3].GetCorrelationId())
+	require.Equal(t, "http://base.url/slug3", shortened.GetUrls()[3].GetShortUrl())
+
+	stream = &shortenURLStream{requests: shortenStreamRequests(1)}
+	stream.requests[0].Url = "invalid-url"
+	require.Equal(t, codes.InvalidArgument, status.Code(h.ShortenURLStream(stream)))
+
+	stream = &shortenURLStream{requests: shortenStreamRequests(1)}
+	mockSrv.EXPECT().ShortenURLBatch(gomock.Any(), gomock.Any()).Return(nil, e.ErrUserBanned)
+	require.Equal(t, codes.PermissionDenied, status.Code(h.ShortenURLStream(stream)))
+
+	stream = &shortenURLStream{requests: shortenStreamRequests(1)}
+	mockSrv.EXPECT().ShortenURLBatch(gomock.Any(), gomock.Any()).Return(nil, context.Canceled)
+	require.Equal(t, codes.Canceled, status.Code(h.ShortenURLStream(stream)))
+
+	stream = &shortenURLStream{requests: shortenStreamRequests(1)}
+	mockSrv.EXPECT().ShortenURLBatch(gomock.Any(), gomock.Any()).Return(nil, e.ErrTestGeneral)
+	require.Equal(t, codes.Internal, status.Code(h.ShortenURLStream(stream)))
+}
+
+func TestGRPCListUserURLsStream(t *testing.T) {
+	t.Parallel()
+
+	mockSrv, h := setupGRPCStreamHandler(t)
+	urls := dto.URLPairBatch{
+		{Slug: "http://base.url/slug1", OriginalURL: "https://example.com/1", Clicks: 0, MaxClicks: 0},
+		{Slug: "http://base.url/slug2", OriginalURL: "https://example.com/2", Clicks: 3, MaxClicks: 10},
+	}
+
+	mockSrv.EXPECT().GetUserURLs(gomock.Any()).Return(&urls, nil)
+
+	stream := &listUserURLsStream{}
+	require.NoError(t, h.ListUserURLsStream(&pb.ListUserURLsStreamRequest{}, stream))
+	require.Len(t, stream.responses, 2)
+	require.Equal(t, "http://base.url/slug2", stream.responses[1].GetShortUrl())
+	require.Equal(t, int64(3), stream.responses[1].GetClicks())
+	require.Equal(t, int64(10), stream.responses[1].GetMaxClicks())
+
+	mockSrv.EXPECT().GetUserURLs(gomock.Any()).Return(nil, e.ErrUserNotFound)
+
+	stream = &listUserURLsStream{}
+	require.NoError(t, h.ListUserURLsStream(&pb.ListUserURLsStreamRequest{}, stream))
+	require.Empty(t, stream.responses)
+
+	mockSrv.EXPECT().GetUserURLs(gomock.Any()).Return(nil, e.ErrTestGeneral)
+	require.Equal(t, codes.Internal, status.Code(h.ListUserURLsStream(&pb.ListUserURLsStreamRequest{}, stream)))
+}
+
+func TestGRPCErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupGRPCShortenerHandler(t)
+	defer ctrl.Finish()
+
+	_, err := h.ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: "invalid-url"})
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	require.Equal(t, "url", badRequest.GetFieldViolations()[0].GetField())
+	require.NotEmpty(t, badRequest.GetFieldViolations()[0].GetDescription())
+
+	mockSrv.EXPECT().ShortenURL(gomock.Any(), gomock.Any()).Return(domain.Slug("abcd1234"), e.ErrOriginalExists)
+
+	_, err = h.ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: "https://example.com"})
+	st = status.Convert(err)
+	require.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, handler.ErrorDomain, info.GetDomain())
+	require.Equal(t, handler.ReasonURLCollision, info.GetReason())
+	require.Equal(t, "http://base.url/abcd1234", info.GetMetadata()["short_url"])
+
+	mockSrv.EXPECT().FollowURL(gomock.Any(), gomock.Any()).Return(nil, e.ErrSlugDeleted)
+
+	_, err = h.GetOriginalURL(context.Background(), &pb.GetOriginalURLRequest{Slug: "abcd1234"})
+	st = status.Convert(err)
+	require.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	info, ok = st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, handler.ReasonSlugDeleted, info.GetReason())
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"embed"
+	"html/template"
+
 	"github.com/go-chi/chi/v5"
 )
 
@@ -9,6 +12,35 @@ const (
 	ContentType     = "Content-Type"
 	ContentTypeText = "text/plain"
 	ContentTypeJSON = "application/json"
+	ContentTypeHTML = "text/html; charset=utf-8"
+	CacheControl    = "Cache-Control"
+)
+
+// Password protected links aux constants.
+const (
+	LinkPasswordHeader = "X-Link-Password" // Header carrying the password of a protected link.
+	LinkPasswordField  = "password"        // Form field carrying the password of a protected link.
+)
+
+// A/B split links aux constants.
+const (
+	VariantCookiePrefix = "variant_"     // Prefix of the per slug cookie carrying the assigned variant number.
+	VariantCookieMaxAge = 30 * 24 * 3600 // Variant assignment lifetime in seconds.
+)
+
+// Cache-Control header values.
+const (
+	CacheControlNoStore   = "private, no-store"     // Never cache, e.g. temporary redirects counting every click.
+	CacheControlPermanent = "public, max-age=86400" // Cache permanent redirects for a day.
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+// HTML page templates.
+var (
+	previewTemplate  = template.Must(template.ParseFS(templates, "templates/preview.html"))
+	passwordTemplate = template.Must(template.ParseFS(templates, "templates/password.html"))
 )
 
 // Handler can register its routes within router.
@@ -16,14 +16,21 @@ import (
 // DeleteHandler handles requests related to deleting slugs.
 type DeleteHandler struct {
 	remover remover.URLRemover
+	auth    *middleware.JWTMiddleware
 	config  *config.Config
 	log     *zerolog.Logger
 }
 
 // NewDeleteHandler creates and returns a new DeleteHandler instance.
-func NewDeleteHandler(remover remover.URLRemover, config *config.Config, log *zerolog.Logger) *DeleteHandler {
+func NewDeleteHandler(
+	remover remover.URLRemover,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *DeleteHandler {
 	return &DeleteHandler{
 		remover: remover,
+		auth:    auth,
 		config:  config,
 		log:     log,
 	}
@@ -32,7 +39,7 @@ func NewDeleteHandler(remover remover.URLRemover, config *config.Config, log *ze
 // RegisterRoutes register all handler routes within http router.
 func (h *DeleteHandler) RegisterRoutes(router chi.Router) {
 	router.Group(func(r chi.Router) {
-		r.Use(middleware.Authorize(h.log, h.config))
+		r.Use(h.auth.AuthorizeHandler)
 		r.Delete("/api/user/urls", h.HandleDelUserURLs)
 	})
 }
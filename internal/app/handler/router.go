@@ -6,11 +6,18 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 )
 
 // HTTP router.
-func NewRouter(log *zerolog.Logger, handlers ...Handler) http.Handler {
+// Requests are tagged with their source and requests bearing API keys are authenticated before reaching handlers.
+func NewRouter(
+	log *zerolog.Logger,
+	config *config.Config,
+	keys middleware.APIKeyResolver,
+	handlers ...Handler,
+) http.Handler {
 	router := chi.NewRouter()
 
 	// Apply common middleware to all routes
@@ -19,6 +26,8 @@ func NewRouter(log *zerolog.Logger, handlers ...Handler) http.Handler {
 	router.Use(middleware.Compress())
 	router.Use(middleware.Decompress())
 	router.Use(middleware.Logger(log))
+	router.Use(middleware.RequestSource(log, config))
+	router.Use(middleware.AuthenticateAPIKey(keys, log))
 
 	// Register handlers
 	for _, h := range handlers {
@@ -26,8 +26,8 @@ func setupDeleteHandler(t *testing.T) (*gomock.Controller, *mock.MockURLRemover,
 	ctrl := gomock.NewController(t)
 	mockSrv := mock.NewMockURLRemover(ctrl)
 	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
-	config := &config.Config{BaseURL: "http://base.url"}
-	h := handler.NewDeleteHandler(mockSrv, config, log)
+	config := &config.Config{BaseURL: "http://base.url", JWTSecret: "test-secret"}
+	h := handler.NewDeleteHandler(mockSrv, newJWTMiddleware(t, log, config), config, log)
 
 	return ctrl, mockSrv, h
 }
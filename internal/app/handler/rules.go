@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+// HandleGetURLRules returns the ordered redirect rules of a shortened URL owned by the requesting user.
+func (h *ShortenerHandler) HandleGetURLRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.GetURLRules(r.Context(), domain.Slug(chi.URLParam(r, "slug")))
+	if !h.handleRulesError(w, err) {
+		return
+	}
+
+	if rules == nil {
+		rules = domain.RedirectRules{}
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+	w.Header().Set(CacheControl, CacheControlNoStore)
+
+	if _, err = easyjson.MarshalToWriter(rules, w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+}
+
+// HandleSetURLRules replaces the redirect rules of a shortened URL owned by the requesting user.
+func (h *ShortenerHandler) HandleSetURLRules(w http.ResponseWriter, r *http.Request) {
+	var rules domain.RedirectRules
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &rules); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err := h.service.SetURLRules(r.Context(), domain.Slug(chi.URLParam(r, "slug")), rules)
+	if !h.handleRulesError(w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleAddURLRule appends a redirect rule to a shortened URL owned by the requesting user.
+func (h *ShortenerHandler) HandleAddURLRule(w http.ResponseWriter, r *http.Request) {
+	rule := domain.RedirectRule{
+		Platform: domain.PlatformAny,
+		Language: "",
+		CIDR:     "",
+		Country:  "",
+		Target:   "",
+	}
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err := h.service.AddURLRule(r.Context(), domain.Slug(chi.URLParam(r, "slug")), &rule)
+	if !h.handleRulesError(w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// HandleDeleteURLRule removes a redirect rule by its zero-based position
+// from a shortened URL owned by the requesting user.
+func (h *ShortenerHandler) HandleDeleteURLRule(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(chi.URLParam(r, "index"))
+	if err != nil {
+		http.Error(w, "invalid rule index", http.StatusBadRequest)
+
+		return
+	}
+
+	err = h.service.DeleteURLRule(r.Context(), domain.Slug(chi.URLParam(r, "slug")), index)
+	if !h.handleRulesError(w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRulesError writes the error response of a redirect rules request, it reports whether there was no error.
+func (h *ShortenerHandler) handleRulesError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case rejectURL(w, err, h.log):
+	case errors.Is(err, e.ErrSlugInvalid) || errors.Is(err, e.ErrRedirectRuleInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, e.ErrSlugNotFound) || errors.Is(err, e.ErrRedirectRuleNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+
+	return false
+}
+
+// requestClient describes the visitor of the request for redirect rules matching,
+// the IP address is the client IP resolved by the RequestSource middleware.
+func (h *ShortenerHandler) requestClient(r *http.Request) *domain.Client {
+	source, _ := middleware.GetSource(r.Context())
+
+	return visitorClient(h.geo, r.UserAgent(), r.Header.Get("Accept-Language"), source.ClientIP)
+}
+
+// visitorClient describes a visitor for redirect rules matching,
+// the country is located by the IP address when it is valid.
+func visitorClient(geo geoip.Locator, userAgent string, acceptLanguage string, ip string) *domain.Client {
+	client := &domain.Client{
+		Platform:  domain.PlatformFromUserAgent(userAgent),
+		Languages: acceptLanguages(acceptLanguage),
+		IP:        netip.Addr{},
+		Country:   "",
+	}
+
+	if addr, err := netip.ParseAddr(ip); err == nil {
+		client.IP = addr.Unmap()
+		client.Country = geo.Country(client.IP)
+	}
+
+	return client
+}
+
+// acceptLanguages parses an Accept-Language header value into language tags ordered by preference.
+// Wildcards and explicitly rejected languages are left out.
+func acceptLanguages(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	langs := make([]weighted, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		if tag == "" || tag == "*" || quality <= 0 {
+			continue
+		}
+
+		langs = append(langs, weighted{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].quality > langs[j].quality
+	})
+
+	res := make([]string, len(langs))
+	for i, lang := range langs {
+		res[i] = lang.tag
+	}
+
+	return res
+}
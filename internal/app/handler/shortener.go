@@ -4,6 +4,8 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/mailru/easyjson"
@@ -13,21 +15,33 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/shortener"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
 )
 
 // ShortenerHandler provides HTTP request handling for URL shortening operations.
 type ShortenerHandler struct {
 	service shortener.URLShortener
+	geo     geoip.Locator
+	auth    *middleware.JWTMiddleware
 	config  *config.Config
 	log     *zerolog.Logger
 }
 
 // NewShortenerHandler creates a new instance of ShortenerHandler.
-func NewShortenerHandler(service shortener.URLShortener, config *config.Config, log *zerolog.Logger) *ShortenerHandler {
+func NewShortenerHandler(
+	service shortener.URLShortener,
+	geo geoip.Locator,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *ShortenerHandler {
 	return &ShortenerHandler{
 		service: service,
+		geo:     geo,
+		auth:    auth,
 		config:  config,
 		log:     log,
 	}
@@ -36,11 +50,15 @@ func NewShortenerHandler(service shortener.URLShortener, config *config.Config,
 // InsistentShortenerHandler creates a new instance of ShortenerHandler with InsistentShortener.
 func InsistentShortenerHandler(
 	service *shortener.InsistentShortener,
+	geo geoip.Locator,
+	auth *middleware.JWTMiddleware,
 	config *config.Config,
 	log *zerolog.Logger,
 ) *ShortenerHandler {
 	return &ShortenerHandler{
 		service: service,
+		geo:     geo,
+		auth:    auth,
 		config:  config,
 		log:     log,
 	}
@@ -49,9 +67,21 @@ func InsistentShortenerHandler(
 // RegisterRoutes register all handler routes within http router.
 func (h *ShortenerHandler) RegisterRoutes(router chi.Router) {
 	router.Group(func(r chi.Router) {
-		r.Use(middleware.Authenticate(h.log, h.config))
+		r.Use(h.auth.AuthenticateHandler)
 		r.Get("/{shortURL}", h.HandleGetOriginalURL)
+		r.Head("/{shortURL}", h.HandleGetOriginalURL)
+		r.Get("/{shortURL}/*", h.HandleGetOriginalURL)
+		r.Head("/{shortURL}/*", h.HandleGetOriginalURL)
+		r.Post("/{shortURL}", h.HandleGetOriginalURL)
+		r.Post("/{shortURL}/*", h.HandleGetOriginalURL)
+		r.Get("/{shortURL}+", h.HandleGetURLPreview)
+		r.Get("/api/info/{slug}", h.HandleGetURLInfo)
 		r.Get("/api/user/urls", h.HandleGetUserURLs)
+		r.Put("/api/user/urls/{slug}/schedule", h.HandleScheduleURL)
+		r.Get("/api/user/urls/{slug}/rules", h.HandleGetURLRules)
+		r.Put("/api/user/urls/{slug}/rules", h.HandleSetURLRules)
+		r.Post("/api/user/urls/{slug}/rules", h.HandleAddURLRule)
+		r.Delete("/api/user/urls/{slug}/rules/{index}", h.HandleDeleteURLRule)
 		r.Post("/api/shorten/batch", h.HandleBatchShortenURLJSON)
 		r.Post("/api/shorten", h.HandleShortenURLJSON)
 		r.Post("/", h.HandleShortenURL)
@@ -59,20 +89,62 @@ func (h *ShortenerHandler) RegisterRoutes(router chi.Router) {
 }
 
 // HandleGetOriginalURL handles requests to retrieve the original URL from a shortened slug.
+// HEAD requests are answered with the same redirect but are not counted as clicks.
+// Query and sub-path of the short URL are passed to the service for links that opted in.
+// Password protected links are followed once a password is supplied in a header or a submitted form,
+// otherwise a password form is served.
+// Redirect rules of the link are matched against the visitor platform, languages, IP and country
+// in order, the first matching rule target replaces the default one.
+// Visitors of A/B split links are kept on their variant with a per slug cookie.
 func (h *ShortenerHandler) HandleGetOriginalURL(w http.ResponseWriter, r *http.Request) {
-	slug := domain.Slug(chi.URLParam(r, "shortURL"))
-	original, err := h.service.GetOriginalURL(r.Context(), slug)
+	visit := &dto.Visit{
+		Slug:     domain.Slug(chi.URLParam(r, "shortURL")),
+		Probe:    r.Method == http.MethodHead,
+		Query:    r.URL.RawQuery,
+		SubPath:  chi.URLParam(r, "*"),
+		Password: r.Header.Get(LinkPasswordHeader),
+		Variant:  0,
+		Client:   h.requestClient(r),
+	}
+
+	if cookie, err := r.Cookie(VariantCookiePrefix + visit.Slug.String()); err == nil {
+		visit.Variant, _ = strconv.Atoi(cookie.Value)
+	}
+
+	if r.Method == http.MethodPost {
+		visit.Password = r.PostFormValue(LinkPasswordField)
+	}
+
+	redirect, err := h.service.FollowURL(r.Context(), visit)
 
 	switch {
-	case errors.Is(err, e.ErrSlugInvalid):
+	case errors.Is(err, e.ErrPasswordRequired):
+		h.renderPasswordForm(w, http.StatusUnauthorized, "")
+
+		return
+	case errors.Is(err, e.ErrPasswordInvalid):
+		h.renderPasswordForm(w, http.StatusForbidden, "Wrong password, please try again.")
+
+		return
+	case errors.Is(err, e.ErrPasswordThrottled):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+
+		return
+	case errors.Is(err, e.ErrSlugInvalid) || errors.Is(err, e.ErrPassthroughInvalid):
 		http.Error(w, err.Error(), http.StatusBadRequest)
 
 		return
-	case errors.Is(err, e.ErrSlugNotFound):
+	case errors.Is(err, e.ErrSlugNotActive) && h.config.InactiveFallbackURL != "":
+		w.Header().Set(CacheControl, CacheControlNoStore)
+		http.Redirect(w, r, h.config.InactiveFallbackURL, http.StatusTemporaryRedirect)
+
+		return
+	case errors.Is(err, e.ErrSlugNotFound) || errors.Is(err, e.ErrSlugNotActive):
 		http.Error(w, err.Error(), http.StatusNotFound)
 
 		return
-	case errors.Is(err, e.ErrSlugDeleted):
+	case errors.Is(err, e.ErrSlugDeleted) || errors.Is(err, e.ErrSlugExhausted) ||
+		errors.Is(err, e.ErrSlugExpired) || errors.Is(err, e.ErrSlugDisabled):
 		http.Error(w, err.Error(), http.StatusGone)
 
 		return
@@ -82,8 +154,162 @@ func (h *ShortenerHandler) HandleGetOriginalURL(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	w.Header().Add("Location", original.String())
-	w.WriteHeader(http.StatusTemporaryRedirect)
+	if isCacheable(redirect) {
+		w.Header().Set(CacheControl, CacheControlPermanent)
+	} else {
+		w.Header().Set(CacheControl, CacheControlNoStore)
+	}
+
+	if redirect.Variant != 0 && redirect.Variant != visit.Variant {
+		setVariantCookie(w, visit.Slug, redirect.Variant)
+	}
+
+	w.Header().Add("Location", redirect.Location.String())
+
+	// a submitted password form must not be re-posted to the redirect target.
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusSeeOther)
+
+		return
+	}
+
+	w.WriteHeader(redirect.Type.StatusCode())
+}
+
+// isCacheable checks whether the redirect may be served by shared caches to any visitor.
+// Redirects of protected, limited or scheduled URLs depend on checks that caches would skip,
+// conditional and split redirects depend on the visitor.
+func isCacheable(redirect *dto.Redirect) bool {
+	if !redirect.Type.IsPermanent() || len(redirect.Rules) != 0 || redirect.Variant != 0 {
+		return false
+	}
+
+	return !redirect.Protected && !redirect.Limited && !redirect.Scheduled
+}
+
+// rejectURL writes the response to a destination URL rejected by the URL policy with its reason code,
+// it reports whether the error was a rejection.
+func rejectURL(w http.ResponseWriter, err error, log *zerolog.Logger) bool {
+	reason, ok := urlpolicy.Reason(err)
+	if !ok {
+		return false
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+	w.WriteHeader(http.StatusUnprocessableEntity)
+
+	if _, errWrite := easyjson.MarshalToWriter(&dto.URLRejection{Error: err.Error(), Reason: reason}, w); errWrite != nil {
+		log.Error().Err(errWrite).Msg("failed to write url rejection")
+	}
+
+	return true
+}
+
+// setVariantCookie remembers the variant assigned to the visitor of an A/B split link.
+func setVariantCookie(w http.ResponseWriter, slug domain.Slug, variant int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     VariantCookiePrefix + slug.String(),
+		Value:    strconv.Itoa(variant),
+		Path:     "/" + slug.String(),
+		MaxAge:   VariantCookieMaxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (h *ShortenerHandler) renderPasswordForm(w http.ResponseWriter, code int, message string) {
+	w.Header().Set(ContentType, ContentTypeHTML)
+	w.Header().Set(CacheControl, CacheControlNoStore)
+	w.WriteHeader(code)
+
+	if err := passwordTemplate.Execute(w, struct{ Error string }{Error: message}); err != nil {
+		h.log.Error().Err(err).Msg("failed to render password page")
+	}
+}
+
+// HandleGetURLPreview renders an HTML page describing where a shortened URL leads
+// without redirecting to it or counting a click.
+func (h *ShortenerHandler) HandleGetURLPreview(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.getURLInfo(w, r, domain.Slug(chi.URLParam(r, "shortURL")))
+	if !ok {
+		return
+	}
+
+	w.Header().Set(ContentType, ContentTypeHTML)
+	w.Header().Set(CacheControl, CacheControlNoStore)
+
+	if err := previewTemplate.Execute(w, info); err != nil {
+		h.log.Error().Err(err).Msg("failed to render preview page")
+	}
+}
+
+// HandleGetURLInfo returns the metadata of a shortened URL as JSON
+// without redirecting to it or counting a click.
+func (h *ShortenerHandler) HandleGetURLInfo(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.getURLInfo(w, r, domain.Slug(chi.URLParam(r, "slug")))
+	if !ok {
+		return
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+	w.Header().Set(CacheControl, CacheControlNoStore)
+
+	if _, err := easyjson.MarshalToWriter(info, w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+}
+
+func (h *ShortenerHandler) getURLInfo(w http.ResponseWriter, r *http.Request, slug domain.Slug) (*dto.URLInfo, bool) {
+	info, err := h.service.GetURLInfo(r.Context(), slug)
+
+	switch {
+	case errors.Is(err, e.ErrSlugInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return nil, false
+	case errors.Is(err, e.ErrSlugNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return nil, false
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return nil, false
+	}
+
+	return info, true
+}
+
+// HandleScheduleURL replaces the activation window of a shortened URL owned by the requesting user.
+func (h *ShortenerHandler) HandleScheduleURL(w http.ResponseWriter, r *http.Request) {
+	schedule := dto.URLSchedule{ActiveFrom: time.Time{}, ExpiresAt: time.Time{}}
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &schedule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err := h.service.ScheduleURL(r.Context(), domain.Slug(chi.URLParam(r, "slug")), &schedule)
+
+	switch {
+	case errors.Is(err, e.ErrSlugInvalid) || errors.Is(err, e.ErrActivationWindowInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	case errors.Is(err, e.ErrSlugNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // HandleGetUserURLs retrieves all shortened URLs for the requesting user.
@@ -123,6 +349,22 @@ func (h *ShortenerHandler) HandleShortenURL(w http.ResponseWriter, r *http.Reque
 	}
 
 	slug, err := h.service.ShortenURL(r.Context(), originalURL)
+	if rejectURL(w, err, h.log) {
+		return
+	}
+
+	if errors.Is(err, e.ErrUserBanned) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+
+		return
+	}
+
+	if errors.Is(err, e.ErrOriginalOptions) {
+		http.Error(w, err.Error(), http.StatusConflict)
+
+		return
+	}
+
 	if err != nil && !errors.Is(err, e.ErrOriginalExists) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
@@ -146,7 +388,16 @@ func (h *ShortenerHandler) HandleShortenURL(w http.ResponseWriter, r *http.Reque
 
 // HandleShortenURLJSON handles URL shortening requests with a JSON payload.
 func (h *ShortenerHandler) HandleShortenURLJSON(w http.ResponseWriter, r *http.Request) {
-	urlReq := dto.ShortenURLRequest{LongURL: ""}
+	urlReq := dto.ShortenURLRequest{
+		LongURL:      "",
+		RedirectType: domain.RedirectDefault,
+		PassQuery:    false,
+		PassPath:     false,
+		Password:     "",
+		MaxClicks:    0,
+		ActiveFrom:   time.Time{},
+		Variants:     nil,
+	}
 
 	if err := easyjson.UnmarshalFromReader(r.Body, &urlReq); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -154,7 +405,60 @@ func (h *ShortenerHandler) HandleShortenURLJSON(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	slug, err := h.service.ShortenURL(r.Context(), domain.OriginalURL(urlReq.LongURL))
+	if !urlReq.RedirectType.IsValid() {
+		http.Error(w, "unsupported redirect type", http.StatusBadRequest)
+
+		return
+	}
+
+	if urlReq.MaxClicks < 0 {
+		http.Error(w, "max clicks must not be negative", http.StatusBadRequest)
+
+		return
+	}
+
+	opts := []domain.URLMappingOption{
+		domain.WithRedirectType(urlReq.RedirectType),
+		domain.WithPassthrough(urlReq.PassQuery, urlReq.PassPath),
+		domain.WithMaxClicks(urlReq.MaxClicks),
+		domain.WithActiveFrom(urlReq.ActiveFrom),
+		domain.WithVariants(urlReq.Variants),
+	}
+
+	if urlReq.Password != "" {
+		hash, err := domain.NewPasswordHash(urlReq.Password)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		opts = append(opts, domain.WithPasswordHash(hash))
+	}
+
+	slug, err := h.service.ShortenURL(r.Context(), domain.OriginalURL(urlReq.LongURL), opts...)
+	if rejectURL(w, err, h.log) {
+		return
+	}
+
+	if errors.Is(err, e.ErrUserBanned) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+
+		return
+	}
+
+	if errors.Is(err, e.ErrActivationWindowInvalid) || errors.Is(err, e.ErrVariantsInvalid) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if errors.Is(err, e.ErrOriginalOptions) {
+		http.Error(w, err.Error(), http.StatusConflict)
+
+		return
+	}
+
 	if err != nil && !errors.Is(err, e.ErrOriginalExists) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
@@ -189,6 +493,16 @@ func (h *ShortenerHandler) HandleBatchShortenURLJSON(w http.ResponseWriter, r *h
 	}
 
 	batch, err := h.service.ShortenURLBatch(r.Context(), &urlReqs)
+	if rejectURL(w, err, h.log) {
+		return
+	}
+
+	if errors.Is(err, e.ErrUserBanned) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+
+		return
+	}
+
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
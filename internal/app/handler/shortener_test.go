@@ -6,42 +6,68 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/mailru/easyjson"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
 )
 
+// testClient is the visitor of the test requests, matched against redirect rules.
+var testClient = &domain.Client{
+	Platform:  domain.PlatformAny,
+	Languages: []string{},
+	IP:        netip.Addr{},
+	Country:   "",
+}
+
+// newJWTMiddleware creates a JWTMiddleware of the configured JWT keys as the app does.
+func newJWTMiddleware(t *testing.T, log *zerolog.Logger, cfg *config.Config) *middleware.JWTMiddleware {
+	t.Helper()
+
+	keys, err := middleware.NewJWTKeySet(cfg, log)
+	require.NoError(t, err)
+
+	return middleware.NewKeysJWTMiddleware(keys, log, middleware.WithJWTConfig(cfg))
+}
+
 func setupHandler(t *testing.T) (*gomock.Controller, *mock.MockURLShortener, *handler.ShortenerHandler) {
 	t.Helper()
 
 	ctrl := gomock.NewController(t)
 	mockSrv := mock.NewMockURLShortener(ctrl)
 	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
-	config := &config.Config{BaseURL: "http://base.url"}
-	h := handler.NewShortenerHandler(mockSrv, config, log)
+	config := &config.Config{BaseURL: "http://base.url", JWTSecret: "test-secret"}
+	auth := newJWTMiddleware(t, log, config)
+	h := handler.NewShortenerHandler(mockSrv, geoip.NopLocator{}, auth, config, log)
 
 	return ctrl, mockSrv, h
 }
 
 type testCaseGetOriginalURL struct {
-	name         string
-	shortURL     string
-	mockBehavior func()
-	expectedCode int
-	expectedBody string
+	name          string
+	method        string
+	shortURL      string
+	mockBehavior  func()
+	expectedCode  int
+	expectedBody  string
+	expectedCache string
 }
 
 func TestHandleGetOriginalURL(t *testing.T) {
@@ -50,46 +76,128 @@ func TestHandleGetOriginalURL(t *testing.T) {
 	ctrl, mockSrv, handler := setupHandler(t)
 	defer ctrl.Finish()
 
+	visit := &dto.Visit{Slug: "shortURL", Probe: false, Client: testClient}
 	tests := []testCaseGetOriginalURL{
 		{
 			name:     "Successful Redirect",
+			method:   http.MethodGet,
 			shortURL: "shortURL",
 			mockBehavior: func() {
-				mockSrv.EXPECT().GetOriginalURL(gomock.Any(), domain.Slug("shortURL")).
-					Return(domain.OriginalURL("https://ya.ru"), nil).Times(1)
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(&dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectTemporary}, nil).Times(1)
 			},
-			expectedCode: http.StatusTemporaryRedirect,
-			expectedBody: "",
+			expectedCode:  http.StatusTemporaryRedirect,
+			expectedBody:  "",
+			expectedCache: "private, no-store",
+		},
+		{
+			name:     "Permanent Redirect",
+			method:   http.MethodGet,
+			shortURL: "shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(&dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectPermanent}, nil).Times(1)
+			},
+			expectedCode:  http.StatusPermanentRedirect,
+			expectedBody:  "",
+			expectedCache: "public, max-age=86400",
+		},
+		{
+			name:     "Permanent Redirect Protected",
+			method:   http.MethodGet,
+			shortURL: "shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(&dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectPermanent, Protected: true}, nil)
+			},
+			expectedCode:  http.StatusPermanentRedirect,
+			expectedBody:  "",
+			expectedCache: "private, no-store",
+		},
+		{
+			name:     "Permanent Redirect Limited",
+			method:   http.MethodGet,
+			shortURL: "shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(&dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectPermanent, Limited: true}, nil)
+			},
+			expectedCode:  http.StatusPermanentRedirect,
+			expectedBody:  "",
+			expectedCache: "private, no-store",
+		},
+		{
+			name:     "Permanent Redirect Scheduled",
+			method:   http.MethodGet,
+			shortURL: "shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(&dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectPermanent, Scheduled: true}, nil)
+			},
+			expectedCode:  http.StatusPermanentRedirect,
+			expectedBody:  "",
+			expectedCache: "private, no-store",
+		},
+		{
+			name:     "Found Redirect On HEAD",
+			method:   http.MethodHead,
+			shortURL: "shortURL",
+			mockBehavior: func() {
+				probe := &dto.Visit{Slug: "shortURL", Probe: true, Client: testClient}
+				mockSrv.EXPECT().FollowURL(gomock.Any(), probe).
+					Return(&dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectFound}, nil).Times(1)
+			},
+			expectedCode:  http.StatusFound,
+			expectedBody:  "",
+			expectedCache: "private, no-store",
 		},
 		{
 			name:     "Slug Not Found",
+			method:   http.MethodGet,
 			shortURL: "shortURL",
 			mockBehavior: func() {
-				mockSrv.EXPECT().GetOriginalURL(gomock.Any(), domain.Slug("shortURL")).
-					Return(domain.OriginalURL(""), e.ErrSlugNotFound)
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(nil, e.ErrSlugNotFound)
 			},
-			expectedCode: http.StatusNotFound,
-			expectedBody: "slug not found",
+			expectedCode:  http.StatusNotFound,
+			expectedBody:  "slug not found",
+			expectedCache: "",
 		},
 		{
 			name:     "Slug Invalid",
+			method:   http.MethodGet,
 			shortURL: "shortURL",
 			mockBehavior: func() {
-				mockSrv.EXPECT().GetOriginalURL(gomock.Any(), domain.Slug("shortURL")).
-					Return(domain.OriginalURL(""), e.ErrSlugInvalid)
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(nil, e.ErrSlugInvalid)
 			},
-			expectedCode: http.StatusBadRequest,
-			expectedBody: "invalid slug",
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  "invalid slug",
+			expectedCache: "",
+		},
+		{
+			name:     "Slug Exhausted",
+			method:   http.MethodGet,
+			shortURL: "shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(nil, e.ErrSlugExhausted)
+			},
+			expectedCode:  http.StatusGone,
+			expectedBody:  "slug clicks exhausted",
+			expectedCache: "",
 		},
 		{
 			name:     "Internal Error",
+			method:   http.MethodGet,
 			shortURL: "shortURL",
 			mockBehavior: func() {
-				mockSrv.EXPECT().GetOriginalURL(gomock.Any(), domain.Slug("shortURL")).
-					Return(domain.OriginalURL(""), e.ErrShortenerInternal)
+				mockSrv.EXPECT().FollowURL(gomock.Any(), visit).
+					Return(nil, e.ErrShortenerInternal)
 			},
-			expectedCode: http.StatusInternalServerError,
-			expectedBody: "internal error",
+			expectedCode:  http.StatusInternalServerError,
+			expectedBody:  "internal error",
+			expectedCache: "",
 		},
 	}
 
@@ -104,7 +212,7 @@ func runHandleGetOriginalURL(t *testing.T, handler *handler.ShortenerHandler, te
 	t.Run(test.name, func(t *testing.T) {
 		test.mockBehavior()
 
-		req := httptest.NewRequest(http.MethodGet, "/{shortURL}/", nil)
+		req := httptest.NewRequest(test.method, "/{shortURL}/", nil)
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("shortURL", test.shortURL)
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
@@ -117,6 +225,7 @@ func runHandleGetOriginalURL(t *testing.T, handler *handler.ShortenerHandler, te
 		defer res.Body.Close()
 
 		assert.Equal(t, test.expectedCode, res.StatusCode)
+		assert.Equal(t, test.expectedCache, res.Header.Get("Cache-Control"))
 
 		if test.expectedBody != "" {
 			body, _ := io.ReadAll(res.Body)
@@ -206,7 +315,7 @@ func TestHandleShortenURLJSON(t *testing.T) {
 			name: "Successful Shorten URL JSON",
 			body: `{"url": "https://example.com"}`,
 			mockBehavior: func() {
-				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com")).
+				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
 					Return(domain.Slug("shortURL"), nil).Times(1)
 			},
 			expectedCode: http.StatusCreated,
@@ -216,12 +325,77 @@ func TestHandleShortenURLJSON(t *testing.T) {
 			name: "Original Exists Conflict JSON",
 			body: `{"url": "https://example.com"}`,
 			mockBehavior: func() {
-				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com")).
+				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
 					Return(domain.Slug("shortURL"), e.ErrOriginalExists)
 			},
 			expectedCode: http.StatusConflict,
 			expectedBody: `"result":"http://base.url/shortURL"`,
 		},
+		{
+			name: "Original Exists With Other Options JSON",
+			body: `{"url": "https://example.com", "max_clicks": 10}`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
+					Return(domain.Slug(""), e.ErrOriginalOptions)
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "original url exists with other options",
+		},
+		{
+			name: "Shorten URL JSON With Redirect Type",
+			body: `{"url": "https://example.com", "redirect_type": 301}`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
+					Return(domain.Slug("shortURL"), nil).Times(1)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `"result":"http://base.url/shortURL"`,
+		},
+		{
+			name:         "Unsupported Redirect Type",
+			body:         `{"url": "https://example.com", "redirect_type": 200}`,
+			mockBehavior: func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "unsupported redirect type",
+		},
+		{
+			name: "Shorten URL JSON With Max Clicks",
+			body: `{"url": "https://example.com", "max_clicks": 10}`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
+					Return(domain.Slug("shortURL"), nil).Times(1)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `"result":"http://base.url/shortURL"`,
+		},
+		{
+			name:         "Negative Max Clicks",
+			body:         `{"url": "https://example.com", "max_clicks": -1}`,
+			mockBehavior: func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "max clicks must not be negative",
+		},
+		{
+			name: "Invalid Variants",
+			body: `{"url": "https://example.com", "variants": [{"target": "https://a.example.com", "weight": 1}]}`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com"), gomock.Any()).
+					Return(domain.Slug(""), e.ErrVariantsInvalid)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: e.ErrVariantsInvalid.Error(),
+		},
+		{
+			name: "Rejected URL",
+			body: `{"url": "http://169.254.169.254/latest/meta-data"}`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().
+					ShortenURL(gomock.Any(), domain.OriginalURL("http://169.254.169.254/latest/meta-data"), gomock.Any()).
+					Return(domain.Slug(""), e.ErrURLPrivateAddress)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `"reason":"private_address"`,
+		},
 		{
 			name:         "Invalid JSON",
 			body:         `invalid json`,
@@ -358,12 +532,13 @@ func TestHandleGetUserURLs(t *testing.T) {
 				mockSrv.EXPECT().GetUserURLs(gomock.Any()).
 					Return(&dto.URLPairBatch{
 						{Slug: "http://base.url/short1", OriginalURL: "https://example1.com"},
-						{Slug: "http://base.url/short2", OriginalURL: "https://example2.com"},
+						{Slug: "http://base.url/short2", OriginalURL: "https://example2.com", Clicks: 2, MaxClicks: 5},
 					}, nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `[{"short_url":"http://base.url/short1","original_url":"https://example1.com"},
-											{"short_url":"http://base.url/short2","original_url":"https://example2.com"}]`,
+											{"short_url":"http://base.url/short2","original_url":"https://example2.com",
+											"clicks":2,"max_clicks":5}]`,
 		},
 		{
 			name: "No URLs Found",
@@ -407,3 +582,685 @@ func TestHandleGetUserURLs(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGetURLInfo(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, hlr := setupHandler(t)
+	defer ctrl.Finish()
+
+	created := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+	clicks := int64(42)
+	info := &dto.URLInfo{
+		ShortURL:    "http://base.url/shortURL",
+		OriginalURL: "https://example.com",
+		CreatedAt:   created,
+		ExpiresAt:   created.Add(time.Hour),
+		Deleted:     false,
+		Protected:   false,
+		Clicks:      &clicks,
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		mockBehavior func()
+		expectedCode int
+		expectedType string
+		expectedBody string
+	}{
+		{
+			name: "JSON info",
+			path: "/api/info/shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(info, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: handler.ContentTypeJSON,
+			expectedBody: `"original_url":"https://example.com","created_at":"2025-01-02T03:04:05Z"`,
+		},
+		{
+			name: "HTML preview",
+			path: "/shortURL+",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(info, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: handler.ContentTypeHTML,
+			expectedBody: `<tr><th>Clicks</th><td>42</td></tr>`,
+		},
+		{
+			name: "HTML preview disabled",
+			path: "/shortURL+",
+			mockBehavior: func() {
+				disabled := *info
+				disabled.OriginalURL = ""
+				disabled.Disabled = true
+				disabled.Expired = true
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(&disabled, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: handler.ContentTypeHTML,
+			expectedBody: `<tr><th>Status</th><td>disabled</td></tr>`,
+		},
+		{
+			name: "HTML preview expired",
+			path: "/shortURL+",
+			mockBehavior: func() {
+				expired := *info
+				expired.Expired = true
+				expired.Exhausted = true
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(&expired, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: handler.ContentTypeHTML,
+			expectedBody: `<tr><th>Status</th><td>expired</td></tr>`,
+		},
+		{
+			name: "HTML preview exhausted",
+			path: "/shortURL+",
+			mockBehavior: func() {
+				exhausted := *info
+				exhausted.Exhausted = true
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(&exhausted, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: handler.ContentTypeHTML,
+			expectedBody: `<tr><th>Status</th><td>exhausted</td></tr>`,
+		},
+		{
+			name: "HTML preview active",
+			path: "/shortURL+",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(info, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: handler.ContentTypeHTML,
+			expectedBody: `<tr><th>Status</th><td>active</td></tr>`,
+		},
+		{
+			name: "Not found",
+			path: "/api/info/shortURL",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(nil, e.ErrSlugNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedType: "",
+			expectedBody: "slug not found",
+		},
+		{
+			name: "Invalid slug",
+			path: "/shortURL+",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLInfo(gomock.Any(), domain.Slug("shortURL")).Return(nil, e.ErrSlugInvalid)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedType: "",
+			expectedBody: "invalid slug",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehavior()
+
+			router := chi.NewRouter()
+			router.Get("/{shortURL}+", hlr.HandleGetURLPreview)
+			router.Get("/api/info/{slug}", hlr.HandleGetURLInfo)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedCode, res.StatusCode)
+
+			if test.expectedType != "" {
+				assert.Equal(t, test.expectedType, res.Header.Get("Content-Type"))
+				assert.Empty(t, res.Header.Get("Location"))
+			}
+
+			body, _ := io.ReadAll(res.Body)
+			assert.Contains(t, string(body), test.expectedBody)
+		})
+	}
+}
+
+func TestHandleGetOriginalURLPassthrough(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, hlr := setupHandler(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		path         string
+		visit        *dto.Visit
+		redirect     *dto.Redirect
+		err          error
+		expectedCode int
+	}{
+		{
+			name:         "Query",
+			path:         "/shortURL?utm_source=x",
+			visit:        &dto.Visit{Slug: "shortURL", Probe: false, Query: "utm_source=x", SubPath: "", Client: testClient},
+			redirect:     &dto.Redirect{Location: "https://ya.ru?utm_source=x", Type: domain.RedirectTemporary},
+			err:          nil,
+			expectedCode: http.StatusTemporaryRedirect,
+		},
+		{
+			name: "Sub-path and query",
+			path: "/shortURL/docs/a?utm_source=x",
+			visit: &dto.Visit{
+				Slug: "shortURL", Probe: false, Query: "utm_source=x", SubPath: "docs/a", Client: testClient,
+			},
+			redirect:     &dto.Redirect{Location: "https://ya.ru/docs/a?utm_source=x", Type: domain.RedirectTemporary},
+			err:          nil,
+			expectedCode: http.StatusTemporaryRedirect,
+		},
+		{
+			name:         "Invalid passthrough",
+			path:         "/shortURL/a?x=%zz",
+			visit:        &dto.Visit{Slug: "shortURL", Probe: false, Query: "x=%zz", SubPath: "a", Client: testClient},
+			redirect:     nil,
+			err:          e.ErrPassthroughInvalid,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockSrv.EXPECT().FollowURL(gomock.Any(), test.visit).Return(test.redirect, test.err)
+
+			router := chi.NewRouter()
+			router.Get("/{shortURL}", hlr.HandleGetOriginalURL)
+			router.Get("/{shortURL}/*", hlr.HandleGetOriginalURL)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedCode, res.StatusCode)
+
+			if test.redirect != nil {
+				assert.Equal(t, test.redirect.Location.String(), res.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestHandleGetOriginalURLPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, hlr := setupHandler(t)
+	defer ctrl.Finish()
+
+	redirect := &dto.Redirect{Location: "https://ya.ru", Type: domain.RedirectPermanent}
+	tests := []struct {
+		name         string
+		method       string
+		header       string
+		form         string
+		password     string
+		redirect     *dto.Redirect
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{"Form served", http.MethodGet, "", "", "", nil, e.ErrPasswordRequired, http.StatusUnauthorized, `type="password"`},
+		{"Header password", http.MethodGet, "secret", "", "secret", redirect, nil, http.StatusPermanentRedirect, ""},
+		{"Form password", http.MethodPost, "", "password=secret", "secret", redirect, nil, http.StatusSeeOther, ""},
+		{
+			"Wrong password", http.MethodPost, "", "password=wrong", "wrong", nil, e.ErrPasswordInvalid,
+			http.StatusForbidden, "Wrong password",
+		},
+		{
+			"Throttled", http.MethodPost, "", "password=wrong", "wrong", nil, e.ErrPasswordThrottled,
+			http.StatusTooManyRequests, "too many password attempts",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			visit := &dto.Visit{
+				Slug:     "shortURL",
+				Probe:    false,
+				Query:    "",
+				SubPath:  "",
+				Password: test.password,
+				Client:   testClient,
+			}
+			mockSrv.EXPECT().FollowURL(gomock.Any(), visit).Return(test.redirect, test.err)
+
+			router := chi.NewRouter()
+			router.Get("/{shortURL}", hlr.HandleGetOriginalURL)
+			router.Post("/{shortURL}", hlr.HandleGetOriginalURL)
+
+			req := httptest.NewRequest(test.method, "/shortURL", strings.NewReader(test.form))
+			if test.form != "" {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			if test.header != "" {
+				req.Header.Set(handler.LinkPasswordHeader, test.header)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedCode, res.StatusCode)
+
+			if test.redirect != nil {
+				assert.Equal(t, test.redirect.Location.String(), res.Header.Get("Location"))
+			}
+
+			body, _ := io.ReadAll(res.Body)
+			assert.Contains(t, string(body), test.expectedBody)
+		})
+	}
+}
+
+func TestHandleGetOriginalURLSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		fallback     string
+		err          error
+		expectedCode int
+		expectedLoc  string
+	}{
+		{"Not Yet Active", "", e.ErrSlugNotActive, http.StatusNotFound, ""},
+		{"Not Yet Active With Fallback", "https://example.com/soon", e.ErrSlugNotActive, http.StatusTemporaryRedirect,
+			"https://example.com/soon"},
+		{"Expired", "https://example.com/soon", e.ErrSlugExpired, http.StatusGone, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSrv := mock.NewMockURLShortener(ctrl)
+			log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+			config := &config.Config{BaseURL: "http://base.url", JWTSecret: "test-secret", InactiveFallbackURL: test.fallback}
+			auth := newJWTMiddleware(t, log, config)
+			hlr := handler.NewShortenerHandler(mockSrv, geoip.NopLocator{}, auth, config, log)
+
+			mockSrv.EXPECT().FollowURL(gomock.Any(), gomock.Any()).Return(nil, test.err)
+
+			router := chi.NewRouter()
+			router.Get("/{shortURL}", hlr.HandleGetOriginalURL)
+
+			req := httptest.NewRequest(http.MethodGet, "/shortURL", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedCode, res.StatusCode)
+			assert.Equal(t, test.expectedLoc, res.Header.Get("Location"))
+		})
+	}
+}
+
+func TestHandleScheduleURL(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, hlr := setupHandler(t)
+	defer ctrl.Finish()
+
+	activeFrom := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		body         string
+		schedule     *dto.URLSchedule
+		err          error
+		expectedCode int
+	}{
+		{
+			"Scheduled", `{"active_from":"2030-01-01T00:00:00Z"}`,
+			&dto.URLSchedule{ActiveFrom: activeFrom, ExpiresAt: time.Time{}}, nil, http.StatusNoContent,
+		},
+		{
+			"Invalid Window", `{"active_from":"2030-01-01T00:00:00Z","expires_at":"2029-01-01T00:00:00Z"}`,
+			&dto.URLSchedule{ActiveFrom: activeFrom, ExpiresAt: activeFrom.AddDate(-1, 0, 0)},
+			e.ErrActivationWindowInvalid, http.StatusBadRequest,
+		},
+		{"Not Owned", `{}`, &dto.URLSchedule{}, e.ErrSlugNotFound, http.StatusNotFound},
+		{"Internal Error", `{}`, &dto.URLSchedule{}, e.ErrShortenerInternal, http.StatusInternalServerError},
+		{"Invalid JSON", `invalid json`, nil, nil, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.schedule != nil {
+				mockSrv.EXPECT().ScheduleURL(gomock.Any(), domain.Slug("shortURL"), test.schedule).Return(test.err)
+			}
+
+			router := chi.NewRouter()
+			router.Put("/api/user/urls/{slug}/schedule", hlr.HandleScheduleURL)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/user/urls/shortURL/schedule", strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedCode, res.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetOriginalURLRules(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mock.NewMockURLShortener(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	geo, err := geoip.NewDatabase(strings.NewReader("81.2.69.0/24,GB\n"))
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		BaseURL:        "http://base.url",
+		JWTSecret:      "test-secret",
+		TrustedProxies: []string{"10.0.0.0/8"},
+		ClientIPHeader: config.ClientIPHeaderXForwardedFor,
+	}
+	hlr := handler.NewShortenerHandler(mockSrv, geo, newJWTMiddleware(t, log, cfg), cfg, log)
+	redirect := &dto.Redirect{
+		Location: "https://example.com",
+		Type:     domain.RedirectPermanent,
+		Rules: domain.RedirectRules{
+			{Platform: domain.PlatformIOS, Target: "https://apps.apple.com/app"},
+			{Platform: domain.PlatformAndroid, Target: "https://play.google.com/app"},
+			{Country: "GB", Target: "https://example.co.uk"},
+			{Language: "de", Target: "https://example.de"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		userAgent      string
+		acceptLanguage string
+		remoteAddr     string
+		forwardedFor   string
+		expectedLoc    string
+	}{
+		{
+			"iOS", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", "", "192.0.2.1:1234", "",
+			"https://apps.apple.com/app",
+		},
+		{"Android", "Mozilla/5.0 (Linux; Android 14)", "", "192.0.2.1:1234", "", "https://play.google.com/app"},
+		{"Country", "Mozilla/5.0 (Windows NT 10.0)", "de", "81.2.69.10:1234", "", "https://example.co.uk"},
+		{"Trusted Proxy", "Mozilla/5.0 (Windows NT 10.0)", "de", "10.0.0.1:1234", "81.2.69.10", "https://example.co.uk"},
+		{"Untrusted Proxy", "Mozilla/5.0 (Windows NT 10.0)", "", "192.0.2.1:1234", "81.2.69.10", "https://example.com"},
+		{
+			"Language", "Mozilla/5.0 (Windows NT 10.0)", "fr;q=0.5, de-AT, *;q=0.1", "192.0.2.1:1234", "",
+			"https://example.de",
+		},
+		{"Rejected Language", "Mozilla/5.0 (Windows NT 10.0)", "en, de;q=0", "192.0.2.1:1234", "", "https://example.com"},
+		{"Default", "curl/8.4.0", "", "192.0.2.1:1234", "", "https://example.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the service redirects to the target of the first rule matching the visitor client.
+			mockSrv.EXPECT().FollowURL(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, visit *dto.Visit) (*dto.Redirect, error) {
+					matched := *redirect
+					if target, ok := redirect.Rules.Match(visit.Client); ok {
+						matched.Location = target
+					}
+
+					return &matched, nil
+				})
+
+			router := chi.NewRouter()
+			router.Use(middleware.RequestSource(log, cfg))
+			router.Get("/{shortURL}", hlr.HandleGetOriginalURL)
+
+			req := httptest.NewRequest(http.MethodGet, "/shortURL", nil)
+			req.RemoteAddr = test.remoteAddr
+			req.Header.Set("User-Agent", test.userAgent)
+
+			if test.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+
+			if test.forwardedFor != "" {
+				req.Header.Set(middleware.HeaderXForwardedFor, test.forwardedFor)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusPermanentRedirect, res.StatusCode)
+			assert.Equal(t, test.expectedLoc, res.Header.Get("Location"))
+			assert.Equal(t, "private, no-store", res.Header.Get("Cache-Control"))
+		})
+	}
+}
+
+func TestHandleURLRules(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, hlr := setupHandler(t)
+	defer ctrl.Finish()
+
+	slug := domain.Slug("shortURL")
+	rule := domain.RedirectRule{Platform: domain.PlatformIOS, Target: "https://apps.apple.com/app"}
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		mockBehavior func()
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "Get Rules",
+			method: http.MethodGet,
+			path:   "/api/user/urls/shortURL/rules",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLRules(gomock.Any(), slug).Return(domain.RedirectRules{rule}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"platform":"ios","target":"https://apps.apple.com/app"}]`,
+		},
+		{
+			name:   "Get No Rules",
+			method: http.MethodGet,
+			path:   "/api/user/urls/shortURL/rules",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLRules(gomock.Any(), slug).Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:   "Get Rules Not Owned",
+			method: http.MethodGet,
+			path:   "/api/user/urls/shortURL/rules",
+			mockBehavior: func() {
+				mockSrv.EXPECT().GetURLRules(gomock.Any(), slug).Return(nil, e.ErrSlugNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "slug not found",
+		},
+		{
+			name:   "Set Rules",
+			method: http.MethodPut,
+			path:   "/api/user/urls/shortURL/rules",
+			body:   `[{"platform":"ios","target":"https://apps.apple.com/app"}]`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().SetURLRules(gomock.Any(), slug, domain.RedirectRules{rule}).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "Set Invalid Rules",
+			method: http.MethodPut,
+			path:   "/api/user/urls/shortURL/rules",
+			body:   `[{"target":"https://example.com"}]`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().SetURLRules(gomock.Any(), slug, gomock.Any()).Return(e.ErrRedirectRuleInvalid)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid redirect rule",
+		},
+		{
+			name:         "Set Rules Invalid JSON",
+			method:       http.MethodPut,
+			path:         "/api/user/urls/shortURL/rules",
+			body:         `{`,
+			mockBehavior: func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Add Rule",
+			method: http.MethodPost,
+			path:   "/api/user/urls/shortURL/rules",
+			body:   `{"platform":"ios","target":"https://apps.apple.com/app"}`,
+			mockBehavior: func() {
+				mockSrv.EXPECT().AddURLRule(gomock.Any(), slug, &rule).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "Delete Rule",
+			method: http.MethodDelete,
+			path:   "/api/user/urls/shortURL/rules/1",
+			mockBehavior: func() {
+				mockSrv.EXPECT().DeleteURLRule(gomock.Any(), slug, 1).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "Delete Unknown Rule",
+			method: http.MethodDelete,
+			path:   "/api/user/urls/shortURL/rules/5",
+			mockBehavior: func() {
+				mockSrv.EXPECT().DeleteURLRule(gomock.Any(), slug, 5).Return(e.ErrRedirectRuleNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "redirect rule not found",
+		},
+		{
+			name:         "Delete Invalid Index",
+			method:       http.MethodDelete,
+			path:         "/api/user/urls/shortURL/rules/first",
+			mockBehavior: func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid rule index",
+		},
+		{
+			name:   "Internal Error",
+			method: http.MethodDelete,
+			path:   "/api/user/urls/shortURL/rules/0",
+			mockBehavior: func() {
+				mockSrv.EXPECT().DeleteURLRule(gomock.Any(), slug, 0).Return(e.ErrShortenerInternal)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehavior()
+
+			router := chi.NewRouter()
+			router.Get("/api/user/urls/{slug}/rules", hlr.HandleGetURLRules)
+			router.Put("/api/user/urls/{slug}/rules", hlr.HandleSetURLRules)
+			router.Post("/api/user/urls/{slug}/rules", hlr.HandleAddURLRule)
+			router.Delete("/api/user/urls/{slug}/rules/{index}", hlr.HandleDeleteURLRule)
+
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedCode, res.StatusCode)
+
+			body, _ := io.ReadAll(res.Body)
+			assert.Contains(t, string(body), test.expectedBody)
+		})
+	}
+}
+
+func TestHandleGetOriginalURLVariants(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, hlr := setupHandler(t)
+	defer ctrl.Finish()
+
+	router := chi.NewRouter()
+	router.Get("/{shortURL}", hlr.HandleGetOriginalURL)
+
+	redirect := &dto.Redirect{Location: "https://b.example.com", Type: domain.RedirectPermanent, Variant: 2}
+
+	// new visitors are assigned a variant cookie.
+	mockSrv.EXPECT().
+		FollowURL(gomock.Any(), gomock.Cond(func(v *dto.Visit) bool { return v.Variant == 0 })).
+		Return(redirect, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/shortURL", nil))
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusPermanentRedirect, res.StatusCode)
+	assert.Equal(t, "https://b.example.com", res.Header.Get("Location"))
+	assert.Equal(t, "private, no-store", res.Header.Get("Cache-Control"))
+
+	cookies := res.Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, handler.VariantCookiePrefix+"shortURL", cookies[0].Name)
+	assert.Equal(t, "2", cookies[0].Value)
+	assert.Equal(t, "/shortURL", cookies[0].Path)
+
+	// returning visitors keep their variant.
+	mockSrv.EXPECT().
+		FollowURL(gomock.Any(), gomock.Cond(func(v *dto.Visit) bool { return v.Variant == 2 })).
+		Return(redirect, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/shortURL", nil)
+	req.AddCookie(cookies[0])
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	res = w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, "https://b.example.com", res.Header.Get("Location"))
+	assert.Empty(t, res.Cookies())
+}
@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/mailru/easyjson"
 	"github.com/rs/zerolog"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/statsprovider"
 )
@@ -33,17 +38,36 @@ func NewStatsProviderHandler(
 }
 
 // RegisterRoutes register all handler routes within http router.
+// Stats are restricted to clients with certificates of the stats CA and to the trusted subnet when configured.
 func (h *StatsProviderHandler) RegisterRoutes(router chi.Router) {
 	router.Group(func(r chi.Router) {
+		r.Use(middleware.ClientCAMiddleware(h.log, h.config.StatsClientCAPath))
 		r.Use(middleware.SubnetMiddleware(h.log, h.config))
 		r.Get("/api/internal/stats", h.HandleGetStats)
 	})
 }
 
 // HandleGetStats handles requests to retrieve repository statistics.
+// Optional days and top query parameters set the number of days of daily stats and of top users.
 func (h *StatsProviderHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
-	stats, err := h.service.GetStats(r.Context())
-	if err != nil {
+	days, daysErr := statsQueryParam(r, "days")
+	topUsers, topErr := statsQueryParam(r, "top")
+
+	if daysErr != nil || topErr != nil {
+		http.Error(w, e.ErrStatsProviderParams.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	params := dto.StatsParams{Days: days, TopUsers: topUsers, Now: time.Time{}}
+	stats, err := h.service.GetStats(r.Context(), params)
+
+	switch {
+	case errors.Is(err, e.ErrStatsProviderParams):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	case err != nil:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
 		return
@@ -57,3 +81,13 @@ func (h *StatsProviderHandler) HandleGetStats(w http.ResponseWriter, r *http.Req
 		return
 	}
 }
+
+// statsQueryParam parses an optional integer query parameter, missing parameters result in zero.
+func statsQueryParam(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
@@ -58,12 +58,14 @@ func TestHandleGetStats(t *testing.T) {
 	defer ctrl.Finish()
 
 	stats := &dto.RepoStats{
-		CountSlugs: int64(2),
-		CountUsers: int64(1),
+		CountSlugs:  int64(2),
+		CountUsers:  int64(1),
+		CountActive: int64(2),
+		Daily:       []dto.DailyStats{{Date: "2024-05-02", CountSlugs: 2}},
 	}
 
 	t.Run("successful request", func(t *testing.T) {
-		mockSrv.EXPECT().GetStats(gomock.Any()).Return(stats, nil)
+		mockSrv.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(stats, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/internal/stats", nil)
 		w := httptest.NewRecorder()
@@ -76,11 +78,55 @@ func TestHandleGetStats(t *testing.T) {
 		body, _ := io.ReadAll(res.Body)
 
 		assert.Equal(t, http.StatusOK, res.StatusCode)
-		assert.JSONEq(t, `{"urls":2,"users":1}`, string(body))
+		assert.JSONEq(t, `{"urls":2,"users":1,"active":2,"deleted":0,"expired":0,"storage_bytes":0,`+
+			`"daily":[{"date":"2024-05-02","urls":2}],"top_users":null}`, string(body))
+	})
+
+	t.Run("query params", func(t *testing.T) {
+		mockSrv.EXPECT().
+			GetStats(gomock.Any(), dto.StatsParams{Days: 7, TopUsers: 3}).
+			Return(stats, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/stats?days=7&top=3", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGetStats(w, req)
+		res := w.Result()
+
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("bad query params", func(t *testing.T) {
+		for _, query := range []string{"days=abc", "top=1.5"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/stats?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleGetStats(w, req)
+			res := w.Result()
+			res.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		}
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		mockSrv.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, e.ErrStatsProviderParams)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/stats?days=1000", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGetStats(w, req)
+		res := w.Result()
+
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 	})
 
 	t.Run("failed service", func(t *testing.T) {
-		mockSrv.EXPECT().GetStats(gomock.Any()).Return(nil, e.ErrTestGeneral)
+		mockSrv.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, e.ErrTestGeneral)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/internal/stats", nil)
 		w := httptest.NewRecorder()
@@ -94,7 +140,7 @@ func TestHandleGetStats(t *testing.T) {
 	})
 
 	t.Run("failed response write", func(t *testing.T) {
-		mockSrv.EXPECT().GetStats(gomock.Any()).Return(stats, nil)
+		mockSrv.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(stats, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/internal/stats", nil)
 		w := httptest.NewRecorder()
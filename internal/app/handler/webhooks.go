@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
+)
+
+// WebhooksHandler handles requests related to webhooks of users.
+type WebhooksHandler struct {
+	service webhooks.Webhooks
+	auth    *middleware.JWTMiddleware
+	config  *config.Config
+	log     *zerolog.Logger
+}
+
+// NewWebhooksHandler creates and returns a new WebhooksHandler instance.
+func NewWebhooksHandler(
+	service webhooks.Webhooks,
+	auth *middleware.JWTMiddleware,
+	config *config.Config,
+	log *zerolog.Logger,
+) *WebhooksHandler {
+	return &WebhooksHandler{
+		service: service,
+		auth:    auth,
+		config:  config,
+		log:     log,
+	}
+}
+
+// RegisterRoutes register all handler routes within http router.
+// Webhooks are managed with user tokens only, requests authenticated with API keys are forbidden.
+func (h *WebhooksHandler) RegisterRoutes(router chi.Router) {
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.DenyAPIKeys())
+		r.Use(h.auth.AuthorizeHandler)
+		r.Post("/api/user/webhooks", h.HandleSubscribe)
+		r.Get("/api/user/webhooks", h.HandleGetWebhooks)
+		r.Delete("/api/user/webhooks/{id}", h.HandleUnsubscribe)
+		r.Get("/api/user/webhooks/{id}/dead", h.HandleGetDeadDeliveries)
+	})
+}
+
+// HandleSubscribe handles requests to subscribe a webhook of the user.
+// The signing secret is only disclosed in the response.
+func (h *WebhooksHandler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
+	var hookReq dto.WebhookRequest
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &hookReq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	hook, err := h.service.Subscribe(r.Context(), hookReq.URL, hookReq.Secret)
+	if errors.Is(err, e.ErrWebhookInvalid) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if rejectURL(w, err, h.log) {
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	info := dto.NewWebhookInfo(hook)
+	info.Secret = hook.Secret
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+	w.Header().Set(CacheControl, CacheControlNoStore)
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(info, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write webhook response")
+	}
+}
+
+// HandleGetWebhooks handles requests to list webhooks of the user.
+func (h *WebhooksHandler) HandleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	hooks, err := h.service.GetUserWebhooks(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	infos := make(dto.WebhookInfoBatch, len(hooks))
+	for i := range hooks {
+		infos[i] = dto.NewWebhookInfo(&hooks[i])
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(infos, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write webhooks response")
+	}
+}
+
+// HandleUnsubscribe handles requests to unsubscribe a webhook of the user.
+func (h *WebhooksHandler) HandleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	err := h.service.Unsubscribe(r.Context(), chi.URLParam(r, "id"))
+	if errors.Is(err, e.ErrWebhookNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetDeadDeliveries handles requests to list the latest dead-lettered deliveries of a webhook of the user.
+func (h *WebhooksHandler) HandleGetDeadDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.service.GetDeadDeliveries(r.Context(), chi.URLParam(r, "id"))
+	if errors.Is(err, e.ErrWebhookNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	infos := make(dto.WebhookDeliveryBatch, len(deliveries))
+	for i := range deliveries {
+		infos[i] = dto.NewWebhookDeliveryInfo(&deliveries[i])
+	}
+
+	w.Header().Set(ContentType, ContentTypeJSON)
+
+	if _, err := easyjson.MarshalToWriter(infos, w); err != nil {
+		h.log.Error().Err(err).Msg("failed to write webhook deliveries response")
+	}
+}
@@ -0,0 +1,218 @@
+package handler_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+)
+
+func setupWebhooksHandler(t *testing.T) (*gomock.Controller, *mock.MockWebhooks, *handler.WebhooksHandler) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockSrv := mock.NewMockWebhooks(ctrl)
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+
+	config := config.DefaultConfig()
+
+	return ctrl, mockSrv, handler.NewWebhooksHandler(mockSrv, newJWTMiddleware(t, log, config), config, log)
+}
+
+func withWebhookID(req *http.Request, id string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandleSubscribe(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupWebhooksHandler(t)
+	defer ctrl.Finish()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		body   string
+		setup  func()
+		status int
+	}{
+		{
+			name: "created",
+			body: `{"url":"https://crm.example.com/hooks"}`,
+			setup: func() {
+				mockSrv.EXPECT().Subscribe(gomock.Any(), hook.URL, "").Return(hook, nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "invalid url",
+			body: `{"url":"ftp://crm.example.com/hooks"}`,
+			setup: func() {
+				mockSrv.EXPECT().Subscribe(gomock.Any(), gomock.Any(), "").Return(nil, e.ErrWebhookInvalid)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "rejected url",
+			body: `{"url":"http://127.0.0.1/hooks"}`,
+			setup: func() {
+				mockSrv.EXPECT().Subscribe(gomock.Any(), gomock.Any(), "").Return(nil, e.ErrURLPrivateAddress)
+			},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal error",
+			body: `{"url":"https://crm.example.com/hooks","secret":"0123456789abcdef"}`,
+			setup: func() {
+				mockSrv.EXPECT().Subscribe(gomock.Any(), hook.URL, "0123456789abcdef").Return(nil, e.ErrWebhooksInternal)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "invalid json",
+			body:   `{"url":`,
+			setup:  func() {},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/user/webhooks", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.HandleSubscribe(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.status, res.StatusCode)
+
+			if tt.status != http.StatusCreated {
+				return
+			}
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"secret":"`+hook.Secret+`"`)
+			assert.Equal(t, handler.CacheControlNoStore, res.Header.Get(handler.CacheControl))
+		})
+	}
+}
+
+func TestHandleGetWebhooks(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupWebhooksHandler(t)
+	defer ctrl.Finish()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	mockSrv.EXPECT().GetUserWebhooks(gomock.Any()).Return([]domain.Webhook{*hook}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/webhooks", nil)
+	w := httptest.NewRecorder()
+	h.HandleGetWebhooks(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), `"id":"`+hook.ID+`"`)
+	assert.NotContains(t, string(body), hook.Secret)
+}
+
+func TestHandleUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupWebhooksHandler(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "deleted", err: nil, status: http.StatusNoContent},
+		{name: "not found", err: e.ErrWebhookNotFound, status: http.StatusNotFound},
+		{name: "internal error", err: e.ErrWebhooksInternal, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSrv.EXPECT().Unsubscribe(gomock.Any(), "0123456789abcdef").Return(tt.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/user/webhooks/0123456789abcdef", nil)
+			w := httptest.NewRecorder()
+			h.HandleUnsubscribe(w, withWebhookID(req, "0123456789abcdef"))
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
+
+func TestHandleGetDeadDeliveries(t *testing.T) {
+	t.Parallel()
+
+	ctrl, mockSrv, h := setupWebhooksHandler(t)
+	defer ctrl.Finish()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", hook.UserID)
+	dead := domain.NewWebhookDelivery(hook, &event, []byte(`{}`))
+	dead.Fail("status 500", 0, 1)
+
+	mockSrv.EXPECT().GetDeadDeliveries(gomock.Any(), hook.ID).Return([]domain.WebhookDelivery{dead}, nil)
+	mockSrv.EXPECT().GetDeadDeliveries(gomock.Any(), "unknown").Return(nil, e.ErrWebhookNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/webhooks/"+hook.ID+"/dead", nil)
+	w := httptest.NewRecorder()
+	h.HandleGetDeadDeliveries(w, withWebhookID(req, hook.ID))
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), `"event_id":"`+event.ID+`"`)
+	assert.Contains(t, string(body), `"status":"dead"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/user/webhooks/unknown/dead", nil)
+	w = httptest.NewRecorder()
+	h.HandleGetDeadDeliveries(w, withWebhookID(req, "unknown"))
+
+	res = w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
@@ -124,6 +124,7 @@ func NewReader(fileName string, log *zerolog.Logger) (*Reader, error) {
 // LoadState loads the state from the file and returns it as a Memento instance.
 func (r *Reader) LoadState() (*Memento, error) {
 	state := make(dto.URLMappings)
+	res := NewMemento(state)
 	var count int
 
 	if err := r.Reset(); err != nil {
@@ -132,6 +133,19 @@ func (r *Reader) LoadState() (*Memento, error) {
 
 	for r.scanner.Scan() {
 		data := r.scanner.Bytes()
+		record := newRecord("")
+
+		if err := record.UnmarshalJSON(data); err != nil {
+			return nil, e.Wrap("failed to unmarshal state", err, errLabel)
+		}
+
+		if record.Kind != "" {
+			res.addRecord(&record)
+			count++
+
+			continue
+		}
+
 		link := domain.URLMapping{}
 
 		err := link.UnmarshalJSON(data)
@@ -163,7 +177,7 @@ func (r *Reader) LoadState() (*Memento, error) {
 		Int("total_records", count).
 		Msg("completed loading state")
 
-	return NewMemento(state), nil
+	return res, nil
 }
 
 // Reset resets the scanner to the beginning of the file.
@@ -243,6 +257,18 @@ func (w *Writer) SaveState(state *Memento) error {
 			Msg("preserved record")
 	}
 
+	for _, record := range state.records() {
+		if _, err := easyjson.MarshalToWriter(record, writer); err != nil {
+			return e.Wrap("failed to write state", err, errLabel)
+		}
+
+		if _, err := writer.WriteString(EOL); err != nil {
+			return e.Wrap("failed to write EOL", err, errLabel)
+		}
+
+		count++
+	}
+
 	w.log.Info().
 		Int("total_records", count).
 		Msg("completed saving state")
@@ -1,10 +1,14 @@
 package memento_test
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
@@ -49,3 +53,114 @@ func TestFileReadWrite(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestFileReadWriteAccountsModeration(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.Disabled).GetLogger()
+	ctx := context.Background()
+	cfg := config.DefaultConfig()
+	cfg.FileStoragePath = filepath.Join(t.TempDir(), "records.json")
+
+	urlRepo := repository.NewInMemoryURLRepository()
+	userRepo := repository.NewInMemoryUserRepository()
+
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+	require.NoError(t, userRepo.AddUser(ctx, user))
+
+	_, err = urlRepo.AddURLMapping(ctx, domain.NewURLMapping("XXXYZZZZ", "http://ya.com", user.ID))
+	require.NoError(t, err)
+
+	ban, err := domain.NewModerationEntry(domain.ActionBanUser, "admin", "spam")
+	require.NoError(t, err)
+
+	ban.UserID = user.ID
+	require.NoError(t, urlRepo.ModerateUser(ctx, ban))
+
+	manager := memento.NewStateManager(cfg, memento.NewOriginators(urlRepo, userRepo), log)
+	require.NoError(t, manager.StoreToFile())
+
+	// the state is restored into empty repositories, as on restart
+	urlRepo = repository.NewInMemoryURLRepository()
+	userRepo = repository.NewInMemoryUserRepository()
+	manager = memento.NewStateManager(cfg, memento.NewOriginators(urlRepo, userRepo), log)
+	require.NoError(t, manager.RestoreFromFile())
+
+	_, err = urlRepo.GetURLMapping(ctx, "XXXYZZZZ")
+	require.NoError(t, err)
+
+	restored, err := userRepo.GetUserByEmail(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, restored.ID)
+	assert.Equal(t, user.PasswordHash, restored.PasswordHash)
+	assert.True(t, restored.CreatedAt.Equal(user.CreatedAt))
+
+	banned, err := urlRepo.IsUserBanned(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+	entries, err := urlRepo.GetModerationLog(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, domain.ActionBanUser, entries[0].Action)
+	assert.Equal(t, "spam", entries[0].Reason)
+
+	// new moderation log records continue the restored IDs
+	unban, err := domain.NewModerationEntry(domain.ActionUnbanUser, "admin", "appeal")
+	require.NoError(t, err)
+
+	unban.UserID = user.ID
+	require.NoError(t, urlRepo.ModerateUser(ctx, unban))
+	assert.Equal(t, int64(2), unban.ID)
+}
+
+func TestFileReadWriteKeysTokensWebhooks(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.Disabled).GetLogger()
+	ctx := context.Background()
+	cfg := config.DefaultConfig()
+	cfg.FileStoragePath = filepath.Join(t.TempDir(), "records.json")
+	userID := domain.NewUserID()
+
+	keyRepo := repository.NewInMemoryAPIKeyRepository()
+	tokenRepo := repository.NewInMemoryTokenRepository()
+	hookRepo := repository.NewInMemoryWebhookRepository()
+
+	key, _, err := domain.NewAPIKey(userID, "ci", domain.APIKeyScopes{domain.ScopeRead, domain.ScopeWrite})
+	require.NoError(t, err)
+	require.NoError(t, keyRepo.AddAPIKey(ctx, key))
+
+	require.NoError(t, tokenRepo.RevokeToken(ctx, "revoked", time.Now().Add(time.Hour)))
+
+	hook, err := domain.NewWebhook(userID, "https://example.com/hooks", "")
+	require.NoError(t, err)
+	require.NoError(t, hookRepo.AddWebhook(ctx, hook))
+
+	manager := memento.NewStateManager(cfg, memento.NewOriginators(keyRepo, tokenRepo, hookRepo), log)
+	require.NoError(t, manager.StoreToFile())
+
+	// the state is restored into empty repositories, as on restart
+	keyRepo = repository.NewInMemoryAPIKeyRepository()
+	tokenRepo = repository.NewInMemoryTokenRepository()
+	hookRepo = repository.NewInMemoryWebhookRepository()
+	manager = memento.NewStateManager(cfg, memento.NewOriginators(keyRepo, tokenRepo, hookRepo), log)
+	require.NoError(t, manager.RestoreFromFile())
+
+	restoredKey, err := keyRepo.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key.Hash, restoredKey.Hash)
+	assert.Equal(t, key.Scopes, restoredKey.Scopes)
+	assert.True(t, restoredKey.CreatedAt.Equal(key.CreatedAt))
+
+	revoked, err := tokenRepo.IsTokenRevoked(ctx, "revoked")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	hooks, err := hookRepo.GetUserWebhooks(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, hook.URL, hooks[0].URL)
+	assert.Equal(t, hook.Secret, hooks[0].Secret)
+}
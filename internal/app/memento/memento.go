@@ -1,20 +1,205 @@
 package memento
 
-import "github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+import (
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+)
 
-// Memento is a struct that stores a snapshot of the URL mappings state.
+// Memento is a struct that stores a snapshot of the URL mappings state, along with the moderation state,
+// the user accounts, API keys, revoked tokens and webhook subscriptions of in-memory repositories.
 type Memento struct {
-	state dto.URLMappings
+	state      dto.URLMappings
+	bans       []domain.UserID
+	moderation []domain.ModerationEntry
+	users      []domain.User
+	apiKeys    []domain.APIKey
+	tokens     []dto.RevokedToken
+	webhooks   []domain.Webhook
 }
 
 // NewMemento creates and returns a new Memento instance with the given state.
 func NewMemento(state dto.URLMappings) *Memento {
 	return &Memento{
-		state: state,
+		state:      state,
+		bans:       nil,
+		moderation: nil,
+		users:      nil,
+		apiKeys:    nil,
+		tokens:     nil,
+		webhooks:   nil,
 	}
 }
 
+// WithModeration adds the banned users and the moderation log to the Memento.
+func (m *Memento) WithModeration(bans []domain.UserID, log []domain.ModerationEntry) *Memento {
+	m.bans = bans
+	m.moderation = log
+
+	return m
+}
+
+// WithUsers adds the user accounts to the Memento.
+func (m *Memento) WithUsers(users []domain.User) *Memento {
+	m.users = users
+
+	return m
+}
+
+// WithAPIKeys adds the API keys to the Memento.
+func (m *Memento) WithAPIKeys(keys []domain.APIKey) *Memento {
+	m.apiKeys = keys
+
+	return m
+}
+
+// WithTokens adds the revoked tokens to the Memento.
+func (m *Memento) WithTokens(tokens []dto.RevokedToken) *Memento {
+	m.tokens = tokens
+
+	return m
+}
+
+// WithWebhooks adds the webhook subscriptions to the Memento.
+func (m *Memento) WithWebhooks(hooks []domain.Webhook) *Memento {
+	m.webhooks = hooks
+
+	return m
+}
+
 // GetState returns the current stored state of the Memento.
 func (m *Memento) GetState() dto.URLMappings {
 	return m.state
 }
+
+// GetBans returns the banned users stored in the Memento.
+func (m *Memento) GetBans() []domain.UserID {
+	return m.bans
+}
+
+// GetModerationLog returns the moderation log stored in the Memento, oldest first.
+func (m *Memento) GetModerationLog() []domain.ModerationEntry {
+	return m.moderation
+}
+
+// GetUsers returns the user accounts stored in the Memento.
+func (m *Memento) GetUsers() []domain.User {
+	return m.users
+}
+
+// GetAPIKeys returns the API keys stored in the Memento.
+func (m *Memento) GetAPIKeys() []domain.APIKey {
+	return m.apiKeys
+}
+
+// GetTokens returns the revoked tokens stored in the Memento.
+func (m *Memento) GetTokens() []dto.RevokedToken {
+	return m.tokens
+}
+
+// GetWebhooks returns the webhook subscriptions stored in the Memento.
+func (m *Memento) GetWebhooks() []domain.Webhook {
+	return m.webhooks
+}
+
+// merge adds the parts of the state stored in another Memento.
+func (m *Memento) merge(other *Memento) {
+	if other == nil {
+		return
+	}
+
+	for slug, mapping := range other.state {
+		m.state[slug] = mapping
+	}
+
+	m.bans = append(m.bans, other.bans...)
+	m.moderation = append(m.moderation, other.moderation...)
+	m.users = append(m.users, other.users...)
+	m.apiKeys = append(m.apiKeys, other.apiKeys...)
+	m.tokens = append(m.tokens, other.tokens...)
+	m.webhooks = append(m.webhooks, other.webhooks...)
+}
+
+// records returns the state file records of the parts of the state other than URL mappings.
+func (m *Memento) records() []dto.StateRecord {
+	res := make([]dto.StateRecord, 0,
+		len(m.bans)+len(m.moderation)+len(m.users)+len(m.apiKeys)+len(m.tokens)+len(m.webhooks))
+
+	for _, user := range m.bans {
+		record := newRecord(dto.StateRecordBan)
+		record.Ban = user
+		res = append(res, record)
+	}
+
+	for i := range m.moderation {
+		record := newRecord(dto.StateRecordModeration)
+		record.Moderation = &m.moderation[i]
+		res = append(res, record)
+	}
+
+	for i := range m.users {
+		record := newRecord(dto.StateRecordUser)
+		record.User = &m.users[i]
+		res = append(res, record)
+	}
+
+	for i := range m.apiKeys {
+		record := newRecord(dto.StateRecordAPIKey)
+		record.APIKey = &m.apiKeys[i]
+		res = append(res, record)
+	}
+
+	for i := range m.tokens {
+		record := newRecord(dto.StateRecordToken)
+		record.Token = &m.tokens[i]
+		res = append(res, record)
+	}
+
+	for i := range m.webhooks {
+		record := newRecord(dto.StateRecordWebhook)
+		record.Webhook = &m.webhooks[i]
+		res = append(res, record)
+	}
+
+	return res
+}
+
+// addRecord adds a state file record to the parts of the state other than URL mappings.
+func (m *Memento) addRecord(record *dto.StateRecord) {
+	switch record.Kind {
+	case dto.StateRecordBan:
+		m.bans = append(m.bans, record.Ban)
+	case dto.StateRecordModeration:
+		if record.Moderation != nil {
+			m.moderation = append(m.moderation, *record.Moderation)
+		}
+	case dto.StateRecordUser:
+		if record.User != nil {
+			m.users = append(m.users, *record.User)
+		}
+	case dto.StateRecordAPIKey:
+		if record.APIKey != nil {
+			m.apiKeys = append(m.apiKeys, *record.APIKey)
+		}
+	case dto.StateRecordToken:
+		if record.Token != nil {
+			m.tokens = append(m.tokens, *record.Token)
+		}
+	case dto.StateRecordWebhook:
+		if record.Webhook != nil {
+			m.webhooks = append(m.webhooks, *record.Webhook)
+		}
+	}
+}
+
+// newRecord returns an empty state file record of the kind.
+func newRecord(kind dto.StateRecordKind) dto.StateRecord {
+	return dto.StateRecord{
+		Kind:       kind,
+		Ban:        domain.UserID{},
+		Moderation: nil,
+		User:       nil,
+		APIKey:     nil,
+		Token:      nil,
+		Webhook:    nil,
+	}
+}
@@ -1,5 +1,7 @@
 package memento
 
+import "github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+
 // Originator is an interface that defines the methods required for creating and restoring Mementos.
 type Originator interface {
 	// CreateMemento creates a new Memento that stores the current state of the object.
@@ -7,3 +9,39 @@ type Originator interface {
 	// RestoreMemento restores the object's state from a given Memento.
 	RestoreMemento(m *Memento) error
 }
+
+// originators is an Originator of the state parts of several originators, e.g. of several repositories.
+type originators []Originator
+
+// NewOriginators combines the originators of parts of the state into one.
+// Each originator stores and restores its own part of a shared Memento.
+func NewOriginators(parts ...Originator) Originator {
+	return originators(parts)
+}
+
+// CreateMemento creates a Memento of the state parts of all originators.
+func (o originators) CreateMemento() (*Memento, error) {
+	res := NewMemento(make(dto.URLMappings))
+
+	for _, originator := range o {
+		part, err := originator.CreateMemento()
+		if err != nil {
+			return nil, err
+		}
+
+		res.merge(part)
+	}
+
+	return res, nil
+}
+
+// RestoreMemento restores the state parts of all originators.
+func (o originators) RestoreMemento(m *Memento) error {
+	for _, originator := range o {
+		if err := originator.RestoreMemento(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+// AdminActorKey is the context key of the administrator acting on a request.
+const AdminActorKey contextKey = "admin_actor"
+
+// AdminMiddleware is a middleware handler that restricts requests to administrators.
+// Administrators are users of the admin role and clients of explicitly trusted subnets,
+// clients of denied subnets are refused either way.
+// The administrator is added to the request context as the actor: the user ID or the client IP.
+func AdminMiddleware(
+	log *zerolog.Logger,
+	config *config.Config,
+	auth *JWTMiddleware,
+) func(http.Handler) http.Handler {
+	acl, aclErr := NewSubnetACL(config)
+	if aclErr != nil {
+		log.Error().Err(aclErr).
+			Str("subnet", config.TrustedSubnet).
+			Msg("invalid subnet")
+	}
+
+	admins := make(map[domain.UserID]struct{}, len(config.AdminUsers))
+
+	for _, user := range config.AdminUsers {
+		userID, err := domain.ParseUserID(user)
+		if err != nil {
+			log.Error().Err(err).
+				Str("user_id", user).
+				Msg("invalid admin user")
+
+			aclErr = err
+
+			continue
+		}
+
+		admins[userID] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if aclErr != nil {
+				response.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+
+			ip := acl.resolver.ClientIP(request)
+			if utils.SubnetsContain(acl.deny, ip) {
+				log.Info().
+					Str("remote_addr", request.RemoteAddr).
+					Str("ip", ip.String()).
+					Msg("ip is denied")
+
+				response.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			var actor string
+
+			userID, identified := auth.Identify(request)
+			_, isAdmin := admins[userID]
+
+			switch {
+			case identified && isAdmin:
+				actor = userID.String()
+			case acl.Trusted(ip):
+				actor = ip.String()
+			default:
+				log.Info().
+					Str("remote_addr", request.RemoteAddr).
+					Bool("identified", identified).
+					Msg("admin access denied")
+
+				response.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			next.ServeHTTP(response, request.WithContext(context.WithValue(request.Context(), AdminActorKey, actor)))
+		})
+	}
+}
+
+// GetAdminActor extracts the administrator acting on the request from the request context.
+func GetAdminActor(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(AdminActorKey).(string)
+
+	return actor, ok
+}
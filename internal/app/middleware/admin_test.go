@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	cfg := config.DefaultConfig()
+	auth := newJWTMiddleware(t, &log, cfg)
+	adminID, userID := domain.NewUserID(), domain.NewUserID()
+
+	adminToken, err := auth.GenerateToken(adminID)
+	require.NoError(t, err)
+
+	userToken, err := auth.GenerateToken(userID)
+	require.NoError(t, err)
+
+	admins := func(subnets ...string) *config.Config {
+		return &config.Config{AdminUsers: []string{adminID.String()}, TrustedSubnets: subnets}
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		config         *config.Config
+		expectedStatus int
+		expectedActor  string
+	}{
+		{"Admin user", adminToken, admins(), http.StatusOK, adminID.String()},
+		{"Regular user", userToken, admins(), http.StatusForbidden, ""},
+		{"Anonymous", "", admins(), http.StatusForbidden, ""},
+		{"Trusted subnet", "", admins(testProxy + "/32"), http.StatusOK, testProxy},
+		{"Untrusted subnet", userToken, admins("10.0.0.0/8"), http.StatusForbidden, ""},
+		{"Admin user preferred", adminToken, admins(testProxy + "/32"), http.StatusOK, adminID.String()},
+		{"Denied subnet", adminToken, &config.Config{
+			AdminUsers:    []string{adminID.String()},
+			DeniedSubnets: []string{testProxy + "/32"},
+		}, http.StatusForbidden, ""},
+		{"Broken admin user", adminToken, &config.Config{AdminUsers: []string{"admin"}}, http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := middleware.AdminMiddleware(&log, tt.config, auth)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					actor, ok := middleware.GetAdminActor(r.Context())
+					assert.True(t, ok)
+					assert.Equal(t, tt.expectedActor, actor)
+					w.WriteHeader(http.StatusOK)
+				}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/moderation", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// APIKeyCtxKey is the context key of the API key authenticating a request.
+const APIKeyCtxKey contextKey = "api_key"
+
+// APIKeyResolver looks up the API key of a key token, rejecting unknown, revoked or mismatching keys.
+type APIKeyResolver interface {
+	ResolveAPIKey(ctx context.Context, token string) (*domain.APIKey, error)
+}
+
+// MethodScope returns the API key scope required for an HTTP method.
+func MethodScope(method string) domain.APIKeyScope {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return domain.ScopeRead
+	case http.MethodDelete:
+		return domain.ScopeDelete
+	default:
+		return domain.ScopeWrite
+	}
+}
+
+// AuthenticateAPIKey is a middleware handler that authenticates requests with an API key bearer token.
+// The key owner is added to the request context the same way as for JWT tokens,
+// so the JWT middleware lets such requests through. Requests without an API key are passed as is.
+func AuthenticateAPIKey(resolver APIKeyResolver, log *zerolog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerAPIKey(r.Header.Get("Authorization"))
+			if !ok {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			key, err := authenticateAPIKey(r.Context(), resolver, token, MethodScope(r.Method), log)
+
+			switch {
+			case errors.Is(err, e.ErrAuthScope):
+				http.Error(w, `forbidden`, http.StatusForbidden)
+			case err != nil:
+				http.Error(w, `unauthorized`, http.StatusUnauthorized)
+			default:
+				next.ServeHTTP(w, r.Clone(WithAPIKey(r.Context(), key)))
+			}
+		})
+	}
+}
+
+// APIKeyInterceptor is the grpc server interceptor authenticating requests with an API key bearer token metadata.
+// Scopes map methods to the API key scope they require, other methods are not available with API keys.
+func APIKeyInterceptor(
+	resolver APIKeyResolver,
+	scopes map[string]domain.APIKeyScope,
+	log *zerolog.Logger,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		var header string
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["authorization"]) > 0 {
+			header = md["authorization"][0]
+		}
+
+		token, ok := bearerAPIKey(header)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		scope, ok := scopes[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "Forbidden")
+		}
+
+		key, err := authenticateAPIKey(ctx, resolver, token, scope, log)
+
+		switch {
+		case errors.Is(err, e.ErrAuthScope):
+			return nil, status.Errorf(codes.PermissionDenied, "Forbidden")
+		case err != nil:
+			return nil, status.Errorf(codes.Unauthenticated, "Unauthorized")
+		default:
+			return handler(WithAPIKey(ctx, key), req)
+		}
+	}
+}
+
+// WithAPIKey returns a context authenticated with the API key on behalf of its owner.
+func WithAPIKey(ctx context.Context, key *domain.APIKey) context.Context {
+	return context.WithValue(context.WithValue(ctx, UserIDKey, key.UserID), APIKeyCtxKey, key)
+}
+
+// GetAPIKey extracts the API key authenticating the request from the request context.
+func GetAPIKey(ctx context.Context) (*domain.APIKey, bool) {
+	key, ok := ctx.Value(APIKeyCtxKey).(*domain.APIKey)
+
+	return key, ok
+}
+
+// bearerAPIKey extracts an API key from an authorization header value.
+func bearerAPIKey(header string) (string, bool) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || !domain.IsAPIKey(token) {
+		return ``, false
+	}
+
+	return token, true
+}
+
+func authenticateAPIKey(
+	ctx context.Context,
+	resolver APIKeyResolver,
+	token string,
+	scope domain.APIKeyScope,
+	log *zerolog.Logger,
+) (*domain.APIKey, error) {
+	key, err := resolver.ResolveAPIKey(ctx, token)
+	if err != nil {
+		log.Error().Err(err).Msg(`failed to resolve api key`)
+
+		return nil, err
+	}
+
+	if !key.Scopes.Has(scope) {
+		log.Info().
+			Str("key_id", key.ID).
+			Str("scope", string(scope)).
+			Msg("api key scope not granted")
+
+		return nil, e.ErrAuthScope
+	}
+
+	return key, nil
+}
+
+// DenyAPIKeys is a middleware handler that rejects requests authenticated with an API key,
+// e.g. to keep API keys from managing API keys.
+func DenyAPIKeys() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := GetAPIKey(r.Context()); ok {
+				http.Error(w, `forbidden`, http.StatusForbidden)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
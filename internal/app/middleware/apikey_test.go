@@ -0,0 +1,154 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+)
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := zerolog.Nop()
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	resolver := mock.NewMockAPIKeyResolver(ctrl)
+	key, token, err := domain.NewAPIKey(domain.NewUserID(), "ci", domain.APIKeyScopes{domain.ScopeRead})
+	require.NoError(t, err)
+
+	resolver.EXPECT().ResolveAPIKey(gomock.Any(), token).Return(key, nil).AnyTimes()
+	resolver.EXPECT().ResolveAPIKey(gomock.Any(), "sk_invalid").Return(nil, e.ErrAPIKeyInvalid).AnyTimes()
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		status int
+		user   bool
+	}{
+		{"read scope", http.MethodGet, "Bearer " + token, http.StatusOK, true},
+		{"write scope", http.MethodPost, "Bearer " + token, http.StatusForbidden, false},
+		{"delete scope", http.MethodDelete, "Bearer " + token, http.StatusForbidden, false},
+		{"invalid key", http.MethodGet, "Bearer sk_invalid", http.StatusUnauthorized, false},
+		{"no key", http.MethodGet, "", http.StatusUnauthorized, false},
+		{"jwt token", http.MethodGet, "Bearer eyJhbGciOiJIUzI1NiJ9", http.StatusUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// api key requests go through the jwt authorization.
+			handler := middleware.AuthenticateAPIKey(resolver, &log)(
+				newJWTMiddleware(t, &log, cfg).AuthorizeHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					userID, ok := middleware.GetUserID(r.Context())
+					assert.Equal(t, tt.user, ok)
+					assert.Equal(t, key.UserID, userID)
+				})),
+			)
+
+			req := httptest.NewRequest(tt.method, "/api/user/urls", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Empty(t, resp.Cookies())
+		})
+	}
+}
+
+func TestDenyAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	handler := middleware.DenyAPIKeys()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	key := &domain.APIKey{UserID: domain.NewUserID(), Scopes: domain.APIKeyScopes{domain.ScopeWrite}}
+	tests := []struct {
+		ctx    context.Context
+		status int
+	}{
+		{context.Background(), http.StatusNoContent},
+		{middleware.WithAPIKey(context.Background(), key), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequestWithContext(tt.ctx, http.MethodPost, "/api/user/keys", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		resp := rec.Result()
+		resp.Body.Close()
+
+		assert.Equal(t, tt.status, resp.StatusCode)
+	}
+}
+
+func TestAPIKeyInterceptor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := zerolog.Nop()
+	resolver := mock.NewMockAPIKeyResolver(ctrl)
+	key, token, err := domain.NewAPIKey(domain.NewUserID(), "ci", domain.APIKeyScopes{domain.ScopeWrite})
+	require.NoError(t, err)
+
+	resolver.EXPECT().ResolveAPIKey(gomock.Any(), token).Return(key, nil).AnyTimes()
+
+	scopes := map[string]domain.APIKeyScope{"/svc/Write": domain.ScopeWrite, "/svc/Read": domain.ScopeRead}
+	interceptor := middleware.APIKeyInterceptor(resolver, scopes, &log)
+	handler := func(ctx context.Context, _ any) (any, error) {
+		userID, _ := middleware.GetUserID(ctx)
+
+		return userID, nil
+	}
+
+	tests := []struct {
+		method string
+		md     metadata.MD
+		code   codes.Code
+	}{
+		{"/svc/Write", metadata.Pairs("authorization", "Bearer "+token), codes.OK},
+		{"/svc/Read", metadata.Pairs("authorization", "Bearer "+token), codes.PermissionDenied},
+		{"/svc/Other", metadata.Pairs("authorization", "Bearer "+token), codes.PermissionDenied},
+		{"/svc/Write", metadata.Pairs(), codes.OK},
+	}
+
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+		res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+		require.Equal(t, tt.code, status.Code(err), tt.method)
+
+		if err == nil && len(tt.md) > 0 {
+			assert.Equal(t, key.UserID, res)
+		}
+	}
+}
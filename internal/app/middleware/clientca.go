@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"crypto/x509"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+// ClientCAMiddleware is a middleware handler that verifies that request has been made over TLS
+// with a client certificate issued by a CA of the bundle at caPath.
+// Without a bundle all requests are passed, with a broken bundle all requests fail.
+func ClientCAMiddleware(log *zerolog.Logger, caPath string) func(http.Handler) http.Handler {
+	pool, poolErr := utils.LoadCertPool(caPath)
+	if poolErr != nil {
+		log.Error().Err(poolErr).
+			Str("ca_path", caPath).
+			Msg("invalid client ca bundle")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if caPath == "" {
+				next.ServeHTTP(response, request)
+
+				return
+			}
+
+			if poolErr != nil {
+				response.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+
+			if request.TLS == nil || len(request.TLS.PeerCertificates) == 0 {
+				log.Info().
+					Str("ca_path", caPath).
+					Msg("client certificate is missing")
+
+				response.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range request.TLS.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			leaf := request.TLS.PeerCertificates[0]
+
+			_, err := leaf.Verify(x509.VerifyOptions{
+				Roots:         pool,
+				Intermediates: intermediates,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			if err != nil {
+				log.Info().Err(err).
+					Str("ca_path", caPath).
+					Str("subject", leaf.Subject.String()).
+					Msg("client certificate is not issued by ca")
+
+				response.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			next.ServeHTTP(response, request)
+		})
+	}
+}
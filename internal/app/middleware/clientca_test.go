@@ -0,0 +1,139 @@
+package middleware_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, name string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func (ca *testCA) write(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestClientCAMiddleware(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+	statsCA := newTestCA(t, "stats")
+	otherCA := newTestCA(t, "other")
+	caPath := statsCA.write(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		caPath string
+		tls    *tls.ConnectionState
+		status int
+	}{
+		{"No ca", "", nil, http.StatusOK},
+		{"Plaintext", caPath, nil, http.StatusForbidden},
+		{"No client cert", caPath, &tls.ConnectionState{}, http.StatusForbidden},
+		{
+			"Stats ca cert",
+			caPath,
+			&tls.ConnectionState{PeerCertificates: []*x509.Certificate{statsCA.issue(t, "stats-client")}},
+			http.StatusOK,
+		},
+		{
+			"Other ca cert",
+			caPath,
+			&tls.ConnectionState{PeerCertificates: []*x509.Certificate{otherCA.issue(t, "client")}},
+			http.StatusForbidden,
+		},
+		{"Broken ca", filepath.Join(t.TempDir(), "missing.pem"), nil, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/stats", nil)
+			req.TLS = tt.tls
+
+			recorder := httptest.NewRecorder()
+			middleware.ClientCAMiddleware(log, tt.caPath)(handler).ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.status, recorder.Code)
+		})
+	}
+}
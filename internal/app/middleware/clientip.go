@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/peer"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+// Client IP headers.
+const (
+	HeaderForwarded     = "Forwarded"
+	HeaderXForwardedFor = "X-Forwarded-For"
+	HeaderXRealIP       = "X-Real-IP"
+)
+
+// ClientIPResolver resolves client IP addresses of requests received through trusted proxies.
+//
+// Only the one header set by the trusted proxies is taken into account and only for requests of trusted proxies,
+// other client IP headers may be sent by clients as is. Forwarded and X-Forwarded-For headers are read
+// right to left, the client is the first hop that is not a trusted proxy. Requests of other peers
+// and requests without the header are resolved to their remote address.
+type ClientIPResolver struct {
+	header  string
+	proxies []*net.IPNet
+}
+
+// NewClientIPResolver creates a ClientIPResolver of trusted proxies subnets setting the client IP header,
+// which is one of Forwarded, X-Forwarded-For and X-Real-IP. Headers are ignored if the header is empty.
+func NewClientIPResolver(header string, proxies ...string) (*ClientIPResolver, error) {
+	header = http.CanonicalHeaderKey(header)
+
+	switch header {
+	case "", HeaderForwarded, HeaderXForwardedFor, http.CanonicalHeaderKey(HeaderXRealIP):
+	default:
+		return nil, e.ErrInvalidConfig
+	}
+
+	subnets, err := utils.ParseSubnets(proxies...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientIPResolver{header: header, proxies: subnets}, nil
+}
+
+// ClientIP returns the client IP address of a request, nil if it can not be resolved.
+func (r *ClientIPResolver) ClientIP(request *http.Request) net.IP {
+	remote := parseHop(request.RemoteAddr)
+	if remote == nil || !utils.SubnetsContain(r.proxies, remote) {
+		return remote
+	}
+
+	values := request.Header.Values(r.header)
+	if r.header == "" || len(values) == 0 {
+		return remote
+	}
+
+	var hops []string
+
+	switch r.header {
+	case HeaderForwarded:
+		hops = forwardedFor(values)
+	case HeaderXForwardedFor:
+		for _, value := range values {
+			hops = append(hops, strings.Split(value, ",")...)
+		}
+	default:
+		return parseHop(values[len(values)-1])
+	}
+
+	client := remote
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		client = parseHop(hops[i])
+		if client == nil || !utils.SubnetsContain(r.proxies, client) {
+			return client
+		}
+	}
+
+	return client
+}
+
+// PeerIP returns the IP address of a grpc peer, nil if it can not be resolved.
+func PeerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+
+	if addr, ok := p.Addr.(*net.TCPAddr); ok {
+		return normalizeIP(addr.IP)
+	}
+
+	return parseHop(p.Addr.String())
+}
+
+// forwardedFor returns the for parameters of Forwarded header values (RFC 7239) in order,
+// elements without the parameter result in empty hops.
+func forwardedFor(values []string) []string {
+	hops := make([]string, 0, len(values))
+
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			hop := ""
+
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					hop = strings.Trim(val, `"`)
+				}
+			}
+
+			hops = append(hops, hop)
+		}
+	}
+
+	return hops
+}
+
+// parseHop parses an IP address of a hop with an optional port, IPv6 addresses with ports are bracketed.
+func parseHop(hop string) net.IP {
+	hop = strings.TrimSpace(hop)
+
+	if ip := net.ParseIP(hop); ip != nil {
+		return normalizeIP(ip)
+	}
+
+	host, _, err := net.SplitHostPort(hop)
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return normalizeIP(ip)
+	}
+
+	return nil
+}
+
+// normalizeIP converts IPv4-mapped IPv6 addresses to IPv4 ones.
+func normalizeIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+
+	return ip
+}
@@ -14,22 +14,43 @@ import (
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/shortener"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlgenerator"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
 )
 
-func setupHandleShortenURLCompression() http.Handler {
+func setupHandleShortenURLCompression(t *testing.T) http.Handler {
+	t.Helper()
+
 	config := config.DefaultConfig()
 	repo := repository.NewInMemoryURLRepository()
 	gen := urlgenerator.NewRandURLGenerator(config.URLsize)
 	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
-	srv := shortener.NewInsistentShortener(repo, gen, config, log)
-	handler := http.HandlerFunc(handler.NewShortenerHandler(srv, config, log).HandleShortenURL)
+	srv := shortener.NewInsistentShortener(
+		repo,
+		repo,
+		gen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		log,
+	)
+	handler := http.HandlerFunc(handler.NewShortenerHandler(
+		srv,
+		geoip.NopLocator{},
+		newJWTMiddleware(t, log, config),
+		config,
+		log,
+	).HandleShortenURL)
 
 	return middleware.Decompress()(middleware.Compress()(handler))
 }
@@ -37,7 +58,7 @@ func setupHandleShortenURLCompression() http.Handler {
 func TestHandleShortenURLCompression(t *testing.T) {
 	t.Parallel()
 
-	handler := setupHandleShortenURLCompression()
+	handler := setupHandleShortenURLCompression(t)
 	userID := domain.NewUserID()
 
 	tests := []struct {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -26,65 +27,20 @@ const (
 	defaultTokenDuration            = 365 * 24 * time.Hour
 )
 
-// Authenticate is a middleware handler that checks if the request has a valid JWT token.
-// If the token is not valid, it generates a new one and sets it in the response cookie.
-func Authenticate(log *zerolog.Logger, config *config.Config) func(next http.Handler) http.Handler {
-	auth := NewJWTMiddleware(
-		func(*jwt.Token) (any, error) { return []byte(config.JWTSecret), nil },
-		log,
-	)
-
-	return auth.AuthenticateHandler
-}
-
-// Authorize is a middleware handler that checks if the request is authorized with a valid JWT token.
-// It ensures that the user is authenticated before allowing access to the resource.
-func Authorize(log *zerolog.Logger, config *config.Config) func(next http.Handler) http.Handler {
-	auth := NewJWTMiddleware(
-		func(*jwt.Token) (any, error) { return []byte(config.JWTSecret), nil },
-		log,
-	)
-
-	return auth.AuthorizeHandler
-}
-
-// AuthenticateGRPC is a GRPC interceptor that checks if the request has a valid JWT token.
-// If the token is not valid, it generates a new one and sets it in the response cookie.
-func AuthenticateGRPC(
-	filter func(string) bool,
-	log *zerolog.Logger,
-	config *config.Config,
-) grpc.UnaryServerInterceptor {
-	auth := NewJWTMiddleware(
-		func(*jwt.Token) (any, error) { return []byte(config.JWTSecret), nil },
-		log,
-	)
-
-	return auth.JWTAuthenticateInterceptor(filter)
-}
-
-// AuthorizeGRPC is a GRPC interceptor that checks if the request is authorized with a valid JWT token.
-// It ensures that the user is authenticated before allowing access to the resource.
-func AuthorizeGRPC(
-	filter func(string) bool,
-	log *zerolog.Logger,
-	config *config.Config,
-) grpc.UnaryServerInterceptor {
-	auth := NewJWTMiddleware(
-		func(*jwt.Token) (any, error) { return []byte(config.JWTSecret), nil },
-		log,
-	)
-
-	return auth.JWTAuthorizeInterceptor(filter)
-}
-
 // Aux types.
 type (
 	contextKey     string
 	TokenExtractor func(r *http.Request) (string, error)
 	MDExtractor    func(ctx context.Context) (string, error)
+	JWTOption      func(auth *JWTMiddleware)
 )
 
+// TokenDenylist keeps revoked tokens by their jti claim until they expire.
+type TokenDenylist interface {
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
 // Claims represents the JWT claims for a user. It includes the user ID and standard JWT claims.
 type Claims struct {
 	UserID string `json:"user_id"`
@@ -93,10 +49,21 @@ type Claims struct {
 
 // JWTMiddleware is a struct that provides JWT-based authentication and authorization middleware.
 type JWTMiddleware struct {
-	keyFunc       jwt.Keyfunc
+	keys          JWTKeys
 	log           *zerolog.Logger
 	httpExtractor TokenExtractor
 	grpcExtractor MDExtractor
+	tokenTTL      time.Duration
+	renewBefore   time.Duration
+	cookie        http.Cookie
+	denylist      TokenDenylist
+}
+
+// authToken is a validated JWT token of a request.
+type authToken struct {
+	raw    string
+	userID domain.UserID
+	claims *Claims
 }
 
 // CookieTokenExtractor extracts the JWT token from a cookie in the HTTP request.
@@ -114,6 +81,33 @@ func CookieTokenExtractor(r *http.Request) (string, error) {
 	return cookie.Value, nil
 }
 
+// BearerTokenExtractor extracts the JWT token from the Authorization header of the HTTP request.
+// API keys are not JWT tokens and are ignored.
+func BearerTokenExtractor(r *http.Request) (string, error) {
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || tokenString == `` || domain.IsAPIKey(tokenString) {
+		return ``, e.ErrAuthNoCookie
+	}
+
+	return tokenString, nil
+}
+
+// ChainTokenExtractors combines extractors into one which tries them in order until a token is found.
+func ChainTokenExtractors(extractors ...TokenExtractor) TokenExtractor {
+	return func(r *http.Request) (string, error) {
+		for _, extractor := range extractors {
+			token, err := extractor(r)
+			if errors.Is(err, e.ErrAuthNoCookie) {
+				continue
+			}
+
+			return token, err
+		}
+
+		return ``, e.ErrAuthNoCookie
+	}
+}
+
 // MetaDataTokenExtractor extracts the JWT token from a metadat in the GRPC request.
 func MetaDataTokenExtractor(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -134,30 +128,102 @@ func MetaDataTokenExtractor(ctx context.Context) (string, error) {
 	return strings.TrimPrefix(tokenString, "Bearer "), nil
 }
 
-// NewJWTMiddleware creates a new JWTMiddleware instance with the provided key function and logger.
-func NewJWTMiddleware(keyFunc jwt.Keyfunc, log *zerolog.Logger) *JWTMiddleware {
+// hmacKeyFunc provides HS256 keys of a key function.
+type hmacKeyFunc jwt.Keyfunc
+
+func (f hmacKeyFunc) SigningKey() (*JWTKey, error) {
+	secret, err := f(jwt.New(jwt.SigningMethodHS256))
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := secret.([]byte)
+	if !ok {
+		return nil, e.ErrAuthKeyInvalid
+	}
+
+	return NewHMACKey(key), nil
+}
+
+func (f hmacKeyFunc) VerificationKey(t *jwt.Token) (any, error) {
 	// add signature method validation
-	kfunc := func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, e.ErrAuthUnexpectedSign
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, e.ErrAuthUnexpectedSign
+	}
+
+	return f(t)
+}
+
+// WithJWTConfig sets the configured token lifetime, renewal window and auth cookie attributes.
+func WithJWTConfig(config *config.Config) JWTOption {
+	return func(auth *JWTMiddleware) {
+		if config.JWTTokenTTL > 0 {
+			auth.tokenTTL = config.JWTTokenTTL
+		}
+
+		if config.JWTRenewBefore > 0 {
+			auth.renewBefore = config.JWTRenewBefore
 		}
 
-		return keyFunc(t)
+		sameSite, _ := config.AuthCookieSameSiteMode()
+
+		auth.cookie.Domain = config.AuthCookieDomain
+		auth.cookie.Secure = config.AuthCookieSecure
+		auth.cookie.HttpOnly = config.AuthCookieHTTPOnly
+		auth.cookie.SameSite = sameSite
 	}
+}
 
-	return &JWTMiddleware{
-		keyFunc:       kfunc,
+// WithTokenDenylist sets the denylist of revoked tokens.
+func WithTokenDenylist(denylist TokenDenylist) JWTOption {
+	return func(auth *JWTMiddleware) {
+		auth.denylist = denylist
+	}
+}
+
+// WithTokenExtractor sets the extractor of HTTP request tokens.
+func WithTokenExtractor(extractor TokenExtractor) JWTOption {
+	return func(auth *JWTMiddleware) {
+		auth.httpExtractor = extractor
+	}
+}
+
+// NewJWTMiddleware creates a new JWTMiddleware instance for HS256 tokens with the provided key function and logger.
+func NewJWTMiddleware(keyFunc jwt.Keyfunc, log *zerolog.Logger, opts ...JWTOption) *JWTMiddleware {
+	return NewKeysJWTMiddleware(hmacKeyFunc(keyFunc), log, opts...)
+}
+
+// NewKeysJWTMiddleware creates a new JWTMiddleware instance for tokens signed with the provided keys.
+// By default tokens are extracted from the Authorization header and then from the auth cookie,
+// they are never renewed nor revoked.
+func NewKeysJWTMiddleware(keys JWTKeys, log *zerolog.Logger, opts ...JWTOption) *JWTMiddleware {
+	auth := &JWTMiddleware{
+		keys:          keys,
 		log:           log,
-		httpExtractor: CookieTokenExtractor,
+		httpExtractor: ChainTokenExtractors(BearerTokenExtractor, CookieTokenExtractor),
 		grpcExtractor: MetaDataTokenExtractor,
+		tokenTTL:      defaultTokenDuration,
+		renewBefore:   0,
+		cookie: http.Cookie{
+			Name:     AuthCookieName,
+			Path:     "/",
+			HttpOnly: true,
+		},
+		denylist: nil,
 	}
+
+	for _, opt := range opts {
+		opt(auth)
+	}
+
+	return auth
 }
 
 // ValidateToken validates the JWT token string and returns the claims if valid.
 func (auth *JWTMiddleware) ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, auth.keyFunc)
+	token, err := jwt.ParseWithClaims(tokenString, claims, auth.keys.VerificationKey)
 	if err != nil {
 		msg := "failed to parse JWT token"
 		auth.log.Error().Err(err).
@@ -194,8 +260,9 @@ func (auth *JWTMiddleware) GenerateToken(userID domain.UserID) (string, error) {
 	claims := &Claims{
 		UserID: userID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(defaultTokenDuration)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(auth.tokenTTL)),
 		},
 	}
 
@@ -203,9 +270,7 @@ func (auth *JWTMiddleware) GenerateToken(userID domain.UserID) (string, error) {
 		Str("userID", userID.String()).
 		Msg("generating token")
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	signingKey, err := auth.keyFunc(token)
+	signingKey, err := auth.keys.SigningKey()
 	if err != nil {
 		msg := `failed to retrieve signing key`
 		auth.log.Error().Err(err).Msg(msg)
@@ -213,7 +278,12 @@ func (auth *JWTMiddleware) GenerateToken(userID domain.UserID) (string, error) {
 		return ``, e.Wrap(msg, err, errLabel)
 	}
 
-	tokenString, err := token.SignedString(signingKey)
+	token := jwt.NewWithClaims(signingKey.Method, claims)
+	if signingKey.ID != hmacKeyID {
+		token.Header[JWTKeyIDHeader] = signingKey.ID
+	}
+
+	tokenString, err := token.SignedString(signingKey.Sign)
 	if err != nil {
 		msg := `failed to sign token`
 		auth.log.Error().Err(err).Msg(msg)
@@ -224,103 +294,234 @@ func (auth *JWTMiddleware) GenerateToken(userID domain.UserID) (string, error) {
 	return tokenString, nil
 }
 
-func (auth *JWTMiddleware) extractAndValidateHTTPToken(r *http.Request) (domain.UserID, string, error) {
+func (auth *JWTMiddleware) extractAndValidateHTTPToken(r *http.Request) (*authToken, error) {
 	token, err := auth.httpExtractor(r)
 	if err != nil {
 		auth.log.Error().Err(err).
 			Str(`extractor`, `httpExtractor`).
 			Msg(`failed to extract token`)
 
-		return domain.UserID{}, ``, err
+		return nil, err
 	}
 
-	return auth.validateAndParse(token)
+	return auth.validateAndParse(r.Context(), token)
 }
 
-func (auth *JWTMiddleware) extractAndValidateGRPCToken(ctx context.Context) (domain.UserID, string, error) {
+func (auth *JWTMiddleware) extractAndValidateGRPCToken(ctx context.Context) (*authToken, error) {
 	token, err := auth.grpcExtractor(ctx)
 	if err != nil {
 		auth.log.Error().Err(err).
 			Str(`extractor`, `grpcExtractor`).
 			Msg(`failed to extract token`)
 
-		return domain.UserID{}, ``, err
+		return nil, err
 	}
 
-	return auth.validateAndParse(token)
+	return auth.validateAndParse(ctx, token)
 }
 
-func (auth *JWTMiddleware) validateAndParse(tokenStr string) (domain.UserID, string, error) {
+func (auth *JWTMiddleware) validateAndParse(ctx context.Context, tokenStr string) (*authToken, error) {
 	claims, err := auth.ValidateToken(tokenStr)
 	if err != nil {
 		auth.log.Error().Err(err).Msg(`failed to validate token`)
 
-		return domain.UserID{}, ``, err
+		return nil, err
 	}
 
 	userID, err := domain.ParseUserID(claims.UserID)
 	if err != nil {
 		auth.log.Error().Err(err).Msg(`failed to parse user from token claims`)
 
-		return domain.UserID{}, ``, e.Wrap("failed to parse user from token claims", err, errLabel)
+		return nil, e.Wrap("failed to parse user from token claims", err, errLabel)
+	}
+
+	// tokens issued before jti claims were introduced can't be revoked
+	if auth.denylist != nil && claims.ID != `` {
+		revoked, err := auth.denylist.IsTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			auth.log.Error().Err(err).Msg(`failed to check revoked token`)
+
+			return nil, e.Wrap("failed to check revoked token", e.ErrAuthDenylist, errLabel)
+		}
+
+		if revoked {
+			auth.log.Info().
+				Str("user_id", claims.UserID).
+				Str("jti", claims.ID).
+				Msg("revoked token")
+
+			return nil, e.ErrAuthTokenRevoked
+		}
+	}
+
+	return &authToken{raw: tokenStr, userID: userID, claims: claims}, nil
+}
+
+// renewToken returns the token as is or a new token of the same user when the token is about to expire.
+// It reports whether the token was renewed.
+func (auth *JWTMiddleware) renewToken(token *authToken) (string, bool, error) {
+	expiresAt := token.claims.ExpiresAt
+	if auth.renewBefore <= 0 || expiresAt == nil || time.Until(expiresAt.Time) > auth.renewBefore {
+		return token.raw, false, nil
 	}
 
-	return userID, tokenStr, nil
+	renewed, err := auth.GenerateToken(token.userID)
+	if err != nil {
+		return ``, false, err
+	}
+
+	return renewed, true, nil
 }
 
 // AuthenticateHandler is the handler for the Authenticate middleware.
-// It validates or generates a token and adds the user ID to the request context.
+// It validates, renews or generates a token and adds the user ID to the request context.
+// Requests authenticated with an API key are passed as is.
 func (auth *JWTMiddleware) AuthenticateHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userID, token, err := auth.extractAndValidateHTTPToken(r)
-		if err != nil {
-			if userID.IsNil() {
-				userID = domain.NewUserID()
-			}
+		if _, ok := GetAPIKey(r.Context()); ok {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		var (
+			userID domain.UserID
+			token  string
+		)
+
+		valid, err := auth.extractAndValidateHTTPToken(r)
 
+		switch {
+		case errors.Is(err, e.ErrAuthDenylist):
+			http.Error(w, "failed to validate token", http.StatusInternalServerError)
+
+			return
+		case err != nil:
+			userID = domain.NewUserID()
 			token, err = auth.GenerateToken(userID)
-			if err != nil {
-				http.Error(w, "failed to generate token", http.StatusInternalServerError)
+		default:
+			userID = valid.userID
+			token, _, err = auth.renewToken(valid)
+		}
 
-				return
-			}
+		if err != nil {
+			http.Error(w, "failed to generate token", http.StatusInternalServerError)
+
+			return
 		}
 
 		r = r.Clone(context.WithValue(r.Context(), UserIDKey, userID))
 
-		http.SetCookie(w, &http.Cookie{
-			Name:     AuthCookieName,
-			HttpOnly: true,
-			Value:    token,
-		})
+		auth.SetTokenCookie(w, token)
 
 		next.ServeHTTP(w, r)
 	})
 }
 
+// Identify returns the user ID of a request with a valid token, without issuing a new one otherwise.
+func (auth *JWTMiddleware) Identify(r *http.Request) (domain.UserID, bool) {
+	token, err := auth.extractAndValidateHTTPToken(r)
+	if err != nil {
+		return domain.UserID{}, false
+	}
+
+	return token.userID, true
+}
+
+// RevokeToken revokes the token of a request until it expires.
+// Tokens without a jti claim and tokens of a middleware without a denylist are not revoked.
+func (auth *JWTMiddleware) RevokeToken(r *http.Request) error {
+	token, err := auth.extractAndValidateHTTPToken(r)
+	if err != nil {
+		return err
+	}
+
+	if auth.denylist == nil || token.claims.ID == `` || token.claims.ExpiresAt == nil {
+		auth.log.Warn().
+			Str("user_id", token.userID.String()).
+			Msg("token can't be revoked")
+
+		return nil
+	}
+
+	if err := auth.denylist.RevokeToken(r.Context(), token.claims.ID, token.claims.ExpiresAt.Time); err != nil {
+		auth.log.Error().Err(err).Msg(`failed to revoke token`)
+
+		return e.Wrap("failed to revoke token", e.ErrAuthDenylist, errLabel)
+	}
+
+	auth.log.Info().
+		Str("user_id", token.userID.String()).
+		Str("jti", token.claims.ID).
+		Msg("token revoked")
+
+	return nil
+}
+
+// SetTokenCookie sets the JWT token as the auth cookie of the response.
+func (auth *JWTMiddleware) SetTokenCookie(w http.ResponseWriter, token string) {
+	cookie := auth.cookie
+	cookie.Value = token
+	cookie.MaxAge = int(auth.tokenTTL.Seconds())
+
+	http.SetCookie(w, &cookie)
+}
+
+// ClearTokenCookie removes the auth cookie.
+func (auth *JWTMiddleware) ClearTokenCookie(w http.ResponseWriter) {
+	cookie := auth.cookie
+	cookie.MaxAge = -1
+
+	http.SetCookie(w, &cookie)
+}
+
 // AuthorizeHandler is the handler for the Authorize middleware.
-// It ensures that the request is authorized with a valid token before proceeding.
+// It ensures that the request is authorized with a valid token or an API key before proceeding.
+// Tokens about to expire are renewed with a new auth cookie.
 func (auth *JWTMiddleware) AuthorizeHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userID, _, err := auth.extractAndValidateHTTPToken(r)
+		if _, ok := GetAPIKey(r.Context()); ok {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		token, err := auth.extractAndValidateHTTPToken(r)
+		if errors.Is(err, e.ErrAuthDenylist) {
+			http.Error(w, "failed to validate token", http.StatusInternalServerError)
+
+			return
+		}
+
 		if err != nil {
 			http.Error(w, `unauthorized`, http.StatusUnauthorized)
 
 			return
 		}
 
+		renewed, ok, err := auth.renewToken(token)
+		if err != nil {
+			http.Error(w, "failed to generate token", http.StatusInternalServerError)
+
+			return
+		}
+
+		if ok {
+			auth.SetTokenCookie(w, renewed)
+		}
+
 		auth.log.Info().
-			Str("user_id", userID.String()).
+			Str("user_id", token.userID.String()).
 			Msg("user_id authorized")
 
-		r = r.Clone(context.WithValue(r.Context(), UserIDKey, userID))
+		r = r.Clone(context.WithValue(r.Context(), UserIDKey, token.userID))
 
 		next.ServeHTTP(w, r)
 	})
 }
 
 // JWTAuthenticateInterceptor is the grpc server interceptor for the Authenticate middleware.
-// It validates or generates a token and adds the user ID to the request context.
+// It validates, renews or generates a token and adds the user ID to the request context.
 func (auth *JWTMiddleware) JWTAuthenticateInterceptor(filter func(string) bool) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -328,24 +529,34 @@ func (auth *JWTMiddleware) JWTAuthenticateInterceptor(filter func(string) bool)
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		// check supported methods
-		if !filter(info.FullMethod) {
+		// check supported methods, requests authenticated with api keys are already authorized
+		if _, ok := GetAPIKey(ctx); ok || !filter(info.FullMethod) {
 			return handler(ctx, req)
 		}
 
-		userID, token, err := auth.extractAndValidateGRPCToken(ctx)
-		if err != nil {
-			if userID.IsNil() {
-				userID = domain.NewUserID()
-			}
+		var (
+			userID domain.UserID
+			token  string
+		)
+
+		valid, err := auth.extractAndValidateGRPCToken(ctx)
 
+		switch {
+		case errors.Is(err, e.ErrAuthDenylist):
+			return nil, status.Errorf(codes.Internal, "Internal Server Error")
+		case err != nil:
+			userID = domain.NewUserID()
 			token, err = auth.GenerateToken(userID)
-			if err != nil {
-				auth.log.Error().Err(err).
-					Msg("failed to generate token")
+		default:
+			userID = valid.userID
+			token, _, err = auth.renewToken(valid)
+		}
 
-				return nil, status.Errorf(codes.Internal, "Internal Server Error")
-			}
+		if err != nil {
+			auth.log.Error().Err(err).
+				Msg("failed to generate token")
+
+			return nil, status.Errorf(codes.Internal, "Internal Server Error")
 		}
 
 		if err := grpc.SetHeader(ctx, metadata.Pairs("authorization", "Bearer "+token)); err != nil {
@@ -370,21 +581,25 @@ func (auth *JWTMiddleware) JWTAuthorizeInterceptor(filter func(string) bool) grp
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		// check supported methods
-		if !filter(info.FullMethod) {
+		// check supported methods, requests authenticated with api keys are already authorized
+		if _, ok := GetAPIKey(ctx); ok || !filter(info.FullMethod) {
 			return handler(ctx, req)
 		}
 
-		userID, _, err := auth.extractAndValidateGRPCToken(ctx)
+		token, err := auth.extractAndValidateGRPCToken(ctx)
+		if errors.Is(err, e.ErrAuthDenylist) {
+			return nil, status.Errorf(codes.Internal, "Internal Server Error")
+		}
+
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "Unauthorized")
 		}
 
 		auth.log.Info().
-			Str("user_id", userID.String()).
+			Str("user_id", token.userID.String()).
 			Msg("user_id authorized")
 
-		ctx = context.WithValue(ctx, UserIDKey, userID)
+		ctx = context.WithValue(ctx, UserIDKey, token.userID)
 
 		return handler(ctx, req)
 	}
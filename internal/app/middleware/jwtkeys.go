@@ -0,0 +1,369 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// Aux JWT keys constants.
+const (
+	JWTKeyIDHeader   = "kid"
+	jwtKeyFileExt    = ".pem"
+	minRSAKeyBits    = 2048
+	hmacKeyID        = ""
+	pemPrivateKey    = "PRIVATE KEY"
+	pemRSAPrivateKey = "RSA PRIVATE KEY"
+	pemPublicKey     = "PUBLIC KEY"
+	defaultJWTSecret = config.DefaultJWTSecret
+)
+
+// JWTHMACKeyID is the JWTSigningKeyID selecting the HMAC key of the JWT secret.
+const JWTHMACKeyID = "hmac"
+
+// JWTKeys provides keys to sign new JWT tokens and to verify existing ones.
+type JWTKeys interface {
+	SigningKey() (*JWTKey, error)
+	VerificationKey(token *jwt.Token) (any, error)
+}
+
+// JWTKey is a JWT signing key identified by its key ID (the kid header of tokens).
+// Keys without a signing part are used only to verify tokens, e.g. retired keys during rotation.
+type JWTKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	Sign   any
+	Verify any
+}
+
+// NewHMACKey creates a HS256 key of a secret.
+// HMAC keys have an empty key ID, so tokens are issued without the kid header as they used to be.
+func NewHMACKey(secret []byte) *JWTKey {
+	return &JWTKey{
+		ID:     hmacKeyID,
+		Method: jwt.SigningMethodHS256,
+		Sign:   secret,
+		Verify: secret,
+	}
+}
+
+// ParseJWTKey parses a PEM encoded RSA or Ed25519 key.
+// RSA keys are used with RS256 and Ed25519 keys with EdDSA.
+// Private keys (PKCS #8 or PKCS #1) sign and verify tokens, public keys (PKIX) only verify them.
+func ParseJWTKey(id string, data []byte) (*JWTKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, e.Wrap("failed to decode pem", e.ErrAuthKeyInvalid, errLabel)
+	}
+
+	var (
+		key any
+		err error
+	)
+
+	switch block.Type {
+	case pemPrivateKey:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case pemRSAPrivateKey:
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case pemPublicKey:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, e.Wrap("unsupported pem block "+block.Type, e.ErrAuthKeyInvalid, errLabel)
+	}
+
+	if err != nil {
+		return nil, e.Wrap("failed to parse key", err, errLabel)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, e.Wrap("rsa key is too short", e.ErrAuthKeyInvalid, errLabel)
+		}
+
+		return &JWTKey{ID: id, Method: jwt.SigningMethodRS256, Sign: k, Verify: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, e.Wrap("rsa key is too short", e.ErrAuthKeyInvalid, errLabel)
+		}
+
+		return &JWTKey{ID: id, Method: jwt.SigningMethodRS256, Sign: nil, Verify: k}, nil
+	case ed25519.PrivateKey:
+		return &JWTKey{ID: id, Method: jwt.SigningMethodEdDSA, Sign: k, Verify: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &JWTKey{ID: id, Method: jwt.SigningMethodEdDSA, Sign: nil, Verify: k}, nil
+	default:
+		return nil, e.Wrap(fmt.Sprintf("unsupported key type %T", key), e.ErrAuthKeyInvalid, errLabel)
+	}
+}
+
+// JWTKeySet is a set of JWT keys, several keys verify tokens concurrently so that signing keys can be rotated.
+//
+// The set consists of the PEM keys in the JWTKeysPath directory, one key per *.pem file with the file name
+// as the key ID, and of the HMAC key of the configured JWT secret, if any.
+// Along with PEM keys the HMAC key verifies kid-less tokens only if it is explicitly configured,
+// either as the active key with the JWTHMACKeyID signing key ID or as a previous key with JWTVerifyHMAC.
+// Tokens are signed with the JWTSigningKeyID key, by default with the private key of the greatest
+// key ID and with the HMAC key without PEM keys.
+// The directory is checked for changes periodically while the set is watched and on Reload,
+// failed reloads keep the previously loaded keys in effect.
+type JWTKeySet struct {
+	sync.RWMutex
+	keys      map[string]*JWTKey
+	signing   *JWTKey
+	secret    string
+	signingID string
+	hmac      bool
+	path      string
+	interval  time.Duration
+	stamp     string
+	log       *zerolog.Logger
+}
+
+// NewJWTKeySet creates a JWTKeySet of the configured JWT secret and keys directory.
+// The default JWT secret is refused in production mode.
+func NewJWTKeySet(config *config.Config, log *zerolog.Logger) (*JWTKeySet, error) {
+	if config.Production && config.JWTSecret == defaultJWTSecret {
+		return nil, e.ErrDefaultJWTSecret
+	}
+
+	s := &JWTKeySet{
+		RWMutex:   sync.RWMutex{},
+		keys:      make(map[string]*JWTKey),
+		signing:   nil,
+		secret:    config.JWTSecret,
+		signingID: config.JWTSigningKeyID,
+		hmac:      config.JWTVerifyHMAC,
+		path:      config.JWTKeysPath,
+		interval:  config.JWTKeysReload,
+		stamp:     "",
+		log:       log,
+	}
+
+	if _, err := s.reload(true); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// SigningKey returns the key to sign new tokens with.
+func (s *JWTKeySet) SigningKey() (*JWTKey, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.signing, nil
+}
+
+// VerificationKey returns the key to verify a token with, it is a jwt.Keyfunc.
+// The token is looked up by its kid header and must be signed with the method of the key.
+func (s *JWTKeySet) VerificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header[JWTKeyIDHeader].(string)
+
+	s.RLock()
+	key, ok := s.keys[kid]
+	s.RUnlock()
+
+	if !ok {
+		return nil, e.ErrAuthKeyNotFound
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, e.ErrAuthUnexpectedSign
+	}
+
+	return key.Verify, nil
+}
+
+// KeyIDs returns the sorted IDs of all keys of the set.
+func (s *JWTKeySet) KeyIDs() []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Reload loads the keys directory regardless of whether it changed, e.g. on SIGHUP.
+func (s *JWTKeySet) Reload() error {
+	_, err := s.reload(true)
+	if err == nil {
+		s.logReload()
+	}
+
+	return err
+}
+
+// Watch reloads the keys directory whenever it changes until the context is done.
+func (s *JWTKeySet) Watch(ctx context.Context) {
+	if s.path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := s.reload(false)
+			if err != nil {
+				s.log.Error().Err(err).Str("path", s.path).Msg("failed to reload jwt keys")
+
+				continue
+			}
+
+			if reloaded {
+				s.logReload()
+			}
+		}
+	}
+}
+
+func (s *JWTKeySet) logReload() {
+	s.RLock()
+	signingID := s.signing.ID
+	s.RUnlock()
+
+	s.log.Info().
+		Str("path", s.path).
+		Strs("key_ids", s.KeyIDs()).
+		Str("signing_key_id", signingID).
+		Msg("jwt keys reloaded")
+}
+
+// reload loads the keys if the keys directory changed since the last load or if forced,
+// it reports whether it did.
+func (s *JWTKeySet) reload(force bool) (bool, error) {
+	files, stamp, err := s.scan()
+	if err != nil {
+		return false, err
+	}
+
+	s.RLock()
+	unchanged := stamp == s.stamp
+	s.RUnlock()
+
+	if unchanged && !force {
+		return false, nil
+	}
+
+	keys := make(map[string]*JWTKey, len(files)+1)
+	if s.secret != "" && (len(files) == 0 || s.signingID == JWTHMACKeyID || s.hmac) {
+		keys[hmacKeyID] = NewHMACKey([]byte(s.secret))
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(s.path, file))
+		if err != nil {
+			return false, e.Wrap("failed to read jwt key", err, errLabel)
+		}
+
+		id := strings.TrimSuffix(file, jwtKeyFileExt)
+
+		key, err := ParseJWTKey(id, data)
+		if err != nil {
+			return false, e.Wrap("failed to load jwt key "+id, err, errLabel)
+		}
+
+		keys[id] = key
+	}
+
+	signing, err := s.signingKey(keys, files)
+	if err != nil {
+		return false, err
+	}
+
+	s.Lock()
+	s.keys = keys
+	s.signing = signing
+	s.stamp = stamp
+	s.Unlock()
+
+	return true, nil
+}
+
+// signingKey selects the signing key of loaded keys, files are sorted by key ID.
+func (s *JWTKeySet) signingKey(keys map[string]*JWTKey, files []string) (*JWTKey, error) {
+	if s.signingID != "" {
+		id := s.signingID
+		if id == JWTHMACKeyID {
+			id = hmacKeyID
+		}
+
+		key, ok := keys[id]
+		if !ok || key.Sign == nil {
+			return nil, e.Wrap("signing key "+s.signingID+" not loaded", e.ErrAuthKeyNotFound, errLabel)
+		}
+
+		return key, nil
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		if key := keys[strings.TrimSuffix(files[i], jwtKeyFileExt)]; key.Sign != nil {
+			return key, nil
+		}
+	}
+
+	if key, ok := keys[hmacKeyID]; ok {
+		return key, nil
+	}
+
+	return nil, e.Wrap("no signing key", e.ErrAuthKeyNotFound, errLabel)
+}
+
+// scan lists sorted key files of the keys directory along with a stamp of their names, sizes and modification times.
+func (s *JWTKeySet) scan() ([]string, string, error) {
+	if s.path == "" {
+		return nil, "", nil
+	}
+
+	entries, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil, "", e.Wrap("failed to read jwt keys directory", err, errLabel)
+	}
+
+	files := make([]string, 0, len(entries))
+
+	var stamp strings.Builder
+
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == jwtKeyFileExt || filepath.Ext(entry.Name()) != jwtKeyFileExt {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, "", e.Wrap("failed to stat jwt key", err, errLabel)
+		}
+
+		files = append(files, entry.Name())
+		fmt.Fprintf(&stamp, "%s:%d:%d;", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+
+	return files, stamp.String(), nil
+}
@@ -0,0 +1,268 @@
+package middleware_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func writeEd25519Key(t *testing.T, dir, id string) ed25519.PublicKey {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, id+".pem"), "PRIVATE KEY", der)
+
+	return pub
+}
+
+func keySetConfig(dir string) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.JWTSecret = "test-secret"
+	cfg.JWTKeysPath = dir
+
+	return cfg
+}
+
+func tokenKeyID(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &middleware.Claims{})
+	require.NoError(t, err)
+
+	kid, _ := parsed.Header[middleware.JWTKeyIDHeader].(string)
+
+	return kid
+}
+
+func TestJWTKeySetRotation(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	dir := t.TempDir()
+	userID := domain.NewUserID()
+
+	writeEd25519Key(t, dir, "2026-01")
+
+	// the HMAC key keeps verifying tokens issued before the rotation
+	cfg := keySetConfig(dir)
+	cfg.JWTVerifyHMAC = true
+
+	keys, err := middleware.NewJWTKeySet(cfg, &log)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "2026-01"}, keys.KeyIDs())
+
+	auth := middleware.NewKeysJWTMiddleware(keys, &log)
+	legacy := middleware.NewJWTMiddleware(func(*jwt.Token) (any, error) { return []byte("test-secret"), nil }, &log)
+
+	oldToken, err := auth.GenerateToken(userID)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01", tokenKeyID(t, oldToken))
+
+	hmacToken, err := legacy.GenerateToken(userID)
+	require.NoError(t, err)
+
+	writeEd25519Key(t, dir, "2026-02")
+	require.NoError(t, keys.Reload())
+
+	newToken, err := auth.GenerateToken(userID)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02", tokenKeyID(t, newToken))
+
+	for _, token := range []string{oldToken, newToken, hmacToken} {
+		claims, err := auth.ValidateToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, userID.String(), claims.UserID)
+	}
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-01.pem")))
+	require.NoError(t, keys.Reload())
+
+	_, err = auth.ValidateToken(oldToken)
+	require.ErrorIs(t, err, e.ErrAuthKeyNotFound)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("garbage"), 0o600))
+	require.ErrorIs(t, keys.Reload(), e.ErrAuthKeyInvalid)
+
+	_, err = auth.ValidateToken(newToken)
+	require.NoError(t, err, "failed reloads keep previous keys")
+}
+
+func TestJWTKeySetSigningKey(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	dir := t.TempDir()
+	userID := domain.NewUserID()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "a-rsa.pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	pub := writeEd25519Key(t, dir, "b-ed25519")
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "c-public.pem"), "PUBLIC KEY", pubDER)
+
+	cfg := keySetConfig(dir)
+	cfg.JWTSigningKeyID = "a-rsa"
+
+	keys, err := middleware.NewJWTKeySet(cfg, &log)
+	require.NoError(t, err)
+
+	signing, err := keys.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, jwt.SigningMethodRS256, signing.Method)
+
+	auth := middleware.NewKeysJWTMiddleware(keys, &log)
+	token, err := auth.GenerateToken(userID)
+	require.NoError(t, err)
+	assert.Equal(t, "a-rsa", tokenKeyID(t, token))
+
+	_, err = auth.ValidateToken(token)
+	require.NoError(t, err)
+
+	cfg.JWTSigningKeyID = "c-public"
+	_, err = middleware.NewJWTKeySet(cfg, &log)
+	require.ErrorIs(t, err, e.ErrAuthKeyNotFound, "public keys can't sign tokens")
+
+	cfg.JWTSigningKeyID = ""
+	keys, err = middleware.NewJWTKeySet(cfg, &log)
+	require.NoError(t, err)
+
+	signing, err = keys.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, "b-ed25519", signing.ID, "greatest private key signs by default")
+}
+
+func TestJWTKeySetHMAC(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	dir := t.TempDir()
+	userID := domain.NewUserID()
+	legacy := middleware.NewJWTMiddleware(func(*jwt.Token) (any, error) { return []byte("test-secret"), nil }, &log)
+
+	hmacToken, err := legacy.GenerateToken(userID)
+	require.NoError(t, err)
+	assert.Empty(t, tokenKeyID(t, hmacToken))
+
+	// without PEM keys the HMAC key signs and verifies tokens
+	keys, err := middleware.NewJWTKeySet(keySetConfig(dir), &log)
+	require.NoError(t, err)
+
+	_, err = middleware.NewKeysJWTMiddleware(keys, &log).ValidateToken(hmacToken)
+	require.NoError(t, err)
+
+	writeEd25519Key(t, dir, "ed")
+	require.NoError(t, keys.Reload())
+	assert.Equal(t, []string{"ed"}, keys.KeyIDs())
+
+	_, err = middleware.NewKeysJWTMiddleware(keys, &log).ValidateToken(hmacToken)
+	require.ErrorIs(t, err, e.ErrAuthKeyNotFound, "kid-less tokens are rejected along with PEM keys")
+
+	cfg := keySetConfig(dir)
+	cfg.JWTSigningKeyID = middleware.JWTHMACKeyID
+
+	keys, err = middleware.NewJWTKeySet(cfg, &log)
+	require.NoError(t, err)
+
+	signing, err := keys.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, jwt.SigningMethodHS256, signing.Method)
+
+	_, err = middleware.NewKeysJWTMiddleware(keys, &log).ValidateToken(hmacToken)
+	require.NoError(t, err)
+
+	cfg.JWTSecret = ""
+	_, err = middleware.NewJWTKeySet(cfg, &log)
+	require.ErrorIs(t, err, e.ErrAuthKeyNotFound, "the HMAC key requires the JWT secret")
+}
+
+func TestJWTKeySetAlgorithmMismatch(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	dir := t.TempDir()
+	pub := writeEd25519Key(t, dir, "ed")
+
+	keys, err := middleware.NewJWTKeySet(keySetConfig(dir), &log)
+	require.NoError(t, err)
+
+	auth := middleware.NewKeysJWTMiddleware(keys, &log)
+
+	// HS256 token signed with the public key bytes under the kid of an EdDSA key
+	claims := &middleware.Claims{UserID: domain.NewUserID().String()}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	forged.Header[middleware.JWTKeyIDHeader] = "ed"
+	token, err := forged.SignedString([]byte(pub))
+	require.NoError(t, err)
+
+	_, err = auth.ValidateToken(token)
+	require.ErrorIs(t, err, e.ErrAuthUnexpectedSign)
+}
+
+func TestJWTKeySetDefaultSecret(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	cfg := config.DefaultConfig()
+
+	_, err := middleware.NewJWTKeySet(cfg, &log)
+	require.NoError(t, err)
+
+	cfg.Production = true
+	_, err = middleware.NewJWTKeySet(cfg, &log)
+	require.ErrorIs(t, err, e.ErrDefaultJWTSecret)
+
+	cfg.JWTSecret = "production-secret"
+	_, err = middleware.NewJWTKeySet(cfg, &log)
+	require.NoError(t, err)
+
+	cfg.JWTSecret = ""
+	_, err = middleware.NewJWTKeySet(cfg, &log)
+	require.ErrorIs(t, err, e.ErrAuthKeyNotFound)
+}
+
+func TestParseJWTKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := middleware.ParseJWTKey("id", []byte("garbage"))
+	require.ErrorIs(t, err, e.ErrAuthKeyInvalid)
+
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(weak)})
+	_, err = middleware.ParseJWTKey("id", data)
+	require.ErrorIs(t, err, e.ErrAuthKeyInvalid)
+
+	data = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}})
+	_, err = middleware.ParseJWTKey("id", data)
+	require.ErrorIs(t, err, e.ErrAuthKeyInvalid)
+}
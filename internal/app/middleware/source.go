@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// SourceCtxKey is the context key of the source of a request.
+const SourceCtxKey contextKey = "source"
+
+// RequestSource is a middleware handler that adds the source of a request to the request context,
+// the client IP is resolved with the client IP header of trusted proxies only.
+func RequestSource(log *zerolog.Logger, config *config.Config) func(http.Handler) http.Handler {
+	resolver, err := NewClientIPResolver(config.ClientIPHeader, config.TrustedProxies...)
+	if err != nil {
+		log.Error().Err(err).
+			Strs("proxies", config.TrustedProxies).
+			Str("header", config.ClientIPHeader).
+			Msg("invalid trusted proxies or client IP header, forwarding headers are ignored")
+
+		resolver = &ClientIPResolver{header: "", proxies: nil}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			source := domain.RequestSource{Protocol: domain.ProtocolHTTP, ClientIP: ``}
+			if ip := resolver.ClientIP(r); ip != nil {
+				source.ClientIP = ip.String()
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithSource(r.Context(), source)))
+		})
+	}
+}
+
+// SourceInterceptor is the grpc server interceptor adding the source of a request to the request context.
+func SourceInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		source := domain.RequestSource{Protocol: domain.ProtocolGRPC, ClientIP: ``}
+		if ip := PeerIP(ctx); ip != nil {
+			source.ClientIP = ip.String()
+		}
+
+		return handler(WithSource(ctx, source), req)
+	}
+}
+
+// WithSource returns a context of a request received from the source.
+func WithSource(ctx context.Context, source domain.RequestSource) context.Context {
+	return context.WithValue(ctx, SourceCtxKey, source)
+}
+
+// GetSource extracts the source of the request from the request context.
+func GetSource(ctx context.Context) (domain.RequestSource, bool) {
+	source, ok := ctx.Value(SourceCtxKey).(domain.RequestSource)
+
+	return source, ok
+}
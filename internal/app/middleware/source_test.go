@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+func TestRequestSource(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	tests := []struct {
+		name     string
+		config   *config.Config
+		expected string
+	}{
+		{"Trusted proxy", &config.Config{
+			TrustedProxies: []string{testProxy},
+			ClientIPHeader: config.ClientIPHeaderXRealIP,
+		}, "198.51.100.1"},
+		{"Other header", &config.Config{
+			TrustedProxies: []string{testProxy},
+			ClientIPHeader: config.ClientIPHeaderXForwardedFor,
+		}, testProxy},
+		{"Untrusted proxy", &config.Config{ClientIPHeader: config.ClientIPHeaderXRealIP}, testProxy},
+		{"Broken proxies", &config.Config{TrustedProxies: []string{"111"}}, testProxy},
+		{"Broken header", &config.Config{TrustedProxies: []string{testProxy}, ClientIPHeader: "X-Client-IP"}, testProxy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var source domain.RequestSource
+
+			handler := middleware.RequestSource(log, tt.config)(
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					var ok bool
+
+					source, ok = middleware.GetSource(r.Context())
+					assert.True(t, ok)
+				}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Real-IP", "198.51.100.1")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, domain.RequestSource{Protocol: domain.ProtocolHTTP, ClientIP: tt.expected}, source)
+		})
+	}
+}
+
+func TestSourceInterceptor(t *testing.T) {
+	t.Parallel()
+
+	handler := func(ctx context.Context, _ any) (any, error) {
+		source, _ := middleware.GetSource(ctx)
+
+		return source, nil
+	}
+	interceptor := middleware.SourceInterceptor()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 1}})
+	res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestSource{Protocol: domain.ProtocolGRPC, ClientIP: "10.1.2.3"}, res)
+
+	res, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestSource{Protocol: domain.ProtocolGRPC, ClientIP: ""}, res)
+
+	_, ok := middleware.GetSource(context.Background())
+	assert.False(t, ok)
+}
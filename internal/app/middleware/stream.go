@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serverStream is a grpc server stream with the context passed on by interceptors.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamInterceptor adapts a unary grpc server interceptor to server streams.
+// The interceptor is applied to the stream context once before the stream is handled,
+// so it must not depend on request messages, which are not available to it.
+func StreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		unaryInfo := &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}
+
+		_, err := interceptor(stream.Context(), nil, unaryInfo, func(ctx context.Context, _ any) (any, error) {
+			return nil, handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+		})
+
+		return err
+	}
+}
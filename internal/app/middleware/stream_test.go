@@ -0,0 +1,61 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+// testStream is a grpc server stream of the context.
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	interceptor := middleware.StreamInterceptor(func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if info.FullMethod == "/denied" {
+			return nil, status.Error(codes.PermissionDenied, "Forbidden")
+		}
+
+		return handler(context.WithValue(ctx, middleware.UserIDKey, userID), req)
+	})
+
+	var handled domain.UserID
+
+	handler := func(_ any, stream grpc.ServerStream) error {
+		handled, _ = middleware.GetUserID(stream.Context())
+
+		return nil
+	}
+	stream := &testStream{ServerStream: nil, ctx: context.Background()}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/allowed"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, userID, handled)
+
+	handled = domain.UserID{}
+	err = interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/denied"}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, domain.UserID{}, handled)
+}
@@ -1,52 +1,100 @@
 package middleware
 
 import (
+	"context"
 	"net"
 	"net/http"
 
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
 )
 
+// SubnetACL verifies that client IP addresses belong to allowed subnets and do not belong to denied ones.
+// Without allowed subnets all addresses but denied ones are allowed, without subnets the ACL is disabled.
+type SubnetACL struct {
+	allow    []*net.IPNet
+	deny     []*net.IPNet
+	resolver *ClientIPResolver
+}
+
+// NewSubnetACL creates a SubnetACL of the trusted and denied subnets, with client IPs resolved behind trusted proxies.
+func NewSubnetACL(config *config.Config) (*SubnetACL, error) {
+	allow, err := utils.ParseSubnets(append([]string{config.TrustedSubnet}, config.TrustedSubnets...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	deny, err := utils.ParseSubnets(config.DeniedSubnets...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := NewClientIPResolver(config.ClientIPHeader, config.TrustedProxies...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubnetACL{
+		allow:    allow,
+		deny:     deny,
+		resolver: resolver,
+	}, nil
+}
+
+// Enabled reports whether any subnets are configured.
+func (a *SubnetACL) Enabled() bool {
+	return len(a.allow) > 0 || len(a.deny) > 0
+}
+
+// Allowed reports whether the ip is allowed, unresolved addresses are not.
+func (a *SubnetACL) Allowed(ip net.IP) bool {
+	if ip == nil || utils.SubnetsContain(a.deny, ip) {
+		return false
+	}
+
+	return len(a.allow) == 0 || utils.SubnetsContain(a.allow, ip)
+}
+
+// Trusted reports whether the ip is allowed and belongs to explicitly trusted subnets.
+func (a *SubnetACL) Trusted(ip net.IP) bool {
+	return len(a.allow) > 0 && a.Allowed(ip)
+}
+
 // SubnetMiddleware is a middleware handler that verifies that request has been received from a trusted subnet.
+// The client IP is resolved with forwarding headers of trusted proxies only.
 func SubnetMiddleware(log *zerolog.Logger, config *config.Config) func(http.Handler) http.Handler {
+	acl, aclErr := NewSubnetACL(config)
+	if aclErr != nil {
+		log.Error().Err(aclErr).
+			Str("subnet", config.TrustedSubnet).
+			Msg("invalid subnet")
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
-			if config.TrustedSubnet == "" {
-				next.ServeHTTP(response, request)
-				return
-			}
-
-			ipStr := request.Header.Get("X-Real-IP")
-
-			if ipStr == "" {
-				log.Info().
-					Str("subnet", config.TrustedSubnet).
-					Msg("X-Real-IP header is nil")
-
-				response.WriteHeader(http.StatusForbidden)
+			if aclErr != nil {
+				response.WriteHeader(http.StatusInternalServerError)
 
 				return
 			}
 
-			_, trustedNet, err := net.ParseCIDR(config.TrustedSubnet)
-			if err != nil {
-				log.Error().Err(err).
-					Str("subnet", config.TrustedSubnet).
-					Msg("invalid subnet")
-
-				response.WriteHeader(http.StatusInternalServerError)
+			if !acl.Enabled() {
+				next.ServeHTTP(response, request)
 
 				return
 			}
 
-			ip := net.ParseIP(ipStr)
-			if !trustedNet.Contains(ip) {
+			ip := acl.resolver.ClientIP(request)
+			if !acl.Allowed(ip) {
 				log.Info().
-					Str("subnet", config.TrustedSubnet).
+					Str("remote_addr", request.RemoteAddr).
 					Str("ip", ip.String()).
-					Msg("ip does not belong to subnet")
+					Msg("ip is not allowed")
 
 				response.WriteHeader(http.StatusForbidden)
 
@@ -57,3 +105,48 @@ func SubnetMiddleware(log *zerolog.Logger, config *config.Config) func(http.Hand
 		})
 	}
 }
+
+// SubnetInterceptor is the grpc server interceptor verifying that peers of filtered methods belong to a trusted subnet.
+func SubnetInterceptor(
+	log *zerolog.Logger,
+	config *config.Config,
+	filter func(string) bool,
+) grpc.UnaryServerInterceptor {
+	acl, aclErr := NewSubnetACL(config)
+	if aclErr != nil {
+		log.Error().Err(aclErr).
+			Str("subnet", config.TrustedSubnet).
+			Msg("invalid subnet")
+	}
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !filter(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if aclErr != nil {
+			return nil, status.Errorf(codes.Internal, "Internal Server Error")
+		}
+
+		if !acl.Enabled() {
+			return handler(ctx, req)
+		}
+
+		ip := PeerIP(ctx)
+		if !acl.Allowed(ip) {
+			log.Info().
+				Str("method", info.FullMethod).
+				Str("ip", ip.String()).
+				Msg("ip is not allowed")
+
+			return nil, status.Errorf(codes.PermissionDenied, "Forbidden")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -1,18 +1,29 @@
 package middleware_test
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 )
 
+// remote address of httptest requests.
+const testProxy = "192.0.2.1"
+
 func TestSubnetMiddleware(t *testing.T) {
 	t.Parallel()
 
@@ -23,17 +34,46 @@ func TestSubnetMiddleware(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
+	proxied := func(subnets ...string) *config.Config {
+		return &config.Config{
+			TrustedSubnets: subnets,
+			TrustedProxies: []string{testProxy},
+			ClientIPHeader: config.ClientIPHeaderXRealIP,
+		}
+	}
+
 	tests := []struct {
 		name           string
 		ipHeader       string
 		expectedStatus int
 		config         *config.Config
 	}{
-		{"Allowed IP", "192.168.1.100", http.StatusOK, &config.Config{TrustedSubnet: "192.168.1.0/24"}},
-		{"Forbidden IP", "10.0.0.1", http.StatusForbidden, &config.Config{TrustedSubnet: "192.168.1.0/24"}},
-		{"Missing IP Header", "", http.StatusForbidden, &config.Config{TrustedSubnet: "192.168.1.0/24"}},
+		{"Allowed IP", "192.168.1.100", http.StatusOK, proxied("192.168.1.0/24")},
+		{"Forbidden IP", "10.0.0.1", http.StatusForbidden, proxied("192.168.1.0/24")},
+		{"Missing IP Header", "", http.StatusForbidden, proxied("192.168.1.0/24")},
 		{"Broken subnet", "192.168.1.100", http.StatusInternalServerError, &config.Config{TrustedSubnet: "111"}},
 		{"No subnet", "192.168.1.100", http.StatusOK, &config.Config{TrustedSubnet: ""}},
+		{"Untrusted proxy", "192.168.1.100", http.StatusForbidden, &config.Config{TrustedSubnet: "192.168.1.0/24"}},
+		{"Legacy subnet", "192.168.1.100", http.StatusOK, &config.Config{
+			TrustedSubnet:  "192.168.1.0/24",
+			TrustedProxies: []string{testProxy},
+			ClientIPHeader: config.ClientIPHeaderXRealIP,
+		}},
+		{"Second subnet", "10.1.2.3", http.StatusOK, proxied("192.168.1.0/24", "10.0.0.0/8")},
+		{"IPv6 subnet", "2001:db8::1", http.StatusOK, proxied("192.168.1.0/24", "2001:db8::/32")},
+		{"IPv6 forbidden", "2001:db9::1", http.StatusForbidden, proxied("2001:db8::/32")},
+		{"Denied IP", "10.0.0.1", http.StatusForbidden, &config.Config{
+			TrustedSubnets: []string{"10.0.0.0/8"},
+			DeniedSubnets:  []string{"10.0.0.0/24"},
+			TrustedProxies: []string{testProxy},
+			ClientIPHeader: config.ClientIPHeaderXRealIP,
+		}},
+		{"Deny only", "10.1.0.1", http.StatusOK, &config.Config{
+			DeniedSubnets:  []string{"10.0.0.0/24"},
+			TrustedProxies: []string{testProxy},
+			ClientIPHeader: config.ClientIPHeaderXRealIP,
+		}},
+		{"Remote address", "", http.StatusOK, &config.Config{TrustedSubnets: []string{testProxy}}},
 	}
 
 	for _, tt := range tests {
@@ -54,3 +94,128 @@ func TestSubnetMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestClientIPResolver(t *testing.T) {
+	t.Parallel()
+
+	proxies := []string{testProxy, "10.0.0.0/8", "2001:db8::/32"}
+
+	tests := []struct {
+		name       string
+		header     string
+		remoteAddr string
+		headers    map[string][]string
+		expected   string
+	}{
+		{"Direct client", config.ClientIPHeaderXForwardedFor, "203.0.113.5:1234", nil, "203.0.113.5"},
+		{"Direct client ignores headers", config.ClientIPHeaderXForwardedFor, "203.0.113.5:1234", map[string][]string{
+			"X-Forwarded-For": {"198.51.100.1"},
+		}, "203.0.113.5"},
+		{"Proxy without headers", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", nil, testProxy},
+		{"No header", "", testProxy + ":1234", map[string][]string{
+			"X-Forwarded-For": {"198.51.100.1"},
+		}, testProxy},
+		{"X-Real-IP", config.ClientIPHeaderXRealIP, testProxy + ":1234", map[string][]string{
+			"X-Real-IP": {"198.51.100.1"},
+		}, "198.51.100.1"},
+		{"X-Real-IP spoofed", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", map[string][]string{
+			"X-Real-IP": {"10.0.0.1"},
+		}, testProxy},
+		{"X-Forwarded-For right to left", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", map[string][]string{
+			"X-Forwarded-For": {"6.6.6.6, 198.51.100.1, 10.0.0.2"},
+		}, "198.51.100.1"},
+		{"X-Forwarded-For several headers", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", map[string][]string{
+			"X-Forwarded-For": {"6.6.6.6, 198.51.100.1", "10.0.0.2"},
+		}, "198.51.100.1"},
+		{"X-Forwarded-For only proxies", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", map[string][]string{
+			"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"},
+		}, "10.0.0.3"},
+		{"X-Forwarded-For garbage", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", map[string][]string{
+			"X-Forwarded-For": {"198.51.100.1, garbage"},
+		}, "<nil>"},
+		{"Forwarded spoofed", config.ClientIPHeaderXForwardedFor, testProxy + ":1234", map[string][]string{
+			"Forwarded":       {`for=10.0.0.1`},
+			"X-Forwarded-For": {"198.51.100.1"},
+		}, "198.51.100.1"},
+		{"Forwarded", config.ClientIPHeaderForwarded, testProxy + ":1234", map[string][]string{
+			"Forwarded":       {`for=198.51.100.1;proto=https, for="[2001:db8::1]:4711"`},
+			"X-Forwarded-For": {"6.6.6.6"},
+		}, "198.51.100.1"},
+		{"Forwarded IPv6", config.ClientIPHeaderForwarded, testProxy + ":1234", map[string][]string{
+			"Forwarded": {`for="[2001:db9::1]:4711"`},
+		}, "2001:db9::1"},
+		{"Forwarded obfuscated", config.ClientIPHeaderForwarded, testProxy + ":1234", map[string][]string{
+			"Forwarded": {`for=198.51.100.1, for=_hidden`},
+		}, "<nil>"},
+		{"Forwarded without for", config.ClientIPHeaderForwarded, testProxy + ":1234", map[string][]string{
+			"Forwarded": {`proto=https`},
+		}, "<nil>"},
+		{"IPv6 proxy", config.ClientIPHeaderXForwardedFor, "[2001:db8::2]:1234", map[string][]string{
+			"X-Forwarded-For": {"::ffff:198.51.100.1"},
+		}, "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver, err := middleware.NewClientIPResolver(tt.header, proxies...)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			req.RemoteAddr = tt.remoteAddr
+
+			for name, values := range tt.headers {
+				for _, value := range values {
+					req.Header.Add(name, value)
+				}
+			}
+
+			assert.Equal(t, tt.expected, resolver.ClientIP(req).String())
+		})
+	}
+
+	_, err := middleware.NewClientIPResolver("X-Client-IP", proxies...)
+	require.ErrorIs(t, err, e.ErrInvalidConfig)
+}
+
+func TestSubnetInterceptor(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+	cfg := &config.Config{TrustedSubnets: []string{"10.0.0.0/8", "2001:db8::/32"}, DeniedSubnets: []string{"10.0.0.1"}}
+	filter := func(method string) bool { return method == "/internal" }
+	handler := func(_ context.Context, _ any) (any, error) { return "ok", nil }
+
+	tests := []struct {
+		name     string
+		method   string
+		addr     net.Addr
+		config   *config.Config
+		expected codes.Code
+	}{
+		{"Allowed", "/internal", &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 1}, cfg, codes.OK},
+		{"Allowed IPv6", "/internal", &net.TCPAddr{IP: net.ParseIP("2001:db8::5"), Port: 1}, cfg, codes.OK},
+		{"Forbidden", "/internal", &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1}, cfg, codes.PermissionDenied},
+		{"Denied", "/internal", &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1}, cfg, codes.PermissionDenied},
+		{"No peer", "/internal", nil, cfg, codes.PermissionDenied},
+		{"Not filtered", "/public", &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1}, cfg, codes.OK},
+		{"No subnets", "/internal", &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1}, &config.Config{}, codes.OK},
+		{"Broken subnet", "/internal", nil, &config.Config{TrustedSubnet: "111"}, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.addr != nil {
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: tt.addr})
+			}
+
+			interceptor := middleware.SubnetInterceptor(log, tt.config, filter)
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.expected, status.Code(err))
+		})
+	}
+}
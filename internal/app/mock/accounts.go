@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/accounts/accounts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/service/accounts/accounts.go -destination=internal/app/mock/accounts.go -package=mock Accounts
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	dto "github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+)
+
+// MockAccounts is a mock of Accounts interface.
+type MockAccounts struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountsMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountsMockRecorder is the mock recorder for MockAccounts.
+type MockAccountsMockRecorder struct {
+	mock *MockAccounts
+}
+
+// NewMockAccounts creates a new mock instance.
+func NewMockAccounts(ctrl *gomock.Controller) *MockAccounts {
+	mock := &MockAccounts{ctrl: ctrl}
+	mock.recorder = &MockAccountsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounts) EXPECT() *MockAccountsMockRecorder {
+	return m.recorder
+}
+
+// ClaimURLs mocks base method.
+func (m *MockAccounts) ClaimURLs(ctx context.Context, anonymous, user domain.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimURLs", ctx, anonymous, user)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimURLs indicates an expected call of ClaimURLs.
+func (mr *MockAccountsMockRecorder) ClaimURLs(ctx, anonymous, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimURLs", reflect.TypeOf((*MockAccounts)(nil).ClaimURLs), ctx, anonymous, user)
+}
+
+// Login mocks base method.
+func (m *MockAccounts) Login(ctx context.Context, credentials *dto.Credentials) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, credentials)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAccountsMockRecorder) Login(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAccounts)(nil).Login), ctx, credentials)
+}
+
+// Register mocks base method.
+func (m *MockAccounts) Register(ctx context.Context, credentials *dto.Credentials) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, credentials)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockAccountsMockRecorder) Register(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAccounts)(nil).Register), ctx, credentials)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/middleware/apikey.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/middleware/apikey.go -destination=internal/app/mock/apikey.go -package=mock APIKeyResolver
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// MockAPIKeyResolver is a mock of APIKeyResolver interface.
+type MockAPIKeyResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyResolverMockRecorder is the mock recorder for MockAPIKeyResolver.
+type MockAPIKeyResolverMockRecorder struct {
+	mock *MockAPIKeyResolver
+}
+
+// NewMockAPIKeyResolver creates a new mock instance.
+func NewMockAPIKeyResolver(ctrl *gomock.Controller) *MockAPIKeyResolver {
+	mock := &MockAPIKeyResolver{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyResolver) EXPECT() *MockAPIKeyResolverMockRecorder {
+	return m.recorder
+}
+
+// ResolveAPIKey mocks base method.
+func (m *MockAPIKeyResolver) ResolveAPIKey(ctx context.Context, token string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAPIKey", ctx, token)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveAPIKey indicates an expected call of ResolveAPIKey.
+func (mr *MockAPIKeyResolverMockRecorder) ResolveAPIKey(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAPIKey", reflect.TypeOf((*MockAPIKeyResolver)(nil).ResolveAPIKey), ctx, token)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/apikeys/apikeys.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/service/apikeys/apikeys.go -destination=internal/app/mock/apikeys.go -package=mock APIKeys
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// MockAPIKeys is a mock of APIKeys interface.
+type MockAPIKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeysMockRecorder is the mock recorder for MockAPIKeys.
+type MockAPIKeysMockRecorder struct {
+	mock *MockAPIKeys
+}
+
+// NewMockAPIKeys creates a new mock instance.
+func NewMockAPIKeys(ctrl *gomock.Controller) *MockAPIKeys {
+	mock := &MockAPIKeys{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeys) EXPECT() *MockAPIKeysMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeys) CreateAPIKey(ctx context.Context, name string, scopes domain.APIKeyScopes) (*domain.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, name, scopes)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeysMockRecorder) CreateAPIKey(ctx, name, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeys)(nil).CreateAPIKey), ctx, name, scopes)
+}
+
+// GetUserAPIKeys mocks base method.
+func (m *MockAPIKeys) GetUserAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAPIKeys", ctx)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAPIKeys indicates an expected call of GetUserAPIKeys.
+func (mr *MockAPIKeysMockRecorder) GetUserAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAPIKeys", reflect.TypeOf((*MockAPIKeys)(nil).GetUserAPIKeys), ctx)
+}
+
+// ResolveAPIKey mocks base method.
+func (m *MockAPIKeys) ResolveAPIKey(ctx context.Context, token string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAPIKey", ctx, token)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveAPIKey indicates an expected call of ResolveAPIKey.
+func (mr *MockAPIKeysMockRecorder) ResolveAPIKey(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAPIKey", reflect.TypeOf((*MockAPIKeys)(nil).ResolveAPIKey), ctx, token)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeys) RevokeAPIKey(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeysMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeys)(nil).RevokeAPIKey), ctx, id)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/moderation/moderation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/service/moderation/moderation.go -destination=internal/app/mock/moderation.go -package=mock Moderator
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// MockModerator is a mock of Moderator interface.
+type MockModerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockModeratorMockRecorder
+	isgomock struct{}
+}
+
+// MockModeratorMockRecorder is the mock recorder for MockModerator.
+type MockModeratorMockRecorder struct {
+	mock *MockModerator
+}
+
+// NewMockModerator creates a new mock instance.
+func NewMockModerator(ctrl *gomock.Controller) *MockModerator {
+	mock := &MockModerator{ctrl: ctrl}
+	mock.recorder = &MockModeratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerator) EXPECT() *MockModeratorMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockModerator) BanUser(ctx context.Context, user domain.UserID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, user, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockModeratorMockRecorder) BanUser(ctx, user, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockModerator)(nil).BanUser), ctx, user, reason)
+}
+
+// DisableURL mocks base method.
+func (m *MockModerator) DisableURL(ctx context.Context, slug domain.Slug, reason string) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableURL", ctx, slug, reason)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableURL indicates an expected call of DisableURL.
+func (mr *MockModeratorMockRecorder) DisableURL(ctx, slug, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableURL", reflect.TypeOf((*MockModerator)(nil).DisableURL), ctx, slug, reason)
+}
+
+// EnableURL mocks base method.
+func (m *MockModerator) EnableURL(ctx context.Context, slug domain.Slug, reason string) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableURL", ctx, slug, reason)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableURL indicates an expected call of EnableURL.
+func (mr *MockModeratorMockRecorder) EnableURL(ctx, slug, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableURL", reflect.TypeOf((*MockModerator)(nil).EnableURL), ctx, slug, reason)
+}
+
+// GetModerationLog mocks base method.
+func (m *MockModerator) GetModerationLog(ctx context.Context, limit int) ([]domain.ModerationEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationLog", ctx, limit)
+	ret0, _ := ret[0].([]domain.ModerationEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationLog indicates an expected call of GetModerationLog.
+func (mr *MockModeratorMockRecorder) GetModerationLog(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationLog", reflect.TypeOf((*MockModerator)(nil).GetModerationLog), ctx, limit)
+}
+
+// GetURL mocks base method.
+func (m *MockModerator) GetURL(ctx context.Context, slug domain.Slug) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURL", ctx, slug)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURL indicates an expected call of GetURL.
+func (mr *MockModeratorMockRecorder) GetURL(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*MockModerator)(nil).GetURL), ctx, slug)
+}
+
+// GetUserURLs mocks base method.
+func (m *MockModerator) GetUserURLs(ctx context.Context, user domain.UserID) ([]domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserURLs", ctx, user)
+	ret0, _ := ret[0].([]domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserURLs indicates an expected call of GetUserURLs.
+func (mr *MockModeratorMockRecorder) GetUserURLs(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserURLs", reflect.TypeOf((*MockModerator)(nil).GetUserURLs), ctx, user)
+}
+
+// UnbanUser mocks base method.
+func (m *MockModerator) UnbanUser(ctx context.Context, user domain.UserID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanUser", ctx, user, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanUser indicates an expected call of UnbanUser.
+func (mr *MockModeratorMockRecorder) UnbanUser(ctx, user, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanUser", reflect.TypeOf((*MockModerator)(nil).UnbanUser), ctx, user, reason)
+}
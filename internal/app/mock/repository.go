@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 
@@ -89,11 +90,12 @@ func (mr *MockURLRepositoryMockRecorder) CreateMemento() *gomock.Call {
 }
 
 // DelUserURLMappings mocks base method.
-func (m *MockURLRepository) DelUserURLMappings(ctx context.Context, tasks []dto.UserSlug) error {
+func (m *MockURLRepository) DelUserURLMappings(ctx context.Context, tasks []dto.UserSlug) ([]dto.UserSlug, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DelUserURLMappings", ctx, tasks)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]dto.UserSlug)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DelUserURLMappings indicates an expected call of DelUserURLMappings.
@@ -103,18 +105,18 @@ func (mr *MockURLRepositoryMockRecorder) DelUserURLMappings(ctx, tasks any) *gom
 }
 
 // GetStats mocks base method.
-func (m *MockURLRepository) GetStats(ctx context.Context) (*dto.RepoStats, error) {
+func (m *MockURLRepository) GetStats(ctx context.Context, params dto.StatsParams) (*dto.RepoStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStats", ctx)
+	ret := m.ctrl.Call(m, "GetStats", ctx, params)
 	ret0, _ := ret[0].(*dto.RepoStats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStats indicates an expected call of GetStats.
-func (mr *MockURLRepositoryMockRecorder) GetStats(ctx any) *gomock.Call {
+func (mr *MockURLRepositoryMockRecorder) GetStats(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockURLRepository)(nil).GetStats), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockURLRepository)(nil).GetStats), ctx, params)
 }
 
 // GetURLMapping mocks base method.
@@ -147,6 +149,36 @@ func (mr *MockURLRepositoryMockRecorder) GetUserURLMappings(ctx, user any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserURLMappings", reflect.TypeOf((*MockURLRepository)(nil).GetUserURLMappings), ctx, user)
 }
 
+// ReassignUserURLMappings mocks base method.
+func (m *MockURLRepository) ReassignUserURLMappings(ctx context.Context, from, to domain.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignUserURLMappings", ctx, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignUserURLMappings indicates an expected call of ReassignUserURLMappings.
+func (mr *MockURLRepositoryMockRecorder) ReassignUserURLMappings(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignUserURLMappings", reflect.TypeOf((*MockURLRepository)(nil).ReassignUserURLMappings), ctx, from, to)
+}
+
+// RegisterClick mocks base method.
+func (m *MockURLRepository) RegisterClick(ctx context.Context, slug domain.Slug, variant int) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClick", ctx, slug, variant)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterClick indicates an expected call of RegisterClick.
+func (mr *MockURLRepositoryMockRecorder) RegisterClick(ctx, slug, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClick", reflect.TypeOf((*MockURLRepository)(nil).RegisterClick), ctx, slug, variant)
+}
+
 // RestoreMemento mocks base method.
 func (m_2 *MockURLRepository) RestoreMemento(m *memento.Memento) error {
 	m_2.ctrl.T.Helper()
@@ -160,3 +192,666 @@ func (mr *MockURLRepositoryMockRecorder) RestoreMemento(m any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMemento", reflect.TypeOf((*MockURLRepository)(nil).RestoreMemento), m)
 }
+
+// UpdateURLMappingRules mocks base method.
+func (m *MockURLRepository) UpdateURLMappingRules(ctx context.Context, owner dto.UserSlug, rules domain.RedirectRules) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURLMappingRules", ctx, owner, rules)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateURLMappingRules indicates an expected call of UpdateURLMappingRules.
+func (mr *MockURLRepositoryMockRecorder) UpdateURLMappingRules(ctx, owner, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURLMappingRules", reflect.TypeOf((*MockURLRepository)(nil).UpdateURLMappingRules), ctx, owner, rules)
+}
+
+// UpdateURLMappingSchedule mocks base method.
+func (m *MockURLRepository) UpdateURLMappingSchedule(ctx context.Context, owner dto.UserSlug, schedule *dto.URLSchedule) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURLMappingSchedule", ctx, owner, schedule)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateURLMappingSchedule indicates an expected call of UpdateURLMappingSchedule.
+func (mr *MockURLRepositoryMockRecorder) UpdateURLMappingSchedule(ctx, owner, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURLMappingSchedule", reflect.TypeOf((*MockURLRepository)(nil).UpdateURLMappingSchedule), ctx, owner, schedule)
+}
+
+// MockModerationRepository is a mock of ModerationRepository interface.
+type MockModerationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockModerationRepositoryMockRecorder is the mock recorder for MockModerationRepository.
+type MockModerationRepositoryMockRecorder struct {
+	mock *MockModerationRepository
+}
+
+// NewMockModerationRepository creates a new mock instance.
+func NewMockModerationRepository(ctrl *gomock.Controller) *MockModerationRepository {
+	mock := &MockModerationRepository{ctrl: ctrl}
+	mock.recorder = &MockModerationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationRepository) EXPECT() *MockModerationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetModerationLog mocks base method.
+func (m *MockModerationRepository) GetModerationLog(ctx context.Context, limit int) ([]domain.ModerationEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationLog", ctx, limit)
+	ret0, _ := ret[0].([]domain.ModerationEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationLog indicates an expected call of GetModerationLog.
+func (mr *MockModerationRepositoryMockRecorder) GetModerationLog(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationLog", reflect.TypeOf((*MockModerationRepository)(nil).GetModerationLog), ctx, limit)
+}
+
+// IsUserBanned mocks base method.
+func (m *MockModerationRepository) IsUserBanned(ctx context.Context, user domain.UserID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserBanned", ctx, user)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserBanned indicates an expected call of IsUserBanned.
+func (mr *MockModerationRepositoryMockRecorder) IsUserBanned(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBanned", reflect.TypeOf((*MockModerationRepository)(nil).IsUserBanned), ctx, user)
+}
+
+// ModerateURLMapping mocks base method.
+func (m *MockModerationRepository) ModerateURLMapping(ctx context.Context, entry *domain.ModerationEntry) (*domain.URLMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateURLMapping", ctx, entry)
+	ret0, _ := ret[0].(*domain.URLMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateURLMapping indicates an expected call of ModerateURLMapping.
+func (mr *MockModerationRepositoryMockRecorder) ModerateURLMapping(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateURLMapping", reflect.TypeOf((*MockModerationRepository)(nil).ModerateURLMapping), ctx, entry)
+}
+
+// ModerateUser mocks base method.
+func (m *MockModerationRepository) ModerateUser(ctx context.Context, entry *domain.ModerationEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateUser", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModerateUser indicates an expected call of ModerateUser.
+func (mr *MockModerationRepositoryMockRecorder) ModerateUser(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateUser", reflect.TypeOf((*MockModerationRepository)(nil).ModerateUser), ctx, entry)
+}
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEvents mocks base method.
+func (m *MockAuditRepository) AddAuditEvents(ctx context.Context, events []domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuditEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuditEvents indicates an expected call of AddAuditEvents.
+func (mr *MockAuditRepositoryMockRecorder) AddAuditEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEvents", reflect.TypeOf((*MockAuditRepository)(nil).AddAuditEvents), ctx, events)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockAuditRepository) GetAuditEvents(ctx context.Context, filter dto.AuditFilter) ([]domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, filter)
+	ret0, _ := ret[0].([]domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockAuditRepositoryMockRecorder) GetAuditEvents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockAuditRepository)(nil).GetAuditEvents), ctx, filter)
+}
+
+// MockUserRepository is a mock of UserRepository interface.
+type MockUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserRepositoryMockRecorder is the mock recorder for MockUserRepository.
+type MockUserRepositoryMockRecorder struct {
+	mock *MockUserRepository
+}
+
+// NewMockUserRepository creates a new mock instance.
+func NewMockUserRepository(ctrl *gomock.Controller) *MockUserRepository {
+	mock := &MockUserRepository{ctrl: ctrl}
+	mock.recorder = &MockUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddUser mocks base method.
+func (m *MockUserRepository) AddUser(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockUserRepositoryMockRecorder) AddUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepository)(nil).AddUser), ctx, user)
+}
+
+// CreateMemento mocks base method.
+func (m *MockUserRepository) CreateMemento() (*memento.Memento, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMemento")
+	ret0, _ := ret[0].(*memento.Memento)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMemento indicates an expected call of CreateMemento.
+func (mr *MockUserRepositoryMockRecorder) CreateMemento() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMemento", reflect.TypeOf((*MockUserRepository)(nil).CreateMemento))
+}
+
+// GetUser mocks base method.
+func (m *MockUserRepository) GetUser(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserRepositoryMockRecorder) GetUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserRepository)(nil).GetUser), ctx, id)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockUserRepositoryMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetUserByEmail), ctx, email)
+}
+
+// RestoreMemento mocks base method.
+func (m_2 *MockUserRepository) RestoreMemento(m *memento.Memento) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreMemento", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMemento indicates an expected call of RestoreMemento.
+func (mr *MockUserRepositoryMockRecorder) RestoreMemento(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMemento", reflect.TypeOf((*MockUserRepository)(nil).RestoreMemento), m)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAPIKey mocks base method.
+func (m *MockAPIKeyRepository) AddAPIKey(ctx context.Context, key *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAPIKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAPIKey indicates an expected call of AddAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) AddAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).AddAPIKey), ctx, key)
+}
+
+// CreateMemento mocks base method.
+func (m *MockAPIKeyRepository) CreateMemento() (*memento.Memento, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMemento")
+	ret0, _ := ret[0].(*memento.Memento)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMemento indicates an expected call of CreateMemento.
+func (mr *MockAPIKeyRepositoryMockRecorder) CreateMemento() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMemento", reflect.TypeOf((*MockAPIKeyRepository)(nil).CreateMemento))
+}
+
+// GetAPIKey mocks base method.
+func (m *MockAPIKeyRepository) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, id)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAPIKey), ctx, id)
+}
+
+// GetUserAPIKeys mocks base method.
+func (m *MockAPIKeyRepository) GetUserAPIKeys(ctx context.Context, user domain.UserID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAPIKeys", ctx, user)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAPIKeys indicates an expected call of GetUserAPIKeys.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetUserAPIKeys(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAPIKeys", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetUserAPIKeys), ctx, user)
+}
+
+// RestoreMemento mocks base method.
+func (m_2 *MockAPIKeyRepository) RestoreMemento(m *memento.Memento) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreMemento", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMemento indicates an expected call of RestoreMemento.
+func (mr *MockAPIKeyRepositoryMockRecorder) RestoreMemento(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMemento", reflect.TypeOf((*MockAPIKeyRepository)(nil).RestoreMemento), m)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, user domain.UserID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) RevokeAPIKey(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).RevokeAPIKey), ctx, user, id)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *MockWebhookRepository) AddWebhook(ctx context.Context, hook *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", ctx, hook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) AddWebhook(ctx, hook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).AddWebhook), ctx, hook)
+}
+
+// AddWebhookDeliveries mocks base method.
+func (m *MockWebhookRepository) AddWebhookDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhookDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWebhookDeliveries indicates an expected call of AddWebhookDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) AddWebhookDeliveries(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhookDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).AddWebhookDeliveries), ctx, deliveries)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimWebhookDeliveries(ctx context.Context, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, lease, limit)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimWebhookDeliveries(ctx, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimWebhookDeliveries), ctx, lease, limit)
+}
+
+// CreateMemento mocks base method.
+func (m *MockWebhookRepository) CreateMemento() (*memento.Memento, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMemento")
+	ret0, _ := ret[0].(*memento.Memento)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMemento indicates an expected call of CreateMemento.
+func (mr *MockWebhookRepositoryMockRecorder) CreateMemento() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMemento", reflect.TypeOf((*MockWebhookRepository)(nil).CreateMemento))
+}
+
+// DelWebhook mocks base method.
+func (m *MockWebhookRepository) DelWebhook(ctx context.Context, user domain.UserID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelWebhook", ctx, user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelWebhook indicates an expected call of DelWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DelWebhook(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DelWebhook), ctx, user, id)
+}
+
+// GetUserWebhooks mocks base method.
+func (m *MockWebhookRepository) GetUserWebhooks(ctx context.Context, user domain.UserID) ([]domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWebhooks", ctx, user)
+	ret0, _ := ret[0].([]domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWebhooks indicates an expected call of GetUserWebhooks.
+func (mr *MockWebhookRepositoryMockRecorder) GetUserWebhooks(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWebhooks", reflect.TypeOf((*MockWebhookRepository)(nil).GetUserWebhooks), ctx, user)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockWebhookRepository) GetWebhookDeliveries(ctx context.Context, hook *domain.Webhook, status domain.WebhookDeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, hook, status, limit)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookDeliveries(ctx, hook, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookDeliveries), ctx, hook, status, limit)
+}
+
+// RestoreMemento mocks base method.
+func (m_2 *MockWebhookRepository) RestoreMemento(m *memento.Memento) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreMemento", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMemento indicates an expected call of RestoreMemento.
+func (mr *MockWebhookRepositoryMockRecorder) RestoreMemento(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMemento", reflect.TypeOf((*MockWebhookRepository)(nil).RestoreMemento), m)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockWebhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateWebhookDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateWebhookDelivery), ctx, delivery)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimOutboxEvents mocks base method.
+func (m *MockOutboxRepository) ClaimOutboxEvents(ctx context.Context, lease time.Duration, limit int) ([]domain.URLMappingEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxEvents", ctx, lease, limit)
+	ret0, _ := ret[0].([]domain.URLMappingEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxEvents indicates an expected call of ClaimOutboxEvents.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimOutboxEvents(ctx, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxEvents", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimOutboxEvents), ctx, lease, limit)
+}
+
+// DelOutboxEvents mocks base method.
+func (m *MockOutboxRepository) DelOutboxEvents(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelOutboxEvents", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelOutboxEvents indicates an expected call of DelOutboxEvents.
+func (mr *MockOutboxRepositoryMockRecorder) DelOutboxEvents(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelOutboxEvents", reflect.TypeOf((*MockOutboxRepository)(nil).DelOutboxEvents), ctx, ids)
+}
+
+// MockTokenRepository is a mock of TokenRepository interface.
+type MockTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRepositoryMockRecorder is the mock recorder for MockTokenRepository.
+type MockTokenRepositoryMockRecorder struct {
+	mock *MockTokenRepository
+}
+
+// NewMockTokenRepository creates a new mock instance.
+func NewMockTokenRepository(ctrl *gomock.Controller) *MockTokenRepository {
+	mock := &MockTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMemento mocks base method.
+func (m *MockTokenRepository) CreateMemento() (*memento.Memento, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMemento")
+	ret0, _ := ret[0].(*memento.Memento)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMemento indicates an expected call of CreateMemento.
+func (mr *MockTokenRepositoryMockRecorder) CreateMemento() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMemento", reflect.TypeOf((*MockTokenRepository)(nil).CreateMemento))
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockTokenRepositoryMockRecorder) IsTokenRevoked(ctx, jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockTokenRepository)(nil).IsTokenRevoked), ctx, jti)
+}
+
+// RestoreMemento mocks base method.
+func (m_2 *MockTokenRepository) RestoreMemento(m *memento.Memento) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreMemento", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMemento indicates an expected call of RestoreMemento.
+func (mr *MockTokenRepositoryMockRecorder) RestoreMemento(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMemento", reflect.TypeOf((*MockTokenRepository)(nil).RestoreMemento), m)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, jti, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRepositoryMockRecorder) RevokeToken(ctx, jti, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRepository)(nil).RevokeToken), ctx, jti, expiresAt)
+}
@@ -43,6 +43,49 @@ func (m *MockURLShortener) EXPECT() *MockURLShortenerMockRecorder {
 	return m.recorder
 }
 
+// AddURLRule mocks base method.
+func (m *MockURLShortener) AddURLRule(ctx context.Context, slug domain.Slug, rule *domain.RedirectRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddURLRule", ctx, slug, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddURLRule indicates an expected call of AddURLRule.
+func (mr *MockURLShortenerMockRecorder) AddURLRule(ctx, slug, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddURLRule", reflect.TypeOf((*MockURLShortener)(nil).AddURLRule), ctx, slug, rule)
+}
+
+// DeleteURLRule mocks base method.
+func (m *MockURLShortener) DeleteURLRule(ctx context.Context, slug domain.Slug, index int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteURLRule", ctx, slug, index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteURLRule indicates an expected call of DeleteURLRule.
+func (mr *MockURLShortenerMockRecorder) DeleteURLRule(ctx, slug, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLRule", reflect.TypeOf((*MockURLShortener)(nil).DeleteURLRule), ctx, slug, index)
+}
+
+// FollowURL mocks base method.
+func (m *MockURLShortener) FollowURL(ctx context.Context, visit *dto.Visit) (*dto.Redirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowURL", ctx, visit)
+	ret0, _ := ret[0].(*dto.Redirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowURL indicates an expected call of FollowURL.
+func (mr *MockURLShortenerMockRecorder) FollowURL(ctx, visit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowURL", reflect.TypeOf((*MockURLShortener)(nil).FollowURL), ctx, visit)
+}
+
 // GetOriginalURL mocks base method.
 func (m *MockURLShortener) GetOriginalURL(ctx context.Context, slug domain.Slug) (domain.OriginalURL, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +101,36 @@ func (mr *MockURLShortenerMockRecorder) GetOriginalURL(ctx, slug any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalURL", reflect.TypeOf((*MockURLShortener)(nil).GetOriginalURL), ctx, slug)
 }
 
+// GetURLInfo mocks base method.
+func (m *MockURLShortener) GetURLInfo(ctx context.Context, slug domain.Slug) (*dto.URLInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLInfo", ctx, slug)
+	ret0, _ := ret[0].(*dto.URLInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLInfo indicates an expected call of GetURLInfo.
+func (mr *MockURLShortenerMockRecorder) GetURLInfo(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLInfo", reflect.TypeOf((*MockURLShortener)(nil).GetURLInfo), ctx, slug)
+}
+
+// GetURLRules mocks base method.
+func (m *MockURLShortener) GetURLRules(ctx context.Context, slug domain.Slug) (domain.RedirectRules, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLRules", ctx, slug)
+	ret0, _ := ret[0].(domain.RedirectRules)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLRules indicates an expected call of GetURLRules.
+func (mr *MockURLShortenerMockRecorder) GetURLRules(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLRules", reflect.TypeOf((*MockURLShortener)(nil).GetURLRules), ctx, slug)
+}
+
 // GetUserURLs mocks base method.
 func (m *MockURLShortener) GetUserURLs(ctx context.Context) (*dto.URLPairBatch, error) {
 	m.ctrl.T.Helper()
@@ -73,19 +146,52 @@ func (mr *MockURLShortenerMockRecorder) GetUserURLs(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserURLs", reflect.TypeOf((*MockURLShortener)(nil).GetUserURLs), ctx)
 }
 
+// ScheduleURL mocks base method.
+func (m *MockURLShortener) ScheduleURL(ctx context.Context, slug domain.Slug, schedule *dto.URLSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleURL", ctx, slug, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleURL indicates an expected call of ScheduleURL.
+func (mr *MockURLShortenerMockRecorder) ScheduleURL(ctx, slug, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleURL", reflect.TypeOf((*MockURLShortener)(nil).ScheduleURL), ctx, slug, schedule)
+}
+
+// SetURLRules mocks base method.
+func (m *MockURLShortener) SetURLRules(ctx context.Context, slug domain.Slug, rules domain.RedirectRules) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetURLRules", ctx, slug, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetURLRules indicates an expected call of SetURLRules.
+func (mr *MockURLShortenerMockRecorder) SetURLRules(ctx, slug, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetURLRules", reflect.TypeOf((*MockURLShortener)(nil).SetURLRules), ctx, slug, rules)
+}
+
 // ShortenURL mocks base method.
-func (m *MockURLShortener) ShortenURL(ctx context.Context, original domain.OriginalURL) (domain.Slug, error) {
+func (m *MockURLShortener) ShortenURL(ctx context.Context, original domain.OriginalURL, opts ...domain.URLMappingOption) (domain.Slug, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShortenURL", ctx, original)
+	varargs := []any{ctx, original}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ShortenURL", varargs...)
 	ret0, _ := ret[0].(domain.Slug)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ShortenURL indicates an expected call of ShortenURL.
-func (mr *MockURLShortenerMockRecorder) ShortenURL(ctx, original any) *gomock.Call {
+func (mr *MockURLShortenerMockRecorder) ShortenURL(ctx, original any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortenURL", reflect.TypeOf((*MockURLShortener)(nil).ShortenURL), ctx, original)
+	varargs := append([]any{ctx, original}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortenURL", reflect.TypeOf((*MockURLShortener)(nil).ShortenURL), varargs...)
 }
 
 // ShortenURLBatch mocks base method.
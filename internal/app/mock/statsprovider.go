@@ -43,16 +43,16 @@ func (m *MockStatsProvider) EXPECT() *MockStatsProviderMockRecorder {
 }
 
 // GetStats mocks base method.
-func (m *MockStatsProvider) GetStats(ctx context.Context) (*dto.RepoStats, error) {
+func (m *MockStatsProvider) GetStats(ctx context.Context, params dto.StatsParams) (*dto.RepoStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStats", ctx)
+	ret := m.ctrl.Call(m, "GetStats", ctx, params)
 	ret0, _ := ret[0].(*dto.RepoStats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStats indicates an expected call of GetStats.
-func (mr *MockStatsProviderMockRecorder) GetStats(ctx any) *gomock.Call {
+func (mr *MockStatsProviderMockRecorder) GetStats(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockStatsProvider)(nil).GetStats), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockStatsProvider)(nil).GetStats), ctx, params)
 }
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/urlpolicy/urlpolicy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/service/urlpolicy/urlpolicy.go -destination=internal/app/mock/urlpolicy.go -package=mock URLPolicy
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// MockURLPolicy is a mock of URLPolicy interface.
+type MockURLPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockURLPolicyMockRecorder is the mock recorder for MockURLPolicy.
+type MockURLPolicyMockRecorder struct {
+	mock *MockURLPolicy
+}
+
+// NewMockURLPolicy creates a new mock instance.
+func NewMockURLPolicy(ctrl *gomock.Controller) *MockURLPolicy {
+	mock := &MockURLPolicy{ctrl: ctrl}
+	mock.recorder = &MockURLPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLPolicy) EXPECT() *MockURLPolicyMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockURLPolicy) Check(ctx context.Context, original domain.OriginalURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, original)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockURLPolicyMockRecorder) Check(ctx, original any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockURLPolicy)(nil).Check), ctx, original)
+}
@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/webhooks/webhooks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/service/webhooks/webhooks.go -destination=internal/app/mock/webhooks.go -package=mock Webhooks,Notifier
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// MockWebhooks is a mock of Webhooks interface.
+type MockWebhooks struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhooksMockRecorder is the mock recorder for MockWebhooks.
+type MockWebhooksMockRecorder struct {
+	mock *MockWebhooks
+}
+
+// NewMockWebhooks creates a new mock instance.
+func NewMockWebhooks(ctrl *gomock.Controller) *MockWebhooks {
+	mock := &MockWebhooks{ctrl: ctrl}
+	mock.recorder = &MockWebhooksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooks) EXPECT() *MockWebhooksMockRecorder {
+	return m.recorder
+}
+
+// GetDeadDeliveries mocks base method.
+func (m *MockWebhooks) GetDeadDeliveries(ctx context.Context, id string) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadDeliveries", ctx, id)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadDeliveries indicates an expected call of GetDeadDeliveries.
+func (mr *MockWebhooksMockRecorder) GetDeadDeliveries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadDeliveries", reflect.TypeOf((*MockWebhooks)(nil).GetDeadDeliveries), ctx, id)
+}
+
+// GetUserWebhooks mocks base method.
+func (m *MockWebhooks) GetUserWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWebhooks", ctx)
+	ret0, _ := ret[0].([]domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWebhooks indicates an expected call of GetUserWebhooks.
+func (mr *MockWebhooksMockRecorder) GetUserWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWebhooks", reflect.TypeOf((*MockWebhooks)(nil).GetUserWebhooks), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockWebhooks) Subscribe(ctx context.Context, target domain.OriginalURL, secret string) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, target, secret)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockWebhooksMockRecorder) Subscribe(ctx, target, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockWebhooks)(nil).Subscribe), ctx, target, secret)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockWebhooks) Unsubscribe(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockWebhooksMockRecorder) Unsubscribe(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockWebhooks)(nil).Unsubscribe), ctx, id)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(ctx context.Context, events ...domain.WebhookEvent) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Notify", varargs...)
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(ctx any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), varargs...)
+}
@@ -42,6 +42,31 @@ func NewDBURLRepository(pool postgres.ConnenctionPool, log *zerolog.Logger) *DBU
 	}
 }
 
+// urlMappingFromRow converts a database row into a domain URL mapping.
+func urlMappingFromRow(row q.ShortenerUrlmapping) *domain.URLMapping {
+	return &domain.URLMapping{
+		Slug:           row.Slug,
+		OriginalURL:    row.Original,
+		UserID:         row.UserID,
+		CreatedAt:      row.CreatedAt,
+		ExpiresAt:      row.ExpiresAt,
+		Deleted:        row.Deleted,
+		Clicks:         row.Clicks,
+		RedirectType:   row.RedirectType,
+		PassQuery:      row.PassQuery,
+		PassPath:       row.PassPath,
+		PasswordHash:   row.PasswordHash,
+		MaxClicks:      row.MaxClicks,
+		ActiveFrom:     row.ActiveFrom,
+		Rules:          row.Rules,
+		Variants:       row.Variants,
+		Canonical:      row.Canonical,
+		Namespace:      row.Namespace,
+		Disabled:       row.Disabled,
+		DisabledReason: row.DisabledReason,
+	}
+}
+
 // WithRetry retries the execution of the provided query function in case of transient errors
 // such as connection or query execution issues.
 func (repo *DBURLRepository) WithRetry(ctx context.Context, query func() error) error {
@@ -100,25 +125,56 @@ func (repo *DBURLRepository) AddURLMapping(ctx context.Context, urlMap *domain.U
 	var res *domain.URLMapping
 
 	retriableQuery := func() error {
-		qmp, err := repo.queries.AddURLMapping(ctx, q.AddURLMappingParams{
-			Slug:      urlMap.Slug,
-			Original:  urlMap.OriginalURL,
-			UserID:    urlMap.UserID,
-			CreatedAt: urlMap.CreatedAt,
-			ExpiresAt: urlMap.ExpiresAt,
-			Deleted:   urlMap.Deleted,
+		trx, err := repo.connPool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			return e.Wrap("failed to start tx", err, errLabel)
+		}
+
+		defer func() {
+			if err != nil {
+				if rollbackErr := trx.Rollback(ctx); rollbackErr != nil {
+					repo.log.Error().Err(rollbackErr).
+						Msg("failed to rollback tx")
+				}
+			}
+		}()
+
+		txQueries := repo.queries.WithTx(trx)
+
+		qmp, err := txQueries.AddURLMapping(ctx, q.AddURLMappingParams{
+			Slug:         urlMap.Slug,
+			Original:     urlMap.OriginalURL,
+			UserID:       urlMap.UserID,
+			CreatedAt:    urlMap.CreatedAt,
+			ExpiresAt:    urlMap.ExpiresAt,
+			Deleted:      urlMap.Deleted,
+			RedirectType: urlMap.RedirectType,
+			PassQuery:    urlMap.PassQuery,
+			PassPath:     urlMap.PassPath,
+			PasswordHash: urlMap.PasswordHash,
+			MaxClicks:    urlMap.MaxClicks,
+			ActiveFrom:   urlMap.ActiveFrom,
+			Rules:        urlMap.Rules,
+			Variants:     urlMap.Variants,
+			Canonical:    urlMap.Canonical,
+			Namespace:    urlMap.Namespace,
 		})
 		if err != nil {
 			return e.Wrap("failed to query", err, errLabel)
 		}
 
-		res = &domain.URLMapping{
-			Slug:        qmp.Slug,
-			OriginalURL: qmp.Original,
-			UserID:      qmp.UserID,
-			CreatedAt:   qmp.CreatedAt,
-			ExpiresAt:   qmp.ExpiresAt,
-			Deleted:     qmp.Deleted,
+		res = urlMappingFromRow(qmp)
+
+		// existing mapping of the original URL is returned as is, nothing has been created.
+		if res.Slug == urlMap.Slug {
+			err = addOutboxEvents(ctx, txQueries, []domain.URLMappingEvent{domain.URLMappingCreatedEvent(res)})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = trx.Commit(ctx); err != nil {
+			return e.Wrap("failed to commit tx", err, errLabel)
 		}
 
 		return nil
@@ -151,14 +207,7 @@ func (repo *DBURLRepository) GetURLMapping(ctx context.Context, slug domain.Slug
 			return e.Wrap("failed to query", err, errLabel)
 		}
 
-		urlMap = &domain.URLMapping{
-			Slug:        qmr.Slug,
-			OriginalURL: qmr.Original,
-			UserID:      qmr.UserID,
-			CreatedAt:   qmr.CreatedAt,
-			ExpiresAt:   qmr.ExpiresAt,
-			Deleted:     qmr.Deleted,
-		}
+		urlMap = urlMappingFromRow(qmr)
 
 		return nil
 	}
@@ -171,6 +220,156 @@ func (repo *DBURLRepository) GetURLMapping(ctx context.Context, slug domain.Slug
 	return urlMap, nil
 }
 
+// RegisterClick increments the click counter of a URL mapping and returns the updated mapping.
+// Click counter of the served variant is incremented in the same statement, zero variant stands for none.
+// Click limited mappings are checked and incremented in a single statement,
+// so concurrent redirects never go beyond the limit.
+func (repo *DBURLRepository) RegisterClick(
+	ctx context.Context,
+	slug domain.Slug,
+	variant int,
+) (*domain.URLMapping, error) {
+	var urlMap *domain.URLMapping
+
+	if variant < 0 || variant > domain.MaxVariants {
+		variant = 0
+	}
+
+	retriableQuery := func() error {
+		qmr, err := repo.queries.RegisterClick(ctx, q.RegisterClickParams{Slug: slug, Variant: int32(variant)})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			// no row updated: slug is either missing or exhausted.
+			_, errGet := repo.queries.GetURLMapping(ctx, slug)
+			if errors.Is(errGet, sql.ErrNoRows) {
+				return e.ErrSlugNotFound
+			}
+
+			if errGet != nil {
+				return e.Wrap("failed to query", errGet, errLabel)
+			}
+
+			return e.ErrSlugExhausted
+		}
+
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		urlMap = urlMappingFromRow(qmr)
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return nil, e.Wrap("failed to register click", err, errLabel)
+	}
+
+	return urlMap, nil
+}
+
+// UpdateURLMappingSchedule updates the activation window of a URL mapping owned by the given user.
+func (repo *DBURLRepository) UpdateURLMappingSchedule(
+	ctx context.Context,
+	owner dto.UserSlug,
+	schedule *dto.URLSchedule,
+) (*domain.URLMapping, error) {
+	var urlMap *domain.URLMapping
+
+	retriableQuery := func() error {
+		qmr, err := repo.queries.UpdateURLMappingSchedule(ctx, q.UpdateURLMappingScheduleParams{
+			Slug:       owner.Slug,
+			UserID:     owner.UserID,
+			ActiveFrom: schedule.ActiveFrom,
+			ExpiresAt:  schedule.ExpiresAt,
+		})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrSlugNotFound
+		}
+
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		urlMap = urlMappingFromRow(qmr)
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return nil, e.Wrap("failed to update urlmapping schedule", err, errLabel)
+	}
+
+	return urlMap, nil
+}
+
+// UpdateURLMappingRules replaces the redirect rules of a URL mapping owned by the given user.
+func (repo *DBURLRepository) UpdateURLMappingRules(
+	ctx context.Context,
+	owner dto.UserSlug,
+	rules domain.RedirectRules,
+) (*domain.URLMapping, error) {
+	var urlMap *domain.URLMapping
+
+	retriableQuery := func() error {
+		qmr, err := repo.queries.UpdateURLMappingRules(ctx, q.UpdateURLMappingRulesParams{
+			Slug:   owner.Slug,
+			UserID: owner.UserID,
+			Rules:  rules,
+		})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrSlugNotFound
+		}
+
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		urlMap = urlMappingFromRow(qmr)
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return nil, e.Wrap("failed to update urlmapping rules", err, errLabel)
+	}
+
+	return urlMap, nil
+}
+
+// ReassignUserURLMappings transfers all URL mappings of a user to another one and returns their number.
+// Mappings in the namespace of the former user move to the namespace of the new one,
+// unless the new one already shortened the same original URL, such mappings stay with the former user.
+func (repo *DBURLRepository) ReassignUserURLMappings(ctx context.Context, from, to domain.UserID) (int64, error) {
+	var moved int64
+
+	retriableQuery := func() error {
+		rows, err := repo.queries.ReassignUserURLMappings(ctx, q.ReassignUserURLMappingsParams{
+			ToUser:   to,
+			FromUser: from,
+		})
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		moved = rows
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return 0, e.Wrap("failed to reassign user urlmappings", err, errLabel)
+	}
+
+	return moved, nil
+}
+
 // GetUserURLMappings retrieves all URL mappings for a given user from the database.
 func (repo *DBURLRepository) GetUserURLMappings(ctx context.Context, user domain.UserID) ([]domain.URLMapping, error) {
 	var results []domain.URLMapping
@@ -188,14 +387,7 @@ func (repo *DBURLRepository) GetUserURLMappings(ctx context.Context, user domain
 
 		results = make([]domain.URLMapping, len(qresults))
 		for i, qm := range qresults {
-			results[i] = domain.URLMapping{
-				Slug:        qm.Slug,
-				OriginalURL: qm.Original,
-				UserID:      qm.UserID,
-				CreatedAt:   qm.CreatedAt,
-				ExpiresAt:   qm.ExpiresAt,
-				Deleted:     qm.Deleted,
-			}
+			results[i] = *urlMappingFromRow(qm)
 		}
 
 		return nil
@@ -218,42 +410,53 @@ func (repo *DBURLRepository) AddURLMappingBatch(ctx context.Context, batch *[]do
 		}
 
 		defer func() {
-			if errCom := trx.Commit(ctx); errCom != nil {
-				repo.log.
-					Error().
-					Err(errCom).
-					Msg("failed to commit batch tx")
+			if err != nil {
+				if rollbackErr := trx.Rollback(ctx); rollbackErr != nil {
+					repo.log.Error().Err(rollbackErr).
+						Msg("failed to rollback batch tx")
+				}
 			}
 		}()
 
 		txQueries := repo.queries.WithTx(trx)
 		batchParams := make([]q.AddURLMappingBatchCopyParams, len(*batch))
+		events := make([]domain.URLMappingEvent, len(*batch))
 
 		for i, urlMapping := range *batch {
 			batchParams[i] = q.AddURLMappingBatchCopyParams{
-				Slug:      urlMapping.Slug,
-				Original:  urlMapping.OriginalURL,
-				UserID:    urlMapping.UserID,
-				CreatedAt: urlMapping.CreatedAt,
-				ExpiresAt: urlMapping.ExpiresAt,
-				Deleted:   urlMapping.Deleted,
+				Slug:         urlMapping.Slug,
+				Original:     urlMapping.OriginalURL,
+				UserID:       urlMapping.UserID,
+				CreatedAt:    urlMapping.CreatedAt,
+				ExpiresAt:    urlMapping.ExpiresAt,
+				Deleted:      urlMapping.Deleted,
+				RedirectType: urlMapping.RedirectType,
+				PassQuery:    urlMapping.PassQuery,
+				PassPath:     urlMapping.PassPath,
+				PasswordHash: urlMapping.PasswordHash,
+				MaxClicks:    urlMapping.MaxClicks,
+				ActiveFrom:   urlMapping.ActiveFrom,
+				Rules:        urlMapping.Rules,
+				Variants:     urlMapping.Variants,
+				Canonical:    urlMapping.Canonical,
+				Namespace:    urlMapping.Namespace,
 			}
+			events[i] = domain.URLMappingCreatedEvent(&urlMapping)
 		}
 
 		rowsAffected, err := txQueries.AddURLMappingBatchCopy(ctx, batchParams)
-		if err != nil {
-			if errRB := trx.Rollback(ctx); errRB != nil {
-				repo.log.
-					Error().
-					Err(errRB).
-					Msg("failed to rollback batch tx")
-
-				return e.Wrap("failed to rollback batch tx", err, errLabel)
-			}
+		if err == nil {
+			err = addOutboxEvents(ctx, txQueries, events)
+		}
 
+		if err != nil {
 			return e.Wrap("error while running batch tx", err, errLabel)
 		}
 
+		if err = trx.Commit(ctx); err != nil {
+			return e.Wrap("failed to commit batch tx", err, errLabel)
+		}
+
 		repo.log.
 			Info().
 			Int64("rows_affected", rowsAffected).
@@ -271,9 +474,12 @@ func (repo *DBURLRepository) AddURLMappingBatch(ctx context.Context, batch *[]do
 }
 
 // DelUserURLMappings deletes URL mappings for a user based on their slugs.
-func (repo *DBURLRepository) DelUserURLMappings(ctx context.Context, tasks []dto.UserSlug) error {
+// It returns the tasks of URL mappings that have been deleted, mappings of other users
+// and mappings deleted before are left as is.
+func (repo *DBURLRepository) DelUserURLMappings(ctx context.Context, tasks []dto.UserSlug) ([]dto.UserSlug, error) {
 	var err error
 	var rowsAffected int64
+	var deleted []dto.UserSlug
 
 	retriableQuery := func() error {
 		trx, beginErr := repo.connPool.BeginTx(ctx, pgx.TxOptions{})
@@ -311,40 +517,82 @@ func (repo *DBURLRepository) DelUserURLMappings(ctx context.Context, tasks []dto
 			return e.Wrap("error filling temp table", err, errLabel)
 		}
 
-		if err = txQueries.DeleteSlugsInTarget(ctx); err != nil {
+		rows, delErr := txQueries.DeleteSlugsInTarget(ctx)
+		if err = delErr; err != nil {
 			return e.Wrap("error deleting slugs in target", err, errLabel)
 		}
 
+		events := make([]domain.URLMappingEvent, len(rows))
+		for i, row := range rows {
+			events[i] = domain.NewURLMappingEvent(domain.URLMappingDeleted, row.Slug, row.UserID)
+		}
+
+		if err = addOutboxEvents(ctx, txQueries, events); err != nil {
+			return err
+		}
+
 		if err = trx.Commit(ctx); err != nil {
 			return e.Wrap("failed to commit batch tx", err, errLabel)
 		}
 
 		repo.log.Info().Int64("rows_affected", rowsAffected).
+			Int("rows_deleted", len(rows)).
 			Msg("url mappings deleted in batch tx")
 
+		deleted = make([]dto.UserSlug, len(rows))
+		for i, row := range rows {
+			deleted[i] = dto.UserSlug{Slug: row.Slug, UserID: row.UserID}
+		}
+
 		return nil
 	}
 
 	if err = repo.WithRetry(ctx, retriableQuery); err != nil {
-		return e.Wrap("failed to delete user URL mappings", err, errLabel)
+		return nil, e.Wrap("failed to delete user URL mappings", err, errLabel)
 	}
 
-	return nil
+	return deleted, nil
 }
 
-// GetStats retrieves repo statistics.
-func (repo *DBURLRepository) GetStats(ctx context.Context) (*dto.RepoStats, error) {
+// GetStats retrieves repo statistics from aggregates maintained by the database on URL mappings changes.
+func (repo *DBURLRepository) GetStats(ctx context.Context, params dto.StatsParams) (*dto.RepoStats, error) {
 	var stats *dto.RepoStats
 
 	retriableQuery := func() error {
-		qresults, err := repo.queries.GetStats(ctx)
+		totals, err := repo.queries.GetStats(ctx, params.Now)
 		if err != nil {
 			return err
 		}
 
+		days, err := repo.queries.GetStatsDaily(ctx, params.Since())
+		if err != nil {
+			return err
+		}
+
+		users, err := repo.queries.GetStatsTopUsers(ctx, int32(params.TopUsers))
+		if err != nil {
+			return err
+		}
+
+		daily := make(map[string]int64, len(days))
+		for _, day := range days {
+			daily[day.Day.Format(time.DateOnly)] = day.Links
+		}
+
+		topUsers := make([]dto.UserStats, len(users))
+		for i, user := range users {
+			topUsers[i] = dto.UserStats{UserID: user.UserID.String(), CountSlugs: user.Links}
+		}
+
 		stats = &dto.RepoStats{
-			CountSlugs: qresults.Countslugs,
-			CountUsers: qresults.Countusers,
+			CountSlugs:   totals.Countslugs,
+			CountUsers:   totals.Countusers,
+			CountActive:  totals.Countslugs - totals.Countdeleted - totals.Countexpired,
+			CountDeleted: totals.Countdeleted,
+			CountExpired: totals.Countexpired,
+			StorageBytes: totals.Storagebytes,
+			Daily:        dto.NewDailyStats(params, daily),
+			TopUsers:     topUsers,
 		}
 
 		return nil
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
+)
+
+// DBAPIKeyRepository is responsible for interacting with the database to handle API keys.
+type DBAPIKeyRepository struct {
+	queries *q.Queries
+	log     *zerolog.Logger
+}
+
+// NewDBAPIKeyRepository creates a new instance of DBAPIKeyRepository with a connection pool and logger.
+func NewDBAPIKeyRepository(pool postgres.ConnenctionPool, log *zerolog.Logger) *DBAPIKeyRepository {
+	return &DBAPIKeyRepository{
+		queries: q.New(pool),
+		log:     log,
+	}
+}
+
+// apiKeyFromRow converts a database row into a domain API key.
+func apiKeyFromRow(row q.ShortenerApiKey) *domain.APIKey {
+	return &domain.APIKey{
+		ID:        row.KeyID,
+		UserID:    row.UserID,
+		Name:      row.Name,
+		Hash:      row.KeyHash,
+		Scopes:    row.Scopes,
+		CreatedAt: row.CreatedAt,
+		Revoked:   row.Revoked,
+	}
+}
+
+// AddAPIKey adds a new API key to the database.
+func (repo *DBAPIKeyRepository) AddAPIKey(ctx context.Context, key *domain.APIKey) error {
+	err := repo.queries.AddAPIKey(ctx, q.AddAPIKeyParams{
+		KeyID:     key.ID,
+		UserID:    key.UserID,
+		Name:      key.Name,
+		KeyHash:   key.Hash,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to add api key")
+
+		return e.Wrap("failed to add api key", err, errLabel)
+	}
+
+	return nil
+}
+
+// GetAPIKey retrieves an API key by its ID from the database.
+func (repo *DBAPIKeyRepository) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	row, err := repo.queries.GetAPIKey(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, e.ErrAPIKeyNotFound
+	}
+
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to get api key")
+
+		return nil, e.Wrap("failed to get api key", err, errLabel)
+	}
+
+	return apiKeyFromRow(row), nil
+}
+
+// GetUserAPIKeys retrieves all API keys of a user from the database, including revoked ones, oldest first.
+func (repo *DBAPIKeyRepository) GetUserAPIKeys(ctx context.Context, user domain.UserID) ([]domain.APIKey, error) {
+	rows, err := repo.queries.GetUserAPIKeys(ctx, user)
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to get user api keys")
+
+		return nil, e.Wrap("failed to get user api keys", err, errLabel)
+	}
+
+	res := make([]domain.APIKey, len(rows))
+	for i, row := range rows {
+		res[i] = *apiKeyFromRow(row)
+	}
+
+	return res, nil
+}
+
+// RevokeAPIKey revokes an API key owned by the given user in the database.
+func (repo *DBAPIKeyRepository) RevokeAPIKey(ctx context.Context, user domain.UserID, id string) error {
+	revoked, err := repo.queries.RevokeAPIKey(ctx, q.RevokeAPIKeyParams{KeyID: id, UserID: user})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to revoke api key")
+
+		return e.Wrap("failed to revoke api key", err, errLabel)
+	}
+
+	if revoked == 0 {
+		return e.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// CreateMemento creates a memento of the API keys of the repository.
+func (repo *DBAPIKeyRepository) CreateMemento() (*memento.Memento, error) {
+	return nil, e.ErrStateNotmplemented
+}
+
+// RestoreMemento restores the API keys of the repository from the given memento.
+func (repo *DBAPIKeyRepository) RestoreMemento(_ *memento.Memento) error {
+	return e.ErrStateNotmplemented
+}
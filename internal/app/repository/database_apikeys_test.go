@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func apiKeyRows(keys ...*domain.APIKey) *pgxmock.Rows {
+	rows := pgxmock.NewRows([]string{"key_id", "user_id", "name", "key_hash", "scopes", "created_at", "revoked"})
+	for _, k := range keys {
+		rows.AddRow(k.ID, k.UserID, k.Name, k.Hash, k.Scopes, k.CreatedAt, k.Revoked)
+	}
+
+	return rows
+}
+
+func TestDBAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBAPIKeyRepository(mockPool, log)
+	ctx := context.Background()
+	key, _, err := domain.NewAPIKey(domain.NewUserID(), "ci", domain.APIKeyScopes{domain.ScopeRead})
+	require.NoError(t, err)
+
+	mockPool.
+		ExpectExec(`INSERT INTO shortener.api_keys \(key_id, user_id, name, key_hash, scopes, created_at\)`).
+		WithArgs(key.ID, key.UserID, key.Name, key.Hash, key.Scopes, key.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.AddAPIKey(ctx, key))
+
+	mockPool.
+		ExpectQuery(`FROM shortener.api_keys\s+WHERE key_id = \$1`).
+		WithArgs(key.ID).
+		WillReturnRows(apiKeyRows(key))
+
+	res, err := repo.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key, res)
+
+	mockPool.
+		ExpectQuery(`FROM shortener.api_keys\s+WHERE key_id = \$1`).
+		WithArgs("unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetAPIKey(ctx, "unknown")
+	require.ErrorIs(t, err, e.ErrAPIKeyNotFound)
+
+	mockPool.
+		ExpectQuery(`FROM shortener.api_keys\s+WHERE user_id = \$1\s+ORDER BY created_at`).
+		WithArgs(key.UserID).
+		WillReturnRows(apiKeyRows(key))
+
+	keys, err := repo.GetUserAPIKeys(ctx, key.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.APIKey{*key}, keys)
+
+	mockPool.
+		ExpectExec(`UPDATE shortener.api_keys\s+SET revoked = true`).
+		WithArgs(key.ID, key.UserID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, repo.RevokeAPIKey(ctx, key.UserID, key.ID))
+
+	mockPool.
+		ExpectExec(`UPDATE shortener.api_keys\s+SET revoked = true`).
+		WithArgs(key.ID, key.UserID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	require.ErrorIs(t, repo.RevokeAPIKey(ctx, key.UserID, key.ID), e.ErrAPIKeyNotFound)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
+)
+
+// DBAuditRepository is responsible for interacting with the database to handle the audit log.
+// The audit log table is append-only, updates and deletions are rejected by the database.
+type DBAuditRepository struct {
+	queries *q.Queries
+	log     *zerolog.Logger
+}
+
+// NewDBAuditRepository creates a new instance of DBAuditRepository with a connection pool and logger.
+func NewDBAuditRepository(pool postgres.ConnenctionPool, log *zerolog.Logger) *DBAuditRepository {
+	return &DBAuditRepository{
+		queries: q.New(pool),
+		log:     log,
+	}
+}
+
+// AddAuditEvents appends events to the audit log.
+func (repo *DBAuditRepository) AddAuditEvents(ctx context.Context, events []domain.AuditEvent) error {
+	params := make([]q.AddAuditEventsParams, len(events))
+	for i, event := range events {
+		params[i] = q.AddAuditEventsParams{
+			Action:    event.Action,
+			Slug:      event.Slug,
+			UserID:    event.UserID,
+			Protocol:  event.Protocol,
+			ClientIp:  event.ClientIP,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		}
+	}
+
+	if _, err := repo.queries.AddAuditEvents(ctx, params); err != nil {
+		repo.log.Error().Err(err).Msg("failed to add audit events")
+
+		return e.Wrap("failed to add audit events", err, errLabel)
+	}
+
+	return nil
+}
+
+// GetAuditEvents retrieves the latest events of the audit log matching the filter, newest first.
+func (repo *DBAuditRepository) GetAuditEvents(
+	ctx context.Context,
+	filter dto.AuditFilter,
+) ([]domain.AuditEvent, error) {
+	rows, err := repo.queries.GetAuditEvents(ctx, q.GetAuditEventsParams{
+		Slug:      string(filter.Slug),
+		AnyUser:   filter.UserID.IsNil(),
+		UserID:    filter.UserID,
+		MaxEvents: int32(filter.Limit),
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to get audit events")
+
+		return nil, e.Wrap("failed to get audit events", err, errLabel)
+	}
+
+	events := make([]domain.AuditEvent, len(rows))
+	for i, row := range rows {
+		events[i] = domain.AuditEvent{
+			ID:        row.ID,
+			Action:    row.Action,
+			Slug:      row.Slug,
+			UserID:    row.UserID,
+			Protocol:  row.Protocol,
+			ClientIP:  row.ClientIp,
+			Details:   row.Details,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestDBAddAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBAuditRepository(mockPool, log)
+	ctx := context.Background()
+	source := domain.RequestSource{Protocol: domain.ProtocolHTTP, ClientIP: "127.0.0.1"}
+	events := []domain.AuditEvent{
+		domain.NewAuditEvent(domain.AuditCreated, "slug1", domain.NewUserID(), source, ""),
+		domain.NewAuditEvent(domain.AuditDeleted, "slug2", domain.NewUserID(), source, ""),
+	}
+	columns := []string{"action", "slug", "user_id", "protocol", "client_ip", "details", "created_at"}
+
+	mockPool.ExpectCopyFrom([]string{"shortener", "audit_log"}, columns).WillReturnResult(2)
+
+	err = repo.AddAuditEvents(ctx, events)
+	require.NoError(t, err)
+
+	mockPool.ExpectCopyFrom([]string{"shortener", "audit_log"}, columns).WillReturnError(e.ErrTestGeneral)
+
+	err = repo.AddAuditEvents(ctx, events)
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestDBGetAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBAuditRepository(mockPool, log)
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	now := time.Now()
+	columns := []string{"id", "action", "slug", "user_id", "protocol", "client_ip", "details", "created_at"}
+
+	mockPool.
+		ExpectQuery(`FROM shortener.audit_log`).
+		WithArgs("slug1", true, domain.UserID{}, int32(10)).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(int64(2), domain.AuditDeleted, domain.Slug("slug1"), userID, domain.ProtocolGRPC, "10.0.0.1", "", now).
+			AddRow(int64(1), domain.AuditCreated, domain.Slug("slug1"), userID, domain.ProtocolHTTP, "10.0.0.1", "", now))
+
+	events, err := repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "slug1", UserID: domain.UserID{}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.AuditDeleted, events[0].Action)
+	assert.Equal(t, domain.ProtocolGRPC, events[0].Protocol)
+	assert.Equal(t, userID, events[1].UserID)
+
+	mockPool.
+		ExpectQuery(`FROM shortener.audit_log`).
+		WithArgs("", false, userID, int32(10)).
+		WillReturnError(e.ErrTestGeneral)
+
+	_, err = repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "", UserID: userID, Limit: 10})
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
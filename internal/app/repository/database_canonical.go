@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+)
+
+const canonicalBackfillBatchSize = 500
+
+// CanonicalizeURLMappings canonicalizes URL mappings stored before canonicalization was introduced,
+// which kept their original URLs as canonical ones, batch by batch until none is left.
+// Of URL mappings sharing a canonical URL within a namespace the oldest one gets it,
+// others keep their original URLs as canonical ones, so no mapping is lost.
+func (repo *DBURLRepository) CanonicalizeURLMappings(ctx context.Context) error {
+	var total int
+
+	for {
+		var count int
+
+		retriableQuery := func() error {
+			var err error
+
+			count, err = repo.canonicalizeBatch(ctx)
+
+			return err
+		}
+
+		if err := repo.WithRetry(ctx, retriableQuery); err != nil {
+			return e.Wrap("failed to canonicalize urlmappings", err, errLabel)
+		}
+
+		if count == 0 {
+			break
+		}
+
+		total += count
+	}
+
+	if total > 0 {
+		repo.log.Info().
+			Int("urlmappings", total).
+			Msg("canonicalized urlmappings")
+	}
+
+	return nil
+}
+
+// canonicalizeBatch canonicalizes a batch of pending URL mappings, oldest first, in a single tx.
+func (repo *DBURLRepository) canonicalizeBatch(ctx context.Context) (int, error) {
+	trx, err := repo.connPool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, e.Wrap("failed to start canonicalize tx", err, errLabel)
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := trx.Rollback(ctx); rollbackErr != nil {
+				repo.log.Error().Err(rollbackErr).
+					Msg("failed to rollback canonicalize tx")
+			}
+		}
+	}()
+
+	txQueries := repo.queries.WithTx(trx)
+
+	rows, err := txQueries.GetCanonicalBackfill(ctx, canonicalBackfillBatchSize)
+	if err != nil {
+		return 0, e.Wrap("failed to get canonical backfill", err, errLabel)
+	}
+
+	for _, row := range rows {
+		if err = canonicalizeURLMapping(ctx, txQueries, row); err != nil {
+			return 0, e.Wrap("failed to canonicalize urlmapping", err, errLabel)
+		}
+	}
+
+	if err = trx.Commit(ctx); err != nil {
+		return 0, e.Wrap("failed to commit canonicalize tx", err, errLabel)
+	}
+
+	return len(rows), nil
+}
+
+// canonicalizeURLMapping sets the canonical URL of a pending URL mapping unless an older one holds it already.
+// A newer holder gives the canonical URL up and takes the original URL of the pending one instead.
+func canonicalizeURLMapping(ctx context.Context, txQueries *q.Queries, row q.GetCanonicalBackfillRow) error {
+	canonical := row.Original.Canonical(false)
+
+	if canonical != row.Canonical {
+		holder, err := txQueries.GetCanonicalHolder(ctx, q.GetCanonicalHolderParams{
+			Namespace: row.Namespace,
+			Canonical: canonical,
+		})
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = txQueries.UpdateURLMappingCanonical(ctx, q.UpdateURLMappingCanonicalParams{
+				Slug:      row.Slug,
+				Canonical: canonical,
+			})
+		case err == nil && (row.CreatedAt.Before(holder.CreatedAt) ||
+			row.CreatedAt.Equal(holder.CreatedAt) && row.Slug < holder.Slug):
+			err = takeCanonical(ctx, txQueries, row, holder.Slug, canonical)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return txQueries.DelCanonicalBackfill(ctx, row.Slug)
+}
+
+// takeCanonical moves a canonical URL from its holder to the pending URL mapping,
+// the holder takes the original URL of the pending one as canonical instead.
+// Canonical URLs are unique per namespace and checked per statement,
+// so the pending mapping parks its canonical URL at its slug, which is never a URL, first.
+func takeCanonical(
+	ctx context.Context,
+	txQueries *q.Queries,
+	row q.GetCanonicalBackfillRow,
+	holder domain.Slug,
+	canonical domain.OriginalURL,
+) error {
+	steps := []q.UpdateURLMappingCanonicalParams{
+		{Slug: row.Slug, Canonical: domain.OriginalURL(row.Slug)},
+		{Slug: holder, Canonical: row.Canonical},
+		{Slug: row.Slug, Canonical: canonical},
+	}
+
+	for _, step := range steps {
+		if err := txQueries.UpdateURLMappingCanonical(ctx, step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestDBCanonicalizeURLMappings(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	namespace := domain.NewUserID()
+	now := time.Now().UTC()
+	columns := []string{"slug", "original", "canonical", "namespace", "created_at"}
+	holderColumns := []string{"slug", "created_at"}
+
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectQuery(`FROM shortener.canonical_backfill`).
+		WithArgs(int32(500)).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(domain.Slug("free"), domain.OriginalURL("HTTP://A.com/x/"),
+				domain.OriginalURL("HTTP://A.com/x/"), namespace, now).
+			AddRow(domain.Slug("older"), domain.OriginalURL("http://example.com"),
+				domain.OriginalURL("http://example.com"), namespace, now.Add(time.Second)).
+			AddRow(domain.Slug("newer"), domain.OriginalURL("http://Example.com/"),
+				domain.OriginalURL("http://Example.com/"), namespace, now.Add(2*time.Second)).
+			AddRow(domain.Slug("same"), domain.OriginalURL("http://b.com/"),
+				domain.OriginalURL("http://b.com/"), namespace, now.Add(3*time.Second)))
+
+	// no mapping holds the canonical URL yet.
+	mockPool.
+		ExpectQuery(`FROM shortener.urlmapping`).
+		WithArgs(namespace, domain.OriginalURL("http://a.com/x")).
+		WillReturnError(sql.ErrNoRows)
+	mockPool.
+		ExpectExec(`UPDATE shortener.urlmapping`).
+		WithArgs(domain.Slug("free"), domain.OriginalURL("http://a.com/x")).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.canonical_backfill`).
+		WithArgs(domain.Slug("free")).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	// a newer mapping holds the canonical URL, the older one takes it.
+	mockPool.
+		ExpectQuery(`FROM shortener.urlmapping`).
+		WithArgs(namespace, domain.OriginalURL("http://example.com/")).
+		WillReturnRows(pgxmock.NewRows(holderColumns).AddRow(domain.Slug("created"), now.Add(time.Hour)))
+	mockPool.
+		ExpectExec(`UPDATE shortener.urlmapping`).
+		WithArgs(domain.Slug("older"), domain.OriginalURL("older")).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockPool.
+		ExpectExec(`UPDATE shortener.urlmapping`).
+		WithArgs(domain.Slug("created"), domain.OriginalURL("http://example.com")).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockPool.
+		ExpectExec(`UPDATE shortener.urlmapping`).
+		WithArgs(domain.Slug("older"), domain.OriginalURL("http://example.com/")).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.canonical_backfill`).
+		WithArgs(domain.Slug("older")).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	// an older mapping holds the canonical URL, the newer one keeps its original URL.
+	mockPool.
+		ExpectQuery(`FROM shortener.urlmapping`).
+		WithArgs(namespace, domain.OriginalURL("http://example.com/")).
+		WillReturnRows(pgxmock.NewRows(holderColumns).AddRow(domain.Slug("older"), now.Add(time.Second)))
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.canonical_backfill`).
+		WithArgs(domain.Slug("newer")).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	// already canonical.
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.canonical_backfill`).
+		WithArgs(domain.Slug("same")).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mockPool.ExpectCommit()
+
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectQuery(`FROM shortener.canonical_backfill`).
+		WithArgs(int32(500)).
+		WillReturnRows(pgxmock.NewRows(columns))
+	mockPool.ExpectCommit()
+
+	require.NoError(t, repo.CanonicalizeURLMappings(ctx))
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestDBCanonicalizeURLMappingsRollback(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectQuery(`FROM shortener.canonical_backfill`).
+		WithArgs(int32(500)).
+		WillReturnError(sql.ErrConnDone)
+	mockPool.ExpectRollback()
+
+	require.ErrorIs(t, repo.CanonicalizeURLMappings(ctx), sql.ErrConnDone)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
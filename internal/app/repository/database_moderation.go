@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+)
+
+// ModerateURLMapping disables or re-enables a URL mapping and records the action in the moderation log.
+// Both happen in a single statement, the entry is completed with the owner of the URL mapping and its log record ID.
+func (repo *DBURLRepository) ModerateURLMapping(
+	ctx context.Context,
+	entry *domain.ModerationEntry,
+) (*domain.URLMapping, error) {
+	if entry.Action != domain.ActionDisableURL && entry.Action != domain.ActionEnableURL {
+		return nil, e.ErrModerationActionInvalid
+	}
+
+	var urlMap *domain.URLMapping
+
+	disabled := entry.Action == domain.ActionDisableURL
+	disabledReason := ""
+
+	if disabled {
+		disabledReason = entry.Reason
+	}
+
+	retriableQuery := func() error {
+		row, err := repo.queries.ModerateURLMapping(ctx, q.ModerateURLMappingParams{
+			Disabled:       disabled,
+			DisabledReason: disabledReason,
+			Slug:           entry.Slug,
+			Action:         string(entry.Action),
+			Actor:          entry.Actor,
+			Reason:         entry.Reason,
+			CreatedAt:      entry.CreatedAt,
+		})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrSlugNotFound
+		}
+
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		urlMap = urlMappingFromRow(q.ShortenerUrlmapping{
+			Slug:           row.Slug,
+			Original:       row.Original,
+			UserID:         row.UserID,
+			CreatedAt:      row.CreatedAt,
+			ExpiresAt:      row.ExpiresAt,
+			Deleted:        row.Deleted,
+			Clicks:         row.Clicks,
+			RedirectType:   row.RedirectType,
+			PassQuery:      row.PassQuery,
+			PassPath:       row.PassPath,
+			PasswordHash:   row.PasswordHash,
+			MaxClicks:      row.MaxClicks,
+			ActiveFrom:     row.ActiveFrom,
+			Rules:          row.Rules,
+			Variants:       row.Variants,
+			Canonical:      row.Canonical,
+			Namespace:      row.Namespace,
+			Disabled:       row.Disabled,
+			DisabledReason: row.DisabledReason,
+		})
+		entry.ID = row.LogID
+		entry.UserID = row.UserID
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return nil, e.Wrap("failed to moderate urlmapping", err, errLabel)
+	}
+
+	return urlMap, nil
+}
+
+// ModerateUser bans or unbans a user and records the action in the moderation log in a single statement.
+func (repo *DBURLRepository) ModerateUser(ctx context.Context, entry *domain.ModerationEntry) error {
+	var query func() (int64, error)
+
+	switch entry.Action {
+	case domain.ActionBanUser:
+		query = func() (int64, error) {
+			return repo.queries.BanUser(ctx, q.BanUserParams{
+				UserID:    entry.UserID,
+				Reason:    entry.Reason,
+				CreatedAt: entry.CreatedAt,
+				Action:    entry.Action,
+				Actor:     entry.Actor,
+			})
+		}
+	case domain.ActionUnbanUser:
+		query = func() (int64, error) {
+			return repo.queries.UnbanUser(ctx, q.UnbanUserParams{
+				UserID:    entry.UserID,
+				Action:    entry.Action,
+				Actor:     entry.Actor,
+				Reason:    entry.Reason,
+				CreatedAt: entry.CreatedAt,
+			})
+		}
+	case domain.ActionDisableURL, domain.ActionEnableURL:
+		return e.ErrModerationActionInvalid
+	}
+
+	retriableQuery := func() error {
+		id, err := query()
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		entry.ID = id
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return e.Wrap("failed to moderate user", err, errLabel)
+	}
+
+	return nil
+}
+
+// IsUserBanned checks whether a user is banned from creating links.
+func (repo *DBURLRepository) IsUserBanned(ctx context.Context, user domain.UserID) (bool, error) {
+	var banned bool
+
+	retriableQuery := func() error {
+		var err error
+
+		banned, err = repo.queries.IsUserBanned(ctx, user)
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return false, e.Wrap("failed to check user ban", err, errLabel)
+	}
+
+	return banned, nil
+}
+
+// GetModerationLog retrieves up to limit latest records of the moderation log, newest first.
+func (repo *DBURLRepository) GetModerationLog(ctx context.Context, limit int) ([]domain.ModerationEntry, error) {
+	var entries []domain.ModerationEntry
+
+	retriableQuery := func() error {
+		rows, err := repo.queries.GetModerationLog(ctx, int32(limit))
+		if err != nil {
+			return e.Wrap("failed to query", err, errLabel)
+		}
+
+		entries = make([]domain.ModerationEntry, len(rows))
+		for i, row := range rows {
+			entries[i] = domain.ModerationEntry{
+				ID:        row.ID,
+				Action:    row.Action,
+				Slug:      row.Slug,
+				UserID:    row.UserID,
+				Actor:     row.Actor,
+				Reason:    row.Reason,
+				CreatedAt: row.CreatedAt,
+			}
+		}
+
+		return nil
+	}
+
+	err := repo.WithRetry(ctx, retriableQuery)
+	if err != nil {
+		return nil, e.Wrap("failed to get moderation log", err, errLabel)
+	}
+
+	return entries, nil
+}
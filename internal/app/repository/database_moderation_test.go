@@ -0,0 +1,154 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestDBModerateURLMapping(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("slug1", "http://example.com", domain.NewUserID())
+	urlm.Moderate(domain.ActionDisableURL, "phishing")
+
+	entry, err := domain.NewModerationEntry(domain.ActionDisableURL, "admin", "phishing")
+	require.NoError(t, err)
+
+	entry.Slug = urlm.Slug
+	rows := pgxmock.NewRows([]string{
+		"slug", "original", "user_id", "created_at", "expires_at", "deleted", "clicks", "redirect_type",
+		"pass_query", "pass_path", "password_hash", "max_clicks", "active_from", "rules", "variants", "canonical",
+		"namespace", "disabled", "disabled_reason", "log_id",
+	}).AddRow(append(urlMappingValues(urlm), int64(7))...)
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping`).
+		WithArgs(true, "phishing", urlm.Slug, "disable_url", "admin", "phishing", entry.CreatedAt).
+		WillReturnRows(rows)
+
+	m, err := repo.ModerateURLMapping(ctx, entry)
+	require.NoError(t, err)
+	assert.True(t, m.Disabled)
+	assert.Equal(t, "phishing", m.DisabledReason)
+	assert.Equal(t, int64(7), entry.ID)
+	assert.Equal(t, urlm.UserID, entry.UserID)
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping`).
+		WithArgs(true, "phishing", urlm.Slug, "disable_url", "admin", "phishing", entry.CreatedAt).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.ModerateURLMapping(ctx, entry)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+
+	entry.Action = domain.ActionBanUser
+	_, err = repo.ModerateURLMapping(ctx, entry)
+	require.ErrorIs(t, err, e.ErrModerationActionInvalid)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestDBModerateUser(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	userID := domain.NewUserID()
+
+	entry, err := domain.NewModerationEntry(domain.ActionBanUser, "admin", "spam")
+	require.NoError(t, err)
+
+	entry.UserID = userID
+
+	mockPool.
+		ExpectQuery(`INSERT INTO shortener.user_bans`).
+		WithArgs(userID, "spam", entry.CreatedAt, domain.ActionBanUser, "admin").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+
+	require.NoError(t, repo.ModerateUser(ctx, entry))
+	assert.Equal(t, int64(1), entry.ID)
+
+	mockPool.
+		ExpectQuery(`SELECT EXISTS`).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	banned, err := repo.IsUserBanned(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+	entry.Action = domain.ActionUnbanUser
+
+	mockPool.
+		ExpectQuery(`DELETE FROM shortener.user_bans`).
+		WithArgs(userID, domain.ActionUnbanUser, "admin", "spam", entry.CreatedAt).
+		WillReturnError(e.ErrTestGeneral)
+
+	require.ErrorIs(t, repo.ModerateUser(ctx, entry), e.ErrTestGeneral)
+
+	entry.Action = domain.ActionEnableURL
+	require.ErrorIs(t, repo.ModerateUser(ctx, entry), e.ErrModerationActionInvalid)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestDBGetModerationLog(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	now := time.Now()
+
+	mockPool.
+		ExpectQuery(`FROM shortener.moderation_log`).
+		WithArgs(int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "action", "slug", "user_id", "actor", "reason", "created_at"}).
+			AddRow(int64(2), domain.ActionBanUser, domain.Slug(""), userID, "admin", "spam", now).
+			AddRow(int64(1), domain.ActionDisableURL, domain.Slug("slug1"), userID, "admin", "phishing", now))
+
+	entries, err := repo.GetModerationLog(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.ActionBanUser, entries[0].Action)
+	assert.Equal(t, domain.Slug("slug1"), entries[1].Slug)
+
+	mockPool.
+		ExpectQuery(`FROM shortener.moderation_log`).
+		WithArgs(int32(2)).
+		WillReturnError(e.ErrTestGeneral)
+
+	_, err = repo.GetModerationLog(ctx, 2)
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
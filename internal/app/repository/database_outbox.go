@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+)
+
+// addOutboxEvents adds URL mapping events to the outbox within the transaction of the URL mappings changes.
+func addOutboxEvents(ctx context.Context, txQueries *q.Queries, events []domain.URLMappingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	params := make([]q.AddOutboxEventsParams, len(events))
+	for i, event := range events {
+		params[i] = q.AddOutboxEventsParams{
+			EventID:     event.EventID,
+			EventType:   event.Type,
+			Slug:        event.Slug,
+			UserID:      event.UserID,
+			Original:    event.OriginalURL,
+			CreatedAt:   event.CreatedAt,
+			AvailableAt: event.CreatedAt,
+		}
+	}
+
+	if _, err := txQueries.AddOutboxEvents(ctx, params); err != nil {
+		return e.Wrap("failed to add outbox events", err, errLabel)
+	}
+
+	return nil
+}
+
+// ClaimOutboxEvents claims up to limit available outbox events for the lease in the order they were added.
+// Events claimed by concurrent relays are skipped.
+func (repo *DBURLRepository) ClaimOutboxEvents(
+	ctx context.Context,
+	lease time.Duration,
+	limit int,
+) ([]domain.URLMappingEvent, error) {
+	now := time.Now()
+
+	rows, err := repo.queries.ClaimOutboxEvents(ctx, q.ClaimOutboxEventsParams{
+		LeaseUntil: now.Add(lease),
+		Now:        now,
+		MaxEvents:  int32(limit),
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to claim outbox events")
+
+		return nil, e.Wrap("failed to claim outbox events", err, errLabel)
+	}
+
+	res := make([]domain.URLMappingEvent, len(rows))
+	for i, row := range rows {
+		res[i] = domain.URLMappingEvent{
+			ID:          row.ID,
+			EventID:     row.EventID,
+			Type:        row.EventType,
+			Slug:        row.Slug,
+			UserID:      row.UserID,
+			OriginalURL: row.Original,
+			CreatedAt:   row.CreatedAt,
+		}
+	}
+
+	// rows returned by update are not ordered.
+	slices.SortFunc(res, func(a, b domain.URLMappingEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+// DelOutboxEvents deletes published events from the outbox.
+func (repo *DBURLRepository) DelOutboxEvents(ctx context.Context, ids []int64) error {
+	if err := repo.queries.DelOutboxEvents(ctx, ids); err != nil {
+		repo.log.Error().Err(err).Msg("failed to delete outbox events")
+
+		return e.Wrap("failed to delete outbox events", err, errLabel)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestDBOutbox(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("slug1", "https://example.com/", domain.NewUserID())
+
+	created := domain.URLMappingCreatedEvent(urlm)
+	deleted := domain.NewURLMappingEvent(domain.URLMappingDeleted, urlm.Slug, urlm.UserID)
+	columns := []string{"id", "event_id", "event_type", "slug", "user_id", "original", "created_at", "available_at"}
+
+	// rows are returned by update in any order
+	mockPool.
+		ExpectQuery(`UPDATE shortener.outbox`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), int32(10)).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(int64(2), deleted.EventID, deleted.Type, deleted.Slug, deleted.UserID, deleted.OriginalURL,
+				deleted.CreatedAt, deleted.CreatedAt).
+			AddRow(int64(1), created.EventID, created.Type, created.Slug, created.UserID, created.OriginalURL,
+				created.CreatedAt, created.CreatedAt))
+
+	claimed, err := repo.ClaimOutboxEvents(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, int64(1), claimed[0].ID)
+	assert.Equal(t, created.EventID, claimed[0].EventID)
+	assert.Equal(t, urlm.OriginalURL, claimed[0].OriginalURL)
+	assert.Equal(t, domain.URLMappingDeleted, claimed[1].Type)
+
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.outbox`).
+		WithArgs([]int64{1, 2}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	require.NoError(t, repo.DelOutboxEvents(ctx, []int64{1, 2}))
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.outbox`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), int32(10)).
+		WillReturnError(e.ErrTestGeneral)
+
+	_, err = repo.ClaimOutboxEvents(ctx, time.Minute, 10)
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.outbox`).
+		WithArgs([]int64{1}).
+		WillReturnError(e.ErrTestGeneral)
+
+	require.ErrorIs(t, repo.DelOutboxEvents(ctx, []int64{1}), e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -19,6 +20,52 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
 )
 
+// insertURLMappingQuery matches the shortener.urlmapping insert query.
+const insertURLMappingQuery = `INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at`
+
+// urlMappingInsertColumns lists the shortener.urlmapping columns populated on insert.
+var urlMappingInsertColumns = []string{
+	"slug", "original", "user_id", "created_at", "expires_at", "deleted", "redirect_type",
+	"pass_query", "pass_path", "password_hash", "max_clicks", "active_from", "rules", "variants", "canonical", "namespace",
+}
+
+// outboxTable and outboxColumns identify the shortener.outbox copy.
+var (
+	outboxTable   = []string{"shortener", "outbox"}
+	outboxColumns = []string{"event_id", "event_type", "slug", "user_id", "original", "created_at", "available_at"}
+)
+
+// urlMappingRows returns mocked shortener.urlmapping rows for the given mappings.
+func urlMappingRows(maps ...*domain.URLMapping) *pgxmock.Rows {
+	rows := pgxmock.NewRows([]string{
+		"slug", "original", "user_id", "created_at", "expires_at", "deleted", "clicks", "redirect_type",
+		"pass_query", "pass_path", "password_hash", "max_clicks", "active_from", "rules", "variants", "canonical",
+		"namespace", "disabled", "disabled_reason",
+	})
+	for _, m := range maps {
+		rows.AddRow(urlMappingValues(m)...)
+	}
+
+	return rows
+}
+
+// urlMappingValues returns the column values of a mapping in shortener.urlmapping order.
+func urlMappingValues(m *domain.URLMapping) []any {
+	return []any{
+		m.Slug, m.OriginalURL, m.UserID, m.CreatedAt, m.ExpiresAt, m.Deleted, m.Clicks, m.RedirectType,
+		m.PassQuery, m.PassPath, m.PasswordHash, m.MaxClicks, m.ActiveFrom, m.Rules, m.Variants, m.Canonical, m.Namespace,
+		m.Disabled, m.DisabledReason,
+	}
+}
+
+// urlMappingArgs returns the insert arguments of a mapping in shortener.urlmapping order.
+func urlMappingArgs(m *domain.URLMapping) []any {
+	return []any{
+		m.Slug, m.OriginalURL, m.UserID, m.CreatedAt, m.ExpiresAt, m.Deleted, m.RedirectType,
+		m.PassQuery, m.PassPath, m.PasswordHash, m.MaxClicks, m.ActiveFrom, m.Rules, m.Variants, m.Canonical, m.Namespace,
+	}
+}
+
 func TestAddURLMappingSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -32,11 +79,14 @@ func TestAddURLMappingSuccess(t *testing.T) {
 
 	urlm := domain.NewURLMapping("a", "b", userID)
 
+	// created event is added to the outbox in the same transaction
+	mockPool.ExpectBegin()
 	mockPool.
-		ExpectQuery(`INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at, deleted\)`).
-		WithArgs(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted).
-		WillReturnRows(pgxmock.NewRows([]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
-			AddRow(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted))
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlm)...).
+		WillReturnRows(urlMappingRows(urlm))
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnResult(1)
+	mockPool.ExpectCommit()
 
 	result, err := repo.AddURLMapping(ctx, urlm)
 	require.NoError(t, err)
@@ -66,24 +116,39 @@ func TestAddURLMappingErrors(t *testing.T) {
 	urlmd := domain.NewURLMapping("slug2", "url1", userID)
 
 	// unique vialation for duplicate slug
+	mockPool.ExpectBegin()
 	mockPool.
-		ExpectQuery(`INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at, deleted\)`).
-		WithArgs(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted).
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlm)...).
 		WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+	mockPool.ExpectRollback()
 
 	_, err = repo.AddURLMapping(ctx, urlm)
 	require.ErrorIs(t, err, e.ErrSlugExists)
 
 	// duplicate url will not trigger error but rather return existing slug
+	mockPool.ExpectBegin()
 	mockPool.
-		ExpectQuery(`INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at, deleted\)`).
-		WithArgs(urlmd.Slug, urlmd.OriginalURL, urlmd.UserID, urlmd.CreatedAt, urlmd.ExpiresAt, urlmd.Deleted).
-		WillReturnRows(pgxmock.NewRows([]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
-			AddRow(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted))
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlmd)...).
+		WillReturnRows(urlMappingRows(urlm))
+	mockPool.ExpectCommit()
 
 	_, err = repo.AddURLMapping(ctx, urlmd)
 	require.ErrorIs(t, err, e.ErrOriginalExists)
 
+	// outbox failure rolls back created mapping
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlm)...).
+		WillReturnRows(urlMappingRows(urlm))
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnError(e.ErrTestGeneral)
+	mockPool.ExpectRollback()
+
+	_, err = repo.AddURLMapping(ctx, urlm)
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
 	err = mockPool.ExpectationsWereMet()
 	require.NoError(t, err)
 }
@@ -100,20 +165,26 @@ func TestAddURLMappingRetriable(t *testing.T) {
 	ctx := context.Background()
 	urlm := domain.NewURLMapping("a", "b", userID)
 
+	mockPool.ExpectBegin()
 	mockPool.
-		ExpectQuery(`INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at, deleted\)`).
-		WithArgs(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted).
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlm)...).
 		WillReturnError(&pgconn.PgError{Code: pgerrcode.ConnectionFailure}) // First retry
+	mockPool.ExpectRollback()
+	mockPool.ExpectBegin()
 	mockPool.
-		ExpectQuery(`INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at, deleted\)`).
-		WithArgs(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted).
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlm)...).
 		WillReturnError(&pgconn.PgError{Code: pgerrcode.ConnectionFailure}) // Second retry
+	mockPool.ExpectRollback()
 	// Success on third try
+	mockPool.ExpectBegin()
 	mockPool.
-		ExpectQuery(`INSERT INTO shortener.urlmapping \(slug, original, user_id, created_at, expires_at, deleted\)`).
-		WithArgs(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted).
-		WillReturnRows(pgxmock.NewRows([]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
-			AddRow(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted))
+		ExpectQuery(insertURLMappingQuery).
+		WithArgs(urlMappingArgs(urlm)...).
+		WillReturnRows(urlMappingRows(urlm))
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnResult(1)
+	mockPool.ExpectCommit()
 
 	result, err := repo.AddURLMapping(ctx, urlm)
 	require.NoError(t, err)
@@ -140,8 +211,7 @@ func TestGetURLMapping(t *testing.T) {
 	urlm := domain.NewURLMapping("a", "b", userID)
 
 	// success
-	expectedRes := pgxmock.NewRows([]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
-		AddRow(urlm.Slug, urlm.OriginalURL, urlm.UserID, urlm.CreatedAt, urlm.ExpiresAt, urlm.Deleted)
+	expectedRes := urlMappingRows(urlm)
 
 	mockPool.
 		ExpectQuery(`SELECT slug, original, user_id, created_at, expires_at, deleted`).
@@ -180,6 +250,169 @@ func TestGetURLMapping(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRegisterClick(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("a", "b", domain.NewUserID())
+	urlm.Clicks = 3
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET clicks = clicks \+ 1`).
+		WithArgs(urlm.Slug, int32(0)).
+		WillReturnRows(urlMappingRows(urlm))
+
+	res, err := repo.RegisterClick(ctx, urlm.Slug, 0)
+	require.NoError(t, err)
+	assert.Equal(t, urlm.Clicks, res.Clicks)
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET clicks = clicks \+ 1`).
+		WithArgs(urlm.Slug, int32(0)).
+		WillReturnError(sql.ErrNoRows)
+	mockPool.
+		ExpectQuery(`SELECT slug, original, user_id, created_at, expires_at`).
+		WithArgs(urlm.Slug).
+		WillReturnError(sql.ErrNoRows)
+
+	res, err = repo.RegisterClick(ctx, urlm.Slug, 0)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+	assert.Nil(t, res)
+
+	// click limit reached
+	urlm.MaxClicks = urlm.Clicks
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET clicks = clicks \+ 1,[\s\S]+WHERE slug = \$1\s+AND \(max_clicks = 0`).
+		WithArgs(urlm.Slug, int32(0)).
+		WillReturnError(sql.ErrNoRows)
+	mockPool.
+		ExpectQuery(`SELECT slug, original, user_id, created_at, expires_at`).
+		WithArgs(urlm.Slug).
+		WillReturnRows(urlMappingRows(urlm))
+
+	res, err = repo.RegisterClick(ctx, urlm.Slug, 0)
+	require.ErrorIs(t, err, e.ErrSlugExhausted)
+	assert.Nil(t, res)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestRegisterClickVariant(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("a", "b", domain.NewUserID(), domain.WithVariants(domain.Variants{
+		{Target: "https://a.example.com", Weight: 1, Clicks: 0},
+		{Target: "https://b.example.com", Weight: 1, Clicks: 0},
+	}))
+	urlm.Clicks = 1
+	urlm.Variants[1].Clicks = 1
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET clicks = clicks \+ 1,\s+variants = CASE`).
+		WithArgs(urlm.Slug, int32(2)).
+		WillReturnRows(urlMappingRows(urlm))
+
+	res, err := repo.RegisterClick(ctx, urlm.Slug, 2)
+	require.NoError(t, err)
+	assert.Equal(t, urlm.Variants, res.Variants)
+
+	// unknown variants only count the slug click.
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET clicks = clicks \+ 1`).
+		WithArgs(urlm.Slug, int32(0)).
+		WillReturnRows(urlMappingRows(urlm))
+
+	_, err = repo.RegisterClick(ctx, urlm.Slug, domain.MaxVariants+1)
+	require.NoError(t, err)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestUpdateURLMappingSchedule(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("a", "b", domain.NewUserID())
+	urlm.ActiveFrom = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	owner := dto.UserSlug{Slug: urlm.Slug, UserID: urlm.UserID}
+	schedule := &dto.URLSchedule{ActiveFrom: urlm.ActiveFrom, ExpiresAt: urlm.ExpiresAt}
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET active_from = \$3`).
+		WithArgs(urlm.Slug, urlm.UserID, schedule.ActiveFrom, schedule.ExpiresAt).
+		WillReturnRows(urlMappingRows(urlm))
+
+	res, err := repo.UpdateURLMappingSchedule(ctx, owner, schedule)
+	require.NoError(t, err)
+	assert.Equal(t, urlm.ActiveFrom, res.ActiveFrom)
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET active_from = \$3`).
+		WithArgs(urlm.Slug, urlm.UserID, schedule.ActiveFrom, schedule.ExpiresAt).
+		WillReturnError(sql.ErrNoRows)
+
+	res, err = repo.UpdateURLMappingSchedule(ctx, owner, schedule)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+	assert.Nil(t, res)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestUpdateURLMappingRules(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("a", "b", domain.NewUserID())
+	urlm.Rules = domain.RedirectRules{{Language: "de", Target: "https://example.de"}}
+	owner := dto.UserSlug{Slug: urlm.Slug, UserID: urlm.UserID}
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET rules = \$3`).
+		WithArgs(urlm.Slug, urlm.UserID, urlm.Rules).
+		WillReturnRows(urlMappingRows(urlm))
+
+	res, err := repo.UpdateURLMappingRules(ctx, owner, urlm.Rules)
+	require.NoError(t, err)
+	assert.Equal(t, urlm.Rules, res.Rules)
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.urlmapping\s+SET rules = \$3`).
+		WithArgs(urlm.Slug, urlm.UserID, urlm.Rules).
+		WillReturnError(sql.ErrNoRows)
+
+	res, err = repo.UpdateURLMappingRules(ctx, owner, urlm.Rules)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+	assert.Nil(t, res)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
 func TestAddURLMappingBatchSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -201,8 +434,9 @@ func TestAddURLMappingBatchSuccess(t *testing.T) {
 	mockPool.
 		ExpectCopyFrom(
 			[]string{"shortener", "urlmapping"},
-			[]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
+			urlMappingInsertColumns).
 		WillReturnResult(3)
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnResult(3)
 	mockPool.ExpectCommit()
 
 	err = repo.AddURLMappingBatch(ctx, batch)
@@ -233,10 +467,9 @@ func TestAddURLMappingBatchFailure(t *testing.T) {
 	mockPool.
 		ExpectCopyFrom(
 			[]string{"shortener", "urlmapping"},
-			[]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
+			urlMappingInsertColumns).
 		WillReturnError(e.ErrTestGeneral)
 	mockPool.ExpectRollback()
-	mockPool.ExpectCommit() // commit is done in any case
 
 	err = repo.AddURLMappingBatch(ctx, batch)
 	require.Error(t, err)
@@ -245,16 +478,36 @@ func TestAddURLMappingBatchFailure(t *testing.T) {
 	err = mockPool.ExpectationsWereMet()
 	require.NoError(t, err)
 
+	// outbox failure rolls back loaded mappings
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectCopyFrom(
+			[]string{"shortener", "urlmapping"},
+			urlMappingInsertColumns).
+		WillReturnResult(3)
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnError(e.ErrTestGeneral)
+	mockPool.ExpectRollback()
+
+	err = repo.AddURLMappingBatch(ctx, batch)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to add outbox events")
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+
 	mockPool.ExpectBegin()
 	mockPool.
 		ExpectCopyFrom(
 			[]string{"shortener", "urlmapping"},
-			[]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}).
+			urlMappingInsertColumns).
 		WillReturnResult(3)
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnResult(3)
 	mockPool.ExpectCommit().WillReturnError(e.ErrTestGeneral)
+	mockPool.ExpectRollback()
 
 	err = repo.AddURLMappingBatch(ctx, batch)
-	require.NoError(t, err) // commit errors just logged and ignored
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to commit batch tx")
 
 	err = mockPool.ExpectationsWereMet()
 	require.NoError(t, err)
@@ -277,9 +530,9 @@ func TestDBGetUserURLMappings(t *testing.T) {
 		*domain.NewURLMapping("c", "url3", userID),
 	}
 	// Mock a successful query
-	rows := pgxmock.NewRows([]string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"})
+	rows := urlMappingRows()
 	for _, m := range urlMappings {
-		rows.AddRow(m.Slug, m.OriginalURL, m.UserID, m.CreatedAt, m.ExpiresAt, m.Deleted)
+		rows.AddRow(urlMappingValues(&m)...)
 	}
 
 	mockPool.ExpectQuery(`SELECT slug, original, user_id, created_at, expires_at, deleted`).
@@ -340,11 +593,15 @@ func TestDelUserURLMappingsSuccess(t *testing.T) {
 		[]string{"urlmapping_tmp"},
 		[]string{"slug", "user_id"}).
 		WillReturnResult(2)
-	mockPool.ExpectExec(`UPDATE shortener.urlmapping`).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mockPool.ExpectQuery(`UPDATE shortener.urlmapping[\s\S]+RETURNING`).
+		WillReturnRows(pgxmock.NewRows([]string{"slug", "user_id"}).
+			AddRow(userSlugTasks[0].Slug, userSlugTasks[0].UserID))
+	mockPool.ExpectCopyFrom(outboxTable, outboxColumns).WillReturnResult(1)
 	mockPool.ExpectCommit()
 
-	err = repo.DelUserURLMappings(ctx, userSlugTasks)
+	deleted, err := repo.DelUserURLMappings(ctx, userSlugTasks)
 	require.NoError(t, err)
+	assert.Equal(t, userSlugTasks[:1], deleted)
 
 	err = mockPool.ExpectationsWereMet()
 	require.NoError(t, err)
@@ -373,7 +630,7 @@ func TestDelUserURLMappingsFailure(t *testing.T) {
 	mockPool.ExpectRollback()
 
 	// Call the method under test
-	err = repo.DelUserURLMappings(ctx, userSlugTasks)
+	_, err = repo.DelUserURLMappings(ctx, userSlugTasks)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error filling temp table")
 
@@ -381,6 +638,38 @@ func TestDelUserURLMappingsFailure(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReassignUserURLMappings(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBURLRepository(mockPool, log)
+	ctx := context.Background()
+	anonymous, user := domain.NewUserID(), domain.NewUserID()
+
+	mockPool.
+		ExpectExec(`UPDATE shortener.urlmapping AS m\s+SET user_id = \$1`).
+		WithArgs(user, anonymous).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+
+	moved, err := repo.ReassignUserURLMappings(ctx, anonymous, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), moved)
+
+	mockPool.
+		ExpectExec(`UPDATE shortener.urlmapping AS m\s+SET user_id = \$1`).
+		WithArgs(user, anonymous).
+		WillReturnError(&pgconn.PgError{Code: pgerrcode.SyntaxError})
+
+	_, err = repo.ReassignUserURLMappings(ctx, anonymous, user)
+	require.Error(t, err)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
 func TestGetStatsSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -390,22 +679,40 @@ func TestGetStatsSuccess(t *testing.T) {
 
 	repo := repository.NewDBURLRepository(mockPool, log)
 	ctx := context.Background()
-	expected := &dto.RepoStats{
-		CountSlugs: int64(5),
-		CountUsers: int64(3),
-	}
+	user := domain.NewUserID()
+	params := dto.StatsParams{Days: 2, TopUsers: 1, Now: time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)}
 
 	mockPool.
-		ExpectQuery(`SELECT COUNT\(1\)\:\:BIGINT\s+AS CountSlugs`).
+		ExpectQuery(`SELECT\s+COALESCE\(SUM\(d.links\), 0\)\:\:BIGINT AS CountSlugs[\s\S]+FROM shortener.stats_expiry`).
+		WithArgs(params.Now).
 		WillReturnRows(
-			pgxmock.NewRows([]string{"countslugs", "countusers"}).
-				AddRow(expected.CountSlugs, expected.CountUsers),
+			pgxmock.NewRows([]string{"countslugs", "countdeleted", "countusers", "countexpired", "storagebytes"}).
+				AddRow(int64(5), int64(1), int64(3), int64(2), int64(8192)),
 		)
+	mockPool.
+		ExpectQuery(`SELECT day, links, deleted\s+FROM shortener.stats_daily`).
+		WithArgs(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(
+			pgxmock.NewRows([]string{"day", "links", "deleted"}).
+				AddRow(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), int64(4), int64(1)),
+		)
+	mockPool.
+		ExpectQuery(`SELECT user_id, links\s+FROM shortener.stats_users`).
+		WithArgs(int32(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "links"}).AddRow(user, int64(3)))
 
-	actual, err := repo.GetStats(ctx)
+	actual, err := repo.GetStats(ctx, params)
 	require.NoError(t, err)
-	require.Equal(t, expected.CountSlugs, actual.CountSlugs)
-	require.Equal(t, expected.CountUsers, actual.CountUsers)
+	require.Equal(t, &dto.RepoStats{
+		CountSlugs:   5,
+		CountUsers:   3,
+		CountActive:  2,
+		CountDeleted: 1,
+		CountExpired: 2,
+		StorageBytes: 8192,
+		Daily:        []dto.DailyStats{{Date: "2024-05-01", CountSlugs: 0}, {Date: "2024-05-02", CountSlugs: 4}},
+		TopUsers:     []dto.UserStats{{UserID: user.String(), CountSlugs: 3}},
+	}, actual)
 
 	err = mockPool.ExpectationsWereMet()
 	require.NoError(t, err)
@@ -420,12 +727,14 @@ func TestGetStatsFailure(t *testing.T) {
 
 	repo := repository.NewDBURLRepository(mockPool, log)
 	ctx := context.Background()
+	params := dto.StatsParams{Days: 1, TopUsers: 1, Now: time.Now()}
 
 	mockPool.
-		ExpectQuery(`SELECT COUNT\(1\)\:\:BIGINT\s+AS CountSlugs`).
-		WillReturnError(&pgconn.PgError{Code: pgerrcode.ConnectionFailure})
+		ExpectQuery(`SELECT\s+COALESCE\(SUM\(d.links\), 0\)\:\:BIGINT AS CountSlugs`).
+		WithArgs(params.Now).
+		WillReturnError(&pgconn.PgError{Code: pgerrcode.SyntaxError})
 
-	actual, err := repo.GetStats(ctx)
+	actual, err := repo.GetStats(ctx, params)
 	require.Error(t, err)
 	require.Nil(t, actual)
 
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
+)
+
+// DBTokenRepository is responsible for interacting with the database to handle revoked tokens.
+type DBTokenRepository struct {
+	queries *q.Queries
+	log     *zerolog.Logger
+}
+
+// NewDBTokenRepository creates a new instance of DBTokenRepository with a connection pool and logger.
+func NewDBTokenRepository(pool postgres.ConnenctionPool, log *zerolog.Logger) *DBTokenRepository {
+	return &DBTokenRepository{
+		queries: q.New(pool),
+		log:     log,
+	}
+}
+
+// RevokeToken revokes a token until it expires, expired tokens are removed along the way.
+func (repo *DBTokenRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if _, err := repo.queries.DeleteExpiredTokens(ctx, time.Now()); err != nil {
+		repo.log.Error().Err(err).Msg("failed to delete expired tokens")
+
+		return e.Wrap("failed to delete expired tokens", err, errLabel)
+	}
+
+	err := repo.queries.RevokeToken(ctx, q.RevokeTokenParams{Jti: jti, ExpiresAt: expiresAt})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to revoke token")
+
+		return e.Wrap("failed to revoke token", err, errLabel)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked reports whether a token is revoked.
+func (repo *DBTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	revoked, err := repo.queries.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to check revoked token")
+
+		return false, e.Wrap("failed to check revoked token", err, errLabel)
+	}
+
+	return revoked, nil
+}
+
+// CreateMemento creates a memento of the revoked tokens of the repository.
+func (repo *DBTokenRepository) CreateMemento() (*memento.Memento, error) {
+	return nil, e.ErrStateNotmplemented
+}
+
+// RestoreMemento restores the revoked tokens of the repository from the given memento.
+func (repo *DBTokenRepository) RestoreMemento(_ *memento.Memento) error {
+	return e.ErrStateNotmplemented
+}
@@ -0,0 +1,59 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestDBRevokedTokens(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBTokenRepository(mockPool, log)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.revoked_tokens\s+WHERE expires_at < \$1`).
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mockPool.
+		ExpectExec(`INSERT INTO shortener.revoked_tokens \(jti, expires_at\)`).
+		WithArgs("jti", expiresAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.RevokeToken(ctx, "jti", expiresAt))
+
+	mockPool.
+		ExpectQuery(`SELECT EXISTS`).
+		WithArgs("jti").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	revoked, err := repo.IsTokenRevoked(ctx, "jti")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	mockPool.
+		ExpectQuery(`SELECT EXISTS`).
+		WithArgs("jti").
+		WillReturnError(e.ErrTestGeneral)
+
+	_, err = repo.IsTokenRevoked(ctx, "jti")
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
+)
+
+// DBUserRepository is responsible for interacting with the database to handle user accounts.
+type DBUserRepository struct {
+	queries *q.Queries
+	log     *zerolog.Logger
+}
+
+// NewDBUserRepository creates a new instance of DBUserRepository with a connection pool and logger.
+func NewDBUserRepository(pool postgres.ConnenctionPool, log *zerolog.Logger) *DBUserRepository {
+	return &DBUserRepository{
+		queries: q.New(pool),
+		log:     log,
+	}
+}
+
+// userFromRow converts a database row into a domain user.
+func userFromRow(row q.ShortenerUser) *domain.User {
+	return &domain.User{
+		ID:           row.UserID,
+		Email:        row.Email,
+		PasswordHash: row.PasswordHash,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+// AddUser adds a new user account to the database.
+func (repo *DBUserRepository) AddUser(ctx context.Context, user *domain.User) error {
+	err := repo.queries.AddUser(ctx, q.AddUserParams{
+		UserID:       user.ID,
+		Email:        user.Email,
+		PasswordHash: user.PasswordHash,
+		CreatedAt:    user.CreatedAt,
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return e.ErrUserExists
+	}
+
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to add user")
+
+		return e.Wrap("failed to add user", err, errLabel)
+	}
+
+	return nil
+}
+
+// GetUser retrieves a user account by its UserID from the database.
+func (repo *DBUserRepository) GetUser(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	row, err := repo.queries.GetUser(ctx, id)
+
+	return repo.userFromQuery(row, err)
+}
+
+// GetUserByEmail retrieves a user account by its email from the database.
+func (repo *DBUserRepository) GetUserByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
+	row, err := repo.queries.GetUserByEmail(ctx, email)
+
+	return repo.userFromQuery(row, err)
+}
+
+// CreateMemento creates a memento of the user accounts of the repository.
+func (repo *DBUserRepository) CreateMemento() (*memento.Memento, error) {
+	return nil, e.ErrStateNotmplemented
+}
+
+// RestoreMemento restores the user accounts of the repository from the given memento.
+func (repo *DBUserRepository) RestoreMemento(_ *memento.Memento) error {
+	return e.ErrStateNotmplemented
+}
+
+func (repo *DBUserRepository) userFromQuery(row q.ShortenerUser, err error) (*domain.User, error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, e.ErrUserNotFound
+	}
+
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to get user")
+
+		return nil, e.Wrap("failed to get user", err, errLabel)
+	}
+
+	return userFromRow(row), nil
+}
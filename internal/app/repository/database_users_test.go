@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func userRows(user *domain.User) *pgxmock.Rows {
+	return pgxmock.NewRows([]string{"user_id", "email", "password_hash", "created_at"}).
+		AddRow(user.ID, user.Email, user.PasswordHash, user.CreatedAt)
+}
+
+func TestDBAddUser(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBUserRepository(mockPool, log)
+	ctx := context.Background()
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+
+	mockPool.
+		ExpectExec(`INSERT INTO shortener.users \(user_id, email, password_hash, created_at\)`).
+		WithArgs(user.ID, user.Email, user.PasswordHash, user.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.AddUser(ctx, user))
+
+	mockPool.
+		ExpectExec(`INSERT INTO shortener.users`).
+		WithArgs(user.ID, user.Email, user.PasswordHash, user.CreatedAt).
+		WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+
+	require.ErrorIs(t, repo.AddUser(ctx, user), e.ErrUserExists)
+
+	mockPool.
+		ExpectExec(`INSERT INTO shortener.users`).
+		WithArgs(user.ID, user.Email, user.PasswordHash, user.CreatedAt).
+		WillReturnError(&pgconn.PgError{Code: pgerrcode.ConnectionFailure})
+
+	err = repo.AddUser(ctx, user)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, e.ErrUserExists)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestDBGetUser(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBUserRepository(mockPool, log)
+	ctx := context.Background()
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+
+	mockPool.
+		ExpectQuery(`SELECT user_id, email, password_hash, created_at\s+FROM shortener.users\s+WHERE user_id = \$1`).
+		WithArgs(user.ID).
+		WillReturnRows(userRows(user))
+
+	res, err := repo.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user, res)
+
+	mockPool.
+		ExpectQuery(`SELECT user_id, email, password_hash, created_at\s+FROM shortener.users\s+WHERE email = \$1`).
+		WithArgs(user.Email).
+		WillReturnRows(userRows(user))
+
+	res, err = repo.GetUserByEmail(ctx, user.Email)
+	require.NoError(t, err)
+	assert.Equal(t, user, res)
+
+	mockPool.
+		ExpectQuery(`FROM shortener.users\s+WHERE email = \$1`).
+		WithArgs(domain.Email("other@example.com")).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetUserByEmail(ctx, "other@example.com")
+	require.ErrorIs(t, err, e.ErrUserNotFound)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+	q "github.com/patraden/ya-practicum-go-shortly/internal/app/repository/dbqueries"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils/postgres"
+)
+
+// DBWebhookRepository is responsible for interacting with the database to handle webhooks and their outbox.
+// Deliveries are removed along with their webhook.
+type DBWebhookRepository struct {
+	queries *q.Queries
+	log     *zerolog.Logger
+}
+
+// NewDBWebhookRepository creates a new instance of DBWebhookRepository with a connection pool and logger.
+func NewDBWebhookRepository(pool postgres.ConnenctionPool, log *zerolog.Logger) *DBWebhookRepository {
+	return &DBWebhookRepository{
+		queries: q.New(pool),
+		log:     log,
+	}
+}
+
+// AddWebhook adds a new webhook to the database.
+func (repo *DBWebhookRepository) AddWebhook(ctx context.Context, hook *domain.Webhook) error {
+	err := repo.queries.AddWebhook(ctx, q.AddWebhookParams{
+		WebhookID: hook.ID,
+		UserID:    hook.UserID,
+		Url:       hook.URL,
+		Secret:    hook.Secret,
+		CreatedAt: hook.CreatedAt,
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to add webhook")
+
+		return e.Wrap("failed to add webhook", err, errLabel)
+	}
+
+	return nil
+}
+
+// GetUserWebhooks retrieves all webhooks of a user from the database, oldest first.
+func (repo *DBWebhookRepository) GetUserWebhooks(ctx context.Context, user domain.UserID) ([]domain.Webhook, error) {
+	rows, err := repo.queries.GetUserWebhooks(ctx, user)
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to get user webhooks")
+
+		return nil, e.Wrap("failed to get user webhooks", err, errLabel)
+	}
+
+	res := make([]domain.Webhook, len(rows))
+	for i, row := range rows {
+		res[i] = domain.Webhook{
+			ID:        row.WebhookID,
+			UserID:    row.UserID,
+			URL:       row.Url,
+			Secret:    row.Secret,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+
+	return res, nil
+}
+
+// DelWebhook deletes a webhook owned by the given user along with its deliveries from the database.
+func (repo *DBWebhookRepository) DelWebhook(ctx context.Context, user domain.UserID, id string) error {
+	deleted, err := repo.queries.DelWebhook(ctx, q.DelWebhookParams{WebhookID: id, UserID: user})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to delete webhook")
+
+		return e.Wrap("failed to delete webhook", err, errLabel)
+	}
+
+	if deleted == 0 {
+		return e.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// AddWebhookDeliveries adds deliveries to the outbox.
+func (repo *DBWebhookRepository) AddWebhookDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	params := make([]q.AddWebhookDeliveriesParams, len(deliveries))
+	for i, d := range deliveries {
+		params[i] = q.AddWebhookDeliveriesParams{
+			WebhookID:     d.Webhook.ID,
+			EventID:       d.EventID,
+			EventType:     d.EventType,
+			Payload:       d.Payload,
+			Status:        d.Status,
+			Attempts:      int32(d.Attempts),
+			NextAttemptAt: d.NextAttemptAt,
+			LastError:     d.LastError,
+			CreatedAt:     d.CreatedAt,
+		}
+	}
+
+	if _, err := repo.queries.AddWebhookDeliveries(ctx, params); err != nil {
+		repo.log.Error().Err(err).Msg("failed to add webhook deliveries")
+
+		return e.Wrap("failed to add webhook deliveries", err, errLabel)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries claims up to limit pending deliveries due now for the lease, oldest due first.
+// Deliveries claimed by concurrent dispatchers are skipped.
+func (repo *DBWebhookRepository) ClaimWebhookDeliveries(
+	ctx context.Context,
+	lease time.Duration,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	now := time.Now()
+
+	rows, err := repo.queries.ClaimWebhookDeliveries(ctx, q.ClaimWebhookDeliveriesParams{
+		LeaseUntil:    now.Add(lease),
+		Now:           now,
+		MaxDeliveries: int32(limit),
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to claim webhook deliveries")
+
+		return nil, e.Wrap("failed to claim webhook deliveries", err, errLabel)
+	}
+
+	res := make([]domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		res[i] = webhookDeliveryFromRow(q.GetWebhookDeliveriesRow(row))
+	}
+
+	return res, nil
+}
+
+// UpdateWebhookDelivery records the outcome of a delivery attempt in the outbox.
+func (repo *DBWebhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	err := repo.queries.UpdateWebhookDelivery(ctx, q.UpdateWebhookDeliveryParams{
+		ID:            delivery.ID,
+		Status:        delivery.Status,
+		Attempts:      int32(delivery.Attempts),
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to update webhook delivery")
+
+		return e.Wrap("failed to update webhook delivery", err, errLabel)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the latest deliveries of a webhook with the status, newest first.
+func (repo *DBWebhookRepository) GetWebhookDeliveries(
+	ctx context.Context,
+	hook *domain.Webhook,
+	status domain.WebhookDeliveryStatus,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	rows, err := repo.queries.GetWebhookDeliveries(ctx, q.GetWebhookDeliveriesParams{
+		WebhookID: hook.ID,
+		UserID:    hook.UserID,
+		Status:    status,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		repo.log.Error().Err(err).Msg("failed to get webhook deliveries")
+
+		return nil, e.Wrap("failed to get webhook deliveries", err, errLabel)
+	}
+
+	res := make([]domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		res[i] = webhookDeliveryFromRow(row)
+	}
+
+	return res, nil
+}
+
+// webhookDeliveryFromRow converts a database row into a domain webhook delivery.
+func webhookDeliveryFromRow(row q.GetWebhookDeliveriesRow) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID: row.ID,
+		Webhook: domain.Webhook{
+			ID:        row.WebhookID,
+			UserID:    row.UserID,
+			URL:       row.Url,
+			Secret:    row.Secret,
+			CreatedAt: row.WebhookCreatedAt,
+		},
+		EventID:       row.EventID,
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		Status:        row.Status,
+		Attempts:      int(row.Attempts),
+		NextAttemptAt: row.NextAttemptAt,
+		LastError:     row.LastError,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+// CreateMemento creates a memento of the webhook subscriptions of the repository.
+func (repo *DBWebhookRepository) CreateMemento() (*memento.Memento, error) {
+	return nil, e.ErrStateNotmplemented
+}
+
+// RestoreMemento restores the webhook subscriptions of the repository from the given memento.
+func (repo *DBWebhookRepository) RestoreMemento(_ *memento.Memento) error {
+	return e.ErrStateNotmplemented
+}
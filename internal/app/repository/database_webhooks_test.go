@@ -0,0 +1,143 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestDBWebhooks(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBWebhookRepository(mockPool, log)
+	ctx := context.Background()
+	userID := domain.NewUserID()
+
+	hook, err := domain.NewWebhook(userID, "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	mockPool.
+		ExpectExec(`INSERT INTO shortener.webhooks`).
+		WithArgs(hook.ID, hook.UserID, hook.URL, hook.Secret, hook.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.AddWebhook(ctx, hook))
+
+	mockPool.
+		ExpectQuery(`FROM shortener.webhooks`).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"webhook_id", "user_id", "url", "secret", "created_at"}).
+			AddRow(hook.ID, hook.UserID, hook.URL, hook.Secret, hook.CreatedAt))
+
+	hooks, err := repo.GetUserWebhooks(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, *hook, hooks[0])
+
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.webhooks`).
+		WithArgs(hook.ID, userID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.ErrorIs(t, repo.DelWebhook(ctx, userID, hook.ID), e.ErrWebhookNotFound)
+
+	mockPool.
+		ExpectExec(`DELETE FROM shortener.webhooks`).
+		WithArgs(hook.ID, userID).
+		WillReturnError(e.ErrTestGeneral)
+
+	require.ErrorIs(t, repo.DelWebhook(ctx, userID, hook.ID), e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestDBWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := repository.NewDBWebhookRepository(mockPool, log)
+	ctx := context.Background()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", hook.UserID)
+	delivery := domain.NewWebhookDelivery(hook, &event, []byte(`{}`))
+	copyColumns := []string{
+		"webhook_id", "event_id", "event_type", "payload", "status",
+		"attempts", "next_attempt_at", "last_error", "created_at",
+	}
+
+	mockPool.ExpectCopyFrom([]string{"shortener", "webhook_deliveries"}, copyColumns).WillReturnResult(1)
+
+	require.NoError(t, repo.AddWebhookDeliveries(ctx, []domain.WebhookDelivery{delivery}))
+
+	columns := []string{
+		"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts",
+		"next_attempt_at", "last_error", "created_at", "user_id", "url", "secret", "webhook_created_at",
+	}
+	row := []any{
+		int64(1), hook.ID, event.ID, event.Type, delivery.Payload, domain.DeliveryPending, int32(0),
+		delivery.NextAttemptAt, "", delivery.CreatedAt, hook.UserID, hook.URL, hook.Secret, hook.CreatedAt,
+	}
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.webhook_deliveries`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), int32(10)).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(row...))
+
+	claimed, err := repo.ClaimWebhookDeliveries(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, int64(1), claimed[0].ID)
+	assert.Equal(t, *hook, claimed[0].Webhook)
+	assert.Equal(t, event.ID, claimed[0].EventID)
+
+	claimed[0].Fail("status 500", time.Minute, 1)
+
+	mockPool.
+		ExpectExec(`UPDATE shortener.webhook_deliveries`).
+		WithArgs(int64(1), domain.DeliveryDead, int32(1), claimed[0].NextAttemptAt, "status 500").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, &claimed[0]))
+
+	mockPool.
+		ExpectQuery(`FROM shortener.webhook_deliveries`).
+		WithArgs(hook.ID, hook.UserID, domain.DeliveryDead, int32(10)).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(row...))
+
+	dead, err := repo.GetWebhookDeliveries(ctx, hook, domain.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, delivery.Payload, dead[0].Payload)
+
+	mockPool.
+		ExpectQuery(`UPDATE shortener.webhook_deliveries`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), int32(10)).
+		WillReturnError(e.ErrTestGeneral)
+
+	_, err = repo.ClaimWebhookDeliveries(ctx, time.Minute, 10)
+	require.ErrorIs(t, err, e.ErrTestGeneral)
+
+	err = mockPool.ExpectationsWereMet()
+	require.NoError(t, err)
+}
@@ -9,6 +9,82 @@ import (
 	"context"
 )
 
+// iteratorForAddAuditEvents implements pgx.CopyFromSource.
+type iteratorForAddAuditEvents struct {
+	rows                 []AddAuditEventsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForAddAuditEvents) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForAddAuditEvents) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].Action,
+		r.rows[0].Slug,
+		r.rows[0].UserID,
+		r.rows[0].Protocol,
+		r.rows[0].ClientIp,
+		r.rows[0].Details,
+		r.rows[0].CreatedAt,
+	}, nil
+}
+
+func (r iteratorForAddAuditEvents) Err() error {
+	return nil
+}
+
+func (q *Queries) AddAuditEvents(ctx context.Context, arg []AddAuditEventsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"shortener", "audit_log"}, []string{"action", "slug", "user_id", "protocol", "client_ip", "details", "created_at"}, &iteratorForAddAuditEvents{rows: arg})
+}
+
+// iteratorForAddOutboxEvents implements pgx.CopyFromSource.
+type iteratorForAddOutboxEvents struct {
+	rows                 []AddOutboxEventsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForAddOutboxEvents) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForAddOutboxEvents) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].EventID,
+		r.rows[0].EventType,
+		r.rows[0].Slug,
+		r.rows[0].UserID,
+		r.rows[0].Original,
+		r.rows[0].CreatedAt,
+		r.rows[0].AvailableAt,
+	}, nil
+}
+
+func (r iteratorForAddOutboxEvents) Err() error {
+	return nil
+}
+
+func (q *Queries) AddOutboxEvents(ctx context.Context, arg []AddOutboxEventsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"shortener", "outbox"}, []string{"event_id", "event_type", "slug", "user_id", "original", "created_at", "available_at"}, &iteratorForAddOutboxEvents{rows: arg})
+}
+
 // iteratorForAddURLMappingBatchCopy implements pgx.CopyFromSource.
 type iteratorForAddURLMappingBatchCopy struct {
 	rows                 []AddURLMappingBatchCopyParams
@@ -35,6 +111,16 @@ func (r iteratorForAddURLMappingBatchCopy) Values() ([]interface{}, error) {
 		r.rows[0].CreatedAt,
 		r.rows[0].ExpiresAt,
 		r.rows[0].Deleted,
+		r.rows[0].RedirectType,
+		r.rows[0].PassQuery,
+		r.rows[0].PassPath,
+		r.rows[0].PasswordHash,
+		r.rows[0].MaxClicks,
+		r.rows[0].ActiveFrom,
+		r.rows[0].Rules,
+		r.rows[0].Variants,
+		r.rows[0].Canonical,
+		r.rows[0].Namespace,
 	}, nil
 }
 
@@ -43,7 +129,47 @@ func (r iteratorForAddURLMappingBatchCopy) Err() error {
 }
 
 func (q *Queries) AddURLMappingBatchCopy(ctx context.Context, arg []AddURLMappingBatchCopyParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"shortener", "urlmapping"}, []string{"slug", "original", "user_id", "created_at", "expires_at", "deleted"}, &iteratorForAddURLMappingBatchCopy{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"shortener", "urlmapping"}, []string{"slug", "original", "user_id", "created_at", "expires_at", "deleted", "redirect_type", "pass_query", "pass_path", "password_hash", "max_clicks", "active_from", "rules", "variants", "canonical", "namespace"}, &iteratorForAddURLMappingBatchCopy{rows: arg})
+}
+
+// iteratorForAddWebhookDeliveries implements pgx.CopyFromSource.
+type iteratorForAddWebhookDeliveries struct {
+	rows                 []AddWebhookDeliveriesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForAddWebhookDeliveries) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForAddWebhookDeliveries) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].WebhookID,
+		r.rows[0].EventID,
+		r.rows[0].EventType,
+		r.rows[0].Payload,
+		r.rows[0].Status,
+		r.rows[0].Attempts,
+		r.rows[0].NextAttemptAt,
+		r.rows[0].LastError,
+		r.rows[0].CreatedAt,
+	}, nil
+}
+
+func (r iteratorForAddWebhookDeliveries) Err() error {
+	return nil
+}
+
+func (q *Queries) AddWebhookDeliveries(ctx context.Context, arg []AddWebhookDeliveriesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"shortener", "webhook_deliveries"}, []string{"webhook_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_error", "created_at"}, &iteratorForAddWebhookDeliveries{rows: arg})
 }
 
 // iteratorForFillDeletedSlugTempTable implements pgx.CopyFromSource.
@@ -10,13 +10,127 @@ import (
 	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 )
 
-type ShortenerUrlmapping struct {
+type ShortenerApiKey struct {
+	KeyID     string              `db:"key_id"`
+	UserID    domain.UserID       `db:"user_id"`
+	Name      string              `db:"name"`
+	KeyHash   string              `db:"key_hash"`
+	Scopes    domain.APIKeyScopes `db:"scopes"`
+	CreatedAt time.Time           `db:"created_at"`
+	Revoked   bool                `db:"revoked"`
+}
+
+type ShortenerAuditLog struct {
+	ID        int64              `db:"id"`
+	Action    domain.AuditAction `db:"action"`
 	Slug      domain.Slug        `db:"slug"`
-	Original  domain.OriginalURL `db:"original"`
 	UserID    domain.UserID      `db:"user_id"`
+	Protocol  domain.Protocol    `db:"protocol"`
+	ClientIp  string             `db:"client_ip"`
+	Details   string             `db:"details"`
 	CreatedAt time.Time          `db:"created_at"`
-	ExpiresAt time.Time          `db:"expires_at"`
-	Deleted   bool               `db:"deleted"`
+}
+
+type ShortenerCanonicalBackfill struct {
+	Slug domain.Slug `db:"slug"`
+}
+
+type ShortenerModerationLog struct {
+	ID        int64                   `db:"id"`
+	Action    domain.ModerationAction `db:"action"`
+	Slug      domain.Slug             `db:"slug"`
+	UserID    domain.UserID           `db:"user_id"`
+	Actor     string                  `db:"actor"`
+	Reason    string                  `db:"reason"`
+	CreatedAt time.Time               `db:"created_at"`
+}
+
+type ShortenerOutbox struct {
+	ID          int64                      `db:"id"`
+	EventID     string                     `db:"event_id"`
+	EventType   domain.URLMappingEventType `db:"event_type"`
+	Slug        domain.Slug                `db:"slug"`
+	UserID      domain.UserID              `db:"user_id"`
+	Original    domain.OriginalURL         `db:"original"`
+	CreatedAt   time.Time                  `db:"created_at"`
+	AvailableAt time.Time                  `db:"available_at"`
+}
+
+type ShortenerRevokedToken struct {
+	Jti       string    `db:"jti"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type ShortenerStatsDaily struct {
+	Day     time.Time `db:"day"`
+	Links   int64     `db:"links"`
+	Deleted int64     `db:"deleted"`
+}
+
+type ShortenerStatsExpiry struct {
+	Day   time.Time `db:"day"`
+	Links int64     `db:"links"`
+}
+
+type ShortenerStatsUser struct {
+	UserID domain.UserID `db:"user_id"`
+	Links  int64         `db:"links"`
+}
+
+type ShortenerUrlmapping struct {
+	Slug           domain.Slug          `db:"slug"`
+	Original       domain.OriginalURL   `db:"original"`
+	UserID         domain.UserID        `db:"user_id"`
+	CreatedAt      time.Time            `db:"created_at"`
+	ExpiresAt      time.Time            `db:"expires_at"`
+	Deleted        bool                 `db:"deleted"`
+	Clicks         int64                `db:"clicks"`
+	RedirectType   domain.RedirectType  `db:"redirect_type"`
+	PassQuery      bool                 `db:"pass_query"`
+	PassPath       bool                 `db:"pass_path"`
+	PasswordHash   domain.PasswordHash  `db:"password_hash"`
+	MaxClicks      int64                `db:"max_clicks"`
+	ActiveFrom     time.Time            `db:"active_from"`
+	Rules          domain.RedirectRules `db:"rules"`
+	Variants       domain.Variants      `db:"variants"`
+	Canonical      domain.OriginalURL   `db:"canonical"`
+	Namespace      domain.UserID        `db:"namespace"`
+	Disabled       bool                 `db:"disabled"`
+	DisabledReason string               `db:"disabled_reason"`
+}
+
+type ShortenerUserBan struct {
+	UserID    domain.UserID `db:"user_id"`
+	Reason    string        `db:"reason"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+type ShortenerUser struct {
+	UserID       domain.UserID       `db:"user_id"`
+	Email        domain.Email        `db:"email"`
+	PasswordHash domain.PasswordHash `db:"password_hash"`
+	CreatedAt    time.Time           `db:"created_at"`
+}
+
+type ShortenerWebhook struct {
+	WebhookID string             `db:"webhook_id"`
+	UserID    domain.UserID      `db:"user_id"`
+	Url       domain.OriginalURL `db:"url"`
+	Secret    string             `db:"secret"`
+	CreatedAt time.Time          `db:"created_at"`
+}
+
+type ShortenerWebhookDelivery struct {
+	ID            int64                        `db:"id"`
+	WebhookID     string                       `db:"webhook_id"`
+	EventID       string                       `db:"event_id"`
+	EventType     domain.WebhookEventType      `db:"event_type"`
+	Payload       []byte                       `db:"payload"`
+	Status        domain.WebhookDeliveryStatus `db:"status"`
+	Attempts      int32                        `db:"attempts"`
+	NextAttemptAt time.Time                    `db:"next_attempt_at"`
+	LastError     string                       `db:"last_error"`
+	CreatedAt     time.Time                    `db:"created_at"`
 }
 
 type UrlmappingTmp struct {
@@ -12,25 +12,91 @@ import (
 	domain "github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 )
 
-const AddURLMapping = `-- name: AddURLMapping :one
-INSERT INTO shortener.urlmapping (slug, original, user_id, created_at, expires_at, deleted)
+const AddAPIKey = `-- name: AddAPIKey :exec
+INSERT INTO shortener.api_keys (key_id, user_id, name, key_hash, scopes, created_at)
 VALUES ($1, $2, $3, $4, $5, $6)
-ON CONFLICT (original) DO UPDATE
+`
+
+type AddAPIKeyParams struct {
+	KeyID     string              `db:"key_id"`
+	UserID    domain.UserID       `db:"user_id"`
+	Name      string              `db:"name"`
+	KeyHash   string              `db:"key_hash"`
+	Scopes    domain.APIKeyScopes `db:"scopes"`
+	CreatedAt time.Time           `db:"created_at"`
+}
+
+func (q *Queries) AddAPIKey(ctx context.Context, arg AddAPIKeyParams) error {
+	_, err := q.db.Exec(ctx, AddAPIKey,
+		arg.KeyID,
+		arg.UserID,
+		arg.Name,
+		arg.KeyHash,
+		arg.Scopes,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+type AddAuditEventsParams struct {
+	Action    domain.AuditAction `db:"action"`
+	Slug      domain.Slug        `db:"slug"`
+	UserID    domain.UserID      `db:"user_id"`
+	Protocol  domain.Protocol    `db:"protocol"`
+	ClientIp  string             `db:"client_ip"`
+	Details   string             `db:"details"`
+	CreatedAt time.Time          `db:"created_at"`
+}
+
+type AddOutboxEventsParams struct {
+	EventID     string                     `db:"event_id"`
+	EventType   domain.URLMappingEventType `db:"event_type"`
+	Slug        domain.Slug                `db:"slug"`
+	UserID      domain.UserID              `db:"user_id"`
+	Original    domain.OriginalURL         `db:"original"`
+	CreatedAt   time.Time                  `db:"created_at"`
+	AvailableAt time.Time                  `db:"available_at"`
+}
+
+const AddURLMapping = `-- name: AddURLMapping :one
+INSERT INTO shortener.urlmapping (slug, original, user_id, created_at, expires_at, deleted, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT (namespace, canonical) DO UPDATE
 SET slug = shortener.urlmapping.slug,
     user_id = shortener.urlmapping.user_id,
     created_at = shortener.urlmapping.created_at,
     expires_at = shortener.urlmapping.expires_at,
-    deleted = shortener.urlmapping.deleted
-RETURNING slug, original, user_id, created_at, expires_at, deleted
+    deleted = shortener.urlmapping.deleted,
+    redirect_type = shortener.urlmapping.redirect_type,
+    pass_query = shortener.urlmapping.pass_query,
+    pass_path = shortener.urlmapping.pass_path,
+    password_hash = shortener.urlmapping.password_hash,
+    max_clicks = shortener.urlmapping.max_clicks,
+    active_from = shortener.urlmapping.active_from,
+    rules = shortener.urlmapping.rules,
+    variants = shortener.urlmapping.variants,
+    canonical = shortener.urlmapping.canonical,
+    namespace = shortener.urlmapping.namespace
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
 `
 
 type AddURLMappingParams struct {
-	Slug      domain.Slug        `db:"slug"`
-	Original  domain.OriginalURL `db:"original"`
-	UserID    domain.UserID      `db:"user_id"`
-	CreatedAt time.Time          `db:"created_at"`
-	ExpiresAt time.Time          `db:"expires_at"`
-	Deleted   bool               `db:"deleted"`
+	Slug         domain.Slug          `db:"slug"`
+	Original     domain.OriginalURL   `db:"original"`
+	UserID       domain.UserID        `db:"user_id"`
+	CreatedAt    time.Time            `db:"created_at"`
+	ExpiresAt    time.Time            `db:"expires_at"`
+	Deleted      bool                 `db:"deleted"`
+	RedirectType domain.RedirectType  `db:"redirect_type"`
+	PassQuery    bool                 `db:"pass_query"`
+	PassPath     bool                 `db:"pass_path"`
+	PasswordHash domain.PasswordHash  `db:"password_hash"`
+	MaxClicks    int64                `db:"max_clicks"`
+	ActiveFrom   time.Time            `db:"active_from"`
+	Rules        domain.RedirectRules `db:"rules"`
+	Variants     domain.Variants      `db:"variants"`
+	Canonical    domain.OriginalURL   `db:"canonical"`
+	Namespace    domain.UserID        `db:"namespace"`
 }
 
 func (q *Queries) AddURLMapping(ctx context.Context, arg AddURLMappingParams) (ShortenerUrlmapping, error) {
@@ -41,6 +107,16 @@ func (q *Queries) AddURLMapping(ctx context.Context, arg AddURLMappingParams) (S
 		arg.CreatedAt,
 		arg.ExpiresAt,
 		arg.Deleted,
+		arg.RedirectType,
+		arg.PassQuery,
+		arg.PassPath,
+		arg.PasswordHash,
+		arg.MaxClicks,
+		arg.ActiveFrom,
+		arg.Rules,
+		arg.Variants,
+		arg.Canonical,
+		arg.Namespace,
 	)
 	var i ShortenerUrlmapping
 	err := row.Scan(
@@ -50,17 +126,257 @@ func (q *Queries) AddURLMapping(ctx context.Context, arg AddURLMappingParams) (S
 		&i.CreatedAt,
 		&i.ExpiresAt,
 		&i.Deleted,
+		&i.Clicks,
+		&i.RedirectType,
+		&i.PassQuery,
+		&i.PassPath,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.ActiveFrom,
+		&i.Rules,
+		&i.Variants,
+		&i.Canonical,
+		&i.Namespace,
+		&i.Disabled,
+		&i.DisabledReason,
 	)
 	return i, err
 }
 
 type AddURLMappingBatchCopyParams struct {
-	Slug      domain.Slug        `db:"slug"`
-	Original  domain.OriginalURL `db:"original"`
+	Slug         domain.Slug          `db:"slug"`
+	Original     domain.OriginalURL   `db:"original"`
+	UserID       domain.UserID        `db:"user_id"`
+	CreatedAt    time.Time            `db:"created_at"`
+	ExpiresAt    time.Time            `db:"expires_at"`
+	Deleted      bool                 `db:"deleted"`
+	RedirectType domain.RedirectType  `db:"redirect_type"`
+	PassQuery    bool                 `db:"pass_query"`
+	PassPath     bool                 `db:"pass_path"`
+	PasswordHash domain.PasswordHash  `db:"password_hash"`
+	MaxClicks    int64                `db:"max_clicks"`
+	ActiveFrom   time.Time            `db:"active_from"`
+	Rules        domain.RedirectRules `db:"rules"`
+	Variants     domain.Variants      `db:"variants"`
+	Canonical    domain.OriginalURL   `db:"canonical"`
+	Namespace    domain.UserID        `db:"namespace"`
+}
+
+const AddUser = `-- name: AddUser :exec
+INSERT INTO shortener.users (user_id, email, password_hash, created_at)
+VALUES ($1, $2, $3, $4)
+`
+
+type AddUserParams struct {
+	UserID       domain.UserID       `db:"user_id"`
+	Email        domain.Email        `db:"email"`
+	PasswordHash domain.PasswordHash `db:"password_hash"`
+	CreatedAt    time.Time           `db:"created_at"`
+}
+
+func (q *Queries) AddUser(ctx context.Context, arg AddUserParams) error {
+	_, err := q.db.Exec(ctx, AddUser,
+		arg.UserID,
+		arg.Email,
+		arg.PasswordHash,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const AddWebhook = `-- name: AddWebhook :exec
+INSERT INTO shortener.webhooks (webhook_id, user_id, url, secret, created_at)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type AddWebhookParams struct {
+	WebhookID string             `db:"webhook_id"`
 	UserID    domain.UserID      `db:"user_id"`
+	Url       domain.OriginalURL `db:"url"`
+	Secret    string             `db:"secret"`
 	CreatedAt time.Time          `db:"created_at"`
-	ExpiresAt time.Time          `db:"expires_at"`
-	Deleted   bool               `db:"deleted"`
+}
+
+func (q *Queries) AddWebhook(ctx context.Context, arg AddWebhookParams) error {
+	_, err := q.db.Exec(ctx, AddWebhook,
+		arg.WebhookID,
+		arg.UserID,
+		arg.Url,
+		arg.Secret,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+type AddWebhookDeliveriesParams struct {
+	WebhookID     string                       `db:"webhook_id"`
+	EventID       string                       `db:"event_id"`
+	EventType     domain.WebhookEventType      `db:"event_type"`
+	Payload       []byte                       `db:"payload"`
+	Status        domain.WebhookDeliveryStatus `db:"status"`
+	Attempts      int32                        `db:"attempts"`
+	NextAttemptAt time.Time                    `db:"next_attempt_at"`
+	LastError     string                       `db:"last_error"`
+	CreatedAt     time.Time                    `db:"created_at"`
+}
+
+const BanUser = `-- name: BanUser :one
+WITH banned AS (
+  INSERT INTO shortener.user_bans (user_id, reason, created_at)
+  VALUES ($1, $2, $3)
+  ON CONFLICT (user_id) DO UPDATE
+  SET reason = EXCLUDED.reason,
+      created_at = EXCLUDED.created_at
+)
+INSERT INTO shortener.moderation_log (action, slug, user_id, actor, reason, created_at)
+VALUES ($4, '', $1, $5, $2, $3)
+RETURNING id
+`
+
+type BanUserParams struct {
+	UserID    domain.UserID           `db:"user_id"`
+	Reason    string                  `db:"reason"`
+	CreatedAt time.Time               `db:"created_at"`
+	Action    domain.ModerationAction `db:"action"`
+	Actor     string                  `db:"actor"`
+}
+
+func (q *Queries) BanUser(ctx context.Context, arg BanUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, BanUser,
+		arg.UserID,
+		arg.Reason,
+		arg.CreatedAt,
+		arg.Action,
+		arg.Actor,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const ClaimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE shortener.outbox
+SET available_at = $1
+WHERE id IN (
+  SELECT id
+  FROM shortener.outbox
+  WHERE available_at <= $2
+  ORDER BY id
+  LIMIT $3
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event_id, event_type, slug, user_id, original, created_at, available_at
+`
+
+type ClaimOutboxEventsParams struct {
+	LeaseUntil time.Time `db:"lease_until"`
+	Now        time.Time `db:"now"`
+	MaxEvents  int32     `db:"max_events"`
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]ShortenerOutbox, error) {
+	rows, err := q.db.Query(ctx, ClaimOutboxEvents, arg.LeaseUntil, arg.Now, arg.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerOutbox
+	for rows.Next() {
+		var i ShortenerOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.EventType,
+			&i.Slug,
+			&i.UserID,
+			&i.Original,
+			&i.CreatedAt,
+			&i.AvailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ClaimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE shortener.webhook_deliveries AS d
+SET next_attempt_at = $1
+FROM shortener.webhooks AS w
+WHERE w.webhook_id = d.webhook_id
+  AND d.id IN (
+    SELECT id
+    FROM shortener.webhook_deliveries
+    WHERE status = 'pending'
+      AND next_attempt_at <= $2
+    ORDER BY next_attempt_at, id
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+  d.last_error, d.created_at, w.user_id, w.url, w.secret, w.created_at AS webhook_created_at
+`
+
+type ClaimWebhookDeliveriesParams struct {
+	LeaseUntil    time.Time `db:"lease_until"`
+	Now           time.Time `db:"now"`
+	MaxDeliveries int32     `db:"max_deliveries"`
+}
+
+type ClaimWebhookDeliveriesRow struct {
+	ID               int64                        `db:"id"`
+	WebhookID        string                       `db:"webhook_id"`
+	EventID          string                       `db:"event_id"`
+	EventType        domain.WebhookEventType      `db:"event_type"`
+	Payload          []byte                       `db:"payload"`
+	Status           domain.WebhookDeliveryStatus `db:"status"`
+	Attempts         int32                        `db:"attempts"`
+	NextAttemptAt    time.Time                    `db:"next_attempt_at"`
+	LastError        string                       `db:"last_error"`
+	CreatedAt        time.Time                    `db:"created_at"`
+	UserID           domain.UserID                `db:"user_id"`
+	Url              domain.OriginalURL           `db:"url"`
+	Secret           string                       `db:"secret"`
+	WebhookCreatedAt time.Time                    `db:"webhook_created_at"`
+}
+
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, ClaimWebhookDeliveries, arg.LeaseUntil, arg.Now, arg.MaxDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UserID,
+			&i.Url,
+			&i.Secret,
+			&i.WebhookCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const CreateDeletedSlugTempTable = `-- name: CreateDeletedSlugTempTable :exec
@@ -75,17 +391,39 @@ func (q *Queries) CreateDeletedSlugTempTable(ctx context.Context) error {
 	return err
 }
 
-const DeleteSlugsInTarget = `-- name: DeleteSlugsInTarget :exec
+const DeleteSlugsInTarget = `-- name: DeleteSlugsInTarget :many
 UPDATE shortener.urlmapping
 SET deleted = true
 FROM urlmapping_tmp
 WHERE shortener.urlmapping.slug = urlmapping_tmp.slug
   AND shortener.urlmapping.user_id = urlmapping_tmp.user_id
+  AND NOT shortener.urlmapping.deleted
+RETURNING shortener.urlmapping.slug, shortener.urlmapping.user_id
 `
 
-func (q *Queries) DeleteSlugsInTarget(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, DeleteSlugsInTarget)
-	return err
+type DeleteSlugsInTargetRow struct {
+	Slug   domain.Slug   `db:"slug"`
+	UserID domain.UserID `db:"user_id"`
+}
+
+func (q *Queries) DeleteSlugsInTarget(ctx context.Context) ([]DeleteSlugsInTargetRow, error) {
+	rows, err := q.db.Query(ctx, DeleteSlugsInTarget)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeleteSlugsInTargetRow
+	for rows.Next() {
+		var i DeleteSlugsInTargetRow
+		if err := rows.Scan(&i.Slug, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 type FillDeletedSlugTempTableParams struct {
@@ -93,27 +431,332 @@ type FillDeletedSlugTempTableParams struct {
 	UserID domain.UserID `db:"user_id"`
 }
 
-const GetStats = `-- name: GetStats :one
-SELECT 
-  COUNT(1)::BIGINT AS CountSlugs,
-  COUNT(DISTINCT user_id)::BIGINT AS CountUsers
+const DelCanonicalBackfill = `-- name: DelCanonicalBackfill :exec
+DELETE FROM shortener.canonical_backfill
+WHERE slug = $1
+`
+
+func (q *Queries) DelCanonicalBackfill(ctx context.Context, slug domain.Slug) error {
+	_, err := q.db.Exec(ctx, DelCanonicalBackfill, slug)
+	return err
+}
+
+const DelOutboxEvents = `-- name: DelOutboxEvents :exec
+DELETE FROM shortener.outbox
+WHERE id = ANY($1::BIGINT[])
+`
+
+func (q *Queries) DelOutboxEvents(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, DelOutboxEvents, ids)
+	return err
+}
+
+const DelWebhook = `-- name: DelWebhook :execrows
+DELETE FROM shortener.webhooks
+WHERE webhook_id = $1
+  AND user_id = $2
+`
+
+type DelWebhookParams struct {
+	WebhookID string        `db:"webhook_id"`
+	UserID    domain.UserID `db:"user_id"`
+}
+
+func (q *Queries) DelWebhook(ctx context.Context, arg DelWebhookParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DelWebhook, arg.WebhookID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteExpiredTokens = `-- name: DeleteExpiredTokens :execrows
+DELETE FROM shortener.revoked_tokens
+WHERE expires_at < $1
+`
+
+func (q *Queries) DeleteExpiredTokens(ctx context.Context, expiresAt time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteExpiredTokens, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const GetAPIKey = `-- name: GetAPIKey :one
+SELECT key_id, user_id, name, key_hash, scopes, created_at, revoked
+FROM shortener.api_keys
+WHERE key_id = $1
+`
+
+func (q *Queries) GetAPIKey(ctx context.Context, keyID string) (ShortenerApiKey, error) {
+	row := q.db.QueryRow(ctx, GetAPIKey, keyID)
+	var i ShortenerApiKey
+	err := row.Scan(
+		&i.KeyID,
+		&i.UserID,
+		&i.Name,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.Revoked,
+	)
+	return i, err
+}
+
+const GetAuditEvents = `-- name: GetAuditEvents :many
+SELECT id, action, slug, user_id, protocol, client_ip, details, created_at
+FROM shortener.audit_log
+WHERE ($1::VARCHAR = '' OR slug = $1)
+  AND ($2::BOOLEAN OR user_id = $3)
+ORDER BY id DESC
+LIMIT $4
+`
+
+type GetAuditEventsParams struct {
+	Slug      string        `db:"slug"`
+	AnyUser   bool          `db:"any_user"`
+	UserID    domain.UserID `db:"user_id"`
+	MaxEvents int32         `db:"max_events"`
+}
+
+func (q *Queries) GetAuditEvents(ctx context.Context, arg GetAuditEventsParams) ([]ShortenerAuditLog, error) {
+	rows, err := q.db.Query(ctx, GetAuditEvents,
+		arg.Slug,
+		arg.AnyUser,
+		arg.UserID,
+		arg.MaxEvents,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerAuditLog
+	for rows.Next() {
+		var i ShortenerAuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.Slug,
+			&i.UserID,
+			&i.Protocol,
+			&i.ClientIp,
+			&i.Details,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetCanonicalBackfill = `-- name: GetCanonicalBackfill :many
+SELECT u.slug, u.original, u.canonical, u.namespace, u.created_at
+FROM shortener.canonical_backfill b
+JOIN shortener.urlmapping u ON u.slug = b.slug
+ORDER BY u.created_at, u.slug
+LIMIT $1
+`
+
+type GetCanonicalBackfillRow struct {
+	Slug      domain.Slug        `db:"slug"`
+	Original  domain.OriginalURL `db:"original"`
+	Canonical domain.OriginalURL `db:"canonical"`
+	Namespace domain.UserID      `db:"namespace"`
+	CreatedAt time.Time          `db:"created_at"`
+}
+
+func (q *Queries) GetCanonicalBackfill(ctx context.Context, limit int32) ([]GetCanonicalBackfillRow, error) {
+	rows, err := q.db.Query(ctx, GetCanonicalBackfill, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCanonicalBackfillRow
+	for rows.Next() {
+		var i GetCanonicalBackfillRow
+		if err := rows.Scan(
+			&i.Slug,
+			&i.Original,
+			&i.Canonical,
+			&i.Namespace,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetCanonicalHolder = `-- name: GetCanonicalHolder :one
+SELECT slug, created_at
 FROM shortener.urlmapping
+WHERE namespace = $1
+  AND canonical = $2
+`
+
+type GetCanonicalHolderParams struct {
+	Namespace domain.UserID      `db:"namespace"`
+	Canonical domain.OriginalURL `db:"canonical"`
+}
+
+type GetCanonicalHolderRow struct {
+	Slug      domain.Slug `db:"slug"`
+	CreatedAt time.Time   `db:"created_at"`
+}
+
+func (q *Queries) GetCanonicalHolder(ctx context.Context, arg GetCanonicalHolderParams) (GetCanonicalHolderRow, error) {
+	row := q.db.QueryRow(ctx, GetCanonicalHolder, arg.Namespace, arg.Canonical)
+	var i GetCanonicalHolderRow
+	err := row.Scan(&i.Slug, &i.CreatedAt)
+	return i, err
+}
+
+const GetModerationLog = `-- name: GetModerationLog :many
+SELECT id, action, slug, user_id, actor, reason, created_at
+FROM shortener.moderation_log
+ORDER BY id DESC
+LIMIT $1
+`
+
+func (q *Queries) GetModerationLog(ctx context.Context, limit int32) ([]ShortenerModerationLog, error) {
+	rows, err := q.db.Query(ctx, GetModerationLog, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerModerationLog
+	for rows.Next() {
+		var i ShortenerModerationLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.Slug,
+			&i.UserID,
+			&i.Actor,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetStats = `-- name: GetStats :one
+SELECT
+  COALESCE(SUM(d.links), 0)::BIGINT AS CountSlugs,
+  COALESCE(SUM(d.deleted), 0)::BIGINT AS CountDeleted,
+  (
+    SELECT COUNT(1)
+    FROM shortener.stats_users AS u
+    WHERE u.links > 0
+  )::BIGINT AS CountUsers,
+  (
+    SELECT COALESCE(SUM(x.links), 0)
+    FROM shortener.stats_expiry AS x
+    WHERE x.day < $1::TIMESTAMP::DATE
+  )::BIGINT + (
+    SELECT COUNT(1)
+    FROM shortener.urlmapping AS m
+    WHERE m.expires_at >= $1::TIMESTAMP::DATE
+      AND m.expires_at <= $1::TIMESTAMP
+      AND NOT m.deleted
+  )::BIGINT AS CountExpired,
+  pg_total_relation_size('shortener.urlmapping')::BIGINT AS StorageBytes
+FROM shortener.stats_daily AS d
 `
 
 type GetStatsRow struct {
-	Countslugs int64 `db:"countslugs"`
-	Countusers int64 `db:"countusers"`
+	Countslugs   int64 `db:"countslugs"`
+	Countdeleted int64 `db:"countdeleted"`
+	Countusers   int64 `db:"countusers"`
+	Countexpired int64 `db:"countexpired"`
+	Storagebytes int64 `db:"storagebytes"`
 }
 
-func (q *Queries) GetStats(ctx context.Context) (GetStatsRow, error) {
-	row := q.db.QueryRow(ctx, GetStats)
+func (q *Queries) GetStats(ctx context.Context, now time.Time) (GetStatsRow, error) {
+	row := q.db.QueryRow(ctx, GetStats, now)
 	var i GetStatsRow
-	err := row.Scan(&i.Countslugs, &i.Countusers)
+	err := row.Scan(
+		&i.Countslugs,
+		&i.Countdeleted,
+		&i.Countusers,
+		&i.Countexpired,
+		&i.Storagebytes,
+	)
 	return i, err
 }
 
+const GetStatsDaily = `-- name: GetStatsDaily :many
+SELECT day, links, deleted
+FROM shortener.stats_daily
+WHERE day >= $1
+ORDER BY day
+`
+
+func (q *Queries) GetStatsDaily(ctx context.Context, day time.Time) ([]ShortenerStatsDaily, error) {
+	rows, err := q.db.Query(ctx, GetStatsDaily, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerStatsDaily
+	for rows.Next() {
+		var i ShortenerStatsDaily
+		if err := rows.Scan(&i.Day, &i.Links, &i.Deleted); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetStatsTopUsers = `-- name: GetStatsTopUsers :many
+SELECT user_id, links
+FROM shortener.stats_users
+WHERE links > 0
+ORDER BY links DESC, user_id
+LIMIT $1
+`
+
+func (q *Queries) GetStatsTopUsers(ctx context.Context, limit int32) ([]ShortenerStatsUser, error) {
+	rows, err := q.db.Query(ctx, GetStatsTopUsers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerStatsUser
+	for rows.Next() {
+		var i ShortenerStatsUser
+		if err := rows.Scan(&i.UserID, &i.Links); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetURLMapping = `-- name: GetURLMapping :one
-SELECT slug, original, user_id, created_at, expires_at, deleted
+SELECT slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
 FROM shortener.urlmapping
 WHERE slug = $1
 `
@@ -128,12 +771,96 @@ func (q *Queries) GetURLMapping(ctx context.Context, slug domain.Slug) (Shortene
 		&i.CreatedAt,
 		&i.ExpiresAt,
 		&i.Deleted,
+		&i.Clicks,
+		&i.RedirectType,
+		&i.PassQuery,
+		&i.PassPath,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.ActiveFrom,
+		&i.Rules,
+		&i.Variants,
+		&i.Canonical,
+		&i.Namespace,
+		&i.Disabled,
+		&i.DisabledReason,
+	)
+	return i, err
+}
+
+const GetUser = `-- name: GetUser :one
+SELECT user_id, email, password_hash, created_at
+FROM shortener.users
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, userID domain.UserID) (ShortenerUser, error) {
+	row := q.db.QueryRow(ctx, GetUser, userID)
+	var i ShortenerUser
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const GetUserAPIKeys = `-- name: GetUserAPIKeys :many
+SELECT key_id, user_id, name, key_hash, scopes, created_at, revoked
+FROM shortener.api_keys
+WHERE user_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) GetUserAPIKeys(ctx context.Context, userID domain.UserID) ([]ShortenerApiKey, error) {
+	rows, err := q.db.Query(ctx, GetUserAPIKeys, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerApiKey
+	for rows.Next() {
+		var i ShortenerApiKey
+		if err := rows.Scan(
+			&i.KeyID,
+			&i.UserID,
+			&i.Name,
+			&i.KeyHash,
+			&i.Scopes,
+			&i.CreatedAt,
+			&i.Revoked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetUserByEmail = `-- name: GetUserByEmail :one
+SELECT user_id, email, password_hash, created_at
+FROM shortener.users
+WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email domain.Email) (ShortenerUser, error) {
+	row := q.db.QueryRow(ctx, GetUserByEmail, email)
+	var i ShortenerUser
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const GetUserURLMappings = `-- name: GetUserURLMappings :many
-SELECT slug, original, user_id, created_at, expires_at, deleted
+SELECT slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
 FROM shortener.urlmapping
 WHERE user_id =$1
 `
@@ -154,6 +881,128 @@ func (q *Queries) GetUserURLMappings(ctx context.Context, userID domain.UserID)
 			&i.CreatedAt,
 			&i.ExpiresAt,
 			&i.Deleted,
+			&i.Clicks,
+			&i.RedirectType,
+			&i.PassQuery,
+			&i.PassPath,
+			&i.PasswordHash,
+			&i.MaxClicks,
+			&i.ActiveFrom,
+			&i.Rules,
+			&i.Variants,
+			&i.Canonical,
+			&i.Namespace,
+			&i.Disabled,
+			&i.DisabledReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetUserWebhooks = `-- name: GetUserWebhooks :many
+SELECT webhook_id, user_id, url, secret, created_at
+FROM shortener.webhooks
+WHERE user_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) GetUserWebhooks(ctx context.Context, userID domain.UserID) ([]ShortenerWebhook, error) {
+	rows, err := q.db.Query(ctx, GetUserWebhooks, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortenerWebhook
+	for rows.Next() {
+		var i ShortenerWebhook
+		if err := rows.Scan(
+			&i.WebhookID,
+			&i.UserID,
+			&i.Url,
+			&i.Secret,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetWebhookDeliveries = `-- name: GetWebhookDeliveries :many
+SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+  d.last_error, d.created_at, w.user_id, w.url, w.secret, w.created_at AS webhook_created_at
+FROM shortener.webhook_deliveries AS d
+JOIN shortener.webhooks AS w ON w.webhook_id = d.webhook_id
+WHERE d.webhook_id = $1
+  AND w.user_id = $2
+  AND d.status = $3
+ORDER BY d.id DESC
+LIMIT $4
+`
+
+type GetWebhookDeliveriesParams struct {
+	WebhookID string                       `db:"webhook_id"`
+	UserID    domain.UserID                `db:"user_id"`
+	Status    domain.WebhookDeliveryStatus `db:"status"`
+	Limit     int32                        `db:"limit"`
+}
+
+type GetWebhookDeliveriesRow struct {
+	ID               int64                        `db:"id"`
+	WebhookID        string                       `db:"webhook_id"`
+	EventID          string                       `db:"event_id"`
+	EventType        domain.WebhookEventType      `db:"event_type"`
+	Payload          []byte                       `db:"payload"`
+	Status           domain.WebhookDeliveryStatus `db:"status"`
+	Attempts         int32                        `db:"attempts"`
+	NextAttemptAt    time.Time                    `db:"next_attempt_at"`
+	LastError        string                       `db:"last_error"`
+	CreatedAt        time.Time                    `db:"created_at"`
+	UserID           domain.UserID                `db:"user_id"`
+	Url              domain.OriginalURL           `db:"url"`
+	Secret           string                       `db:"secret"`
+	WebhookCreatedAt time.Time                    `db:"webhook_created_at"`
+}
+
+func (q *Queries) GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]GetWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, GetWebhookDeliveries,
+		arg.WebhookID,
+		arg.UserID,
+		arg.Status,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWebhookDeliveriesRow
+	for rows.Next() {
+		var i GetWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UserID,
+			&i.Url,
+			&i.Secret,
+			&i.WebhookCreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -164,3 +1013,392 @@ func (q *Queries) GetUserURLMappings(ctx context.Context, userID domain.UserID)
 	}
 	return items, nil
 }
+
+const IsTokenRevoked = `-- name: IsTokenRevoked :one
+SELECT EXISTS (
+  SELECT 1
+  FROM shortener.revoked_tokens
+  WHERE jti = $1
+)
+`
+
+func (q *Queries) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	row := q.db.QueryRow(ctx, IsTokenRevoked, jti)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const IsUserBanned = `-- name: IsUserBanned :one
+SELECT EXISTS (
+  SELECT 1
+  FROM shortener.user_bans
+  WHERE user_id = $1
+)
+`
+
+func (q *Queries) IsUserBanned(ctx context.Context, userID domain.UserID) (bool, error) {
+	row := q.db.QueryRow(ctx, IsUserBanned, userID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const ModerateURLMapping = `-- name: ModerateURLMapping :one
+WITH moderated AS (
+  UPDATE shortener.urlmapping
+  SET disabled = $1,
+      disabled_reason = $2
+  WHERE slug = $3
+  RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
+), logged AS (
+  INSERT INTO shortener.moderation_log (action, slug, user_id, actor, reason, created_at)
+  SELECT $4::VARCHAR, m.slug, m.user_id, $5::VARCHAR, $6::TEXT, $7::TIMESTAMP
+  FROM moderated AS m
+  RETURNING id
+)
+SELECT m.slug, m.original, m.user_id, m.created_at, m.expires_at, m.deleted, m.clicks, m.redirect_type, m.pass_query, m.pass_path, m.password_hash, m.max_clicks, m.active_from, m.rules, m.variants, m.canonical, m.namespace, m.disabled, m.disabled_reason, l.id AS log_id
+FROM moderated AS m, logged AS l
+`
+
+type ModerateURLMappingParams struct {
+	Disabled       bool        `db:"disabled"`
+	DisabledReason string      `db:"disabled_reason"`
+	Slug           domain.Slug `db:"slug"`
+	Action         string      `db:"action"`
+	Actor          string      `db:"actor"`
+	Reason         string      `db:"reason"`
+	CreatedAt      time.Time   `db:"created_at"`
+}
+
+type ModerateURLMappingRow struct {
+	Slug           domain.Slug          `db:"slug"`
+	Original       domain.OriginalURL   `db:"original"`
+	UserID         domain.UserID        `db:"user_id"`
+	CreatedAt      time.Time            `db:"created_at"`
+	ExpiresAt      time.Time            `db:"expires_at"`
+	Deleted        bool                 `db:"deleted"`
+	Clicks         int64                `db:"clicks"`
+	RedirectType   domain.RedirectType  `db:"redirect_type"`
+	PassQuery      bool                 `db:"pass_query"`
+	PassPath       bool                 `db:"pass_path"`
+	PasswordHash   domain.PasswordHash  `db:"password_hash"`
+	MaxClicks      int64                `db:"max_clicks"`
+	ActiveFrom     time.Time            `db:"active_from"`
+	Rules          domain.RedirectRules `db:"rules"`
+	Variants       domain.Variants      `db:"variants"`
+	Canonical      domain.OriginalURL   `db:"canonical"`
+	Namespace      domain.UserID        `db:"namespace"`
+	Disabled       bool                 `db:"disabled"`
+	DisabledReason string               `db:"disabled_reason"`
+	LogID          int64                `db:"log_id"`
+}
+
+func (q *Queries) ModerateURLMapping(ctx context.Context, arg ModerateURLMappingParams) (ModerateURLMappingRow, error) {
+	row := q.db.QueryRow(ctx, ModerateURLMapping,
+		arg.Disabled,
+		arg.DisabledReason,
+		arg.Slug,
+		arg.Action,
+		arg.Actor,
+		arg.Reason,
+		arg.CreatedAt,
+	)
+	var i ModerateURLMappingRow
+	err := row.Scan(
+		&i.Slug,
+		&i.Original,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Deleted,
+		&i.Clicks,
+		&i.RedirectType,
+		&i.PassQuery,
+		&i.PassPath,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.ActiveFrom,
+		&i.Rules,
+		&i.Variants,
+		&i.Canonical,
+		&i.Namespace,
+		&i.Disabled,
+		&i.DisabledReason,
+		&i.LogID,
+	)
+	return i, err
+}
+
+const ReassignUserURLMappings = `-- name: ReassignUserURLMappings :execrows
+UPDATE shortener.urlmapping AS m
+SET user_id = $1,
+    namespace = CASE WHEN m.namespace = $2 THEN $1 ELSE m.namespace END
+WHERE m.user_id = $2
+  AND NOT (m.namespace = $2 AND EXISTS (
+    SELECT 1
+    FROM shortener.urlmapping AS o
+    WHERE o.namespace = $1
+      AND o.canonical = m.canonical
+  ))
+`
+
+type ReassignUserURLMappingsParams struct {
+	ToUser   domain.UserID `db:"to_user"`
+	FromUser domain.UserID `db:"from_user"`
+}
+
+func (q *Queries) ReassignUserURLMappings(ctx context.Context, arg ReassignUserURLMappingsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ReassignUserURLMappings, arg.ToUser, arg.FromUser)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const RegisterClick = `-- name: RegisterClick :one
+UPDATE shortener.urlmapping
+SET clicks = clicks + 1,
+    variants = CASE
+      WHEN $2::INT > 0 THEN jsonb_set(
+        variants,
+        ARRAY[($2::INT - 1)::TEXT, 'clicks'],
+        to_jsonb(COALESCE((variants -> ($2::INT - 1) ->> 'clicks')::BIGINT, 0) + 1))
+      ELSE variants
+    END
+WHERE slug = $1
+  AND (max_clicks = 0 OR clicks < max_clicks)
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
+`
+
+type RegisterClickParams struct {
+	Slug    domain.Slug `db:"slug"`
+	Variant int32       `db:"variant"`
+}
+
+func (q *Queries) RegisterClick(ctx context.Context, arg RegisterClickParams) (ShortenerUrlmapping, error) {
+	row := q.db.QueryRow(ctx, RegisterClick, arg.Slug, arg.Variant)
+	var i ShortenerUrlmapping
+	err := row.Scan(
+		&i.Slug,
+		&i.Original,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Deleted,
+		&i.Clicks,
+		&i.RedirectType,
+		&i.PassQuery,
+		&i.PassPath,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.ActiveFrom,
+		&i.Rules,
+		&i.Variants,
+		&i.Canonical,
+		&i.Namespace,
+		&i.Disabled,
+		&i.DisabledReason,
+	)
+	return i, err
+}
+
+const RevokeAPIKey = `-- name: RevokeAPIKey :execrows
+UPDATE shortener.api_keys
+SET revoked = true
+WHERE key_id = $1
+  AND user_id = $2
+`
+
+type RevokeAPIKeyParams struct {
+	KeyID  string        `db:"key_id"`
+	UserID domain.UserID `db:"user_id"`
+}
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RevokeAPIKey, arg.KeyID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const RevokeToken = `-- name: RevokeToken :exec
+INSERT INTO shortener.revoked_tokens (jti, expires_at)
+VALUES ($1, $2)
+ON CONFLICT (jti) DO NOTHING
+`
+
+type RevokeTokenParams struct {
+	Jti       string    `db:"jti"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, arg RevokeTokenParams) error {
+	_, err := q.db.Exec(ctx, RevokeToken, arg.Jti, arg.ExpiresAt)
+	return err
+}
+
+const UnbanUser = `-- name: UnbanUser :one
+WITH unbanned AS (
+  DELETE FROM shortener.user_bans
+  WHERE user_id = $1
+)
+INSERT INTO shortener.moderation_log (action, slug, user_id, actor, reason, created_at)
+VALUES ($2, '', $1, $3, $4, $5)
+RETURNING id
+`
+
+type UnbanUserParams struct {
+	UserID    domain.UserID           `db:"user_id"`
+	Action    domain.ModerationAction `db:"action"`
+	Actor     string                  `db:"actor"`
+	Reason    string                  `db:"reason"`
+	CreatedAt time.Time               `db:"created_at"`
+}
+
+func (q *Queries) UnbanUser(ctx context.Context, arg UnbanUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, UnbanUser,
+		arg.UserID,
+		arg.Action,
+		arg.Actor,
+		arg.Reason,
+		arg.CreatedAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const UpdateURLMappingCanonical = `-- name: UpdateURLMappingCanonical :exec
+UPDATE shortener.urlmapping
+SET canonical = $2
+WHERE slug = $1
+`
+
+type UpdateURLMappingCanonicalParams struct {
+	Slug      domain.Slug        `db:"slug"`
+	Canonical domain.OriginalURL `db:"canonical"`
+}
+
+func (q *Queries) UpdateURLMappingCanonical(ctx context.Context, arg UpdateURLMappingCanonicalParams) error {
+	_, err := q.db.Exec(ctx, UpdateURLMappingCanonical, arg.Slug, arg.Canonical)
+	return err
+}
+
+const UpdateURLMappingRules = `-- name: UpdateURLMappingRules :one
+UPDATE shortener.urlmapping
+SET rules = $3
+WHERE slug = $1
+  AND user_id = $2
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
+`
+
+type UpdateURLMappingRulesParams struct {
+	Slug   domain.Slug          `db:"slug"`
+	UserID domain.UserID        `db:"user_id"`
+	Rules  domain.RedirectRules `db:"rules"`
+}
+
+func (q *Queries) UpdateURLMappingRules(ctx context.Context, arg UpdateURLMappingRulesParams) (ShortenerUrlmapping, error) {
+	row := q.db.QueryRow(ctx, UpdateURLMappingRules, arg.Slug, arg.UserID, arg.Rules)
+	var i ShortenerUrlmapping
+	err := row.Scan(
+		&i.Slug,
+		&i.Original,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Deleted,
+		&i.Clicks,
+		&i.RedirectType,
+		&i.PassQuery,
+		&i.PassPath,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.ActiveFrom,
+		&i.Rules,
+		&i.Variants,
+		&i.Canonical,
+		&i.Namespace,
+		&i.Disabled,
+		&i.DisabledReason,
+	)
+	return i, err
+}
+
+const UpdateURLMappingSchedule = `-- name: UpdateURLMappingSchedule :one
+UPDATE shortener.urlmapping
+SET active_from = $3,
+    expires_at = $4
+WHERE slug = $1
+  AND user_id = $2
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
+`
+
+type UpdateURLMappingScheduleParams struct {
+	Slug       domain.Slug   `db:"slug"`
+	UserID     domain.UserID `db:"user_id"`
+	ActiveFrom time.Time     `db:"active_from"`
+	ExpiresAt  time.Time     `db:"expires_at"`
+}
+
+func (q *Queries) UpdateURLMappingSchedule(ctx context.Context, arg UpdateURLMappingScheduleParams) (ShortenerUrlmapping, error) {
+	row := q.db.QueryRow(ctx, UpdateURLMappingSchedule,
+		arg.Slug,
+		arg.UserID,
+		arg.ActiveFrom,
+		arg.ExpiresAt,
+	)
+	var i ShortenerUrlmapping
+	err := row.Scan(
+		&i.Slug,
+		&i.Original,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Deleted,
+		&i.Clicks,
+		&i.RedirectType,
+		&i.PassQuery,
+		&i.PassPath,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.ActiveFrom,
+		&i.Rules,
+		&i.Variants,
+		&i.Canonical,
+		&i.Namespace,
+		&i.Disabled,
+		&i.DisabledReason,
+	)
+	return i, err
+}
+
+const UpdateWebhookDelivery = `-- name: UpdateWebhookDelivery :exec
+UPDATE shortener.webhook_deliveries
+SET status = $2,
+    attempts = $3,
+    next_attempt_at = $4,
+    last_error = $5
+WHERE id = $1
+`
+
+type UpdateWebhookDeliveryParams struct {
+	ID            int64                        `db:"id"`
+	Status        domain.WebhookDeliveryStatus `db:"status"`
+	Attempts      int32                        `db:"attempts"`
+	NextAttemptAt time.Time                    `db:"next_attempt_at"`
+	LastError     string                       `db:"last_error"`
+}
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, UpdateWebhookDelivery,
+		arg.ID,
+		arg.Status,
+		arg.Attempts,
+		arg.NextAttemptAt,
+		arg.LastError,
+	)
+	return err
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/mailru/easyjson"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+)
+
+// Audit log permissions: files are read/write for owner, read-only for others.
+const (
+	auditFilePerm = 0o644
+	auditDirPerm  = 0o755
+)
+
+// FileAuditRepository is an audit log kept in a JSON lines file, one event per line.
+// Events are only ever appended to the file, record IDs are line numbers.
+type FileAuditRepository struct {
+	sync.Mutex
+	path   string
+	lastID int64
+}
+
+// NewFileAuditRepository creates a new instance of FileAuditRepository appending to the file at path,
+// the file and its directory are created when missing.
+func NewFileAuditRepository(path string) (*FileAuditRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), auditDirPerm); err != nil {
+		return nil, e.Wrap("failed to create audit log directory", err, errLabel)
+	}
+
+	repo := &FileAuditRepository{
+		Mutex:  sync.Mutex{},
+		path:   path,
+		lastID: 0,
+	}
+
+	err := repo.scan(func(event *domain.AuditEvent) {
+		repo.lastID = event.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// AddAuditEvents appends events to the audit log file and syncs it, events are assigned their record IDs.
+func (repo *FileAuditRepository) AddAuditEvents(_ context.Context, events []domain.AuditEvent) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	file, err := os.OpenFile(repo.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, auditFilePerm)
+	if err != nil {
+		return e.Wrap("failed to open audit log", err, errLabel)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	lastID := repo.lastID
+
+	for i := range events {
+		lastID++
+		events[i].ID = lastID
+
+		data, err := easyjson.Marshal(dto.NewAuditEvent(&events[i]))
+		if err != nil {
+			return e.Wrap("failed to marshal audit event", err, errLabel)
+		}
+
+		if _, err := writer.Write(append(data, '\n')); err != nil {
+			return e.Wrap("failed to write audit event", err, errLabel)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return e.Wrap("failed to write audit events", err, errLabel)
+	}
+
+	if err := file.Sync(); err != nil {
+		return e.Wrap("failed to sync audit log", err, errLabel)
+	}
+
+	repo.lastID = lastID
+
+	return nil
+}
+
+// GetAuditEvents retrieves the latest events of the audit log matching the filter, newest first.
+func (repo *FileAuditRepository) GetAuditEvents(
+	_ context.Context,
+	filter dto.AuditFilter,
+) ([]domain.AuditEvent, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	events := []domain.AuditEvent{}
+
+	err := repo.scan(func(event *domain.AuditEvent) {
+		if filter.Matches(event) {
+			events = append(events, *event)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(events)
+
+	return events[:min(filter.Limit, len(events))], nil
+}
+
+// scan reads all events of the audit log file in order.
+func (repo *FileAuditRepository) scan(fn func(event *domain.AuditEvent)) error {
+	file, err := os.OpenFile(repo.path, os.O_RDONLY|os.O_CREATE, auditFilePerm)
+	if err != nil {
+		return e.Wrap("failed to open audit log", err, errLabel)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var line dto.AuditEvent
+
+		if err := easyjson.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return e.Wrap("failed to unmarshal audit event", err, errLabel)
+		}
+
+		event, err := line.Event()
+		if err != nil {
+			return e.Wrap("failed to parse audit event", err, errLabel)
+		}
+
+		fn(&event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return e.Wrap("failed to read audit log", err, errLabel)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestFileAuditRepository(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit", "audit_log.jsonl")
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	otherUserID := domain.NewUserID()
+	source := domain.RequestSource{Protocol: domain.ProtocolHTTP, ClientIP: "127.0.0.1"}
+
+	repo, err := repository.NewFileAuditRepository(path)
+	require.NoError(t, err)
+
+	err = repo.AddAuditEvents(ctx, []domain.AuditEvent{
+		domain.NewAuditEvent(domain.AuditCreated, "slug1", userID, source, ""),
+		domain.NewAuditEvent(domain.AuditCreated, "slug2", otherUserID, source, ""),
+	})
+	require.NoError(t, err)
+
+	// events are appended after the ones of previous runs
+	repo, err = repository.NewFileAuditRepository(path)
+	require.NoError(t, err)
+
+	err = repo.AddAuditEvents(ctx, []domain.AuditEvent{
+		domain.NewAuditEvent(domain.AuditUpdated, "slug1", userID, source, "rules"),
+	})
+	require.NoError(t, err)
+
+	events, err := repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "slug1", UserID: domain.UserID{}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(3), events[0].ID)
+	assert.Equal(t, domain.AuditUpdated, events[0].Action)
+	assert.Equal(t, "rules", events[0].Details)
+	assert.Equal(t, int64(1), events[1].ID)
+	assert.Equal(t, source, domain.RequestSource{Protocol: events[1].Protocol, ClientIP: events[1].ClientIP})
+
+	events, err = repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "", UserID: userID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(3), events[0].ID)
+
+	events, err = repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "slug2", UserID: userID, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestFileAuditRepositoryCorrupted(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit_log.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+	_, err := repository.NewFileAuditRepository(path)
+	require.Error(t, err)
+}
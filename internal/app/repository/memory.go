@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
@@ -10,21 +14,180 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
 )
 
+// originalKey identifies an original URL within its namespace.
+type originalKey struct {
+	namespace domain.UserID
+	canonical domain.OriginalURL
+}
+
+// keyOf returns the original URL key of a URL mapping.
+func keyOf(m *domain.URLMapping) originalKey {
+	return originalKey{namespace: m.Namespace, canonical: m.Canonical}
+}
+
+// memoryStats holds stats counters maintained on URL mappings changes.
+type memoryStats struct {
+	daily    map[string]int64          // URL mappings created per day
+	deleted  int64                     // deleted URL mappings
+	expiring map[domain.Slug]time.Time // expiration times of not deleted URL mappings
+	expiries sortedSet[expiry]         // expiration times of not deleted URL mappings, earliest first
+	users    map[domain.UserID]int64   // URL mappings per user
+	top      sortedSet[userLinks]      // URL mappings per user, most first
+	bytes    int64                     // size of stored URLs
+}
+
+func newMemoryStats() memoryStats {
+	return memoryStats{
+		daily:    make(map[string]int64),
+		deleted:  0,
+		expiring: make(map[domain.Slug]time.Time),
+		expiries: sortedSet[expiry]{items: nil, less: expiry.before},
+		users:    make(map[domain.UserID]int64),
+		top:      sortedSet[userLinks]{items: nil, less: userLinks.before},
+		bytes:    0,
+	}
+}
+
+// add counts a new URL mapping.
+func (s *memoryStats) add(m *domain.URLMapping) {
+	s.daily[m.CreatedAt.Format(time.DateOnly)]++
+	s.bytes += int64(len(m.Slug) + len(m.OriginalURL) + len(m.Canonical))
+
+	if m.Deleted {
+		s.deleted++
+	}
+
+	s.count(m.UserID, 1)
+	s.schedule(m)
+}
+
+// schedule counts the expiration time of a URL mapping.
+func (s *memoryStats) schedule(m *domain.URLMapping) {
+	if expiresAt, ok := s.expiring[m.Slug]; ok {
+		s.expiries.remove(expiry{at: expiresAt, slug: m.Slug})
+		delete(s.expiring, m.Slug)
+	}
+
+	if m.ExpiresAt.IsZero() || m.Deleted {
+		return
+	}
+
+	s.expiring[m.Slug] = m.ExpiresAt
+	s.expiries.insert(expiry{at: m.ExpiresAt, slug: m.Slug})
+}
+
+// count changes the number of URL mappings of a user by delta.
+func (s *memoryStats) count(user domain.UserID, delta int64) {
+	links, ok := s.users[user]
+	if ok {
+		s.top.remove(userLinks{user: user, links: links})
+	}
+
+	links += delta
+	if links <= 0 {
+		delete(s.users, user)
+
+		return
+	}
+
+	s.users[user] = links
+	s.top.insert(userLinks{user: user, links: links})
+}
+
+// expired returns the number of not deleted URL mappings expired by now.
+func (s *memoryStats) expired(now time.Time) int64 {
+	return int64(sort.Search(len(s.expiries.items), func(i int) bool {
+		return now.Before(s.expiries.items[i].at)
+	}))
+}
+
+// topUsers returns up to limit users with the most URL mappings.
+func (s *memoryStats) topUsers(limit int) []dto.UserStats {
+	users := make([]dto.UserStats, min(limit, len(s.top.items)))
+	for i := range users {
+		users[i] = dto.UserStats{UserID: s.top.items[i].user.String(), CountSlugs: s.top.items[i].links}
+	}
+
+	return users
+}
+
+// expiry is the expiration time of a URL mapping.
+type expiry struct {
+	at   time.Time
+	slug domain.Slug
+}
+
+func (x expiry) before(y expiry) bool {
+	if !x.at.Equal(y.at) {
+		return x.at.Before(y.at)
+	}
+
+	return x.slug < y.slug
+}
+
+// userLinks is the number of URL mappings of a user.
+type userLinks struct {
+	user  domain.UserID
+	links int64
+}
+
+func (x userLinks) before(y userLinks) bool {
+	if x.links != y.links {
+		return x.links > y.links
+	}
+
+	return bytes.Compare(x.user[:], y.user[:]) < 0
+}
+
+// sortedSet is a set of values kept sorted on changes, same as the database indexes of stats.
+type sortedSet[T comparable] struct {
+	items []T
+	less  func(x, y T) bool
+}
+
+// search returns the position of the first item not less than the value.
+func (s *sortedSet[T]) search(value T) int {
+	return sort.Search(len(s.items), func(i int) bool {
+		return !s.less(s.items[i], value)
+	})
+}
+
+func (s *sortedSet[T]) insert(value T) {
+	s.items = slices.Insert(s.items, s.search(value), value)
+}
+
+func (s *sortedSet[T]) remove(value T) {
+	if i := s.search(value); i < len(s.items) && s.items[i] == value {
+		s.items = slices.Delete(s.items, i, i+1)
+	}
+}
+
 // InMemoryURLRepository is an in-memory implementation of the URL repository.
 type InMemoryURLRepository struct {
 	sync.RWMutex
 	values   dto.URLMappings
-	uIndex   map[domain.OriginalURL]domain.Slug
+	uIndex   map[originalKey]domain.Slug
 	usrIndex map[domain.UserID][]domain.Slug
+	stats    memoryStats
+	bans     map[domain.UserID]struct{}
+	modLog   []domain.ModerationEntry
+	// outbox of URL mapping events and the last assigned event ID.
+	outbox    []outboxEntry
+	outboxSeq int64
 }
 
 // NewInMemoryURLRepository creates a new InMemoryURLRepository instance.
 func NewInMemoryURLRepository() *InMemoryURLRepository {
 	return &InMemoryURLRepository{
-		RWMutex:  sync.RWMutex{},
-		values:   make(dto.URLMappings),
-		uIndex:   make(map[domain.OriginalURL]domain.Slug),
-		usrIndex: make(map[domain.UserID][]domain.Slug),
+		RWMutex:   sync.RWMutex{},
+		values:    make(dto.URLMappings),
+		uIndex:    make(map[originalKey]domain.Slug),
+		usrIndex:  make(map[domain.UserID][]domain.Slug),
+		stats:     newMemoryStats(),
+		bans:      make(map[domain.UserID]struct{}),
+		modLog:    make([]domain.ModerationEntry, 0),
+		outbox:    make([]outboxEntry, 0),
+		outboxSeq: 0,
 	}
 }
 
@@ -40,16 +203,17 @@ func (ms *InMemoryURLRepository) AddURLMapping(
 		return urlMap, e.ErrSlugExists
 	}
 
-	if _, exists := ms.uIndex[urlMap.OriginalURL]; exists {
-		slug := ms.uIndex[urlMap.OriginalURL]
+	if slug, exists := ms.uIndex[keyOf(urlMap)]; exists {
 		urlMapping := ms.values[slug]
 
 		return &urlMapping, e.ErrOriginalExists
 	}
 
 	ms.values[urlMap.Slug] = *urlMap
-	ms.uIndex[urlMap.OriginalURL] = urlMap.Slug
+	ms.uIndex[keyOf(urlMap)] = urlMap.Slug
 	ms.usrIndex[urlMap.UserID] = append(ms.usrIndex[urlMap.UserID], urlMap.Slug)
+	ms.stats.add(urlMap)
+	ms.appendOutbox(domain.URLMappingCreatedEvent(urlMap))
 
 	return urlMap, nil
 }
@@ -67,6 +231,127 @@ func (ms *InMemoryURLRepository) GetURLMapping(_ context.Context, slug domain.Sl
 	return &m, nil
 }
 
+// RegisterClick increments the click counter of a URL mapping and returns the updated mapping.
+// Click counter of the served variant is incremented as well, zero variant stands for none.
+// Click limited mappings are not incremented beyond their limit.
+func (ms *InMemoryURLRepository) RegisterClick(
+	_ context.Context,
+	slug domain.Slug,
+	variant int,
+) (*domain.URLMapping, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, exists := ms.values[slug]
+	if !exists {
+		return nil, e.ErrSlugNotFound
+	}
+
+	if m.Exhausted() {
+		return nil, e.ErrSlugExhausted
+	}
+
+	m.Clicks++
+
+	if m.Variants.Has(variant) {
+		// copy on write as variants may be shared with previously returned mappings.
+		m.Variants = append(domain.Variants(nil), m.Variants...)
+		m.Variants[variant-1].Clicks++
+	}
+
+	ms.values[slug] = m
+
+	return &m, nil
+}
+
+// UpdateURLMappingSchedule updates the activation window of a URL mapping owned by the given user.
+func (ms *InMemoryURLRepository) UpdateURLMappingSchedule(
+	_ context.Context,
+	owner dto.UserSlug,
+	schedule *dto.URLSchedule,
+) (*domain.URLMapping, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, exists := ms.values[owner.Slug]
+	if !exists || m.UserID != owner.UserID {
+		return nil, e.ErrSlugNotFound
+	}
+
+	m.ActiveFrom = schedule.ActiveFrom
+	m.ExpiresAt = schedule.ExpiresAt
+	ms.values[owner.Slug] = m
+	ms.stats.schedule(&m)
+
+	return &m, nil
+}
+
+// UpdateURLMappingRules replaces the redirect rules of a URL mapping owned by the given user.
+func (ms *InMemoryURLRepository) UpdateURLMappingRules(
+	_ context.Context,
+	owner dto.UserSlug,
+	rules domain.RedirectRules,
+) (*domain.URLMapping, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, exists := ms.values[owner.Slug]
+	if !exists || m.UserID != owner.UserID {
+		return nil, e.ErrSlugNotFound
+	}
+
+	m.Rules = append(domain.RedirectRules(nil), rules...)
+	ms.values[owner.Slug] = m
+
+	return &m, nil
+}
+
+// ReassignUserURLMappings transfers all URL mappings of a user to another one and returns their number.
+// Mappings in the namespace of the former user move to the namespace of the new one,
+// unless the new one already shortened the same original URL, such mappings stay with the former user.
+func (ms *InMemoryURLRepository) ReassignUserURLMappings(_ context.Context, from, to domain.UserID) (int64, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	var (
+		moved int64
+		kept  []domain.Slug
+	)
+
+	for _, slug := range ms.usrIndex[from] {
+		m := ms.values[slug]
+
+		if m.Namespace == from {
+			key := originalKey{namespace: to, canonical: m.Canonical}
+			if _, exists := ms.uIndex[key]; exists {
+				kept = append(kept, slug)
+
+				continue
+			}
+
+			delete(ms.uIndex, keyOf(&m))
+			m.Namespace = to
+			ms.uIndex[key] = slug
+		}
+
+		m.UserID = to
+		ms.values[slug] = m
+		ms.usrIndex[to] = append(ms.usrIndex[to], slug)
+		moved++
+	}
+
+	if len(kept) == 0 {
+		delete(ms.usrIndex, from)
+	} else {
+		ms.usrIndex[from] = kept
+	}
+
+	ms.stats.count(from, -moved)
+	ms.stats.count(to, moved)
+
+	return moved, nil
+}
+
 // GetUserURLMappings retrieves all URL mappings for a specific user.
 func (ms *InMemoryURLRepository) GetUserURLMappings(
 	_ context.Context,
@@ -98,7 +383,7 @@ func (ms *InMemoryURLRepository) AddURLMappingBatch(_ context.Context, batch *[]
 			return e.ErrSlugExists
 		}
 
-		if _, exists := ms.uIndex[m.OriginalURL]; exists {
+		if _, exists := ms.uIndex[keyOf(&m)]; exists {
 			return e.ErrOriginalExists
 		}
 	}
@@ -106,8 +391,10 @@ func (ms *InMemoryURLRepository) AddURLMappingBatch(_ context.Context, batch *[]
 	// No conflicts found; proceed with adding to maps.
 	for _, m := range *batch {
 		ms.values[m.Slug] = m
-		ms.uIndex[m.OriginalURL] = m.Slug
+		ms.uIndex[keyOf(&m)] = m.Slug
 		ms.usrIndex[m.UserID] = append(ms.usrIndex[m.UserID], m.Slug)
+		ms.stats.add(&m)
+		ms.appendOutbox(domain.URLMappingCreatedEvent(&m))
 	}
 
 	return nil
@@ -120,7 +407,14 @@ func (ms *InMemoryURLRepository) CreateMemento() (*memento.Memento, error) {
 
 	cp := dto.URLMappingsCopy(ms.values)
 
-	return memento.NewMemento(cp), nil
+	bans := make([]domain.UserID, 0, len(ms.bans))
+	for user := range ms.bans {
+		bans = append(bans, user)
+	}
+
+	modLog := append([]domain.ModerationEntry(nil), ms.modLog...)
+
+	return memento.NewMemento(cp).WithModeration(bans, modLog), nil
 }
 
 // RestoreMemento restores the state of the repository from the given memento.
@@ -136,51 +430,89 @@ func (ms *InMemoryURLRepository) RestoreMemento(m *memento.Memento) error {
 	cp := dto.URLMappingsCopy(m.GetState())
 	ms.values = cp
 
+	ms.bans = make(map[domain.UserID]struct{}, len(m.GetBans()))
+	for _, user := range m.GetBans() {
+		ms.bans[user] = struct{}{}
+	}
+
+	ms.modLog = append(make([]domain.ModerationEntry, 0, len(m.GetModerationLog())), m.GetModerationLog()...)
+
 	// Rebuild indexes to maintain consistency with values.
-	ms.uIndex = make(map[domain.OriginalURL]domain.Slug)
+	ms.uIndex = make(map[originalKey]domain.Slug)
 	ms.usrIndex = make(map[domain.UserID][]domain.Slug)
+	ms.stats = newMemoryStats()
 
 	for slug, mapping := range ms.values {
-		ms.uIndex[mapping.OriginalURL] = slug
+		// states stored before canonicalization lack canonical urls.
+		if mapping.Canonical == "" {
+			mapping.Canonical = mapping.OriginalURL.Canonical(false)
+			ms.values[slug] = mapping
+		}
+
+		// urls sharing a canonical form are deduplicated against the oldest one.
+		if held, exists := ms.uIndex[keyOf(&mapping)]; !exists || isOlder(&mapping, ms.values[held]) {
+			ms.uIndex[keyOf(&mapping)] = slug
+		}
+
 		ms.usrIndex[mapping.UserID] = append(ms.usrIndex[mapping.UserID], slug)
+		ms.stats.add(&mapping)
 	}
 
 	return nil
 }
 
+// isOlder checks whether a URL mapping was created before another one, slugs break ties.
+func isOlder(m *domain.URLMapping, other domain.URLMapping) bool {
+	if m.CreatedAt.Equal(other.CreatedAt) {
+		return m.Slug < other.Slug
+	}
+
+	return m.CreatedAt.Before(other.CreatedAt)
+}
+
 // DelUserURLMappings marks user URL mappings as deleted based on the provided tasks.
-func (ms *InMemoryURLRepository) DelUserURLMappings(_ context.Context, tasks []dto.UserSlug) error {
-	updateTasks := make([]dto.UserSlug, 0, len(tasks))
+// It returns the tasks of URL mappings that have been deleted, mappings of other users
+// and mappings deleted before are left as is.
+func (ms *InMemoryURLRepository) DelUserURLMappings(_ context.Context, tasks []dto.UserSlug) ([]dto.UserSlug, error) {
+	deleted := make([]dto.UserSlug, 0, len(tasks))
+
+	ms.Lock()
+	defer ms.Unlock()
 
-	ms.RLock()
 	for _, task := range tasks {
 		val, ok := ms.values[task.Slug]
-		if ok && val.UserID == task.UserID {
-			updateTasks = append(updateTasks, task)
+		if !ok || val.UserID != task.UserID || val.Deleted {
+			continue
 		}
-	}
-	ms.RUnlock()
-
-	ms.Lock()
 
-	for _, task := range updateTasks {
-		val := ms.values[task.Slug]
 		val.Deleted = true
 		ms.values[task.Slug] = val
+		ms.stats.deleted++
+		ms.stats.schedule(&val)
+		ms.appendOutbox(domain.NewURLMappingEvent(domain.URLMappingDeleted, task.Slug, task.UserID))
+
+		deleted = append(deleted, task)
 	}
-	ms.Unlock()
 
-	return nil
+	return deleted, nil
 }
 
-// GetStats retrieves repo statistics.
-func (ms *InMemoryURLRepository) GetStats(_ context.Context) (*dto.RepoStats, error) {
+// GetStats retrieves repo statistics from counters and sorted sets maintained on changes.
+func (ms *InMemoryURLRepository) GetStats(_ context.Context, params dto.StatsParams) (*dto.RepoStats, error) {
 	ms.RLock()
 	defer ms.RUnlock()
 
+	expired := ms.stats.expired(params.Now)
+
 	stats := &dto.RepoStats{
-		CountSlugs: int64(len(ms.values)),
-		CountUsers: int64(len(ms.usrIndex)),
+		CountSlugs:   int64(len(ms.values)),
+		CountUsers:   int64(len(ms.usrIndex)),
+		CountActive:  int64(len(ms.values)) - ms.stats.deleted - expired,
+		CountDeleted: ms.stats.deleted,
+		CountExpired: expired,
+		StorageBytes: ms.stats.bytes,
+		Daily:        dto.NewDailyStats(params, ms.stats.daily),
+		TopUsers:     ms.stats.topUsers(params.TopUsers),
 	}
 
 	return stats, nil
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+)
+
+// InMemoryAPIKeyRepository is an in-memory implementation of the API key repository.
+type InMemoryAPIKeyRepository struct {
+	sync.RWMutex
+	keys map[string]domain.APIKey
+}
+
+// NewInMemoryAPIKeyRepository creates a new InMemoryAPIKeyRepository instance.
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{
+		RWMutex: sync.RWMutex{},
+		keys:    make(map[string]domain.APIKey),
+	}
+}
+
+// AddAPIKey adds a new API key to the repository.
+func (ms *InMemoryAPIKeyRepository) AddAPIKey(_ context.Context, key *domain.APIKey) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if _, exists := ms.keys[key.ID]; exists {
+		return e.Wrap("api key id collision", e.ErrAPIKeyInvalid, errLabel)
+	}
+
+	ms.keys[key.ID] = *key
+
+	return nil
+}
+
+// GetAPIKey retrieves an API key by its ID.
+func (ms *InMemoryAPIKeyRepository) GetAPIKey(_ context.Context, id string) (*domain.APIKey, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	key, exists := ms.keys[id]
+	if !exists {
+		return nil, e.ErrAPIKeyNotFound
+	}
+
+	return &key, nil
+}
+
+// GetUserAPIKeys retrieves all API keys of a user, including revoked ones, oldest first.
+func (ms *InMemoryAPIKeyRepository) GetUserAPIKeys(_ context.Context, user domain.UserID) ([]domain.APIKey, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	res := make([]domain.APIKey, 0)
+
+	for _, key := range ms.keys {
+		if key.UserID == user {
+			res = append(res, key)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].CreatedAt.Before(res[j].CreatedAt) })
+
+	return res, nil
+}
+
+// RevokeAPIKey revokes an API key owned by the given user.
+func (ms *InMemoryAPIKeyRepository) RevokeAPIKey(_ context.Context, user domain.UserID, id string) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	key, exists := ms.keys[id]
+	if !exists || key.UserID != user {
+		return e.ErrAPIKeyNotFound
+	}
+
+	key.Revoked = true
+	ms.keys[id] = key
+
+	return nil
+}
+
+// CreateMemento creates a memento of the API keys of the repository.
+func (ms *InMemoryAPIKeyRepository) CreateMemento() (*memento.Memento, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	keys := make([]domain.APIKey, 0, len(ms.keys))
+	for _, key := range ms.keys {
+		keys = append(keys, key)
+	}
+
+	return memento.NewMemento(nil).WithAPIKeys(keys), nil
+}
+
+// RestoreMemento restores the API keys of the repository from the given memento.
+func (ms *InMemoryAPIKeyRepository) RestoreMemento(m *memento.Memento) error {
+	if m == nil {
+		return nil
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.keys = make(map[string]domain.APIKey, len(m.GetAPIKeys()))
+
+	for _, key := range m.GetAPIKeys() {
+		ms.keys[key.ID] = key
+	}
+
+	return nil
+}
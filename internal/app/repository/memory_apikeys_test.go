@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryAPIKeyRepository()
+	ctx := context.Background()
+	user, other := domain.NewUserID(), domain.NewUserID()
+
+	key1, _, err := domain.NewAPIKey(user, "first", domain.APIKeyScopes{domain.ScopeRead})
+	require.NoError(t, err)
+	key2, _, err := domain.NewAPIKey(user, "second", domain.APIKeyScopes{domain.ScopeWrite})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddAPIKey(ctx, key1))
+	require.NoError(t, repo.AddAPIKey(ctx, key2))
+	require.Error(t, repo.AddAPIKey(ctx, key1))
+
+	res, err := repo.GetAPIKey(ctx, key1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key1, res)
+
+	_, err = repo.GetAPIKey(ctx, "unknown")
+	require.ErrorIs(t, err, e.ErrAPIKeyNotFound)
+
+	require.ErrorIs(t, repo.RevokeAPIKey(ctx, other, key1.ID), e.ErrAPIKeyNotFound)
+	require.NoError(t, repo.RevokeAPIKey(ctx, user, key1.ID))
+
+	keys, err := repo.GetUserAPIKeys(ctx, user)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "first", keys[0].Name)
+	assert.True(t, keys[0].Revoked)
+	assert.False(t, keys[1].Revoked)
+
+	keys, err = repo.GetUserAPIKeys(ctx, other)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+)
+
+// InMemoryAuditRepository is an in-memory implementation of the audit log, e.g. for runs with an empty repository.
+type InMemoryAuditRepository struct {
+	sync.RWMutex
+	events []domain.AuditEvent
+}
+
+// NewInMemoryAuditRepository creates a new InMemoryAuditRepository instance.
+func NewInMemoryAuditRepository() *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{
+		RWMutex: sync.RWMutex{},
+		events:  make([]domain.AuditEvent, 0),
+	}
+}
+
+// AddAuditEvents appends events to the audit log, events are assigned their record IDs.
+func (ms *InMemoryAuditRepository) AddAuditEvents(_ context.Context, events []domain.AuditEvent) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	for i := range events {
+		events[i].ID = int64(len(ms.events)) + 1
+		ms.events = append(ms.events, events[i])
+	}
+
+	return nil
+}
+
+// GetAuditEvents retrieves the latest events of the audit log matching the filter, newest first.
+func (ms *InMemoryAuditRepository) GetAuditEvents(
+	_ context.Context,
+	filter dto.AuditFilter,
+) ([]domain.AuditEvent, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	events := []domain.AuditEvent{}
+
+	for i := len(ms.events) - 1; i >= 0 && len(events) < filter.Limit; i-- {
+		if filter.Matches(&ms.events[i]) {
+			events = append(events, ms.events[i])
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,41 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemAuditRepository(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryAuditRepository()
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	source := domain.RequestSource{Protocol: domain.ProtocolHTTP, ClientIP: "127.0.0.1"}
+
+	err := repo.AddAuditEvents(ctx, []domain.AuditEvent{
+		domain.NewAuditEvent(domain.AuditCreated, "slug1", userID, source, ""),
+		domain.NewAuditEvent(domain.AuditCreated, "slug2", domain.NewUserID(), source, ""),
+		domain.NewAuditEvent(domain.AuditUpdated, "slug1", userID, source, "rules"),
+	})
+	require.NoError(t, err)
+
+	events, err := repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "slug1", UserID: domain.UserID{}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(3), events[0].ID)
+	assert.Equal(t, "rules", events[0].Details)
+	assert.Equal(t, int64(1), events[1].ID)
+
+	events, err = repo.GetAuditEvents(ctx, dto.AuditFilter{Slug: "", UserID: userID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.AuditUpdated, events[0].Action)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// ModerateURLMapping disables or re-enables a URL mapping and records the action in the moderation log.
+// The entry is completed with the owner of the URL mapping and its log record ID.
+func (ms *InMemoryURLRepository) ModerateURLMapping(
+	_ context.Context,
+	entry *domain.ModerationEntry,
+) (*domain.URLMapping, error) {
+	if entry.Action != domain.ActionDisableURL && entry.Action != domain.ActionEnableURL {
+		return nil, e.ErrModerationActionInvalid
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, exists := ms.values[entry.Slug]
+	if !exists {
+		return nil, e.ErrSlugNotFound
+	}
+
+	m.Moderate(entry.Action, entry.Reason)
+	ms.values[entry.Slug] = m
+
+	entry.UserID = m.UserID
+	ms.appendModerationLog(entry)
+
+	return &m, nil
+}
+
+// ModerateUser bans or unbans a user and records the action in the moderation log.
+func (ms *InMemoryURLRepository) ModerateUser(_ context.Context, entry *domain.ModerationEntry) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	switch entry.Action {
+	case domain.ActionBanUser:
+		ms.bans[entry.UserID] = struct{}{}
+	case domain.ActionUnbanUser:
+		delete(ms.bans, entry.UserID)
+	case domain.ActionDisableURL, domain.ActionEnableURL:
+		return e.ErrModerationActionInvalid
+	}
+
+	ms.appendModerationLog(entry)
+
+	return nil
+}
+
+// IsUserBanned checks whether a user is banned from creating links.
+func (ms *InMemoryURLRepository) IsUserBanned(_ context.Context, user domain.UserID) (bool, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	_, banned := ms.bans[user]
+
+	return banned, nil
+}
+
+// GetModerationLog retrieves up to limit latest records of the moderation log, newest first.
+func (ms *InMemoryURLRepository) GetModerationLog(_ context.Context, limit int) ([]domain.ModerationEntry, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	res := make([]domain.ModerationEntry, 0, min(limit, len(ms.modLog)))
+
+	for i := len(ms.modLog) - 1; i >= 0 && len(res) < limit; i-- {
+		res = append(res, ms.modLog[i])
+	}
+
+	return res, nil
+}
+
+// appendModerationLog assigns the next record ID to the entry and appends it to the moderation log.
+func (ms *InMemoryURLRepository) appendModerationLog(entry *domain.ModerationEntry) {
+	entry.ID = int64(len(ms.modLog)) + 1
+	ms.modLog = append(ms.modLog, *entry)
+}
@@ -0,0 +1,132 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemModerateURLMapping(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	userID := domain.NewUserID()
+
+	_, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug1", "http://example.com", userID))
+	require.NoError(t, err)
+
+	entry, err := domain.NewModerationEntry(domain.ActionDisableURL, "admin", " phishing ")
+	require.NoError(t, err)
+
+	entry.Slug = "slug1"
+
+	m, err := repo.ModerateURLMapping(ctx, entry)
+	require.NoError(t, err)
+	assert.True(t, m.Disabled)
+	assert.Equal(t, "phishing", m.DisabledReason)
+	assert.Equal(t, userID, entry.UserID)
+	assert.Equal(t, int64(1), entry.ID)
+
+	stored, err := repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+	assert.True(t, stored.Disabled)
+
+	entry, err = domain.NewModerationEntry(domain.ActionEnableURL, "admin", "false positive")
+	require.NoError(t, err)
+
+	entry.Slug = "slug1"
+
+	m, err = repo.ModerateURLMapping(ctx, entry)
+	require.NoError(t, err)
+	assert.False(t, m.Disabled)
+	assert.Empty(t, m.DisabledReason)
+
+	entry.Slug = "unknown"
+	_, err = repo.ModerateURLMapping(ctx, entry)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+
+	entry.Action = domain.ActionBanUser
+	_, err = repo.ModerateURLMapping(ctx, entry)
+	require.ErrorIs(t, err, e.ErrModerationActionInvalid)
+}
+
+func TestMemModerateUser(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	userID := domain.NewUserID()
+
+	ban, err := domain.NewModerationEntry(domain.ActionBanUser, "admin", "spam")
+	require.NoError(t, err)
+
+	ban.UserID = userID
+	require.NoError(t, repo.ModerateUser(ctx, ban))
+
+	banned, err := repo.IsUserBanned(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+	unban, err := domain.NewModerationEntry(domain.ActionUnbanUser, "admin", "appeal")
+	require.NoError(t, err)
+
+	unban.UserID = userID
+	require.NoError(t, repo.ModerateUser(ctx, unban))
+
+	banned, err = repo.IsUserBanned(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, banned)
+
+	unban.Action = domain.ActionDisableURL
+	require.ErrorIs(t, repo.ModerateUser(ctx, unban), e.ErrModerationActionInvalid)
+
+	log, err := repo.GetModerationLog(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	assert.Equal(t, domain.ActionUnbanUser, log[0].Action)
+	assert.Equal(t, domain.ActionBanUser, log[1].Action)
+
+	log, err = repo.GetModerationLog(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, log, 1)
+	assert.Equal(t, int64(2), log[0].ID)
+}
+
+func TestMemModerationMemento(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	userID := domain.NewUserID()
+
+	ban, err := domain.NewModerationEntry(domain.ActionBanUser, "admin", "spam")
+	require.NoError(t, err)
+
+	ban.UserID = userID
+	require.NoError(t, repo.ModerateUser(ctx, ban))
+
+	state, err := repo.CreateMemento()
+	require.NoError(t, err)
+
+	unban, err := domain.NewModerationEntry(domain.ActionUnbanUser, "admin", "appeal")
+	require.NoError(t, err)
+
+	unban.UserID = userID
+	require.NoError(t, repo.ModerateUser(ctx, unban))
+	require.NoError(t, repo.RestoreMemento(state))
+
+	banned, err := repo.IsUserBanned(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+	log, err := repo.GetModerationLog(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ModerationEntry{*ban}, log)
+}
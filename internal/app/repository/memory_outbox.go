@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// outboxEntry is a URL mapping event kept in the outbox until published.
+type outboxEntry struct {
+	event       domain.URLMappingEvent
+	availableAt time.Time
+}
+
+// appendOutbox assigns the next IDs to the events and appends them to the outbox.
+func (ms *InMemoryURLRepository) appendOutbox(events ...domain.URLMappingEvent) {
+	for _, event := range events {
+		ms.outboxSeq++
+		event.ID = ms.outboxSeq
+		ms.outbox = append(ms.outbox, outboxEntry{event: event, availableAt: event.CreatedAt})
+	}
+}
+
+// ClaimOutboxEvents claims up to limit available outbox events in the order they were added.
+// Claimed events are not available to other claims until the lease expires.
+func (ms *InMemoryURLRepository) ClaimOutboxEvents(
+	_ context.Context,
+	lease time.Duration,
+	limit int,
+) ([]domain.URLMappingEvent, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	now := time.Now()
+	events := make([]domain.URLMappingEvent, 0, limit)
+
+	for i := range ms.outbox {
+		if len(events) == limit {
+			break
+		}
+
+		if ms.outbox[i].availableAt.After(now) {
+			continue
+		}
+
+		ms.outbox[i].availableAt = now.Add(lease)
+		events = append(events, ms.outbox[i].event)
+	}
+
+	return events, nil
+}
+
+// DelOutboxEvents deletes published events from the outbox, unknown IDs are ignored.
+func (ms *InMemoryURLRepository) DelOutboxEvents(_ context.Context, ids []int64) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.outbox = slices.DeleteFunc(ms.outbox, func(entry outboxEntry) bool {
+		return slices.Contains(ids, entry.event.ID)
+	})
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemOutbox(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	user := domain.NewUserID()
+
+	urlm := domain.NewURLMapping("slug1", "https://example.com/1", user)
+	_, err := repo.AddURLMapping(ctx, urlm)
+	require.NoError(t, err)
+
+	// conflicting mappings are not streamed
+	_, err = repo.AddURLMapping(ctx, urlm)
+	require.Error(t, err)
+
+	require.NoError(t, repo.AddURLMappingBatch(ctx, &[]domain.URLMapping{
+		*domain.NewURLMapping("slug2", "https://example.com/2", user),
+	}))
+
+	_, err = repo.DelUserURLMappings(ctx, []dto.UserSlug{
+		{Slug: "slug1", UserID: user},
+		{Slug: "slug2", UserID: domain.NewUserID()},
+	})
+	require.NoError(t, err)
+
+	claimed, err := repo.ClaimOutboxEvents(ctx, time.Minute, 2)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, int64(1), claimed[0].ID)
+	assert.Equal(t, domain.URLMappingCreated, claimed[0].Type)
+	assert.Equal(t, urlm.OriginalURL, claimed[0].OriginalURL)
+	assert.Equal(t, domain.Slug("slug2"), claimed[1].Slug)
+
+	rest, err := repo.ClaimOutboxEvents(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, domain.URLMappingDeleted, rest[0].Type)
+	assert.Equal(t, domain.Slug("slug1"), rest[0].Slug)
+	assert.Empty(t, rest[0].OriginalURL)
+
+	// all events are leased
+	leased, err := repo.ClaimOutboxEvents(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, leased)
+
+	require.NoError(t, repo.DelOutboxEvents(ctx, []int64{claimed[0].ID, claimed[1].ID}))
+
+	// unpublished events are claimed again once the lease expires
+	repo = repository.NewInMemoryURLRepository()
+	_, err = repo.AddURLMapping(ctx, urlm)
+	require.NoError(t, err)
+
+	claimed, err = repo.ClaimOutboxEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	reclaimed, err := repo.ClaimOutboxEvents(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, reclaimed, 1)
+	assert.Equal(t, claimed[0].EventID, reclaimed[0].EventID)
+
+	require.NoError(t, repo.DelOutboxEvents(ctx, []int64{reclaimed[0].ID}))
+
+	reclaimed, err = repo.ClaimOutboxEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, reclaimed)
+}
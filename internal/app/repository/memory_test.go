@@ -3,6 +3,7 @@ package repository_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +11,7 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
 )
 
@@ -202,11 +204,18 @@ func TestDelUserURLMappings(t *testing.T) {
 	tasks := []dto.UserSlug{
 		{Slug: "slug1", UserID: userID},
 		{Slug: "slug2", UserID: userID},
+		{Slug: "slug3", UserID: userID},
 		{Slug: "slug4", UserID: otherUserID},
 	}
 
-	err = repo.DelUserURLMappings(ctx, tasks)
+	deleted, err := repo.DelUserURLMappings(ctx, tasks)
 	require.NoError(t, err)
+	assert.Equal(t, tasks[:2], deleted)
+
+	// deleting twice only deletes once
+	deleted, err = repo.DelUserURLMappings(ctx, tasks)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
 
 	m1, err := repo.GetURLMapping(ctx, "slug1")
 	require.NoError(t, err)
@@ -221,6 +230,104 @@ func TestDelUserURLMappings(t *testing.T) {
 	assert.False(t, m3.Deleted)
 }
 
+func TestMemRegisterClick(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+
+	_, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug1", "url1", domain.NewUserID()))
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		m, err := repo.RegisterClick(ctx, "slug1", 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(i), m.Clicks)
+	}
+
+	m, err := repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), m.Clicks)
+
+	_, err = repo.RegisterClick(ctx, "slug2", 0)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+}
+
+func TestMemUpdateURLMappingSchedule(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	activeFrom := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := &dto.URLSchedule{ActiveFrom: activeFrom, ExpiresAt: time.Time{}}
+
+	_, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug1", "url1", userID))
+	require.NoError(t, err)
+
+	_, err = repo.UpdateURLMappingSchedule(ctx, dto.UserSlug{Slug: "slug1", UserID: domain.NewUserID()}, schedule)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+
+	_, err = repo.UpdateURLMappingSchedule(ctx, dto.UserSlug{Slug: "slug2", UserID: userID}, schedule)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+
+	m, err := repo.UpdateURLMappingSchedule(ctx, dto.UserSlug{Slug: "slug1", UserID: userID}, schedule)
+	require.NoError(t, err)
+	assert.Equal(t, activeFrom, m.ActiveFrom)
+	assert.True(t, m.ExpiresAt.IsZero())
+
+	m, err = repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+	assert.Equal(t, activeFrom, m.ActiveFrom)
+}
+
+func TestMemUpdateURLMappingRules(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	rules := domain.RedirectRules{{Platform: domain.PlatformIOS, Target: "https://apps.apple.com/app"}}
+
+	_, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug1", "url1", userID))
+	require.NoError(t, err)
+
+	_, err = repo.UpdateURLMappingRules(ctx, dto.UserSlug{Slug: "slug1", UserID: domain.NewUserID()}, rules)
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+
+	m, err := repo.UpdateURLMappingRules(ctx, dto.UserSlug{Slug: "slug1", UserID: userID}, rules)
+	require.NoError(t, err)
+	assert.Equal(t, rules, m.Rules)
+
+	m, err = repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+	assert.Equal(t, rules, m.Rules)
+}
+
+func TestMemRegisterClickLimited(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	urlm := domain.NewURLMapping("slug1", "url1", domain.NewUserID(), domain.WithMaxClicks(2))
+
+	_, err := repo.AddURLMapping(ctx, urlm)
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = repo.RegisterClick(ctx, "slug1", 0)
+		require.NoError(t, err)
+	}
+
+	_, err = repo.RegisterClick(ctx, "slug1", 0)
+	require.ErrorIs(t, err, e.ErrSlugExhausted)
+
+	m, err := repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), m.Clicks)
+	assert.True(t, m.Exhausted())
+}
+
 func TestGetStats(t *testing.T) {
 	t.Parallel()
 
@@ -235,12 +342,228 @@ func TestGetStats(t *testing.T) {
 	_, err = repo.AddURLMapping(ctx, domain.NewURLMapping("slug2", "url2", userID))
 	require.NoError(t, err)
 
-	_, err = repo.AddURLMapping(ctx, domain.NewURLMapping("slug3", "url3", otherUserID))
+	expiring := domain.NewURLMapping("slug3", "url3", otherUserID)
+	expiring.ExpiresAfter(-time.Minute)
+
+	_, err = repo.AddURLMapping(ctx, expiring)
 	require.NoError(t, err)
 
-	stats, err := repo.GetStats(ctx)
+	// deleting twice is counted once
+	for range 2 {
+		_, err = repo.DelUserURLMappings(ctx, []dto.UserSlug{{Slug: "slug1", UserID: userID}})
+		require.NoError(t, err)
+	}
+
+	params := dto.StatsParams{Days: 2, TopUsers: 1, Now: time.Now()}
+	stats, err := repo.GetStats(ctx, params)
 	require.NoError(t, err)
 
 	assert.Equal(t, int64(2), stats.CountUsers)
 	assert.Equal(t, int64(3), stats.CountSlugs)
+	assert.Equal(t, int64(1), stats.CountDeleted)
+	assert.Equal(t, int64(1), stats.CountExpired)
+	assert.Equal(t, int64(1), stats.CountActive)
+	assert.Positive(t, stats.StorageBytes)
+	require.Len(t, stats.Daily, 2)
+	assert.Equal(t, params.Now.Format(time.DateOnly), stats.Daily[1].Date)
+	assert.Equal(t, []dto.UserStats{{UserID: userID.String(), CountSlugs: 2}}, stats.TopUsers)
+
+	// counters follow schedule changes and reassigned mappings
+	schedule := &dto.URLSchedule{ActiveFrom: time.Time{}, ExpiresAt: params.Now.Add(time.Hour)}
+	_, err = repo.UpdateURLMappingSchedule(ctx, dto.UserSlug{Slug: "slug3", UserID: otherUserID}, schedule)
+	require.NoError(t, err)
+
+	_, err = repo.ReassignUserURLMappings(ctx, userID, otherUserID)
+	require.NoError(t, err)
+
+	params.TopUsers = 5
+	stats, err = repo.GetStats(ctx, params)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), stats.CountExpired)
+	assert.Equal(t, int64(2), stats.CountActive)
+	assert.Equal(t, []dto.UserStats{{UserID: otherUserID.String(), CountSlugs: 3}}, stats.TopUsers)
+
+	params.Now = params.Now.Add(2 * time.Hour)
+	stats, err = repo.GetStats(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.CountExpired)
+}
+
+func TestMemRegisterClickVariant(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	variants := domain.Variants{
+		{Target: "https://a.example.com", Weight: 70, Clicks: 0},
+		{Target: "https://b.example.com", Weight: 30, Clicks: 0},
+	}
+	urlm := domain.NewURLMapping("slug1", "url1", domain.NewUserID(), domain.WithVariants(variants))
+
+	_, err := repo.AddURLMapping(ctx, urlm)
+	require.NoError(t, err)
+
+	before, err := repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+
+	for _, variant := range []int{2, 2, 1, 0, 3} {
+		_, err = repo.RegisterClick(ctx, "slug1", variant)
+		require.NoError(t, err)
+	}
+
+	m, err := repo.GetURLMapping(ctx, "slug1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), m.Clicks)
+	assert.Equal(t, int64(1), m.Variants[0].Clicks)
+	assert.Equal(t, int64(2), m.Variants[1].Clicks)
+
+	// previously returned mappings are not affected.
+	assert.Equal(t, int64(0), before.Variants[1].Clicks)
+}
+
+func TestMemAddURLMappingCanonical(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+
+	_, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug1", "http://Example.com/a", domain.NewUserID()))
+	require.NoError(t, err)
+
+	for _, original := range []domain.OriginalURL{"http://example.com/a/", "http://example.com:80/a"} {
+		m, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug2", original, domain.NewUserID()))
+		require.ErrorIs(t, err, e.ErrOriginalExists)
+		assert.Equal(t, domain.Slug("slug1"), m.Slug)
+		assert.Equal(t, domain.OriginalURL("http://Example.com/a"), m.OriginalURL)
+	}
+
+	batch := []domain.URLMapping{*domain.NewURLMapping("slug3", "HTTP://EXAMPLE.COM/a", domain.NewUserID())}
+	require.ErrorIs(t, repo.AddURLMappingBatch(ctx, &batch), e.ErrOriginalExists)
+}
+
+func TestMemRestoreMementoCanonicalCollisions(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	state := dto.URLMappings{}
+	now := time.Now().UTC()
+
+	// states stored before canonicalization lack canonical urls.
+	for slug, original := range map[domain.Slug]domain.OriginalURL{
+		"slug1": "http://example.com",
+		"slug2": "HTTP://Example.com/",
+		"slug3": "http://example.com:80",
+	} {
+		m := domain.NewURLMapping(slug, original, domain.NewUserID())
+		m.Canonical = ""
+		m.CreatedAt = now
+		state[slug] = *m
+	}
+
+	oldest := state["slug2"]
+	oldest.CreatedAt = now.Add(-time.Hour)
+	state["slug2"] = oldest
+
+	// map iteration order varies, so a few restores make sure the oldest slug always wins.
+	for range 10 {
+		require.NoError(t, repo.RestoreMemento(memento.NewMemento(state)))
+
+		m, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug4", "http://example.com/", domain.NewUserID()))
+		require.ErrorIs(t, err, e.ErrOriginalExists)
+		assert.Equal(t, domain.Slug("slug2"), m.Slug)
+	}
+}
+
+func TestMemAddURLMappingNamespace(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	user1, user2 := domain.NewUserID(), domain.NewUserID()
+	original := domain.OriginalURL("http://example.com/a")
+
+	newMapping := func(slug domain.Slug, userID domain.UserID) *domain.URLMapping {
+		m := domain.NewURLMapping(slug, original, userID)
+		m.Namespace = userID
+
+		return m
+	}
+
+	_, err := repo.AddURLMapping(ctx, newMapping("slug1", user1))
+	require.NoError(t, err)
+
+	_, err = repo.AddURLMapping(ctx, newMapping("slug2", user2))
+	require.NoError(t, err)
+
+	m, err := repo.AddURLMapping(ctx, newMapping("slug3", user1))
+	require.ErrorIs(t, err, e.ErrOriginalExists)
+	assert.Equal(t, domain.Slug("slug1"), m.Slug)
+
+	// the global namespace is independent of per-user ones.
+	_, err = repo.AddURLMapping(ctx, domain.NewURLMapping("slug4", original, user1))
+	require.NoError(t, err)
+
+	batch := []domain.URLMapping{*newMapping("slug5", domain.NewUserID()), *newMapping("slug6", user2)}
+	require.ErrorIs(t, repo.AddURLMappingBatch(ctx, &batch), e.ErrOriginalExists)
+}
+
+func TestMemReassignUserURLMappings(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	anonymous, user := domain.NewUserID(), domain.NewUserID()
+
+	newMapping := func(slug domain.Slug, original domain.OriginalURL, userID domain.UserID) *domain.URLMapping {
+		m := domain.NewURLMapping(slug, original, userID)
+		m.Namespace = userID
+
+		return m
+	}
+
+	for _, m := range []*domain.URLMapping{
+		domain.NewURLMapping("global", "https://example.com/global", anonymous),
+		newMapping("own", "https://example.com/own", anonymous),
+		newMapping("dup", "https://example.com/dup", anonymous),
+		newMapping("userdup", "https://example.com/dup", user),
+	} {
+		_, err := repo.AddURLMapping(ctx, m)
+		require.NoError(t, err)
+	}
+
+	moved, err := repo.ReassignUserURLMappings(ctx, anonymous, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	userURLs, err := repo.GetUserURLMappings(ctx, user)
+	require.NoError(t, err)
+	assert.Len(t, userURLs, 3)
+
+	kept, err := repo.GetUserURLMappings(ctx, anonymous)
+	require.NoError(t, err)
+	require.Len(t, kept, 1)
+	assert.Equal(t, domain.Slug("dup"), kept[0].Slug)
+
+	own, err := repo.GetURLMapping(ctx, "own")
+	require.NoError(t, err)
+	assert.Equal(t, user, own.UserID)
+	assert.Equal(t, user, own.Namespace)
+
+	global, err := repo.GetURLMapping(ctx, "global")
+	require.NoError(t, err)
+	assert.Equal(t, user, global.UserID)
+	assert.True(t, global.Namespace.IsNil())
+
+	// moved mappings keep their original urls unique within the new namespace.
+	_, err = repo.AddURLMapping(ctx, newMapping("own2", "https://example.com/own", user))
+	require.ErrorIs(t, err, e.ErrOriginalExists)
+
+	_, err = repo.AddURLMapping(ctx, newMapping("own3", "https://example.com/own", anonymous))
+	require.NoError(t, err)
+
+	moved, err = repo.ReassignUserURLMappings(ctx, domain.NewUserID(), user)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
 }
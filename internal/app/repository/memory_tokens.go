@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+)
+
+// InMemoryTokenRepository is an in-memory implementation of the revoked tokens repository.
+type InMemoryTokenRepository struct {
+	sync.RWMutex
+	tokens map[string]time.Time
+}
+
+// NewInMemoryTokenRepository creates a new InMemoryTokenRepository instance.
+func NewInMemoryTokenRepository() *InMemoryTokenRepository {
+	return &InMemoryTokenRepository{
+		RWMutex: sync.RWMutex{},
+		tokens:  make(map[string]time.Time),
+	}
+}
+
+// RevokeToken revokes a token until it expires, expired tokens are removed along the way.
+func (ms *InMemoryTokenRepository) RevokeToken(_ context.Context, jti string, expiresAt time.Time) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	now := time.Now()
+
+	for id, expires := range ms.tokens {
+		if expires.Before(now) {
+			delete(ms.tokens, id)
+		}
+	}
+
+	ms.tokens[jti] = expiresAt
+
+	return nil
+}
+
+// IsTokenRevoked reports whether a token is revoked.
+func (ms *InMemoryTokenRepository) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	_, revoked := ms.tokens[jti]
+
+	return revoked, nil
+}
+
+// CreateMemento creates a memento of the revoked tokens of the repository, expired tokens are left out.
+func (ms *InMemoryTokenRepository) CreateMemento() (*memento.Memento, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	now := time.Now()
+	tokens := make([]dto.RevokedToken, 0, len(ms.tokens))
+
+	for jti, expires := range ms.tokens {
+		if !expires.Before(now) {
+			tokens = append(tokens, dto.RevokedToken{JTI: jti, ExpiresAt: expires})
+		}
+	}
+
+	return memento.NewMemento(nil).WithTokens(tokens), nil
+}
+
+// RestoreMemento restores the revoked tokens of the repository from the given memento.
+func (ms *InMemoryTokenRepository) RestoreMemento(m *memento.Memento) error {
+	if m == nil {
+		return nil
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.tokens = make(map[string]time.Time, len(m.GetTokens()))
+
+	for _, token := range m.GetTokens() {
+		ms.tokens[token.JTI] = token.ExpiresAt
+	}
+
+	return nil
+}
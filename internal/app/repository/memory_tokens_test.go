@@ -0,0 +1,34 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemRevokedTokens(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryTokenRepository()
+	ctx := context.Background()
+
+	revoked, err := repo.IsTokenRevoked(ctx, "jti")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, repo.RevokeToken(ctx, "expired", time.Now().Add(-time.Minute)))
+	require.NoError(t, repo.RevokeToken(ctx, "jti", time.Now().Add(time.Hour)))
+
+	revoked, err = repo.IsTokenRevoked(ctx, "jti")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = repo.IsTokenRevoked(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, revoked, "expired tokens are removed on revocation")
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+)
+
+// InMemoryUserRepository is an in-memory implementation of the user repository.
+type InMemoryUserRepository struct {
+	sync.RWMutex
+	users  map[domain.UserID]domain.User
+	emails map[domain.Email]domain.UserID
+}
+
+// NewInMemoryUserRepository creates a new InMemoryUserRepository instance.
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{
+		RWMutex: sync.RWMutex{},
+		users:   make(map[domain.UserID]domain.User),
+		emails:  make(map[domain.Email]domain.UserID),
+	}
+}
+
+// AddUser adds a new user account to the repository.
+func (ms *InMemoryUserRepository) AddUser(_ context.Context, user *domain.User) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if _, exists := ms.emails[user.Email]; exists {
+		return e.ErrUserExists
+	}
+
+	if _, exists := ms.users[user.ID]; exists {
+		return e.ErrUserExists
+	}
+
+	ms.users[user.ID] = *user
+	ms.emails[user.Email] = user.ID
+
+	return nil
+}
+
+// GetUser retrieves a user account by its UserID.
+func (ms *InMemoryUserRepository) GetUser(_ context.Context, id domain.UserID) (*domain.User, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	user, exists := ms.users[id]
+	if !exists {
+		return nil, e.ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+// GetUserByEmail retrieves a user account by its email.
+func (ms *InMemoryUserRepository) GetUserByEmail(_ context.Context, email domain.Email) (*domain.User, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	id, exists := ms.emails[email]
+	if !exists {
+		return nil, e.ErrUserNotFound
+	}
+
+	user := ms.users[id]
+
+	return &user, nil
+}
+
+// CreateMemento creates a memento of the user accounts of the repository.
+func (ms *InMemoryUserRepository) CreateMemento() (*memento.Memento, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	users := make([]domain.User, 0, len(ms.users))
+	for _, user := range ms.users {
+		users = append(users, user)
+	}
+
+	return memento.NewMemento(nil).WithUsers(users), nil
+}
+
+// RestoreMemento restores the user accounts of the repository from the given memento.
+func (ms *InMemoryUserRepository) RestoreMemento(m *memento.Memento) error {
+	if m == nil {
+		return nil
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.users = make(map[domain.UserID]domain.User, len(m.GetUsers()))
+	ms.emails = make(map[domain.Email]domain.UserID, len(m.GetUsers()))
+
+	for _, user := range m.GetUsers() {
+		ms.users[user.ID] = user
+		ms.emails[user.Email] = user.ID
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemUsers(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryUserRepository()
+	ctx := context.Background()
+
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddUser(ctx, user))
+
+	other, err := domain.NewUser("user@example.com", "other-pass")
+	require.NoError(t, err)
+	require.ErrorIs(t, repo.AddUser(ctx, other), e.ErrUserExists)
+
+	res, err := repo.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user, res)
+
+	res, err = repo.GetUserByEmail(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user, res)
+
+	_, err = repo.GetUser(ctx, other.ID)
+	require.ErrorIs(t, err, e.ErrUserNotFound)
+
+	_, err = repo.GetUserByEmail(ctx, "other@example.com")
+	require.ErrorIs(t, err, e.ErrUserNotFound)
+}
+
+func TestMemUsersMemento(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryUserRepository()
+	ctx := context.Background()
+
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddUser(ctx, user))
+
+	state, err := repo.CreateMemento()
+	require.NoError(t, err)
+
+	other, err := domain.NewUser("other@example.com", "other-pass")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddUser(ctx, other))
+	require.NoError(t, repo.RestoreMemento(state))
+
+	res, err := repo.GetUserByEmail(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user, res)
+
+	_, err = repo.GetUser(ctx, other.ID)
+	require.ErrorIs(t, err, e.ErrUserNotFound)
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/memento"
+)
+
+// InMemoryWebhookRepository is an in-memory implementation of the webhook repository.
+// Delivery IDs are positions in the outbox.
+type InMemoryWebhookRepository struct {
+	sync.RWMutex
+	hooks      map[string]domain.Webhook
+	deliveries []domain.WebhookDelivery
+}
+
+// NewInMemoryWebhookRepository creates a new InMemoryWebhookRepository instance.
+func NewInMemoryWebhookRepository() *InMemoryWebhookRepository {
+	return &InMemoryWebhookRepository{
+		RWMutex:    sync.RWMutex{},
+		hooks:      make(map[string]domain.Webhook),
+		deliveries: make([]domain.WebhookDelivery, 0),
+	}
+}
+
+// AddWebhook adds a new webhook to the repository.
+func (ms *InMemoryWebhookRepository) AddWebhook(_ context.Context, hook *domain.Webhook) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if _, exists := ms.hooks[hook.ID]; exists {
+		return e.Wrap("webhook id collision", e.ErrWebhookInvalid, errLabel)
+	}
+
+	ms.hooks[hook.ID] = *hook
+
+	return nil
+}
+
+// GetUserWebhooks retrieves all webhooks of a user, oldest first.
+func (ms *InMemoryWebhookRepository) GetUserWebhooks(_ context.Context, user domain.UserID) ([]domain.Webhook, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	res := make([]domain.Webhook, 0)
+
+	for _, hook := range ms.hooks {
+		if hook.UserID == user {
+			res = append(res, hook)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].CreatedAt.Before(res[j].CreatedAt) })
+
+	return res, nil
+}
+
+// DelWebhook deletes a webhook owned by the given user, its deliveries are no longer claimed.
+func (ms *InMemoryWebhookRepository) DelWebhook(_ context.Context, user domain.UserID, id string) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	hook, exists := ms.hooks[id]
+	if !exists || hook.UserID != user {
+		return e.ErrWebhookNotFound
+	}
+
+	delete(ms.hooks, id)
+
+	return nil
+}
+
+// AddWebhookDeliveries adds deliveries to the outbox.
+func (ms *InMemoryWebhookRepository) AddWebhookDeliveries(
+	_ context.Context,
+	deliveries []domain.WebhookDelivery,
+) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	for _, d := range deliveries {
+		d.ID = int64(len(ms.deliveries) + 1)
+		ms.deliveries = append(ms.deliveries, d)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries claims up to limit pending deliveries due now for the lease, oldest due first.
+func (ms *InMemoryWebhookRepository) ClaimWebhookDeliveries(
+	_ context.Context,
+	lease time.Duration,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	now := time.Now()
+	due := make([]int, 0)
+
+	for i, d := range ms.deliveries {
+		if _, exists := ms.hooks[d.Webhook.ID]; exists && d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, i)
+		}
+	}
+
+	slices.SortStableFunc(due, func(i, j int) int {
+		return ms.deliveries[i].NextAttemptAt.Compare(ms.deliveries[j].NextAttemptAt)
+	})
+
+	res := make([]domain.WebhookDelivery, 0, min(limit, len(due)))
+
+	for _, i := range due[:min(limit, len(due))] {
+		ms.deliveries[i].NextAttemptAt = now.Add(lease)
+		res = append(res, ms.deliveries[i])
+	}
+
+	return res, nil
+}
+
+// UpdateWebhookDelivery records the outcome of a delivery attempt in the outbox.
+func (ms *InMemoryWebhookRepository) UpdateWebhookDelivery(_ context.Context, delivery *domain.WebhookDelivery) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if delivery.ID < 1 || delivery.ID > int64(len(ms.deliveries)) {
+		return e.Wrap("webhook delivery not found", e.ErrWebhookNotFound, errLabel)
+	}
+
+	d := &ms.deliveries[delivery.ID-1]
+	d.Status = delivery.Status
+	d.Attempts = delivery.Attempts
+	d.NextAttemptAt = delivery.NextAttemptAt
+	d.LastError = delivery.LastError
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the latest deliveries of a webhook with the status, newest first.
+func (ms *InMemoryWebhookRepository) GetWebhookDeliveries(
+	_ context.Context,
+	hook *domain.Webhook,
+	status domain.WebhookDeliveryStatus,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	res := make([]domain.WebhookDelivery, 0)
+
+	if stored, exists := ms.hooks[hook.ID]; !exists || stored.UserID != hook.UserID {
+		return res, nil
+	}
+
+	for i := len(ms.deliveries) - 1; i >= 0 && len(res) < limit; i-- {
+		if d := ms.deliveries[i]; d.Webhook.ID == hook.ID && d.Status == status {
+			res = append(res, d)
+		}
+	}
+
+	return res, nil
+}
+
+// CreateMemento creates a memento of the webhook subscriptions of the repository,
+// pending deliveries are not preserved.
+func (ms *InMemoryWebhookRepository) CreateMemento() (*memento.Memento, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	hooks := make([]domain.Webhook, 0, len(ms.hooks))
+	for _, hook := range ms.hooks {
+		hooks = append(hooks, hook)
+	}
+
+	return memento.NewMemento(nil).WithWebhooks(hooks), nil
+}
+
+// RestoreMemento restores the webhook subscriptions of the repository from the given memento.
+func (ms *InMemoryWebhookRepository) RestoreMemento(m *memento.Memento) error {
+	if m == nil {
+		return nil
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.hooks = make(map[string]domain.Webhook, len(m.GetWebhooks()))
+
+	for _, hook := range m.GetWebhooks() {
+		ms.hooks[hook.ID] = hook
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+func TestMemWebhooks(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryWebhookRepository()
+	ctx := context.Background()
+	user, other := domain.NewUserID(), domain.NewUserID()
+
+	hook1, err := domain.NewWebhook(user, "https://crm.example.com/first", "")
+	require.NoError(t, err)
+	hook2, err := domain.NewWebhook(user, "https://crm.example.com/second", "")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddWebhook(ctx, hook1))
+	require.NoError(t, repo.AddWebhook(ctx, hook2))
+	require.Error(t, repo.AddWebhook(ctx, hook1))
+
+	hooks, err := repo.GetUserWebhooks(ctx, user)
+	require.NoError(t, err)
+	require.Len(t, hooks, 2)
+	assert.Equal(t, hook1.ID, hooks[0].ID)
+
+	hooks, err = repo.GetUserWebhooks(ctx, other)
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+
+	require.ErrorIs(t, repo.DelWebhook(ctx, other, hook2.ID), e.ErrWebhookNotFound)
+	require.NoError(t, repo.DelWebhook(ctx, user, hook2.ID))
+	require.ErrorIs(t, repo.DelWebhook(ctx, user, hook2.ID), e.ErrWebhookNotFound)
+}
+
+func TestMemWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewInMemoryWebhookRepository()
+	ctx := context.Background()
+	user := domain.NewUserID()
+
+	hook, err := domain.NewWebhook(user, "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+	gone, err := domain.NewWebhook(user, "https://crm.example.com/gone", "")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWebhook(ctx, hook))
+	require.NoError(t, repo.AddWebhook(ctx, gone))
+
+	first := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", user)
+	second := domain.NewWebhookEvent(domain.WebhookLinkDeleted, "slug1", user)
+
+	require.NoError(t, repo.AddWebhookDeliveries(ctx, []domain.WebhookDelivery{
+		domain.NewWebhookDelivery(hook, &first, []byte(`{}`)),
+		domain.NewWebhookDelivery(gone, &first, []byte(`{}`)),
+		domain.NewWebhookDelivery(hook, &second, []byte(`{}`)),
+	}))
+	require.NoError(t, repo.DelWebhook(ctx, user, gone.ID))
+
+	claimed, err := repo.ClaimWebhookDeliveries(ctx, time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, first.ID, claimed[0].EventID)
+	assert.Equal(t, hook.URL, claimed[0].Webhook.URL)
+
+	claimed, err = repo.ClaimWebhookDeliveries(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, second.ID, claimed[0].EventID)
+
+	latest := claimed[0]
+
+	// both deliveries are leased
+	claimed, err = repo.ClaimWebhookDeliveries(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	latest.Fail("status 500", time.Minute, 1)
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, &latest))
+
+	unknown := latest
+	unknown.ID = 10
+	require.ErrorIs(t, repo.UpdateWebhookDelivery(ctx, &unknown), e.ErrWebhookNotFound)
+
+	dead, err := repo.GetWebhookDeliveries(ctx, hook, domain.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, second.ID, dead[0].EventID)
+	assert.Equal(t, 1, dead[0].Attempts)
+	assert.Equal(t, "status 500", dead[0].LastError)
+
+	foreign := *hook
+	foreign.UserID = domain.NewUserID()
+	dead, err = repo.GetWebhookDeliveries(ctx, &foreign, domain.DeliveryDead, 10)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
@@ -16,7 +17,82 @@ type URLRepository interface {
 	AddURLMapping(ctx context.Context, m *domain.URLMapping) (*domain.URLMapping, error)
 	AddURLMappingBatch(ctx context.Context, batch *[]domain.URLMapping) error
 	GetURLMapping(ctx context.Context, slug domain.Slug) (*domain.URLMapping, error)
+	RegisterClick(ctx context.Context, slug domain.Slug, variant int) (*domain.URLMapping, error)
 	GetUserURLMappings(ctx context.Context, user domain.UserID) ([]domain.URLMapping, error)
-	DelUserURLMappings(ctx context.Context, tasks []dto.UserSlug) error
-	GetStats(ctx context.Context) (*dto.RepoStats, error)
+	DelUserURLMappings(ctx context.Context, tasks []dto.UserSlug) ([]dto.UserSlug, error)
+	UpdateURLMappingSchedule(
+		ctx context.Context,
+		owner dto.UserSlug,
+		schedule *dto.URLSchedule,
+	) (*domain.URLMapping, error)
+	UpdateURLMappingRules(ctx context.Context, owner dto.UserSlug, rules domain.RedirectRules) (*domain.URLMapping, error)
+	ReassignUserURLMappings(ctx context.Context, from, to domain.UserID) (int64, error)
+	GetStats(ctx context.Context, params dto.StatsParams) (*dto.RepoStats, error)
+}
+
+// ModerationRepository is an interface that defines the methods for moderating URL mappings and users in a repository.
+// Moderation actions are recorded in the moderation log along with the change they make.
+type ModerationRepository interface {
+	ModerateURLMapping(ctx context.Context, entry *domain.ModerationEntry) (*domain.URLMapping, error)
+	ModerateUser(ctx context.Context, entry *domain.ModerationEntry) error
+	IsUserBanned(ctx context.Context, user domain.UserID) (bool, error)
+	GetModerationLog(ctx context.Context, limit int) ([]domain.ModerationEntry, error)
+}
+
+// AuditRepository is an interface that defines the methods for interacting with an append-only audit log.
+type AuditRepository interface {
+	AddAuditEvents(ctx context.Context, events []domain.AuditEvent) error
+	GetAuditEvents(ctx context.Context, filter dto.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// UserRepository is an interface that defines the methods for interacting with user accounts in a repository.
+type UserRepository interface {
+	memento.Originator
+	AddUser(ctx context.Context, user *domain.User) error
+	GetUser(ctx context.Context, id domain.UserID) (*domain.User, error)
+	GetUserByEmail(ctx context.Context, email domain.Email) (*domain.User, error)
+}
+
+// APIKeyRepository is an interface that defines the methods for interacting with API keys in a repository.
+type APIKeyRepository interface {
+	memento.Originator
+	AddAPIKey(ctx context.Context, key *domain.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error)
+	GetUserAPIKeys(ctx context.Context, user domain.UserID) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, user domain.UserID, id string) error
+}
+
+// WebhookRepository is an interface that defines the methods for interacting with webhooks
+// and the outbox of their deliveries in a repository.
+// Due deliveries are claimed for a lease, so that they are retried should the claiming dispatcher stop.
+type WebhookRepository interface {
+	memento.Originator
+	AddWebhook(ctx context.Context, hook *domain.Webhook) error
+	GetUserWebhooks(ctx context.Context, user domain.UserID) ([]domain.Webhook, error)
+	DelWebhook(ctx context.Context, user domain.UserID, id string) error
+	AddWebhookDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	ClaimWebhookDeliveries(ctx context.Context, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetWebhookDeliveries(
+		ctx context.Context,
+		hook *domain.Webhook,
+		status domain.WebhookDeliveryStatus,
+		limit int,
+	) ([]domain.WebhookDelivery, error)
+}
+
+// OutboxRepository is an interface that defines the methods for relaying the outbox of URL mapping events,
+// events are added to the outbox along with the changes of URL mappings.
+// Available events are claimed for a lease, so that they are published again should the claiming relay stop.
+type OutboxRepository interface {
+	ClaimOutboxEvents(ctx context.Context, lease time.Duration, limit int) ([]domain.URLMappingEvent, error)
+	DelOutboxEvents(ctx context.Context, ids []int64) error
+}
+
+// TokenRepository is an interface that defines the methods for interacting with revoked JWT tokens in a repository.
+// Tokens are identified by their jti claim and kept until they expire.
+type TokenRepository interface {
+	memento.Originator
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
 }
@@ -0,0 +1,362 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+// Aux certificate manager constants.
+const (
+	errLabel            = "server"
+	selfSignedValidity  = 365 * 24 * time.Hour
+	selfSignedSerialLen = 128
+	selfSignedOrg       = "shortener development"
+	pemCertificate      = "CERTIFICATE"
+	pemPrivateKey       = "PRIVATE KEY"
+	certModeSelfSigned  = config.TLSCertModeSelfSigned
+	certModeACME        = config.TLSCertModeACME
+)
+
+// CertManager provides TLS certificates of the app servers.
+//
+// In the file mode the certificate is loaded from TLSCertPath and TLSKeyPath and reloaded
+// whenever the files change while the manager is watched, so renewed certificates are served without restart.
+// The self-signed mode is the file mode for development, missing files are generated with a self-signed
+// certificate for the base URL host and the loopback addresses.
+// In the ACME mode certificates of ACMEDomains are obtained and renewed from the ACME directory
+// with tls-alpn-01 challenges answered on the TLS listeners.
+type CertManager struct {
+	sync.RWMutex
+	cert     *tls.Certificate
+	stamp    string
+	mode     string
+	enabled  bool
+	certPath string
+	keyPath  string
+	interval time.Duration
+	acme     *autocert.Manager
+	log      *zerolog.Logger
+}
+
+// NewCertManager creates a CertManager of the configured certificate mode, the file mode by default.
+// Certificates are loaded only when HTTPS or gRPC TLS is enabled.
+func NewCertManager(config *config.Config, log *zerolog.Logger) (*CertManager, error) {
+	m := &CertManager{
+		RWMutex:  sync.RWMutex{},
+		cert:     nil,
+		stamp:    "",
+		mode:     config.TLSCertMode,
+		enabled:  config.EnableHTTPS || config.EnableGRPCTLS,
+		certPath: config.TLSCertPath,
+		keyPath:  config.TLSKeyPath,
+		interval: config.TLSCertReload,
+		acme:     nil,
+		log:      log,
+	}
+
+	if !m.enabled {
+		return m, nil
+	}
+
+	if m.mode == certModeACME {
+		manager, err := newACMEManager(config)
+		if err != nil {
+			return nil, err
+		}
+
+		m.acme = manager
+
+		return m, nil
+	}
+
+	if m.mode == certModeSelfSigned {
+		if err := m.ensureSelfSigned(selfSignedHosts(config.BaseURL)); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := m.reload(true); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetCertificate returns the certificate for a TLS handshake, it is a tls.Config GetCertificate.
+func (m *CertManager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if m.acme != nil {
+		return m.acme.GetCertificate(hello)
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.cert == nil {
+		return nil, e.ErrServerNoCert
+	}
+
+	return m.cert, nil
+}
+
+// NextProtos returns the application protocols the TLS listeners must offer for certificates to be obtained.
+func (m *CertManager) NextProtos() []string {
+	if m.acme != nil {
+		return []string{acme.ALPNProto}
+	}
+
+	return nil
+}
+
+// Reload loads the certificate files regardless of whether they changed, e.g. on SIGHUP.
+func (m *CertManager) Reload() error {
+	if !m.enabled || m.acme != nil {
+		return nil
+	}
+
+	_, err := m.reload(true)
+	if err == nil {
+		m.logReload()
+	}
+
+	return err
+}
+
+// Watch reloads the certificate files whenever they change until the context is done.
+// ACME certificates are renewed by the manager itself.
+func (m *CertManager) Watch(ctx context.Context) {
+	if !m.enabled || m.acme != nil || m.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := m.reload(false)
+			if err != nil {
+				m.log.Error().Err(err).
+					Str("cert_path", m.certPath).
+					Msg("failed to reload tls certificate")
+
+				continue
+			}
+
+			if reloaded {
+				m.logReload()
+			}
+		}
+	}
+}
+
+func (m *CertManager) logReload() {
+	m.RLock()
+	leaf := m.cert.Leaf
+	m.RUnlock()
+
+	event := m.log.Info().Str("cert_path", m.certPath)
+	if leaf != nil {
+		event = event.Str("subject", leaf.Subject.String()).Time("not_after", leaf.NotAfter)
+	}
+
+	event.Msg("tls certificate reloaded")
+}
+
+// reload loads the certificate files if they changed since the last load or if forced, it reports whether it did.
+// Failed reloads keep the previously loaded certificate in effect.
+func (m *CertManager) reload(force bool) (bool, error) {
+	stamp, err := fileStamp(m.certPath, m.keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	m.RLock()
+	unchanged := stamp == m.stamp
+	m.RUnlock()
+
+	if unchanged && !force {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(m.certPath, m.keyPath)
+	if err != nil {
+		return false, e.Wrap("failed to load tls certificate", err, errLabel)
+	}
+
+	m.Lock()
+	m.cert = &cert
+	m.stamp = stamp
+	m.Unlock()
+
+	return true, nil
+}
+
+// ensureSelfSigned generates and persists a self-signed certificate unless both certificate files exist.
+func (m *CertManager) ensureSelfSigned(hosts []string) error {
+	_, certErr := os.Stat(m.certPath)
+	_, keyErr := os.Stat(m.keyPath)
+
+	if certErr == nil && keyErr == nil {
+		return nil
+	}
+
+	certPEM, keyPEM, err := GenerateSelfSigned(hosts, selfSignedValidity)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(m.keyPath, keyPEM, 0o600); err != nil {
+		return err
+	}
+
+	if err := writeFile(m.certPath, certPEM, 0o644); err != nil {
+		return err
+	}
+
+	m.log.Warn().
+		Str("cert_path", m.certPath).
+		Strs("hosts", hosts).
+		Msg("self-signed tls certificate generated")
+
+	return nil
+}
+
+// GenerateSelfSigned generates PEM encoded self-signed ECDSA P-256 certificate and PKCS #8 key
+// for host names and IP addresses.
+func GenerateSelfSigned(hosts []string, validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, e.Wrap("failed to generate key", err, errLabel)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), selfSignedSerialLen))
+	if err != nil {
+		return nil, nil, e.Wrap("failed to generate serial", err, errLabel)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{selfSignedOrg}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, e.Wrap("failed to create certificate", err, errLabel)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, e.Wrap("failed to marshal key", err, errLabel)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: pemCertificate, Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+// newACMEManager creates an autocert manager of the configured ACME directory.
+// The directory server is trusted with ACMECAPath, e.g. a local Pebble one, by default with the system roots.
+func newACMEManager(config *config.Config) (*autocert.Manager, error) {
+	client := &acme.Client{DirectoryURL: config.ACMEDirectoryURL}
+
+	if config.ACMECAPath != "" {
+		pool, err := utils.LoadCertPool(config.ACMECAPath)
+		if err != nil {
+			return nil, err
+		}
+
+		client.HTTPClient = &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}}
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(config.ACMECacheDir),
+		HostPolicy: autocert.HostWhitelist(config.ACMEDomains...),
+		Email:      config.ACMEEmail,
+		Client:     client,
+	}, nil
+}
+
+// selfSignedHosts returns the base URL host along with localhost and the loopback addresses.
+func selfSignedHosts(baseURL string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+
+	if u, err := url.Parse(baseURL); err == nil && u.Hostname() != "" && !slices.Contains(hosts, u.Hostname()) {
+		hosts = append([]string{u.Hostname()}, hosts...)
+	}
+
+	return hosts
+}
+
+// fileStamp returns a stamp of the files sizes and modification times.
+func fileStamp(paths ...string) (string, error) {
+	stamp := ""
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", e.Wrap("failed to stat tls file", err, errLabel)
+		}
+
+		stamp += fmt.Sprintf("%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return stamp, nil
+}
+
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return e.Wrap("failed to create tls directory", err, errLabel)
+	}
+
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return e.Wrap("failed to write tls file", err, errLabel)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/server"
+)
+
+func testCertConfig(t *testing.T, mode string) *config.Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.BaseURL = "https://shortener.test:8443/"
+	cfg.EnableHTTPS = true
+	cfg.TLSCertMode = mode
+	cfg.TLSCertPath = filepath.Join(dir, "certs", "cert.pem")
+	cfg.TLSKeyPath = filepath.Join(dir, "private", "key.pem")
+	cfg.TLSCertReload = 10 * time.Millisecond
+	cfg.ACMECacheDir = filepath.Join(dir, "acme")
+
+	return cfg
+}
+
+func writeCert(t *testing.T, cfg *config.Config, hosts ...string) {
+	t.Helper()
+
+	certPEM, keyPEM, err := server.GenerateSelfSigned(hosts, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(cfg.TLSCertPath), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Dir(cfg.TLSKeyPath), 0o700))
+	require.NoError(t, os.WriteFile(cfg.TLSKeyPath, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(cfg.TLSCertPath, certPEM, 0o600))
+}
+
+func leafOf(t *testing.T, certs *server.CertManager) *tls.Certificate {
+	t.Helper()
+
+	cert, err := certs.GetCertificate(&tls.ClientHelloInfo{ServerName: "localhost"})
+	require.NoError(t, err)
+	require.NotNil(t, cert.Leaf)
+
+	return cert
+}
+
+func TestCertManagerSelfSigned(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	cfg := testCertConfig(t, config.TLSCertModeSelfSigned)
+
+	certs, err := server.NewCertManager(cfg, log)
+	require.NoError(t, err)
+
+	cert := leafOf(t, certs)
+	assert.ElementsMatch(t, []string{"shortener.test", "localhost"}, cert.Leaf.DNSNames)
+	assert.Len(t, cert.Leaf.IPAddresses, 2)
+	assert.True(t, cert.Leaf.IPAddresses[0].Equal(net.IPv4(127, 0, 0, 1)))
+	assert.Empty(t, certs.NextProtos())
+
+	info, err := os.Stat(cfg.TLSKeyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// persisted certificate is reused
+	certs, err = server.NewCertManager(cfg, log)
+	require.NoError(t, err)
+	assert.Equal(t, cert.Certificate, leafOf(t, certs).Certificate)
+}
+
+func TestCertManagerFile(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+
+	t.Run("missing files", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := server.NewCertManager(testCertConfig(t, config.TLSCertModeFile), log)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("disabled tls", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testCertConfig(t, config.TLSCertModeFile)
+		cfg.EnableHTTPS = false
+
+		certs, err := server.NewCertManager(cfg, log)
+		require.NoError(t, err)
+		require.NoError(t, certs.Reload())
+
+		_, err = certs.GetCertificate(&tls.ClientHelloInfo{ServerName: "localhost"})
+		require.ErrorIs(t, err, e.ErrServerNoCert)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testCertConfig(t, config.TLSCertModeFile)
+		writeCert(t, cfg, "old.test")
+
+		certs, err := server.NewCertManager(cfg, log)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old.test"}, leafOf(t, certs).Leaf.DNSNames)
+
+		writeCert(t, cfg, "new.test")
+		require.NoError(t, certs.Reload())
+		assert.Equal(t, []string{"new.test"}, leafOf(t, certs).Leaf.DNSNames)
+
+		// broken files keep the loaded certificate
+		require.NoError(t, os.WriteFile(cfg.TLSCertPath, []byte("broken"), 0o600))
+		require.Error(t, certs.Reload())
+		assert.Equal(t, []string{"new.test"}, leafOf(t, certs).Leaf.DNSNames)
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testCertConfig(t, config.TLSCertModeFile)
+		writeCert(t, cfg, "old.test")
+
+		certs, err := server.NewCertManager(cfg, log)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go certs.Watch(ctx)
+
+		writeCert(t, cfg, "watched.test")
+
+		assert.Eventually(t, func() bool {
+			cert, err := certs.GetCertificate(&tls.ClientHelloInfo{ServerName: "localhost"})
+
+			return err == nil && cert.Leaf.DNSNames[0] == "watched.test"
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestCertManagerACME(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	cfg := testCertConfig(t, config.TLSCertModeACME)
+	cfg.ACMEDomains = []string{"shortener.test"}
+	cfg.ACMEDirectoryURL = "https://127.0.0.1:1/dir"
+
+	certs, err := server.NewCertManager(cfg, log)
+	require.NoError(t, err)
+	assert.Equal(t, []string{acme.ALPNProto}, certs.NextProtos())
+	require.NoError(t, certs.Reload())
+
+	// hosts out of the policy are refused before the directory is contacted
+	_, err = certs.GetCertificate(&tls.ClientHelloInfo{ServerName: "other.test"})
+	require.ErrorContains(t, err, "not configured in HostWhitelist")
+
+	cfg.ACMECAPath = filepath.Join(t.TempDir(), "missing.pem")
+
+	_, err = server.NewCertManager(cfg, log)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
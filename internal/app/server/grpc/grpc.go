@@ -6,12 +6,16 @@ import (
 
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	pb "github.com/patraden/ya-practicum-go-shortly/api/shortener/v1"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/server"
 )
 
 // Server represents the gRPC server for the URL shortening handler.
@@ -19,38 +23,74 @@ type Server struct {
 	grpcServer *grpc.Server
 	config     *config.Config
 	handler    *handler.GRPCShortenerHandler
+	health     *handler.GRPCHealthHandler
+	certs      *server.CertManager
 	log        *zerolog.Logger
 }
 
 // NewServer creates instance of Server.
-func NewServer(config *config.Config, handler *handler.GRPCShortenerHandler, log *zerolog.Logger) *Server {
+func NewServer(
+	config *config.Config,
+	handler *handler.GRPCShortenerHandler,
+	health *handler.GRPCHealthHandler,
+	certs *server.CertManager,
+	log *zerolog.Logger,
+) *Server {
 	return &Server{
 		grpcServer: &grpc.Server{},
 		config:     config,
 		handler:    handler,
+		health:     health,
+		certs:      certs,
 		log:        log,
 	}
 }
 
 // Run starts the application server.
+// The listener is secured with the app TLS config when EnableGRPCTLS is set.
+// The health service is always registered, the reflection service when EnableGRPCReflection is set.
 func (s *Server) Run() error {
+	intercepters := s.handler.Interceptors()
+
+	intercepters = append(intercepters, middleware.WithLoggingInterceptor(s.log))
+	streamIntercepters := s.handler.StreamInterceptors()
+	streamIntercepters = append(streamIntercepters, middleware.StreamInterceptor(middleware.WithLoggingInterceptor(s.log)))
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(intercepters...),
+		grpc.ChainStreamInterceptor(streamIntercepters...),
+	}
+
+	if s.config.EnableGRPCTLS {
+		tlsConfig, err := server.NewTLSConfig(s.config, s.certs)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
 	listen, err := net.Listen("tcp", s.config.ServerGRPCAddr)
 	if err != nil {
 		return err
 	}
 
-	intercepters := s.handler.Interceptors()
-
-	intercepters = append(intercepters, middleware.WithLoggingInterceptor(s.log))
-	s.grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(intercepters...))
+	s.grpcServer = grpc.NewServer(opts...)
 
 	pb.RegisterURLShortenerServiceServer(s.grpcServer, s.handler)
+	healthpb.RegisterHealthServer(s.grpcServer, s.health)
+
+	if s.config.EnableGRPCReflection {
+		reflection.Register(s.grpcServer)
+	}
 
 	return s.grpcServer.Serve(listen)
 }
 
 // Shutdown stops the application server.
+// The health status is set to not serving first, so that clients and load balancers stop sending requests.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
 	stopped := make(chan struct{})
 
 	go func() {
@@ -2,6 +2,17 @@ package grpc_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -11,28 +22,59 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
 
 	pb "github.com/patraden/ya-practicum-go-shortly/api/shortener/v1"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/geoip"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/handler"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	appserver "github.com/patraden/ya-practicum-go-shortly/internal/app/server"
 	server "github.com/patraden/ya-practicum-go-shortly/internal/app/server/grpc"
 )
 
+// newJWTMiddleware creates a JWTMiddleware of the configured JWT keys as the app does.
+func newJWTMiddleware(t *testing.T, log *zerolog.Logger, cfg *config.Config) *middleware.JWTMiddleware {
+	t.Helper()
+
+	keys, err := middleware.NewJWTKeySet(cfg, log)
+	require.NoError(t, err)
+
+	return middleware.NewKeysJWTMiddleware(keys, log, middleware.WithJWTConfig(cfg))
+}
+
 func setupGRPCServer(t *testing.T) (*gomock.Controller, *mock.MockURLShortener, *server.Server) {
 	t.Helper()
 
 	ctrl := gomock.NewController(t)
 	mockSrv := mock.NewMockURLShortener(ctrl)
-	cfg := &config.Config{BaseURL: "http://base.url", ServerGRPCAddr: "127.0.0.1:50051"}
+	mockStats := mock.NewMockStatsProvider(ctrl)
+	cfg := &config.Config{
+		BaseURL:              "http://base.url",
+		JWTSecret:            "test-secret",
+		ServerGRPCAddr:       "127.0.0.1:50051",
+		GRPCStreamChunk:      2,
+		GRPCStreamMaxURLs:    10,
+		EnableGRPCReflection: true,
+	}
 	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
-	handler, err := handler.NewGRPCURLShortenerHandler(mockSrv, cfg, log)
+	auth := newJWTMiddleware(t, log, cfg)
+	keys := mock.NewMockAPIKeyResolver(ctrl)
+	health := handler.NewGRPCHealthHandler(nil, cfg, log)
+	handler, err := handler.NewGRPCURLShortenerHandler(mockSrv, mockStats, geoip.NopLocator{}, keys, auth, cfg, log)
 	require.NoError(t, err)
 
-	srv := server.NewServer(cfg, handler, log)
+	certs, err := appserver.NewCertManager(cfg, log)
+	require.NoError(t, err)
+
+	srv := server.NewServer(cfg, handler, health, certs, log)
 
 	return ctrl, mockSrv, srv
 }
@@ -72,11 +114,215 @@ func TestGRPCServerRunAndShutdown(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
 
+	mockSrv.EXPECT().
+		ShortenURLBatch(gomock.Any(), gomock.Any()).
+		Return(&dto.SlugBatch{{CorrelationID: "1", Slug: "slug1"}}, nil)
+
+	stream, err := client.ShortenURLStream(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.ShortenURLStreamRequest{CorrelationId: "1", Url: "https://example.com"}))
+
+	streamResp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Len(t, streamResp.GetUrls(), 1)
+	assert.Equal(t, "http://base.url/slug1", streamResp.GetUrls()[0].GetShortUrl())
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.GetStatus())
+
+	reflection, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, reflection.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+
+	services, err := reflection.Recv()
+	require.NoError(t, err)
+	require.NoError(t, reflection.CloseSend())
+
+	names := make([]string, 0, len(services.GetListServicesResponse().GetService()))
+	for _, service := range services.GetListServicesResponse().GetService() {
+		names = append(names, service.GetName())
+	}
+
+	assert.Contains(t, names, pb.URLShortenerService_ServiceDesc.ServiceName)
+	assert.Contains(t, names, healthpb.Health_ServiceDesc.ServiceName)
+
+	watch, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	health, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.GetStatus())
+
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
+	// watchers are told the server is not serving and do not hold back the graceful stop
 	err = srv.Shutdown(ctx)
 	require.NoError(t, err)
 
+	health, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, health.GetStatus())
+
+	wgr.Wait()
+}
+
+// writeTestPKI writes a server certificate for 127.0.0.1, a client CA and a client certificate of that CA.
+func writeTestPKI(t *testing.T) (string, string, string, tls.Certificate) {
+	t.Helper()
+
+	dir := t.TempDir()
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+
+		return path
+	}
+
+	newCert := func(
+		template *x509.Certificate,
+		parent *x509.Certificate,
+		parentKey *ecdsa.PrivateKey,
+	) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+
+		return cert, key
+	}
+
+	now := time.Now()
+	serverCert, serverKey := newCert(&x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "server"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, nil, nil)
+	caCert, caKey := newCert(&x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "client ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	clientCert, clientKey := newCert(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caCert, caKey)
+
+	serverKeyDER, err := x509.MarshalECPrivateKey(serverKey)
+	require.NoError(t, err)
+
+	certPath := writePEM("cert.pem", "CERTIFICATE", serverCert.Raw)
+	keyPath := writePEM("key.pem", "EC PRIVATE KEY", serverKeyDER)
+	caPath := writePEM("ca.pem", "CERTIFICATE", caCert.Raw)
+	client := tls.Certificate{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey, Leaf: clientCert}
+
+	return certPath, keyPath, caPath, client
+}
+
+func TestGRPCServerMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	certPath, keyPath, caPath, clientCert := writeTestPKI(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mock.NewMockURLShortener(ctrl)
+	mockStats := mock.NewMockStatsProvider(ctrl)
+	cfg := &config.Config{
+		BaseURL:              "http://base.url",
+		JWTSecret:            "test-secret",
+		ServerGRPCAddr:       "127.0.0.1:50052",
+		EnableGRPCTLS:        true,
+		TLSCertPath:          certPath,
+		TLSKeyPath:           keyPath,
+		TLSClientCAPath:      caPath,
+		TLSRequireClientCert: true,
+	}
+	log := logger.NewLogger(zerolog.InfoLevel).GetLogger()
+	auth := newJWTMiddleware(t, log, cfg)
+	keys := mock.NewMockAPIKeyResolver(ctrl)
+	health := handler.NewGRPCHealthHandler(nil, cfg, log)
+	handler, err := handler.NewGRPCURLShortenerHandler(mockSrv, mockStats, geoip.NopLocator{}, keys, auth, cfg, log)
+	require.NoError(t, err)
+
+	certs, err := appserver.NewCertManager(cfg, log)
+	require.NoError(t, err)
+
+	srv := server.NewServer(cfg, handler, health, certs, log)
+	wgr := sync.WaitGroup{}
+
+	wgr.Add(1)
+
+	go func() {
+		defer wgr.Done()
+
+		err := srv.Run()
+		assert.NoError(t, err)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	serverCert, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(serverCert))
+
+	mockSrv.EXPECT().
+		ShortenURL(gomock.Any(), domain.OriginalURL("https://example.com")).
+		Return(domain.Slug("slug1"), nil)
+
+	dial := func(certs []tls.Certificate) error {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: certs, MinVersion: tls.VersionTLS12})
+
+		conn, err := grpc.NewClient(cfg.ServerGRPCAddr, grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err = pb.NewURLShortenerServiceClient(conn).ShortenURL(ctx, &pb.ShortenURLRequest{Url: "https://example.com"})
+
+		return err
+	}
+
+	require.NoError(t, dial([]tls.Certificate{clientCert}))
+	require.Error(t, dial(nil), "client certificate is required")
+
+	conn, err := grpc.NewClient(cfg.ServerGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = pb.NewURLShortenerServiceClient(conn).ShortenURL(context.Background(), &pb.ShortenURLRequest{Url: "x"})
+	require.Error(t, err, "plaintext is refused")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, srv.Shutdown(ctx))
+
 	wgr.Wait()
 }
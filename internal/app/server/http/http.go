@@ -5,16 +5,18 @@ import (
 	"net/http"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/server"
 )
 
 // Server represents the HTTP server for the URL shortening service.
 type Server struct {
 	httpServer *http.Server
 	config     *config.Config
+	certs      *server.CertManager
 }
 
 // NewServer creates instance of Server.
-func NewServer(config *config.Config, handler http.Handler) *Server {
+func NewServer(config *config.Config, handler http.Handler, certs *server.CertManager) *Server {
 	return &Server{
 		httpServer: &http.Server{
 			Addr:              config.ServerAddr,
@@ -24,13 +26,21 @@ func NewServer(config *config.Config, handler http.Handler) *Server {
 			IdleTimeout:       config.ServerIdleTimeout,
 		},
 		config: config,
+		certs:  certs,
 	}
 }
 
 // Run starts the application server.
 func (s *Server) Run() error {
 	if s.config.EnableHTTPS {
-		return s.httpServer.ListenAndServeTLS(s.config.TLSCertPath, s.config.TLSKeyPath)
+		tlsConfig, err := server.NewTLSConfig(s.config, s.certs)
+		if err != nil {
+			return err
+		}
+
+		s.httpServer.TLSConfig = tlsConfig
+
+		return s.httpServer.ListenAndServeTLS("", "")
 	}
 
 	return s.httpServer.ListenAndServe()
@@ -16,10 +16,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	appserver "github.com/patraden/ya-practicum-go-shortly/internal/app/server"
 	server "github.com/patraden/ya-practicum-go-shortly/internal/app/server/http"
 )
 
@@ -50,7 +53,10 @@ func TestServerRunAndShutdown(t *testing.T) {
 	}
 
 	handler := http.NewServeMux()
-	server := server.NewServer(cfg, handler)
+	certs, err := appserver.NewCertManager(cfg, logger.NewLogger(zerolog.InfoLevel).GetLogger())
+	require.NoError(t, err)
+
+	server := server.NewServer(cfg, handler, certs)
 	ctx := context.Background()
 	wgr := sync.WaitGroup{}
 
@@ -180,7 +186,10 @@ func TestServerRunAndShutdownWithHTTPS(t *testing.T) {
 	}
 
 	handler := testRouter(t)
-	server := server.NewServer(cfg, handler)
+	certs, err := appserver.NewCertManager(cfg, logger.NewLogger(zerolog.InfoLevel).GetLogger())
+	require.NoError(t, err)
+
+	server := server.NewServer(cfg, handler, certs)
 	ctx := context.Background()
 	wgr := sync.WaitGroup{}
 
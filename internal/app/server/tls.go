@@ -0,0 +1,40 @@
+package server
+
+import (
+	"crypto/tls"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+// NewTLSConfig creates the TLS config of the app servers with certificates of the certificate manager.
+//
+// With client CAs configured, client certificates are verified against both TLSClientCAPath
+// and StatsClientCAPath bundles when presented, and are required with TLSRequireClientCert.
+// Which CA a client certificate comes from is up to the handlers, see middleware.ClientCAMiddleware.
+func NewTLSConfig(config *config.Config, certs *CertManager) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certs.GetCertificate,
+		NextProtos:     certs.NextProtos(),
+		ClientAuth:     tls.NoClientCert,
+	}
+
+	if config.TLSClientCAPath == "" && config.StatsClientCAPath == "" {
+		return tlsConfig, nil
+	}
+
+	pool, err := utils.LoadCertPool(config.TLSClientCAPath, config.StatsClientCAPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+
+	if config.TLSRequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
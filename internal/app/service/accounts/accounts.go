@@ -0,0 +1,19 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+)
+
+const errLabel = "accounts"
+
+// Accounts defines the interface for a user accounts service.
+// It includes methods for registering and logging in user accounts
+// and claiming URLs shortened by anonymous identities.
+type Accounts interface {
+	Register(ctx context.Context, credentials *dto.Credentials) (*domain.User, error)
+	Login(ctx context.Context, credentials *dto.Credentials) (*domain.User, error)
+	ClaimURLs(ctx context.Context, anonymous, user domain.UserID) (int64, error)
+}
@@ -0,0 +1,148 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+// RepoAccounts is a user accounts service backed by the user, URL and moderation repositories.
+type RepoAccounts struct {
+	users      repository.UserRepository
+	urls       repository.URLRepository
+	moderation repository.ModerationRepository
+	dummyHash  domain.PasswordHash
+	log        *zerolog.Logger
+}
+
+// NewRepoAccounts creates a new instance of RepoAccounts.
+func NewRepoAccounts(
+	users repository.UserRepository,
+	urls repository.URLRepository,
+	moderation repository.ModerationRepository,
+	log *zerolog.Logger,
+) (*RepoAccounts, error) {
+	// unknown emails are checked against a dummy hash,
+	// so they take as long as wrong passwords and do not reveal registered accounts.
+	dummyHash, err := domain.NewPasswordHash("dummy password")
+	if err != nil {
+		return nil, e.Wrap("failed to hash dummy password", err, errLabel)
+	}
+
+	return &RepoAccounts{
+		users:      users,
+		urls:       urls,
+		moderation: moderation,
+		dummyHash:  dummyHash,
+		log:        log,
+	}, nil
+}
+
+// Register creates a new user account with the given credentials.
+func (s *RepoAccounts) Register(ctx context.Context, credentials *dto.Credentials) (*domain.User, error) {
+	user, err := domain.NewUser(credentials.Email, credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.users.AddUser(ctx, user)
+	if errors.Is(err, e.ErrUserExists) {
+		return nil, e.ErrUserExists
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to add user")
+
+		return nil, e.ErrAccountsInternal
+	}
+
+	s.log.Info().
+		Str("user_id", user.ID.String()).
+		Msg("user registered")
+
+	return user, nil
+}
+
+// Login returns the user account matching the given credentials.
+func (s *RepoAccounts) Login(ctx context.Context, credentials *dto.Credentials) (*domain.User, error) {
+	email, err := domain.ParseEmail(credentials.Email)
+	if err != nil {
+		return nil, e.ErrLoginFailed
+	}
+
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, e.ErrUserNotFound) {
+		s.dummyHash.Matches(credentials.Password)
+
+		return nil, e.ErrLoginFailed
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get user")
+
+		return nil, e.ErrAccountsInternal
+	}
+
+	if !user.PasswordHash.Matches(credentials.Password) {
+		return nil, e.ErrLoginFailed
+	}
+
+	return user, nil
+}
+
+// ClaimURLs transfers the URLs of an anonymous identity to a user account and returns their number.
+// Identities of registered user accounts are never claimed,
+// identities of banned users are refused so that their bans do not get lost with their URLs.
+func (s *RepoAccounts) ClaimURLs(ctx context.Context, anonymous, user domain.UserID) (int64, error) {
+	if anonymous.IsNil() || anonymous == user {
+		return 0, nil
+	}
+
+	_, err := s.users.GetUser(ctx, anonymous)
+	if err == nil {
+		return 0, nil
+	}
+
+	if !errors.Is(err, e.ErrUserNotFound) {
+		s.log.Error().Err(err).Msg("failed to get user")
+
+		return 0, e.ErrAccountsInternal
+	}
+
+	banned, err := s.moderation.IsUserBanned(ctx, anonymous)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to check user ban")
+
+		return 0, e.ErrAccountsInternal
+	}
+
+	if banned {
+		s.log.Info().
+			Str("user_id", user.String()).
+			Str("anonymous_id", anonymous.String()).
+			Msg("banned anonymous urls claim refused")
+
+		return 0, e.ErrUserBanned
+	}
+
+	claimed, err := s.urls.ReassignUserURLMappings(ctx, anonymous, user)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to reassign user urls")
+
+		return 0, e.ErrAccountsInternal
+	}
+
+	s.log.Info().
+		Str("user_id", user.String()).
+		Str("anonymous_id", anonymous.String()).
+		Int64("claimed", claimed).
+		Msg("anonymous urls claimed")
+
+	return claimed, nil
+}
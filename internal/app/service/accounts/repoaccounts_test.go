@@ -0,0 +1,164 @@
+package accounts_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/accounts"
+)
+
+func setupRepoAccountsTest(t *testing.T) (
+	*gomock.Controller,
+	*mock.MockUserRepository,
+	*mock.MockURLRepository,
+	*mock.MockModerationRepository,
+	*accounts.RepoAccounts,
+) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	users := mock.NewMockUserRepository(ctrl)
+	urls := mock.NewMockURLRepository(ctrl)
+	moderation := mock.NewMockModerationRepository(ctrl)
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	svc, err := accounts.NewRepoAccounts(users, urls, moderation, log)
+	require.NoError(t, err)
+
+	return ctrl, users, urls, moderation, svc
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl, users, _, _, svc := setupRepoAccountsTest(t)
+
+	defer ctrl.Finish()
+
+	credentials := &dto.Credentials{Email: "User@Example.com", Password: "s3cret-pass", Claim: false}
+
+	users.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(nil)
+
+	user, err := svc.Register(ctx, credentials)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Email("user@example.com"), user.Email)
+
+	users.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(e.ErrUserExists)
+
+	_, err = svc.Register(ctx, credentials)
+	require.ErrorIs(t, err, e.ErrUserExists)
+
+	users.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral)
+
+	_, err = svc.Register(ctx, credentials)
+	require.ErrorIs(t, err, e.ErrAccountsInternal)
+
+	_, err = svc.Register(ctx, &dto.Credentials{Email: "user@example.com", Password: "short", Claim: false})
+	require.ErrorIs(t, err, e.ErrUserCredentialsInvalid)
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl, users, _, _, svc := setupRepoAccountsTest(t)
+
+	defer ctrl.Finish()
+
+	user, err := domain.NewUser("user@example.com", "s3cret-pass")
+	require.NoError(t, err)
+
+	users.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil).Times(2)
+
+	res, err := svc.Login(ctx, &dto.Credentials{Email: "USER@example.com", Password: "s3cret-pass", Claim: false})
+	require.NoError(t, err)
+	assert.Equal(t, user, res)
+
+	_, err = svc.Login(ctx, &dto.Credentials{Email: "user@example.com", Password: "wrong-pass", Claim: false})
+	require.ErrorIs(t, err, e.ErrLoginFailed)
+
+	users.EXPECT().GetUserByEmail(gomock.Any(), domain.Email("other@example.com")).Return(nil, e.ErrUserNotFound)
+
+	_, err = svc.Login(ctx, &dto.Credentials{Email: "other@example.com", Password: "s3cret-pass", Claim: false})
+	require.ErrorIs(t, err, e.ErrLoginFailed)
+
+	_, err = svc.Login(ctx, &dto.Credentials{Email: "not an email", Password: "s3cret-pass", Claim: false})
+	require.ErrorIs(t, err, e.ErrLoginFailed)
+
+	users.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.Login(ctx, &dto.Credentials{Email: "user@example.com", Password: "s3cret-pass", Claim: false})
+	require.ErrorIs(t, err, e.ErrAccountsInternal)
+}
+
+func TestClaimURLs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl, users, urls, moderation, svc := setupRepoAccountsTest(t)
+
+	defer ctrl.Finish()
+
+	anonymous, user := domain.NewUserID(), domain.NewUserID()
+
+	users.EXPECT().GetUser(gomock.Any(), anonymous).Return(nil, e.ErrUserNotFound)
+	moderation.EXPECT().IsUserBanned(gomock.Any(), anonymous).Return(false, nil)
+	urls.EXPECT().ReassignUserURLMappings(gomock.Any(), anonymous, user).Return(int64(2), nil)
+
+	claimed, err := svc.ClaimURLs(ctx, anonymous, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), claimed)
+
+	// identities of registered accounts are never claimed.
+	users.EXPECT().GetUser(gomock.Any(), anonymous).Return(&domain.User{ID: anonymous}, nil)
+
+	claimed, err = svc.ClaimURLs(ctx, anonymous, user)
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+
+	claimed, err = svc.ClaimURLs(ctx, user, user)
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+
+	users.EXPECT().GetUser(gomock.Any(), anonymous).Return(nil, e.ErrUserNotFound)
+	moderation.EXPECT().IsUserBanned(gomock.Any(), anonymous).Return(false, nil)
+	urls.EXPECT().ReassignUserURLMappings(gomock.Any(), anonymous, user).Return(int64(0), e.ErrTestGeneral)
+
+	_, err = svc.ClaimURLs(ctx, anonymous, user)
+	require.ErrorIs(t, err, e.ErrAccountsInternal)
+
+	users.EXPECT().GetUser(gomock.Any(), anonymous).Return(nil, e.ErrUserNotFound)
+	moderation.EXPECT().IsUserBanned(gomock.Any(), anonymous).Return(false, e.ErrTestGeneral)
+
+	_, err = svc.ClaimURLs(ctx, anonymous, user)
+	require.ErrorIs(t, err, e.ErrAccountsInternal)
+}
+
+func TestClaimURLsBanned(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl, users, _, moderation, svc := setupRepoAccountsTest(t)
+
+	defer ctrl.Finish()
+
+	anonymous, user := domain.NewUserID(), domain.NewUserID()
+
+	// urls of a banned identity stay with it, so the ban is not lost by registering.
+	users.EXPECT().GetUser(gomock.Any(), anonymous).Return(nil, e.ErrUserNotFound)
+	moderation.EXPECT().IsUserBanned(gomock.Any(), anonymous).Return(true, nil)
+
+	claimed, err := svc.ClaimURLs(ctx, anonymous, user)
+	require.ErrorIs(t, err, e.ErrUserBanned)
+	assert.Zero(t, claimed)
+}
@@ -0,0 +1,17 @@
+package apikeys
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// APIKeys defines the interface for an API keys service.
+// It includes methods for creating, listing and revoking API keys of the current user
+// and resolving API keys authenticating requests.
+type APIKeys interface {
+	CreateAPIKey(ctx context.Context, name string, scopes domain.APIKeyScopes) (*domain.APIKey, string, error)
+	GetUserAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	ResolveAPIKey(ctx context.Context, token string) (*domain.APIKey, error)
+}
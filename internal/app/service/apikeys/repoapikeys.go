@@ -0,0 +1,138 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+// RepoAPIKeys is an API keys service backed by the API key repository.
+type RepoAPIKeys struct {
+	repo repository.APIKeyRepository
+	log  *zerolog.Logger
+}
+
+// NewRepoAPIKeys creates a new instance of RepoAPIKeys.
+func NewRepoAPIKeys(repo repository.APIKeyRepository, log *zerolog.Logger) *RepoAPIKeys {
+	return &RepoAPIKeys{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateAPIKey creates an API key of the current user and returns it along with the key itself.
+func (s *RepoAPIKeys) CreateAPIKey(
+	ctx context.Context,
+	name string,
+	scopes domain.APIKeyScopes,
+) (*domain.APIKey, string, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return nil, "", e.ErrAPIKeysInternal
+	}
+
+	key, token, err := domain.NewAPIKey(userID, name, scopes)
+	if errors.Is(err, e.ErrAPIKeyInvalid) {
+		return nil, "", err
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to generate api key")
+
+		return nil, "", e.ErrAPIKeysInternal
+	}
+
+	if err := s.repo.AddAPIKey(ctx, key); err != nil {
+		s.log.Error().Err(err).Msg("failed to add api key")
+
+		return nil, "", e.ErrAPIKeysInternal
+	}
+
+	s.log.Info().
+		Str("user_id", userID.String()).
+		Str("key_id", key.ID).
+		Msg("api key created")
+
+	return key, token, nil
+}
+
+// GetUserAPIKeys returns all API keys of the current user.
+func (s *RepoAPIKeys) GetUserAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return nil, e.ErrAPIKeysInternal
+	}
+
+	keys, err := s.repo.GetUserAPIKeys(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get user api keys")
+
+		return nil, e.ErrAPIKeysInternal
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key of the current user.
+func (s *RepoAPIKeys) RevokeAPIKey(ctx context.Context, id string) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return e.ErrAPIKeysInternal
+	}
+
+	err := s.repo.RevokeAPIKey(ctx, userID, id)
+	if errors.Is(err, e.ErrAPIKeyNotFound) {
+		return err
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to revoke api key")
+
+		return e.ErrAPIKeysInternal
+	}
+
+	s.log.Info().
+		Str("user_id", userID.String()).
+		Str("key_id", id).
+		Msg("api key revoked")
+
+	return nil
+}
+
+// ResolveAPIKey returns the API key of a key token.
+// Malformed, unknown, revoked and mismatching keys are all reported as invalid.
+func (s *RepoAPIKeys) ResolveAPIKey(ctx context.Context, token string) (*domain.APIKey, error) {
+	id, err := domain.ParseAPIKeyID(token)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.repo.GetAPIKey(ctx, id)
+	if errors.Is(err, e.ErrAPIKeyNotFound) {
+		return nil, e.ErrAPIKeyInvalid
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get api key")
+
+		return nil, e.ErrAPIKeysInternal
+	}
+
+	if key.Revoked || !key.Matches(token) {
+		return nil, e.ErrAPIKeyInvalid
+	}
+
+	return key, nil
+}
@@ -0,0 +1,119 @@
+package apikeys_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/apikeys"
+)
+
+func setupRepoAPIKeysTest(t *testing.T) (*gomock.Controller, *mock.MockAPIKeyRepository, *apikeys.RepoAPIKeys) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockAPIKeyRepository(ctrl)
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	return ctrl, repo, apikeys.NewRepoAPIKeys(repo, log)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl, repo, svc := setupRepoAPIKeysTest(t)
+	defer ctrl.Finish()
+
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	scopes := domain.APIKeyScopes{domain.ScopeRead}
+
+	repo.EXPECT().AddAPIKey(gomock.Any(), gomock.Any()).Return(nil)
+
+	key, token, err := svc.CreateAPIKey(ctx, "ci", scopes)
+	require.NoError(t, err)
+	assert.Equal(t, userID, key.UserID)
+	assert.True(t, key.Matches(token))
+
+	_, _, err = svc.CreateAPIKey(ctx, "ci", domain.APIKeyScopes{})
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	repo.EXPECT().AddAPIKey(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral)
+
+	_, _, err = svc.CreateAPIKey(ctx, "ci", scopes)
+	require.ErrorIs(t, err, e.ErrAPIKeysInternal)
+
+	_, _, err = svc.CreateAPIKey(context.Background(), "ci", scopes)
+	require.ErrorIs(t, err, e.ErrAPIKeysInternal)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl, repo, svc := setupRepoAPIKeysTest(t)
+	defer ctrl.Finish()
+
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+
+	repo.EXPECT().RevokeAPIKey(gomock.Any(), userID, "id").Return(nil)
+	require.NoError(t, svc.RevokeAPIKey(ctx, "id"))
+
+	repo.EXPECT().RevokeAPIKey(gomock.Any(), userID, "id").Return(e.ErrAPIKeyNotFound)
+	require.ErrorIs(t, svc.RevokeAPIKey(ctx, "id"), e.ErrAPIKeyNotFound)
+
+	repo.EXPECT().RevokeAPIKey(gomock.Any(), userID, "id").Return(e.ErrTestGeneral)
+	require.ErrorIs(t, svc.RevokeAPIKey(ctx, "id"), e.ErrAPIKeysInternal)
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl, repo, svc := setupRepoAPIKeysTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	key, token, err := domain.NewAPIKey(domain.NewUserID(), "ci", domain.APIKeyScopes{domain.ScopeRead})
+	require.NoError(t, err)
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), key.ID).Return(key, nil)
+
+	res, err := svc.ResolveAPIKey(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, key, res)
+
+	_, err = svc.ResolveAPIKey(ctx, "sk_malformed")
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), key.ID).Return(key, nil)
+
+	_, err = svc.ResolveAPIKey(ctx, token[:len(token)-1]+"x")
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), key.ID).Return(nil, e.ErrAPIKeyNotFound)
+
+	_, err = svc.ResolveAPIKey(ctx, token)
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	revoked := *key
+	revoked.Revoked = true
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), key.ID).Return(&revoked, nil)
+
+	_, err = svc.ResolveAPIKey(ctx, token)
+	require.ErrorIs(t, err, e.ErrAPIKeyInvalid)
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), key.ID).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.ResolveAPIKey(ctx, token)
+	require.ErrorIs(t, err, e.ErrAPIKeysInternal)
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+)
+
+// Audit log limits.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Auditor defines the interface for an audit service of link lifecycle events.
+// Recording is best effort: failures are logged and never fail the audited operations.
+type Auditor interface {
+	Record(ctx context.Context, events ...domain.AuditEvent)
+	GetEvents(ctx context.Context, filter dto.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// NopAuditor is an Auditor which records nothing.
+type NopAuditor struct{}
+
+// Record discards the events.
+func (NopAuditor) Record(_ context.Context, _ ...domain.AuditEvent) {}
+
+// GetEvents always returns no events.
+func (NopAuditor) GetEvents(_ context.Context, _ dto.AuditFilter) ([]domain.AuditEvent, error) {
+	return []domain.AuditEvent{}, nil
+}
+
+// NewEvent creates an audit event of an action on a slug taken by the user of the request,
+// the source of the request is unknown outside of the http and grpc servers.
+func NewEvent(ctx context.Context, action domain.AuditAction, slug domain.Slug, details string) domain.AuditEvent {
+	userID, _ := middleware.GetUserID(ctx)
+	source, _ := middleware.GetSource(ctx)
+
+	return domain.NewAuditEvent(action, slug, userID, source, details)
+}
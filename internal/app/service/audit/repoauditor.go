@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+// RepoAuditor is an audit service backed by the audit repository.
+type RepoAuditor struct {
+	repo repository.AuditRepository
+	log  *zerolog.Logger
+}
+
+// NewRepoAuditor creates a new instance of RepoAuditor.
+func NewRepoAuditor(repo repository.AuditRepository, log *zerolog.Logger) *RepoAuditor {
+	return &RepoAuditor{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// Record appends the events to the audit log.
+func (s *RepoAuditor) Record(ctx context.Context, events ...domain.AuditEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	if err := s.repo.AddAuditEvents(ctx, events); err != nil {
+		s.log.Error().Err(err).
+			Str("action", string(events[0].Action)).
+			Int("count", len(events)).
+			Msg("failed to record audit events")
+	}
+}
+
+// GetEvents returns the latest events of a slug, of a user or of both, newest first.
+func (s *RepoAuditor) GetEvents(ctx context.Context, filter dto.AuditFilter) ([]domain.AuditEvent, error) {
+	if (filter.Slug == "" && filter.UserID.IsNil()) || filter.Limit <= 0 || filter.Limit > MaxLimit {
+		return nil, e.ErrAuditParams
+	}
+
+	events, err := s.repo.GetAuditEvents(ctx, filter)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get audit events")
+
+		return nil, e.ErrAuditInternal
+	}
+
+	return events, nil
+}
@@ -0,0 +1,111 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
+)
+
+func setupRepoAuditorTest(t *testing.T) (*gomock.Controller, *mock.MockAuditRepository, *audit.RepoAuditor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockAuditRepository(ctrl)
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	return ctrl, repo, audit.NewRepoAuditor(repo, log)
+}
+
+func TestNewEvent(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	source := domain.RequestSource{Protocol: domain.ProtocolGRPC, ClientIP: "10.0.0.1"}
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	ctx = middleware.WithSource(ctx, source)
+
+	event := audit.NewEvent(ctx, domain.AuditUpdated, "slug1", "rules")
+	assert.Equal(t, domain.AuditUpdated, event.Action)
+	assert.Equal(t, domain.Slug("slug1"), event.Slug)
+	assert.Equal(t, userID, event.UserID)
+	assert.Equal(t, domain.ProtocolGRPC, event.Protocol)
+	assert.Equal(t, "10.0.0.1", event.ClientIP)
+	assert.Equal(t, "rules", event.Details)
+
+	event = audit.NewEvent(context.Background(), domain.AuditDeleted, "slug1", "")
+	assert.True(t, event.UserID.IsNil())
+	assert.Empty(t, event.Protocol)
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	ctrl, repo, svc := setupRepoAuditorTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	event := audit.NewEvent(ctx, domain.AuditCreated, "slug1", "")
+
+	repo.EXPECT().AddAuditEvents(gomock.Any(), []domain.AuditEvent{event}).Return(nil)
+	svc.Record(ctx, event)
+
+	// failures are only logged
+	repo.EXPECT().AddAuditEvents(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral)
+	svc.Record(ctx, event)
+
+	// nothing to record
+	svc.Record(ctx)
+}
+
+func TestGetEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl, repo, svc := setupRepoAuditorTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	filter := dto.AuditFilter{Slug: "slug1", UserID: domain.UserID{}, Limit: audit.DefaultLimit}
+	events := []domain.AuditEvent{audit.NewEvent(ctx, domain.AuditCreated, "slug1", "")}
+
+	repo.EXPECT().GetAuditEvents(gomock.Any(), filter).Return(events, nil)
+
+	res, err := svc.GetEvents(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, events, res)
+
+	repo.EXPECT().GetAuditEvents(gomock.Any(), filter).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.GetEvents(ctx, filter)
+	require.ErrorIs(t, err, e.ErrAuditInternal)
+
+	for _, invalid := range []dto.AuditFilter{
+		{Slug: "", UserID: domain.UserID{}, Limit: audit.DefaultLimit},
+		{Slug: "slug1", UserID: domain.UserID{}, Limit: 0},
+		{Slug: "", UserID: domain.NewUserID(), Limit: audit.MaxLimit + 1},
+	} {
+		_, err = svc.GetEvents(ctx, invalid)
+		require.ErrorIs(t, err, e.ErrAuditParams)
+	}
+}
+
+func TestNopAuditor(t *testing.T) {
+	t.Parallel()
+
+	auditor := audit.NopAuditor{}
+	auditor.Record(context.Background(), audit.NewEvent(context.Background(), domain.AuditCreated, "slug1", ""))
+
+	events, err := auditor.GetEvents(context.Background(), dto.AuditFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
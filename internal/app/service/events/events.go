@@ -0,0 +1,24 @@
+package events
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+const errLabel = "events"
+
+// EventPublisher publishes URL mapping events to a message broker.
+// Publish returns once the broker has acknowledged the event, events may be published more than once
+// and consumers should deduplicate them by their event ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *domain.URLMappingEvent) error
+}
+
+// NopPublisher is an EventPublisher which drops all events, it is used when no broker is configured.
+type NopPublisher struct{}
+
+// Publish drops the event.
+func (NopPublisher) Publish(_ context.Context, _ *domain.URLMappingEvent) error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// InProcessPublisher is an EventPublisher delivering events to a channel within the process.
+// Publishing blocks until the event is received or the context is done.
+type InProcessPublisher struct {
+	events chan domain.URLMappingEvent
+}
+
+// NewInProcessPublisher creates a new instance of InProcessPublisher with the channel buffer size.
+func NewInProcessPublisher(buffer int) *InProcessPublisher {
+	return &InProcessPublisher{
+		events: make(chan domain.URLMappingEvent, buffer),
+	}
+}
+
+// Publish sends the event to the channel.
+func (p *InProcessPublisher) Publish(ctx context.Context, event *domain.URLMappingEvent) error {
+	select {
+	case p.events <- *event:
+		return nil
+	case <-ctx.Done():
+		return e.Wrap("event not received", ctx.Err(), errLabel)
+	}
+}
+
+// Events returns the channel of published events.
+func (p *InProcessPublisher) Events() <-chan domain.URLMappingEvent {
+	return p.events
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+)
+
+const natsReconnectWait = 2 * time.Second
+
+// NATSPublisher is an EventPublisher publishing events to NATS JetStream.
+// Events of each type are published to the configured subject prefix followed by the event type,
+// e.g. shortener.events.urlmapping.created, a stream capturing the subjects is expected to exist.
+// Event IDs are set as message IDs, so the stream discards redelivered events within its duplicates window.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+	baseURL string
+	log     *zerolog.Logger
+}
+
+// NewNATSPublisher creates a new instance of NATSPublisher connected to the configured NATS server.
+// The server needs not to be available yet, the connection is retried in the background
+// and events are published once it is established.
+func NewNATSPublisher(config *config.Config, log *zerolog.Logger) (*NATSPublisher, error) {
+	conn, err := nats.Connect(
+		config.EventsNATSURL,
+		nats.Name("shortener"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(natsReconnectWait),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			log.Warn().Err(err).Msg("events: disconnected from nats")
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Info().Str("url", conn.ConnectedUrlRedacted()).Msg("events: connected to nats")
+		}),
+	)
+	if err != nil {
+		return nil, e.Wrap("failed to connect to nats", err, errLabel)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+
+		return nil, e.Wrap("failed to create jetstream context", err, errLabel)
+	}
+
+	return &NATSPublisher{
+		conn:    conn,
+		js:      js,
+		subject: config.EventsSubject,
+		baseURL: config.BaseURL,
+		log:     log,
+	}, nil
+}
+
+// Publish publishes the event and waits for the stream acknowledgement.
+func (p *NATSPublisher) Publish(ctx context.Context, event *domain.URLMappingEvent) error {
+	payload, err := easyjson.Marshal(dto.NewURLMappingEvent(event, p.baseURL))
+	if err != nil {
+		return e.Wrap("failed to marshal event", err, errLabel)
+	}
+
+	ack, err := p.js.Publish(ctx, p.subject+"."+string(event.Type), payload, jetstream.WithMsgID(event.EventID))
+	if err != nil {
+		return e.Wrap("failed to publish event", err, errLabel)
+	}
+
+	if ack.Duplicate {
+		p.log.Debug().
+			Str("event_id", event.EventID).
+			Msg("events: duplicate event discarded by stream")
+	}
+
+	return nil
+}
+
+// Close drains pending messages and closes the connection, a connection not established yet is just closed.
+func (p *NATSPublisher) Close() error {
+	if !p.conn.IsConnected() {
+		p.conn.Close()
+
+		return nil
+	}
+
+	if err := p.conn.Drain(); err != nil {
+		return e.Wrap("failed to drain nats connection", err, errLabel)
+	}
+
+	return nil
+}
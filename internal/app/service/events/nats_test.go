@@ -0,0 +1,34 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/events"
+)
+
+func TestNATSPublisherUnavailable(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.EventsNATSURL = "nats://127.0.0.1:1"
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	// unavailable server does not fail the start
+	publisher, err := events.NewNATSPublisher(cfg, log)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	event := domain.NewURLMappingEvent(domain.URLMappingDeleted, "slug1", domain.NewUserID())
+	require.Error(t, publisher.Publish(ctx, &event))
+	require.NoError(t, publisher.Close())
+}
@@ -0,0 +1,136 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+const relayBatchSize = 100
+
+// Relay publishes URL mapping events added to the repository outbox along with URL mappings changes.
+// Events are claimed for a lease and deleted once published in the order they were added,
+// so an event interrupted by a publishing failure, a crash or a shutdown is published again
+// once its lease expires: events are delivered at least once.
+type Relay struct {
+	repo      repository.OutboxRepository
+	publisher EventPublisher
+	config    *config.Config
+	log       *zerolog.Logger
+	wg        *sync.WaitGroup
+}
+
+// NewRelay creates a new instance of Relay.
+func NewRelay(
+	repo repository.OutboxRepository,
+	publisher EventPublisher,
+	config *config.Config,
+	log *zerolog.Logger,
+) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		config:    config,
+		log:       log,
+		wg:        &sync.WaitGroup{},
+	}
+}
+
+// Start initiates polling of the outbox in a separate goroutine until the context is done.
+func (r *Relay) Start(ctx context.Context) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.config.EventsPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.drain(ctx)
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the relay, waiting for events in flight to be published.
+func (r *Relay) Stop(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		r.log.Info().
+			Msg("events: relay stopped gracefully")
+	case <-ctx.Done():
+		r.log.Error().
+			Msg("events: relay shutdown timed out")
+	}
+}
+
+// drain relays events until no more events are available in the outbox.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		if r.Relay(ctx) < relayBatchSize {
+			return
+		}
+	}
+}
+
+// Relay claims available events and publishes them in order, it returns the number of published events.
+// Publishing stops at the first failure, the rest of the claimed events are published once their lease expires.
+func (r *Relay) Relay(ctx context.Context) int {
+	events, err := r.repo.ClaimOutboxEvents(ctx, r.config.EventsLease, relayBatchSize)
+	if err != nil {
+		r.log.Error().Err(err).Msg("events: failed to claim outbox events")
+
+		return 0
+	}
+
+	if len(events) == 0 {
+		return 0
+	}
+
+	// publishing never outlives the lease, so that events are not published concurrently by another relay.
+	pubCtx, cancel := context.WithTimeout(ctx, r.config.EventsLease)
+	defer cancel()
+
+	published := make([]int64, 0, len(events))
+
+	for i := range events {
+		if err := r.publisher.Publish(pubCtx, &events[i]); err != nil {
+			r.log.Error().Err(err).
+				Str("event_id", events[i].EventID).
+				Msg("events: failed to publish event")
+
+			break
+		}
+
+		published = append(published, events[i].ID)
+	}
+
+	if len(published) == 0 {
+		return 0
+	}
+
+	// published events are deleted regardless of cancellation, they would be published again otherwise.
+	if err := r.repo.DelOutboxEvents(context.WithoutCancel(ctx), published); err != nil {
+		r.log.Error().Err(err).
+			Int("events", len(published)).
+			Msg("events: failed to delete published events")
+	}
+
+	return len(published)
+}
@@ -0,0 +1,163 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/events"
+)
+
+func setupRelayTest(t *testing.T) (*repository.InMemoryURLRepository, *config.Config, *zerolog.Logger) {
+	t.Helper()
+
+	repo := repository.NewInMemoryURLRepository()
+	ctx := context.Background()
+	user := domain.NewUserID()
+
+	_, err := repo.AddURLMapping(ctx, domain.NewURLMapping("slug1", "https://example.com/1", user))
+	require.NoError(t, err)
+	require.NoError(t, repo.AddURLMappingBatch(ctx, &[]domain.URLMapping{
+		*domain.NewURLMapping("slug2", "https://example.com/2", user),
+	}))
+	_, err = repo.DelUserURLMappings(ctx, []dto.UserSlug{{Slug: "slug1", UserID: user}})
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig()
+	cfg.EventsPollInterval = 10 * time.Millisecond
+
+	return repo, cfg, logger.NewLogger(zerolog.DebugLevel).GetLogger()
+}
+
+func TestRelayPublishes(t *testing.T) {
+	t.Parallel()
+
+	repo, cfg, log := setupRelayTest(t)
+	publisher := events.NewInProcessPublisher(10)
+	relay := events.NewRelay(repo, publisher, cfg, log)
+
+	require.Equal(t, 3, relay.Relay(context.Background()))
+
+	first, second, third := <-publisher.Events(), <-publisher.Events(), <-publisher.Events()
+	assert.Equal(t, domain.URLMappingCreated, first.Type)
+	assert.Equal(t, domain.Slug("slug1"), first.Slug)
+	assert.Equal(t, domain.OriginalURL("https://example.com/1"), first.OriginalURL)
+	assert.Equal(t, domain.Slug("slug2"), second.Slug)
+	assert.Equal(t, domain.URLMappingDeleted, third.Type)
+	assert.Equal(t, domain.Slug("slug1"), third.Slug)
+
+	// published events are deleted from the outbox
+	require.Equal(t, 0, relay.Relay(context.Background()))
+}
+
+func TestRelayRepublishesFailed(t *testing.T) {
+	t.Parallel()
+
+	repo, cfg, log := setupRelayTest(t)
+	cfg.EventsLease = 0
+
+	ctrl := gomock.NewController(t)
+	publisher := mock.NewMockEventPublisher(ctrl)
+	relay := events.NewRelay(repo, publisher, cfg, log)
+
+	var published []string
+
+	record := func(_ context.Context, event *domain.URLMappingEvent) error {
+		published = append(published, event.EventID)
+
+		return nil
+	}
+
+	// publishing stops at the first failure
+	gomock.InOrder(
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(record),
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral),
+	)
+
+	require.Equal(t, 1, relay.Relay(context.Background()))
+
+	// failed event is published again once its lease expires
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(record).Times(2)
+
+	require.Equal(t, 2, relay.Relay(context.Background()))
+	require.Equal(t, 0, relay.Relay(context.Background()))
+	require.Len(t, published, 3)
+	assert.NotEqual(t, published[0], published[1])
+}
+
+func TestRelayRepoFailures(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockOutboxRepository(ctrl)
+	publisher := events.NewInProcessPublisher(1)
+	cfg := config.DefaultConfig()
+	relay := events.NewRelay(repo, publisher, cfg, logger.NewLogger(zerolog.DebugLevel).GetLogger())
+
+	repo.EXPECT().ClaimOutboxEvents(gomock.Any(), cfg.EventsLease, gomock.Any()).Return(nil, e.ErrTestGeneral)
+	require.Equal(t, 0, relay.Relay(context.Background()))
+
+	event := domain.NewURLMappingEvent(domain.URLMappingDeleted, "slug1", domain.NewUserID())
+	event.ID = 1
+
+	// events which failed to be deleted are published again once their lease expires
+	repo.EXPECT().
+		ClaimOutboxEvents(gomock.Any(), cfg.EventsLease, gomock.Any()).
+		Return([]domain.URLMappingEvent{event}, nil)
+	repo.EXPECT().DelOutboxEvents(gomock.Any(), []int64{1}).Return(e.ErrTestGeneral)
+
+	require.Equal(t, 1, relay.Relay(context.Background()))
+	assert.Equal(t, event.EventID, (<-publisher.Events()).EventID)
+}
+
+func TestRelayCancelled(t *testing.T) {
+	t.Parallel()
+
+	repo, cfg, log := setupRelayTest(t)
+	relay := events.NewRelay(repo, events.NewInProcessPublisher(0), cfg, log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// nothing is received, so nothing is deleted
+	require.Equal(t, 0, relay.Relay(ctx))
+}
+
+func TestRelayStartStop(t *testing.T) {
+	t.Parallel()
+
+	repo, cfg, log := setupRelayTest(t)
+	publisher := events.NewInProcessPublisher(10)
+	relay := events.NewRelay(repo, publisher, cfg, log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	relay.Start(ctx)
+
+	for range 3 {
+		select {
+		case <-publisher.Events():
+		case <-time.After(5 * time.Second):
+			t.Fatal("event was not published")
+		}
+	}
+
+	cancel()
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+	defer stopCancel()
+
+	relay.Stop(stopCtx)
+	require.NoError(t, stopCtx.Err())
+}
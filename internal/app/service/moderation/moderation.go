@@ -0,0 +1,26 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+// Moderation log limits.
+const (
+	DefaultLogLimit = 100
+	MaxLogLimit     = 1000
+)
+
+// Moderator defines the interface for a moderation service of administrators.
+// It includes methods for looking up links of any user, disabling and re-enabling links,
+// banning users from creating links and viewing the moderation log.
+type Moderator interface {
+	GetURL(ctx context.Context, slug domain.Slug) (*domain.URLMapping, error)
+	DisableURL(ctx context.Context, slug domain.Slug, reason string) (*domain.URLMapping, error)
+	EnableURL(ctx context.Context, slug domain.Slug, reason string) (*domain.URLMapping, error)
+	GetUserURLs(ctx context.Context, user domain.UserID) ([]domain.URLMapping, error)
+	BanUser(ctx context.Context, user domain.UserID, reason string) error
+	UnbanUser(ctx context.Context, user domain.UserID, reason string) error
+	GetModerationLog(ctx context.Context, limit int) ([]domain.ModerationEntry, error)
+}
@@ -0,0 +1,190 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+)
+
+// RepoModerator is a moderation service backed by the URL and moderation repositories.
+type RepoModerator struct {
+	repo       repository.URLRepository
+	moderation repository.ModerationRepository
+	log        *zerolog.Logger
+}
+
+// NewRepoModerator creates a new instance of RepoModerator.
+func NewRepoModerator(
+	repo repository.URLRepository,
+	moderation repository.ModerationRepository,
+	log *zerolog.Logger,
+) *RepoModerator {
+	return &RepoModerator{
+		repo:       repo,
+		moderation: moderation,
+		log:        log,
+	}
+}
+
+// GetURL returns the URL mapping of any slug, including deleted and disabled ones.
+func (s *RepoModerator) GetURL(ctx context.Context, slug domain.Slug) (*domain.URLMapping, error) {
+	m, err := s.repo.GetURLMapping(ctx, slug)
+	if errors.Is(err, e.ErrSlugNotFound) {
+		return nil, e.ErrSlugNotFound
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("slug", string(slug)).
+			Msg("failed to get urlmapping")
+
+		return nil, e.ErrModerationInternal
+	}
+
+	return m, nil
+}
+
+// DisableURL disables redirects of a slug for the reason given.
+func (s *RepoModerator) DisableURL(ctx context.Context, slug domain.Slug, reason string) (*domain.URLMapping, error) {
+	return s.moderateURL(ctx, slug, domain.ActionDisableURL, reason)
+}
+
+// EnableURL re-enables redirects of a disabled slug for the reason given.
+func (s *RepoModerator) EnableURL(ctx context.Context, slug domain.Slug, reason string) (*domain.URLMapping, error) {
+	return s.moderateURL(ctx, slug, domain.ActionEnableURL, reason)
+}
+
+// GetUserURLs returns all URL mappings of any user, users without links have none.
+func (s *RepoModerator) GetUserURLs(ctx context.Context, user domain.UserID) ([]domain.URLMapping, error) {
+	urls, err := s.repo.GetUserURLMappings(ctx, user)
+	if errors.Is(err, e.ErrUserNotFound) {
+		return []domain.URLMapping{}, nil
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("user_id", user.String()).
+			Msg("failed to get user urlmappings")
+
+		return nil, e.ErrModerationInternal
+	}
+
+	return urls, nil
+}
+
+// BanUser bans a user from creating links for the reason given.
+func (s *RepoModerator) BanUser(ctx context.Context, user domain.UserID, reason string) error {
+	return s.moderateUser(ctx, user, domain.ActionBanUser, reason)
+}
+
+// UnbanUser lifts the ban of a user for the reason given.
+func (s *RepoModerator) UnbanUser(ctx context.Context, user domain.UserID, reason string) error {
+	return s.moderateUser(ctx, user, domain.ActionUnbanUser, reason)
+}
+
+// GetModerationLog returns up to limit latest records of the moderation log, newest first.
+func (s *RepoModerator) GetModerationLog(ctx context.Context, limit int) ([]domain.ModerationEntry, error) {
+	if limit <= 0 || limit > MaxLogLimit {
+		return nil, e.ErrModerationParams
+	}
+
+	entries, err := s.moderation.GetModerationLog(ctx, limit)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get moderation log")
+
+		return nil, e.ErrModerationInternal
+	}
+
+	return entries, nil
+}
+
+func (s *RepoModerator) moderateURL(
+	ctx context.Context,
+	slug domain.Slug,
+	action domain.ModerationAction,
+	reason string,
+) (*domain.URLMapping, error) {
+	entry, err := s.newEntry(ctx, action, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Slug = slug
+
+	m, err := s.moderation.ModerateURLMapping(ctx, entry)
+	if errors.Is(err, e.ErrSlugNotFound) {
+		return nil, e.ErrSlugNotFound
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("slug", string(slug)).
+			Str("action", string(action)).
+			Msg("failed to moderate urlmapping")
+
+		return nil, e.ErrModerationInternal
+	}
+
+	s.log.Info().
+		Str("actor", entry.Actor).
+		Str("slug", string(slug)).
+		Str("action", string(action)).
+		Str("reason", entry.Reason).
+		Msg("urlmapping moderated")
+
+	return m, nil
+}
+
+func (s *RepoModerator) moderateUser(
+	ctx context.Context,
+	user domain.UserID,
+	action domain.ModerationAction,
+	reason string,
+) error {
+	entry, err := s.newEntry(ctx, action, reason)
+	if err != nil {
+		return err
+	}
+
+	entry.UserID = user
+
+	if err := s.moderation.ModerateUser(ctx, entry); err != nil {
+		s.log.Error().Err(err).
+			Str("user_id", user.String()).
+			Str("action", string(action)).
+			Msg("failed to moderate user")
+
+		return e.ErrModerationInternal
+	}
+
+	s.log.Info().
+		Str("actor", entry.Actor).
+		Str("user_id", user.String()).
+		Str("action", string(action)).
+		Str("reason", entry.Reason).
+		Msg("user moderated")
+
+	return nil
+}
+
+// newEntry creates a moderation log record of the administrator acting on the request.
+func (s *RepoModerator) newEntry(
+	ctx context.Context,
+	action domain.ModerationAction,
+	reason string,
+) (*domain.ModerationEntry, error) {
+	actor, ok := middleware.GetAdminActor(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get admin actor from context")
+
+		return nil, e.ErrModerationInternal
+	}
+
+	return domain.NewModerationEntry(action, actor, reason)
+}
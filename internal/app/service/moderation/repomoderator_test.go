@@ -0,0 +1,154 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/moderation"
+)
+
+func setupRepoModeratorTest(t *testing.T) (
+	*gomock.Controller,
+	*mock.MockURLRepository,
+	*mock.MockModerationRepository,
+	*moderation.RepoModerator,
+) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockURLRepository(ctrl)
+	mod := mock.NewMockModerationRepository(ctrl)
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	return ctrl, repo, mod, moderation.NewRepoModerator(repo, mod, log)
+}
+
+func TestModerateURL(t *testing.T) {
+	t.Parallel()
+
+	ctrl, _, mod, svc := setupRepoModeratorTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), middleware.AdminActorKey, "admin")
+	urlm := domain.NewURLMapping("slug1", "http://example.com", domain.NewUserID())
+
+	mod.EXPECT().
+		ModerateURLMapping(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.ModerationEntry) (*domain.URLMapping, error) {
+			assert.Equal(t, domain.ActionDisableURL, entry.Action)
+			assert.Equal(t, domain.Slug("slug1"), entry.Slug)
+			assert.Equal(t, "admin", entry.Actor)
+			assert.Equal(t, "phishing", entry.Reason)
+			urlm.Moderate(entry.Action, entry.Reason)
+
+			return urlm, nil
+		})
+
+	m, err := svc.DisableURL(ctx, "slug1", "phishing")
+	require.NoError(t, err)
+	assert.True(t, m.Disabled)
+
+	_, err = svc.EnableURL(ctx, "slug1", " ")
+	require.ErrorIs(t, err, e.ErrModerationReasonInvalid)
+
+	mod.EXPECT().ModerateURLMapping(gomock.Any(), gomock.Any()).Return(nil, e.ErrSlugNotFound)
+
+	_, err = svc.EnableURL(ctx, "slug1", "false positive")
+	require.ErrorIs(t, err, e.ErrSlugNotFound)
+
+	mod.EXPECT().ModerateURLMapping(gomock.Any(), gomock.Any()).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.EnableURL(ctx, "slug1", "false positive")
+	require.ErrorIs(t, err, e.ErrModerationInternal)
+
+	_, err = svc.EnableURL(context.Background(), "slug1", "false positive")
+	require.ErrorIs(t, err, e.ErrModerationInternal)
+}
+
+func TestModerateUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl, _, mod, svc := setupRepoModeratorTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), middleware.AdminActorKey, "admin")
+	userID := domain.NewUserID()
+
+	mod.EXPECT().
+		ModerateUser(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.ModerationEntry) error {
+			assert.Equal(t, domain.ActionBanUser, entry.Action)
+			assert.Equal(t, userID, entry.UserID)
+
+			return nil
+		})
+
+	require.NoError(t, svc.BanUser(ctx, userID, "spam"))
+
+	mod.EXPECT().ModerateUser(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral)
+	require.ErrorIs(t, svc.UnbanUser(ctx, userID, "appeal"), e.ErrModerationInternal)
+
+	require.ErrorIs(t, svc.UnbanUser(ctx, userID, ""), e.ErrModerationReasonInvalid)
+}
+
+func TestGetUserURLs(t *testing.T) {
+	t.Parallel()
+
+	ctrl, repo, _, svc := setupRepoModeratorTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := domain.NewUserID()
+	urls := []domain.URLMapping{*domain.NewURLMapping("slug1", "http://example.com", userID)}
+
+	repo.EXPECT().GetUserURLMappings(gomock.Any(), userID).Return(urls, nil)
+
+	res, err := svc.GetUserURLs(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, urls, res)
+
+	repo.EXPECT().GetUserURLMappings(gomock.Any(), userID).Return(nil, e.ErrUserNotFound)
+
+	res, err = svc.GetUserURLs(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	repo.EXPECT().GetUserURLMappings(gomock.Any(), userID).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.GetUserURLs(ctx, userID)
+	require.ErrorIs(t, err, e.ErrModerationInternal)
+}
+
+func TestGetModerationLog(t *testing.T) {
+	t.Parallel()
+
+	ctrl, _, mod, svc := setupRepoModeratorTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mod.EXPECT().GetModerationLog(gomock.Any(), moderation.DefaultLogLimit).Return([]domain.ModerationEntry{}, nil)
+
+	_, err := svc.GetModerationLog(ctx, moderation.DefaultLogLimit)
+	require.NoError(t, err)
+
+	_, err = svc.GetModerationLog(ctx, 0)
+	require.ErrorIs(t, err, e.ErrModerationParams)
+
+	_, err = svc.GetModerationLog(ctx, moderation.MaxLogLimit+1)
+	require.ErrorIs(t, err, e.ErrModerationParams)
+
+	mod.EXPECT().GetModerationLog(gomock.Any(), 1).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.GetModerationLog(ctx, 1)
+	require.ErrorIs(t, err, e.ErrModerationInternal)
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
 	b "github.com/patraden/ya-practicum-go-shortly/pkg/batcher"
 )
 
@@ -29,20 +31,32 @@ type URLRemover interface {
 // BatchRemover is a concrete implementation of the URLRemover interface
 // that handles the removal of user slugs in batches.
 type BatchRemover struct {
-	repo    repository.URLRepository
-	batcher *b.Batcher
-	log     *zerolog.Logger
-	wg      *sync.WaitGroup
+	repo     repository.URLRepository
+	auditor  audit.Auditor
+	notifier webhooks.Notifier
+	batcher  *b.Batcher
+	log      *zerolog.Logger
+	wg       *sync.WaitGroup
 }
 
 // NewBatchRemover creates a new instance of BatchRemover with the specified repository and logger.
-func NewBatchRemover(repo repository.URLRepository, log *zerolog.Logger) (*BatchRemover, error) {
+// Batched operations carry the audit events of the deletions, recorded once the slugs are actually deleted.
+// Actually deleted slugs are notified to webhooks of their owners as well.
+func NewBatchRemover(
+	repo repository.URLRepository,
+	auditor audit.Auditor,
+	notifier webhooks.Notifier,
+	log *zerolog.Logger,
+) (*BatchRemover, error) {
 	commitFn := func(ctx context.Context, batch b.Batch) {
 		slugs := make([]dto.UserSlug, 0, len(batch))
+		events := make(map[dto.UserSlug]domain.AuditEvent, len(batch))
 
 		for _, op := range batch {
-			if slug, ok := op.Value.(dto.UserSlug); ok {
+			if event, ok := op.Value.(domain.AuditEvent); ok {
+				slug := dto.UserSlug{UserID: event.UserID, Slug: event.Slug}
 				slugs = append(slugs, slug)
+				events[slug] = event
 			} else {
 				op.SetError(e.ErrFailedCast)
 			}
@@ -52,7 +66,7 @@ func NewBatchRemover(repo repository.URLRepository, log *zerolog.Logger) (*Batch
 			return
 		}
 
-		err := repo.DelUserURLMappings(ctx, slugs)
+		deleted, err := repo.DelUserURLMappings(ctx, slugs)
 		batch.SetError(err)
 
 		if err != nil {
@@ -61,6 +75,17 @@ func NewBatchRemover(repo repository.URLRepository, log *zerolog.Logger) (*Batch
 				Msg("remover: batch failed")
 		}
 
+		deletedEvents := make([]domain.AuditEvent, 0, len(deleted))
+		hookEvents := make([]domain.WebhookEvent, 0, len(deleted))
+
+		for _, slug := range deleted {
+			deletedEvents = append(deletedEvents, events[slug])
+			hookEvents = append(hookEvents, domain.NewWebhookEvent(domain.WebhookLinkDeleted, slug.Slug, slug.UserID))
+		}
+
+		auditor.Record(ctx, deletedEvents...)
+		notifier.Notify(ctx, hookEvents...)
+
 		select {
 		case <-ctx.Done():
 			log.Info().
@@ -85,10 +110,12 @@ func NewBatchRemover(repo repository.URLRepository, log *zerolog.Logger) (*Batch
 	}
 
 	return &BatchRemover{
-		repo:    repo,
-		batcher: batcher,
-		log:     log,
-		wg:      &sync.WaitGroup{},
+		repo:     repo,
+		auditor:  auditor,
+		notifier: notifier,
+		batcher:  batcher,
+		log:      log,
+		wg:       &sync.WaitGroup{},
 	}, nil
 }
 
@@ -122,16 +149,15 @@ func (r *BatchRemover) Stop(ctx context.Context) {
 
 // RemoveUserSlugs removes a list of user slugs asynchronously by batching the requests.
 func (r *BatchRemover) RemoveUserSlugs(ctx context.Context, slugs []domain.Slug) error {
-	userID, ok := middleware.GetUserID(ctx)
-	if !ok {
+	if _, ok := middleware.GetUserID(ctx); !ok {
 		return e.ErrRemoverInternal
 	}
 
 	errCount := 0
 
 	for _, slug := range slugs {
-		userSlug := dto.UserSlug{UserID: userID, Slug: slug}
-		if _, err := r.batcher.Send(ctx, userSlug); err != nil {
+		event := audit.NewEvent(ctx, domain.AuditDeleted, slug, "")
+		if _, err := r.batcher.Send(ctx, event); err != nil {
 			errCount++
 		}
 	}
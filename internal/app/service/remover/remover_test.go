@@ -17,7 +17,9 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/remover"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
 )
 
 func TestAsyncRemover(t *testing.T) {
@@ -31,7 +33,7 @@ func TestAsyncRemover(t *testing.T) {
 
 	mockRepo.EXPECT().
 		DelUserURLMappings(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, tasks []dto.UserSlug) error {
+		DoAndReturn(func(_ context.Context, tasks []dto.UserSlug) ([]dto.UserSlug, error) {
 			for _, task := range tasks {
 				time.Sleep(time.Millisecond)
 				atomic.AddInt32(&taskCounter, 1)
@@ -41,7 +43,7 @@ func TestAsyncRemover(t *testing.T) {
 					Msg("Deleted slug")
 			}
 
-			return nil
+			return tasks, nil
 		}).
 		AnyTimes()
 
@@ -53,7 +55,7 @@ func TestAsyncRemover(t *testing.T) {
 		slugs = append(slugs, domain.Slug("slug"+strconv.Itoa(i)))
 	}
 
-	remover, err := remover.NewBatchRemover(mockRepo, log)
+	remover, err := remover.NewBatchRemover(mockRepo, audit.NopAuditor{}, webhooks.NopNotifier{}, log)
 	require.NoError(t, err)
 
 	ctxStart, cancelStart := context.WithCancel(context.Background())
@@ -68,3 +70,65 @@ func TestAsyncRemover(t *testing.T) {
 	remover.Stop(context.Background())
 	assert.Equal(t, expectedTasks, int(taskCounter))
 }
+
+func TestRemoverAudit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	auditor := mock.NewMockAuditor(ctrl)
+	notifier := mock.NewMockNotifier(ctrl)
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	user := domain.NewUserID()
+	source := domain.RequestSource{Protocol: domain.ProtocolHTTP, ClientIP: "127.0.0.1"}
+	recorded := make(chan []domain.AuditEvent, 1)
+	notified := make(chan []domain.WebhookEvent, 1)
+
+	// only slugs actually deleted are recorded and notified
+	mockRepo.EXPECT().
+		DelUserURLMappings(gomock.Any(), gomock.Len(2)).
+		DoAndReturn(func(_ context.Context, tasks []dto.UserSlug) ([]dto.UserSlug, error) {
+			return tasks[:1], nil
+		})
+	auditor.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, events ...domain.AuditEvent) { recorded <- events })
+	notifier.EXPECT().
+		Notify(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, events ...domain.WebhookEvent) { notified <- events })
+
+	remover, err := remover.NewBatchRemover(mockRepo, auditor, notifier, log)
+	require.NoError(t, err)
+
+	ctxStart, cancelStart := context.WithCancel(context.Background())
+	remover.Start(ctxStart)
+
+	ctxRemove := middleware.WithSource(context.WithValue(context.Background(), middleware.UserIDKey, user), source)
+	err = remover.RemoveUserSlugs(ctxRemove, []domain.Slug{"slug1", "slug2"})
+	require.NoError(t, err)
+
+	select {
+	case events := <-recorded:
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.AuditDeleted, events[0].Action)
+		assert.Equal(t, domain.Slug("slug1"), events[0].Slug)
+		assert.Equal(t, user, events[0].UserID)
+		assert.Equal(t, source.ClientIP, events[0].ClientIP)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deletions were not recorded")
+	}
+
+	select {
+	case events := <-notified:
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.WebhookLinkDeleted, events[0].Type)
+		assert.Equal(t, domain.Slug("slug1"), events[0].Slug)
+		assert.Equal(t, user, events[0].UserID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deletions were not notified")
+	}
+
+	cancelStart()
+	remover.Stop(context.Background())
+}
@@ -3,6 +3,8 @@ package shortener
 import (
 	"context"
 	"errors"
+	"math/rand/v2"
+	"slices"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -14,7 +16,10 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlgenerator"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
 )
 
@@ -24,42 +29,51 @@ const batchGenFactor = 100
 // with backoff logic to ensure successful URL shortening.
 type InsistentShortener struct {
 	repo         repository.URLRepository
+	moderation   repository.ModerationRepository
 	urlGenerator urlgenerator.URLGenerator
+	policy       urlpolicy.URLPolicy
+	auditor      audit.Auditor
+	notifier     webhooks.Notifier
+	throttler    *attemptThrottler
 	config       *config.Config
 	log          *zerolog.Logger
 }
 
-// NewInsistentShortener creates a new instance of InsistentShortener with the provided repository,
-// URL generator, configuration, and logger.
+// NewInsistentShortener creates a new instance of InsistentShortener with the provided repositories,
+// URL generator, destination URL policy, auditor, webhook notifier, configuration, and logger.
 func NewInsistentShortener(
 	repo repository.URLRepository,
+	moderation repository.ModerationRepository,
 	gen urlgenerator.URLGenerator,
+	policy urlpolicy.URLPolicy,
+	auditor audit.Auditor,
+	notifier webhooks.Notifier,
 	config *config.Config,
 	log *zerolog.Logger,
 ) *InsistentShortener {
 	return &InsistentShortener{
 		repo:         repo,
+		moderation:   moderation,
 		urlGenerator: gen,
+		policy:       policy,
+		auditor:      auditor,
+		notifier:     notifier,
+		throttler:    newAttemptThrottler(config.PasswordMaxAttempts, config.PasswordLockout),
 		config:       config,
 		log:          log,
 	}
 }
 
+// generateSlugWithBackoff retries the operation until it succeeds, fails permanently or times out.
+// Retrying stops as soon as the context is done, the context error is returned then.
 func (s *InsistentShortener) generateSlugWithBackoff(ctx context.Context, operation func() error) error {
 	// always assume that url generation is an non-injective function.
 	// timeout based backoff is the basic mechanism to address collisions.
 	// in case of high rates of collisions errors,
 	// the intention should rather be to improve URLGenerator algorithms or service.
-	boff := utils.LinearBackoff(s.config.URLGenTimeout, s.config.URLGenRetryInterval)
-	backoff.WithContext(boff, ctx)
+	boff := backoff.WithContext(utils.LinearBackoff(s.config.URLGenTimeout, s.config.URLGenRetryInterval), ctx)
 
-	try := 1
-
-	err := backoff.Retry(func() error {
-		try++
-
-		return operation()
-	}, boff)
+	err := backoff.Retry(operation, boff)
 	if err != nil {
 		return e.Wrap("retry error", err, errLabel)
 	}
@@ -69,9 +83,29 @@ func (s *InsistentShortener) generateSlugWithBackoff(ctx context.Context, operat
 
 // ShortenURL shortens a URL by generating a unique slug for the original URL and saving it to the repository.
 // It retries generating a slug in case of collisions.
-func (s *InsistentShortener) ShortenURL(ctx context.Context, original domain.OriginalURL) (domain.Slug, error) {
+// Optional per-link settings are applied to the new URL mapping.
+// The original URL and A/B split targets must comply with the destination URL policy.
+// Duplicates are detected by the canonical form of the original URL, while redirects use it as supplied.
+// Banned users can't shorten URLs.
+// New links are recorded in the audit log and notified to webhooks of the user.
+func (s *InsistentShortener) ShortenURL(
+	ctx context.Context,
+	original domain.OriginalURL,
+	opts ...domain.URLMappingOption,
+) (domain.Slug, error) {
 	var slug domain.Slug
 
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return "", e.ErrShortenerInternal
+	}
+
+	if err := s.checkBan(ctx, userID); err != nil {
+		return "", err
+	}
+
 	operation := func() error {
 		slug = s.urlGenerator.GenerateSlug(ctx, original)
 		_, errRepo := s.repo.GetURLMapping(ctx, slug)
@@ -92,21 +126,43 @@ func (s *InsistentShortener) ShortenURL(ctx context.Context, original domain.Ori
 			return "", e.ErrSlugCollision
 		}
 
+		if ctx.Err() != nil {
+			return "", e.Wrap("slug generation cancelled", ctx.Err(), errLabel)
+		}
+
 		s.log.Error().Err(err).Msg("slug generation failed")
 
 		return "", e.ErrShortenerInternal
 	}
 
-	userID, ok := middleware.GetUserID(ctx)
-	if !ok {
-		s.log.Error().Msg("failed to get userID from context")
+	newMap := domain.NewURLMapping(slug, original, userID, opts...)
+	newMap.Canonical = original.Canonical(s.config.URLStripTracking)
+	newMap.Namespace = s.namespace(userID)
 
-		return "", e.ErrShortenerInternal
+	if err := domain.ValidateActivationWindow(newMap.ActiveFrom, newMap.ExpiresAt); err != nil {
+		return "", err
+	}
+
+	if err := newMap.Variants.Validate(); err != nil {
+		return "", err
+	}
+
+	targets := []domain.OriginalURL{original}
+	for _, v := range newMap.Variants {
+		targets = append(targets, v.Target)
+	}
+
+	if err := s.checkPolicy(ctx, targets...); err != nil {
+		return "", err
 	}
 
-	newMap := domain.NewURLMapping(slug, original, userID)
 	m, err := s.repo.AddURLMapping(ctx, newMap)
 
+	// the existing mapping is only shared when neither of the mappings has options.
+	if errors.Is(err, e.ErrOriginalExists) && (newMap.HasOptions() || m.HasOptions()) {
+		return "", e.ErrOriginalOptions
+	}
+
 	if errors.Is(err, e.ErrOriginalExists) {
 		return m.Slug, e.ErrOriginalExists
 	}
@@ -117,33 +173,260 @@ func (s *InsistentShortener) ShortenURL(ctx context.Context, original domain.Ori
 		return "", e.ErrShortenerInternal
 	}
 
+	s.auditor.Record(ctx, audit.NewEvent(ctx, domain.AuditCreated, m.Slug, ""))
+	s.notifier.Notify(ctx, createdEvent(m))
+
 	return m.Slug, nil
 }
 
 // GetOriginalURL retrieves the original URL associated with the given slug.
 // If the slug does not exist or has been deleted, appropriate errors are returned.
 func (s *InsistentShortener) GetOriginalURL(ctx context.Context, slug domain.Slug) (domain.OriginalURL, error) {
+	redirect, err := s.FollowURL(ctx, &dto.Visit{
+		Slug:     slug,
+		Probe:    false,
+		Query:    "",
+		SubPath:  "",
+		Password: "",
+		Variant:  0,
+		Client:   nil,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return redirect.Location, nil
+}
+
+// FollowURL resolves the redirect of a shortened URL and counts a click unless the visit is a probe.
+// Links without their own redirect type use the service default one.
+// Visitor query and sub-path are only merged into the redirect target of links that opted in.
+// Password protected links require a matching password, failed attempts are throttled per slug.
+// Click limited links stop redirecting once the limit is reached.
+// Links only redirect within their activation window and unless disabled by moderators.
+// Clicks reaching one of the configured milestones are notified to webhooks of the link owner.
+// A/B split links redirect to the variant the visitor was assigned before,
+// new visitors are assigned a variant randomly in proportion to variant weights.
+// The first redirect rule matching the visitor client overrides the target of the variant.
+func (s *InsistentShortener) FollowURL(ctx context.Context, visit *dto.Visit) (*dto.Redirect, error) {
+	if !s.urlGenerator.IsValidSlug(visit.Slug) {
+		return nil, e.ErrSlugInvalid
+	}
+
+	urlm, err := s.repo.GetURLMapping(ctx, visit.Slug)
+
+	if errors.Is(err, e.ErrSlugNotFound) {
+		return nil, e.ErrSlugNotFound
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("shortener internal error")
+
+		return nil, e.ErrShortenerInternal
+	}
+
+	if urlm.Deleted {
+		return nil, e.ErrSlugDeleted
+	}
+
+	if urlm.Disabled {
+		return nil, e.ErrSlugDisabled
+	}
+
+	now := time.Now()
+
+	if !urlm.IsActive(now) {
+		return nil, e.ErrSlugNotActive
+	}
+
+	if urlm.IsExpired(now) {
+		return nil, e.ErrSlugExpired
+	}
+
+	if urlm.Exhausted() {
+		return nil, e.ErrSlugExhausted
+	}
+
+	if visit.SubPath != "" && !urlm.PassPath {
+		return nil, e.ErrSlugNotFound
+	}
+
+	if err = s.checkPassword(urlm, visit.Password); err != nil {
+		return nil, err
+	}
+
+	variant := assignVariant(urlm.Variants, visit.Variant)
+
+	location, err := s.passthrough(urlm, redirectTarget(urlm, variant, visit.Client), visit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !visit.Probe {
+		var clicked *domain.URLMapping
+
+		clicked, err = s.repo.RegisterClick(ctx, visit.Slug, variant)
+
+		// limit might have been reached by a concurrent visit.
+		if errors.Is(err, e.ErrSlugExhausted) {
+			return nil, e.ErrSlugExhausted
+		}
+
+		if err != nil {
+			s.log.Error().Err(err).Msg("failed to register click")
+
+			return nil, e.ErrShortenerInternal
+		}
+
+		if slices.Contains(s.config.WebhookMilestones, clicked.Clicks) {
+			event := domain.NewWebhookEvent(domain.WebhookLinkMilestone, clicked.Slug, clicked.UserID)
+			event.Clicks = clicked.Clicks
+			s.notifier.Notify(ctx, event)
+		}
+	}
+
+	return &dto.Redirect{
+		Location:  location,
+		Type:      urlm.RedirectType.OrDefault(s.config.DefaultRedirectType),
+		Rules:     urlm.Rules,
+		Variant:   variant,
+		Protected: urlm.PasswordHash.IsSet(),
+		Limited:   urlm.MaxClicks > 0,
+		Scheduled: urlm.Scheduled(),
+	}, nil
+}
+
+// redirectTarget returns the target of the redirect before passthrough,
+// the target of a redirect rule matching the visitor takes precedence over the variant one.
+func redirectTarget(urlm *domain.URLMapping, variant int, client *domain.Client) domain.OriginalURL {
+	if client != nil {
+		if target, ok := urlm.Rules.Match(client); ok {
+			return target
+		}
+	}
+
+	if variant != 0 {
+		return urlm.Variants.Target(variant)
+	}
+
+	return urlm.OriginalURL
+}
+
+// assignVariant keeps a known variant of the visitor or draws a new one by weight.
+// Zero is returned for URLs which are not split.
+func assignVariant(variants domain.Variants, assigned int) int {
+	if len(variants) == 0 {
+		return 0
+	}
+
+	if variants.Has(assigned) {
+		return assigned
+	}
+
+	total := variants.TotalWeight()
+	if total <= 0 {
+		return 0
+	}
+
+	return variants.Pick(rand.IntN(total))
+}
+
+func (s *InsistentShortener) checkPassword(urlm *domain.URLMapping, password string) error {
+	if !urlm.PasswordHash.IsSet() {
+		return nil
+	}
+
+	if password == "" {
+		return e.ErrPasswordRequired
+	}
+
+	if !s.throttler.Allow(urlm.Slug) {
+		return e.ErrPasswordThrottled
+	}
+
+	// the reserved attempt counts as failed unless the password matches.
+	if !urlm.PasswordHash.Matches(password) {
+		return e.ErrPasswordInvalid
+	}
+
+	s.throttler.Reset(urlm.Slug)
+
+	return nil
+}
+
+func (s *InsistentShortener) passthrough(
+	urlm *domain.URLMapping,
+	target domain.OriginalURL,
+	visit *dto.Visit,
+) (domain.OriginalURL, error) {
+	var query, subPath string
+
+	if urlm.PassQuery {
+		query = visit.Query
+	}
+
+	if urlm.PassPath {
+		subPath = visit.SubPath
+	}
+
+	location, err := target.WithPassthrough(query, subPath)
+
+	if errors.Is(err, e.ErrPassthroughInvalid) {
+		return "", e.ErrPassthroughInvalid
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to build redirect location")
+
+		return "", e.ErrShortenerInternal
+	}
+
+	return location, nil
+}
+
+// GetURLInfo retrieves the metadata of a shortened URL without following it.
+// Click counts and A/B split variants, as well as the original URL of password protected and disabled links,
+// are only disclosed to the owner of the slug.
+func (s *InsistentShortener) GetURLInfo(ctx context.Context, slug domain.Slug) (*dto.URLInfo, error) {
 	if !s.urlGenerator.IsValidSlug(slug) {
-		return "", e.ErrSlugInvalid
+		return nil, e.ErrSlugInvalid
 	}
 
 	urlm, err := s.repo.GetURLMapping(ctx, slug)
 
 	if errors.Is(err, e.ErrSlugNotFound) {
-		return "", e.ErrSlugNotFound
+		return nil, e.ErrSlugNotFound
 	}
 
 	if err != nil {
 		s.log.Error().Err(err).Msg("shortener internal error")
 
-		return "", e.ErrShortenerInternal
+		return nil, e.ErrShortenerInternal
 	}
 
-	if urlm.Deleted {
-		return urlm.OriginalURL, e.ErrSlugDeleted
+	info := &dto.URLInfo{
+		ShortURL:    urlm.Slug.WithBaseURL(s.config.BaseURL),
+		OriginalURL: urlm.OriginalURL,
+		CreatedAt:   urlm.CreatedAt,
+		ActiveFrom:  urlm.ActiveFrom,
+		ExpiresAt:   urlm.ExpiresAt,
+		Deleted:     urlm.Deleted,
+		Disabled:    urlm.Disabled,
+		Expired:     urlm.IsExpired(time.Now()),
+		Exhausted:   urlm.Exhausted(),
+		Protected:   urlm.PasswordHash.IsSet(),
+		Clicks:      nil,
+		Variants:    nil,
 	}
 
-	return urlm.OriginalURL, nil
+	if userID, ok := middleware.GetUserID(ctx); ok && userID == urlm.UserID {
+		info.Clicks = &urlm.Clicks
+		info.Variants = urlm.Variants
+	} else if info.Protected || info.Disabled {
+		info.OriginalURL = ""
+	}
+
+	return info, nil
 }
 
 // GetUserURLs retrieves all URL mappings for a specific user.
@@ -171,8 +454,218 @@ func (s *InsistentShortener) GetUserURLs(ctx context.Context) (*dto.URLPairBatch
 	return dto.NewURLPairBatch(&res, s.config.BaseURL), nil
 }
 
+// ScheduleURL updates the activation window of a shortened URL owned by the current user.
+// Slugs of other users are reported as not found.
+func (s *InsistentShortener) ScheduleURL(ctx context.Context, slug domain.Slug, schedule *dto.URLSchedule) error {
+	if !s.urlGenerator.IsValidSlug(slug) {
+		return e.ErrSlugInvalid
+	}
+
+	if err := domain.ValidateActivationWindow(schedule.ActiveFrom, schedule.ExpiresAt); err != nil {
+		return err
+	}
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return e.ErrShortenerInternal
+	}
+
+	normalized := &dto.URLSchedule{ActiveFrom: schedule.ActiveFrom.UTC(), ExpiresAt: schedule.ExpiresAt.UTC()}
+	_, err := s.repo.UpdateURLMappingSchedule(ctx, dto.UserSlug{Slug: slug, UserID: userID}, normalized)
+
+	if errors.Is(err, e.ErrSlugNotFound) {
+		return e.ErrSlugNotFound
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to schedule url")
+
+		return e.ErrShortenerInternal
+	}
+
+	s.auditor.Record(ctx, audit.NewEvent(ctx, domain.AuditUpdated, slug, "schedule"))
+
+	return nil
+}
+
+// GetURLRules retrieves the redirect rules of a shortened URL owned by the current user.
+func (s *InsistentShortener) GetURLRules(ctx context.Context, slug domain.Slug) (domain.RedirectRules, error) {
+	urlm, _, err := s.getOwnedURLMapping(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return urlm.Rules, nil
+}
+
+// SetURLRules replaces the redirect rules of a shortened URL owned by the current user.
+func (s *InsistentShortener) SetURLRules(ctx context.Context, slug domain.Slug, rules domain.RedirectRules) error {
+	if !s.urlGenerator.IsValidSlug(slug) {
+		return e.ErrSlugInvalid
+	}
+
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+
+	for i := range rules {
+		if err := s.checkPolicy(ctx, rules[i].Target); err != nil {
+			return err
+		}
+	}
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return e.ErrShortenerInternal
+	}
+
+	return s.updateURLRules(ctx, dto.UserSlug{Slug: slug, UserID: userID}, rules)
+}
+
+// AddURLRule appends a redirect rule to a shortened URL owned by the current user.
+func (s *InsistentShortener) AddURLRule(ctx context.Context, slug domain.Slug, rule *domain.RedirectRule) error {
+	urlm, owner, err := s.getOwnedURLMapping(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	rules := append(append(domain.RedirectRules{}, urlm.Rules...), *rule)
+	if err = rules.Validate(); err != nil {
+		return err
+	}
+
+	if err = s.checkPolicy(ctx, rule.Target); err != nil {
+		return err
+	}
+
+	return s.updateURLRules(ctx, owner, rules)
+}
+
+// DeleteURLRule removes a redirect rule by its position from a shortened URL owned by the current user.
+func (s *InsistentShortener) DeleteURLRule(ctx context.Context, slug domain.Slug, index int) error {
+	urlm, owner, err := s.getOwnedURLMapping(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(urlm.Rules) {
+		return e.ErrRedirectRuleNotFound
+	}
+
+	rules := append(append(domain.RedirectRules{}, urlm.Rules[:index]...), urlm.Rules[index+1:]...)
+
+	return s.updateURLRules(ctx, owner, rules)
+}
+
+// getOwnedURLMapping retrieves a URL mapping of the current user, slugs of other users are reported as not found.
+func (s *InsistentShortener) getOwnedURLMapping(
+	ctx context.Context,
+	slug domain.Slug,
+) (*domain.URLMapping, dto.UserSlug, error) {
+	owner := dto.UserSlug{Slug: slug, UserID: domain.UserID{}}
+
+	if !s.urlGenerator.IsValidSlug(slug) {
+		return nil, owner, e.ErrSlugInvalid
+	}
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return nil, owner, e.ErrShortenerInternal
+	}
+
+	owner.UserID = userID
+	urlm, err := s.repo.GetURLMapping(ctx, slug)
+
+	if errors.Is(err, e.ErrSlugNotFound) || (err == nil && urlm.UserID != userID) {
+		return nil, owner, e.ErrSlugNotFound
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("shortener internal error")
+
+		return nil, owner, e.ErrShortenerInternal
+	}
+
+	return urlm, owner, nil
+}
+
+// checkPolicy checks destination URLs against the URL policy, rejections are returned as is.
+func (s *InsistentShortener) checkPolicy(ctx context.Context, targets ...domain.OriginalURL) error {
+	for _, target := range targets {
+		err := s.policy.Check(ctx, target)
+		if _, rejected := urlpolicy.Reason(err); rejected {
+			return err
+		}
+
+		if err != nil {
+			s.log.Error().Err(err).Msg("failed to check url policy")
+
+			return e.ErrShortenerInternal
+		}
+	}
+
+	return nil
+}
+
+func (s *InsistentShortener) updateURLRules(ctx context.Context, owner dto.UserSlug, rules domain.RedirectRules) error {
+	_, err := s.repo.UpdateURLMappingRules(ctx, owner, rules)
+
+	if errors.Is(err, e.ErrSlugNotFound) {
+		return e.ErrSlugNotFound
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to update url rules")
+
+		return e.ErrShortenerInternal
+	}
+
+	s.auditor.Record(ctx, audit.NewEvent(ctx, domain.AuditUpdated, owner.Slug, "rules"))
+
+	return nil
+}
+
+// checkBan refuses users banned from creating links.
+func (s *InsistentShortener) checkBan(ctx context.Context, userID domain.UserID) error {
+	banned, err := s.moderation.IsUserBanned(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to check user ban")
+
+		return e.ErrShortenerInternal
+	}
+
+	if banned {
+		s.log.Info().
+			Str("user_id", userID.String()).
+			Msg("banned user refused")
+
+		return e.ErrUserBanned
+	}
+
+	return nil
+}
+
+// namespace returns the namespace in which original URLs of the user are unique.
+// Every user owns a namespace in per-user mode, otherwise all users share the global one.
+func (s *InsistentShortener) namespace(userID domain.UserID) domain.UserID {
+	if s.config.PerUserURLs {
+		return userID
+	}
+
+	return domain.UserID{}
+}
+
 // ShortenURLBatch shortens a batch of URLs by generating unique slugs for each one and storing the mappings.
 // It retries generating slugs in case of collisions for the batch of URLs.
+// The whole batch is rejected if any URL does not comply with the destination URL policy
+// or the user is banned.
+// New links are recorded in the audit log and notified to webhooks of the user.
 func (s *InsistentShortener) ShortenURLBatch(ctx context.Context, batch *dto.OriginalURLBatch) (*dto.SlugBatch, error) {
 	size := len(*batch)
 	originals := batch.Originals()
@@ -186,6 +679,14 @@ func (s *InsistentShortener) ShortenURLBatch(ctx context.Context, batch *dto.Ori
 		return &dto.SlugBatch{}, e.ErrShortenerInternal
 	}
 
+	if err := s.checkBan(ctx, userID); err != nil {
+		return &dto.SlugBatch{}, err
+	}
+
+	if err := s.checkPolicy(ctx, originals...); err != nil {
+		return &dto.SlugBatch{}, err
+	}
+
 	operation := func() error {
 		ctxWithTO, cancel := context.WithTimeout(ctx, time.Duration(batchGenFactor*size)*time.Millisecond)
 		defer cancel()
@@ -198,6 +699,8 @@ func (s *InsistentShortener) ShortenURLBatch(ctx context.Context, batch *dto.Ori
 		// generating a batch of urlmappings
 		for i, slug := range slugs {
 			urlMappings[i] = *domain.NewURLMapping(slug, originals[i], userID)
+			urlMappings[i].Canonical = originals[i].Canonical(s.config.URLStripTracking)
+			urlMappings[i].Namespace = s.namespace(userID)
 		}
 		// trying to add them to repo
 		err = s.repo.AddURLMappingBatch(ctx, &urlMappings)
@@ -225,10 +728,36 @@ func (s *InsistentShortener) ShortenURLBatch(ctx context.Context, batch *dto.Ori
 			return nil, e.ErrSlugCollision
 		}
 
+		if ctx.Err() != nil {
+			return &dto.SlugBatch{}, e.Wrap("slug generation cancelled", ctx.Err(), errLabel)
+		}
+
 		s.log.Error().Err(err).Msg("failed to shorten url batch")
 
 		return &dto.SlugBatch{}, e.ErrShortenerInternal
 	}
 
+	events := make([]domain.AuditEvent, 0, size)
+	for _, slug := range res {
+		events = append(events, audit.NewEvent(ctx, domain.AuditCreated, slug.Slug, "batch"))
+	}
+
+	s.auditor.Record(ctx, events...)
+
+	created := make([]domain.WebhookEvent, 0, size)
+	for i := range urlMappings {
+		created = append(created, createdEvent(&urlMappings[i]))
+	}
+
+	s.notifier.Notify(ctx, created...)
+
 	return &res, nil
 }
+
+// createdEvent returns the webhook event of a new link.
+func createdEvent(urlm *domain.URLMapping) domain.WebhookEvent {
+	event := domain.NewWebhookEvent(domain.WebhookLinkCreated, urlm.Slug, urlm.UserID)
+	event.OriginalURL = urlm.OriginalURL
+
+	return event
+}
@@ -2,6 +2,9 @@ package shortener_test
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,7 +19,11 @@ import (
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/audit"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/shortener"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
 )
 
 func setupShortenURLTest(t *testing.T) (
@@ -33,7 +40,17 @@ func setupShortenURLTest(t *testing.T) (
 	urlGen := mock.NewMockURLGenerator(ctrl)
 	config := config.DefaultConfig()
 	log := zerolog.New(nil)
-	svc := shortener.NewInsistentShortener(repo, urlGen, config, &log)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
 
 	return ctrl, svc, repo, urlGen, config
 }
@@ -81,7 +98,7 @@ func TestShortenURL(t *testing.T) {
 		urlGen.EXPECT().GenerateSlug(gomock.Any(), original).Return(slug)
 		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(nil, e.ErrTestGeneral)
 
-		result, err := svc.ShortenURL(context.Background(), original)
+		result, err := svc.ShortenURL(ctx, original)
 		require.ErrorIs(t, err, e.ErrShortenerInternal)
 		assert.Equal(t, domain.Slug(""), result)
 	})
@@ -98,6 +115,245 @@ func TestShortenURL(t *testing.T) {
 		require.ErrorIs(t, err, e.ErrOriginalExists)
 		assert.Equal(t, "slug1", result.String())
 	})
+
+	t.Run("rejects options for already existing original URL", func(t *testing.T) {
+		original, slugDup := domain.OriginalURL("http://example.com"), domain.Slug("slug2")
+		urlMapping := domain.NewURLMapping("slug1", original, userID)
+
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), original).Return(slugDup).Times(1)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slugDup).Return(nil, e.ErrSlugNotFound).Times(1)
+		repo.EXPECT().AddURLMapping(gomock.Any(), gomock.Any()).Return(urlMapping, e.ErrOriginalExists).Times(1)
+
+		result, err := svc.ShortenURL(ctx, original, domain.WithMaxClicks(1))
+		require.ErrorIs(t, err, e.ErrOriginalOptions)
+		assert.Equal(t, domain.Slug(""), result)
+	})
+
+	t.Run("rejects already existing original URL with options", func(t *testing.T) {
+		original, slugDup := domain.OriginalURL("http://example.com"), domain.Slug("slug2")
+		urlMapping := domain.NewURLMapping("slug1", original, userID, domain.WithPasswordHash("hash"))
+
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), original).Return(slugDup).Times(1)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slugDup).Return(nil, e.ErrSlugNotFound).Times(1)
+		repo.EXPECT().AddURLMapping(gomock.Any(), gomock.Any()).Return(urlMapping, e.ErrOriginalExists).Times(1)
+
+		result, err := svc.ShortenURL(ctx, original)
+		require.ErrorIs(t, err, e.ErrOriginalOptions)
+		assert.Equal(t, domain.Slug(""), result)
+	})
+}
+
+func TestShortenURLCancelled(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	original, slug := domain.OriginalURL("http://example.com"), domain.Slug("slug1")
+	urlMapping := domain.NewURLMapping(slug, original, userID)
+
+	ctrl, svc, repo, urlGen, config := setupShortenURLTest(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), middleware.UserIDKey, userID))
+
+	// the client goes away while slugs collide
+	urlGen.EXPECT().GenerateSlug(gomock.Any(), original).Return(slug).MinTimes(1)
+	repo.EXPECT().
+		GetURLMapping(gomock.Any(), slug).
+		DoAndReturn(func(_ context.Context, _ domain.Slug) (*domain.URLMapping, error) {
+			cancel()
+
+			return urlMapping, nil
+		}).
+		MinTimes(1)
+
+	start := time.Now()
+	_, err := svc.ShortenURL(ctx, original)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), config.URLGenTimeout)
+
+	ctx, cancel = context.WithCancel(context.WithValue(context.Background(), middleware.UserIDKey, userID))
+	batch := dto.OriginalURLBatch{{CorrelationID: "1", OriginalURL: original}}
+
+	urlGen.EXPECT().GenerateSlugs(gomock.Any(), batch.Originals()).Return([]domain.Slug{slug}, nil).MinTimes(1)
+	repo.EXPECT().
+		AddURLMappingBatch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *[]domain.URLMapping) error {
+			cancel()
+
+			return e.ErrSlugExists
+		}).
+		MinTimes(1)
+
+	start = time.Now()
+	_, err = svc.ShortenURLBatch(ctx, &batch)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), config.URLGenTimeout)
+}
+func TestShortenURLCanonical(t *testing.T) {
+	t.Parallel()
+
+	ctrl, svc, repo, urlGen, config := setupShortenURLTest(t)
+	defer ctrl.Finish()
+
+	config.URLStripTracking = true
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, domain.NewUserID())
+	original := domain.OriginalURL("https://Example.com/a/?utm_source=news&id=1")
+
+	urlGen.EXPECT().GenerateSlug(gomock.Any(), original).Return(domain.Slug("slug1"))
+	repo.EXPECT().GetURLMapping(gomock.Any(), domain.Slug("slug1")).Return(nil, e.ErrSlugNotFound)
+	repo.EXPECT().
+		AddURLMapping(gomock.Any(), gomock.Cond(func(m *domain.URLMapping) bool {
+			return m.OriginalURL == original && m.Canonical == "https://example.com/a?id=1"
+		})).
+		DoAndReturn(func(_ context.Context, m *domain.URLMapping) (*domain.URLMapping, error) { return m, nil })
+
+	slug, err := svc.ShortenURL(ctx, original)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Slug("slug1"), slug)
+}
+
+func TestShortenURLNamespace(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	original := domain.OriginalURL("https://example.com/a")
+
+	tests := []struct {
+		name        string
+		perUserURLs bool
+		namespace   domain.UserID
+	}{
+		{"global", false, domain.UserID{}},
+		{"per user", true, userID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl, svc, repo, urlGen, config := setupShortenURLTest(t)
+			defer ctrl.Finish()
+
+			config.PerUserURLs = tt.perUserURLs
+
+			urlGen.EXPECT().GenerateSlug(gomock.Any(), original).Return(domain.Slug("slug1"))
+			repo.EXPECT().GetURLMapping(gomock.Any(), domain.Slug("slug1")).Return(nil, e.ErrSlugNotFound)
+			repo.EXPECT().
+				AddURLMapping(gomock.Any(), gomock.Cond(func(m *domain.URLMapping) bool {
+					return m.UserID == userID && m.Namespace == tt.namespace
+				})).
+				DoAndReturn(func(_ context.Context, m *domain.URLMapping) (*domain.URLMapping, error) { return m, nil })
+
+			_, err := svc.ShortenURL(ctx, original)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestShortenURLPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	policy := mock.NewMockURLPolicy(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		policy,
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, domain.NewUserID())
+	variants := domain.Variants{
+		{Target: "https://a.example.com", Weight: 1, Clicks: 0},
+		{Target: "http://127.0.0.1", Weight: 1, Clicks: 0},
+	}
+
+	t.Run("rejects unsafe split targets", func(t *testing.T) {
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), gomock.Any()).Return(domain.Slug("slug1"))
+		repo.EXPECT().GetURLMapping(gomock.Any(), domain.Slug("slug1")).Return(nil, e.ErrSlugNotFound)
+		policy.EXPECT().Check(gomock.Any(), domain.OriginalURL("https://example.com")).Return(nil)
+		policy.EXPECT().Check(gomock.Any(), domain.OriginalURL("https://a.example.com")).Return(nil)
+		policy.EXPECT().Check(gomock.Any(), domain.OriginalURL("http://127.0.0.1")).Return(e.ErrURLPrivateAddress)
+
+		_, err := svc.ShortenURL(ctx, "https://example.com", domain.WithVariants(variants))
+		require.ErrorIs(t, err, e.ErrURLPrivateAddress)
+	})
+
+	t.Run("rejects batch with unsafe url", func(t *testing.T) {
+		batch := &dto.OriginalURLBatch{
+			{CorrelationID: "1", OriginalURL: "https://example.com"},
+			{CorrelationID: "2", OriginalURL: "https://evil.example"},
+		}
+
+		policy.EXPECT().Check(gomock.Any(), domain.OriginalURL("https://example.com")).Return(nil)
+		policy.EXPECT().Check(gomock.Any(), domain.OriginalURL("https://evil.example")).Return(e.ErrURLBlocked)
+
+		_, err := svc.ShortenURLBatch(ctx, batch)
+		require.ErrorIs(t, err, e.ErrURLBlocked)
+	})
+
+	t.Run("fails on policy errors", func(t *testing.T) {
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), gomock.Any()).Return(domain.Slug("slug2"))
+		repo.EXPECT().GetURLMapping(gomock.Any(), domain.Slug("slug2")).Return(nil, e.ErrSlugNotFound)
+		policy.EXPECT().Check(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral)
+
+		_, err := svc.ShortenURL(ctx, "https://example.com")
+		require.ErrorIs(t, err, e.ErrShortenerInternal)
+	})
+}
+
+func TestShortenURLBanned(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	bans := mock.NewMockModerationRepository(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	batch := &dto.OriginalURLBatch{{CorrelationID: "1", OriginalURL: "https://example.com"}}
+
+	t.Run("rejects urls of banned user", func(t *testing.T) {
+		bans.EXPECT().IsUserBanned(gomock.Any(), userID).Return(true, nil).Times(2)
+
+		_, err := svc.ShortenURL(ctx, "https://example.com")
+		require.ErrorIs(t, err, e.ErrUserBanned)
+
+		_, err = svc.ShortenURLBatch(ctx, batch)
+		require.ErrorIs(t, err, e.ErrUserBanned)
+	})
+
+	t.Run("fails on ban check errors", func(t *testing.T) {
+		bans.EXPECT().IsUserBanned(gomock.Any(), userID).Return(false, e.ErrTestGeneral)
+
+		_, err := svc.ShortenURL(ctx, "https://example.com")
+		require.ErrorIs(t, err, e.ErrShortenerInternal)
+	})
 }
 
 func TestGetOriginalURL(t *testing.T) {
@@ -110,7 +366,17 @@ func TestGetOriginalURL(t *testing.T) {
 	urlGen := mock.NewMockURLGenerator(ctrl)
 	config := config.DefaultConfig()
 	log := zerolog.New(nil)
-	svc := shortener.NewInsistentShortener(repo, urlGen, config, &log)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
 	userID := domain.NewUserID()
 	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
 
@@ -121,12 +387,37 @@ func TestGetOriginalURL(t *testing.T) {
 
 		urlGen.EXPECT().IsValidSlug(slug).Return(true)
 		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(urlMapping, nil)
 
 		result, err := svc.GetOriginalURL(ctx, slug)
 		require.NoError(t, err)
 		assert.Equal(t, original.String(), result.String())
 	})
 
+	t.Run("does not count clicks of deleted URL", func(t *testing.T) {
+		slug := domain.Slug("short1")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+		urlMapping.Deleted = true
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		_, err := svc.GetOriginalURL(ctx, slug)
+		require.ErrorIs(t, err, e.ErrSlugDeleted)
+	})
+
+	t.Run("does not redirect disabled URL", func(t *testing.T) {
+		slug := domain.Slug("short1")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+		urlMapping.Moderate(domain.ActionDisableURL, "phishing")
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		_, err := svc.GetOriginalURL(ctx, slug)
+		require.ErrorIs(t, err, e.ErrSlugDisabled)
+	})
+
 	t.Run("returns not found error for unknown slug", func(t *testing.T) {
 		slug := domain.Slug("unknown")
 
@@ -150,6 +441,74 @@ func TestGetOriginalURL(t *testing.T) {
 	})
 }
 
+func TestGetURLInfo(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ownerID := domain.NewUserID()
+	slug := domain.Slug("short1")
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", ownerID)
+	urlMapping.Clicks = 5
+
+	t.Run("discloses clicks to owner", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), middleware.UserIDKey, ownerID)
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		info, err := svc.GetURLInfo(ctx, slug)
+		require.NoError(t, err)
+		assert.Equal(t, urlMapping.OriginalURL, info.OriginalURL)
+		assert.Equal(t, slug.WithBaseURL(config.BaseURL), info.ShortURL)
+		require.NotNil(t, info.Clicks)
+		assert.Equal(t, int64(5), *info.Clicks)
+	})
+
+	t.Run("hides clicks from other users", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), middleware.UserIDKey, domain.NewUserID())
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		info, err := svc.GetURLInfo(ctx, slug)
+		require.NoError(t, err)
+		assert.Nil(t, info.Clicks)
+	})
+
+	t.Run("returns not found error for unknown slug", func(t *testing.T) {
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(nil, e.ErrSlugNotFound)
+
+		info, err := svc.GetURLInfo(context.Background(), slug)
+		require.ErrorIs(t, err, e.ErrSlugNotFound)
+		assert.Nil(t, info)
+	})
+
+	t.Run("returns invalid slug error", func(t *testing.T) {
+		urlGen.EXPECT().IsValidSlug(domain.Slug("bad")).Return(false)
+
+		_, err := svc.GetURLInfo(context.Background(), "bad")
+		require.ErrorIs(t, err, e.ErrSlugInvalid)
+	})
+}
+
 func TestShortenURLBatch(t *testing.T) {
 	t.Parallel()
 
@@ -160,7 +519,17 @@ func TestShortenURLBatch(t *testing.T) {
 	urlGen := mock.NewMockURLGenerator(ctrl)
 	config := config.DefaultConfig()
 	log := zerolog.New(nil)
-	svc := shortener.NewInsistentShortener(repo, urlGen, config, &log)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
 	userID := domain.NewUserID()
 	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
 
@@ -223,7 +592,17 @@ func TestGetUserURLs(t *testing.T) {
 	urlGen := mock.NewMockURLGenerator(ctrl)
 	config := config.DefaultConfig()
 	log := zerolog.New(nil)
-	svc := shortener.NewInsistentShortener(repo, urlGen, config, &log)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
 	userID := domain.NewUserID()
 	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
 
@@ -255,3 +634,787 @@ func TestGetUserURLs(t *testing.T) {
 		assert.Empty(t, result)
 	})
 }
+
+func TestFollowURL(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ctx := context.Background()
+
+	t.Run("uses service default redirect type", func(t *testing.T) {
+		slug := domain.Slug("short1")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID())
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(urlMapping, nil)
+
+		redirect, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false})
+		require.NoError(t, err)
+		assert.Equal(t, urlMapping.OriginalURL, redirect.Location)
+		assert.Equal(t, config.DefaultRedirectType, redirect.Type)
+	})
+
+	t.Run("uses link redirect type", func(t *testing.T) {
+		slug := domain.Slug("short2")
+		urlMapping := domain.NewURLMapping(
+			slug,
+			"http://example.com",
+			domain.NewUserID(),
+			domain.WithRedirectType(domain.RedirectMovedPermanently),
+		)
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(urlMapping, nil)
+
+		redirect, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false})
+		require.NoError(t, err)
+		assert.Equal(t, domain.RedirectMovedPermanently, redirect.Type)
+	})
+
+	t.Run("does not count clicks of probes", func(t *testing.T) {
+		slug := domain.Slug("short3")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID())
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		redirect, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: true})
+		require.NoError(t, err)
+		assert.Equal(t, urlMapping.OriginalURL, redirect.Location)
+	})
+
+	t.Run("rejects links before activation", func(t *testing.T) {
+		slug := domain.Slug("short6")
+		activeFrom := time.Now().Add(time.Hour)
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID(), domain.WithActiveFrom(activeFrom))
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		_, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false})
+		require.ErrorIs(t, err, e.ErrSlugNotActive)
+	})
+
+	t.Run("rejects expired links", func(t *testing.T) {
+		slug := domain.Slug("short7")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID())
+		urlMapping.ExpiresAt = time.Now().Add(-time.Minute)
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		_, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false})
+		require.ErrorIs(t, err, e.ErrSlugExpired)
+	})
+
+	t.Run("rejects exhausted links", func(t *testing.T) {
+		slug := domain.Slug("short4")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID(), domain.WithMaxClicks(1))
+		urlMapping.Clicks = 1
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		_, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false})
+		require.ErrorIs(t, err, e.ErrSlugExhausted)
+	})
+
+	t.Run("rejects links exhausted by concurrent visits", func(t *testing.T) {
+		slug := domain.Slug("short5")
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID(), domain.WithMaxClicks(1))
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(nil, e.ErrSlugExhausted)
+
+		_, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false})
+		require.ErrorIs(t, err, e.ErrSlugExhausted)
+	})
+}
+
+func TestFollowURLPassthrough(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ctx := context.Background()
+	slug := domain.Slug("short1")
+	visit := &dto.Visit{Slug: slug, Probe: false, Query: "utm_source=x&a=2", SubPath: "docs"}
+
+	tests := []struct {
+		name     string
+		opts     []domain.URLMappingOption
+		location domain.OriginalURL
+		wantErr  error
+	}{
+		{"query only", []domain.URLMappingOption{domain.WithPassthrough(true, false)}, "", e.ErrSlugNotFound},
+		{
+			"query and path",
+			[]domain.URLMappingOption{domain.WithPassthrough(true, true)},
+			"http://example.com/docs?a=1&utm_source=x",
+			nil,
+		},
+		{"path only", []domain.URLMappingOption{domain.WithPassthrough(false, true)}, "http://example.com/docs?a=1", nil},
+		{"disabled", nil, "", e.ErrSlugNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlMapping := domain.NewURLMapping(slug, "http://example.com?a=1", domain.NewUserID(), tt.opts...)
+
+			urlGen.EXPECT().IsValidSlug(slug).Return(true)
+			repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+			if tt.wantErr == nil {
+				repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(urlMapping, nil)
+			}
+
+			redirect, err := svc.FollowURL(ctx, visit)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.location, redirect.Location)
+		})
+	}
+}
+
+func TestFollowURLPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	config.PasswordMaxAttempts = 2
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ctx := context.Background()
+	slug := domain.Slug("short1")
+
+	hash, err := domain.NewPasswordHash("secret")
+	require.NoError(t, err)
+
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID(), domain.WithPasswordHash(hash))
+	follow := func(password string) (*dto.Redirect, error) {
+		return svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: false, Query: "", SubPath: "", Password: password})
+	}
+
+	urlGen.EXPECT().IsValidSlug(slug).Return(true).AnyTimes()
+	repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil).AnyTimes()
+
+	_, err = follow("")
+	require.ErrorIs(t, err, e.ErrPasswordRequired)
+
+	repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(urlMapping, nil).Times(1)
+
+	redirect, err := follow("secret")
+	require.NoError(t, err)
+	assert.Equal(t, urlMapping.OriginalURL, redirect.Location)
+	assert.True(t, redirect.Protected)
+	assert.False(t, redirect.Limited)
+	assert.False(t, redirect.Scheduled)
+
+	_, err = follow("wrong")
+	require.ErrorIs(t, err, e.ErrPasswordInvalid)
+
+	_, err = follow("wrong")
+	require.ErrorIs(t, err, e.ErrPasswordInvalid)
+
+	// the correct password is rejected as well once attempts are exhausted.
+	_, err = follow("secret")
+	require.ErrorIs(t, err, e.ErrPasswordThrottled)
+}
+
+func TestFollowURLPasswordConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	config.PasswordMaxAttempts = 2
+	log := zerolog.New(nil)
+	svc := shortener.NewInsistentShortener(
+		repo,
+		repository.NewInMemoryURLRepository(),
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	slug := domain.Slug("short1")
+
+	hash, err := domain.NewPasswordHash("secret")
+	require.NoError(t, err)
+
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID(), domain.WithPasswordHash(hash))
+
+	urlGen.EXPECT().IsValidSlug(slug).Return(true).AnyTimes()
+	repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil).AnyTimes()
+
+	var (
+		wg      sync.WaitGroup
+		invalid atomic.Int32
+	)
+
+	// guesses running in parallel are limited as well as sequential ones.
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			visit := &dto.Visit{Slug: slug, Probe: false, Query: "", SubPath: "", Password: "wrong"}
+			if _, err := svc.FollowURL(context.Background(), visit); errors.Is(err, e.ErrPasswordInvalid) {
+				invalid.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(2), invalid.Load())
+}
+
+func TestFollowURLVariants(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ctx := context.Background()
+	slug := domain.Slug("short1")
+	variants := domain.Variants{
+		{Target: "https://a.example.com", Weight: 70, Clicks: 0},
+		{Target: "https://b.example.com", Weight: 30, Clicks: 0},
+	}
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID(),
+		domain.WithVariants(variants), domain.WithPassthrough(true, false))
+
+	urlGen.EXPECT().IsValidSlug(slug).Return(true).AnyTimes()
+	repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil).AnyTimes()
+
+	t.Run("keeps assigned variant", func(t *testing.T) {
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, 2).Return(urlMapping, nil)
+
+		redirect, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Query: "a=1", Variant: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 2, redirect.Variant)
+		assert.Equal(t, domain.OriginalURL("https://b.example.com?a=1"), redirect.Location)
+	})
+
+	t.Run("assigns new visitors by weight", func(t *testing.T) {
+		served := make(map[int]int)
+
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, gomock.Any()).Return(urlMapping, nil).Times(1000)
+
+		for range 1000 {
+			redirect, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Variant: 3})
+			require.NoError(t, err)
+			assert.Equal(t, variants.Target(redirect.Variant), redirect.Location)
+
+			served[redirect.Variant]++
+		}
+
+		assert.Len(t, served, 2)
+		assert.Greater(t, served[1], served[2])
+	})
+
+	t.Run("counts no click on probes", func(t *testing.T) {
+		redirect, err := svc.FollowURL(ctx, &dto.Visit{Slug: slug, Probe: true, Variant: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 1, redirect.Variant)
+	})
+}
+
+func TestGetURLInfoProtected(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ownerID := domain.NewUserID()
+	slug := domain.Slug("short1")
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", ownerID, domain.WithPasswordHash("hash"))
+
+	urlGen.EXPECT().IsValidSlug(slug).Return(true).Times(2)
+	repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil).Times(2)
+
+	info, err := svc.GetURLInfo(context.WithValue(context.Background(), middleware.UserIDKey, ownerID), slug)
+	require.NoError(t, err)
+	assert.True(t, info.Protected)
+	assert.Equal(t, urlMapping.OriginalURL, info.OriginalURL)
+
+	info, err = svc.GetURLInfo(context.WithValue(context.Background(), middleware.UserIDKey, domain.NewUserID()), slug)
+	require.NoError(t, err)
+	assert.True(t, info.Protected)
+	assert.Empty(t, info.OriginalURL)
+}
+
+func TestGetURLInfoDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	ownerID := domain.NewUserID()
+	slug := domain.Slug("short1")
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", ownerID, domain.WithMaxClicks(1))
+	urlMapping.Disabled = true
+	urlMapping.Clicks = 1
+	urlMapping.ExpiresAt = time.Now().Add(-time.Hour)
+
+	urlGen.EXPECT().IsValidSlug(slug).Return(true).Times(2)
+	repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil).Times(2)
+
+	info, err := svc.GetURLInfo(context.WithValue(context.Background(), middleware.UserIDKey, ownerID), slug)
+	require.NoError(t, err)
+	assert.True(t, info.Disabled)
+	assert.True(t, info.Expired)
+	assert.True(t, info.Exhausted)
+	assert.Equal(t, urlMapping.OriginalURL, info.OriginalURL)
+
+	// destinations of disabled links are not disclosed to others
+	info, err = svc.GetURLInfo(context.WithValue(context.Background(), middleware.UserIDKey, domain.NewUserID()), slug)
+	require.NoError(t, err)
+	assert.True(t, info.Disabled)
+	assert.Empty(t, info.OriginalURL)
+}
+
+func TestScheduleURL(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	slug := domain.Slug("short1")
+	activeFrom := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl, svc, repo, urlGen, _ := setupShortenURLTest(t)
+	defer ctrl.Finish()
+
+	t.Run("updates owned url", func(t *testing.T) {
+		schedule := &dto.URLSchedule{ActiveFrom: activeFrom, ExpiresAt: activeFrom.Add(24 * time.Hour)}
+		owner := dto.UserSlug{Slug: slug, UserID: userID}
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().UpdateURLMappingSchedule(gomock.Any(), owner, schedule).
+			Return(domain.NewURLMapping(slug, "http://example.com", userID), nil)
+
+		require.NoError(t, svc.ScheduleURL(ctx, slug, schedule))
+	})
+
+	t.Run("rejects window ending before start", func(t *testing.T) {
+		schedule := &dto.URLSchedule{ActiveFrom: activeFrom, ExpiresAt: activeFrom.Add(-time.Hour)}
+
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+
+		require.ErrorIs(t, svc.ScheduleURL(ctx, slug, schedule), e.ErrActivationWindowInvalid)
+	})
+
+	t.Run("reports urls of other users as not found", func(t *testing.T) {
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().UpdateURLMappingSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, e.ErrSlugNotFound)
+
+		require.ErrorIs(t, svc.ScheduleURL(ctx, slug, &dto.URLSchedule{}), e.ErrSlugNotFound)
+	})
+
+	t.Run("fails on repository error", func(t *testing.T) {
+		urlGen.EXPECT().IsValidSlug(slug).Return(true)
+		repo.EXPECT().UpdateURLMappingSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, e.ErrTestGeneral)
+
+		require.ErrorIs(t, svc.ScheduleURL(ctx, slug, &dto.URLSchedule{}), e.ErrShortenerInternal)
+	})
+}
+
+func TestURLRules(t *testing.T) {
+	t.Parallel()
+
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	slug := domain.Slug("short1")
+	owner := dto.UserSlug{Slug: slug, UserID: userID}
+	ios := domain.RedirectRule{Platform: domain.PlatformIOS, Target: "https://apps.apple.com/app"}
+	android := domain.RedirectRule{Platform: domain.PlatformAndroid, Target: "https://play.google.com/app"}
+
+	ctrl, svc, repo, urlGen, _ := setupShortenURLTest(t)
+	defer ctrl.Finish()
+
+	urlGen.EXPECT().IsValidSlug(gomock.Any()).Return(true).AnyTimes()
+
+	t.Run("gets rules of owned url", func(t *testing.T) {
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+		urlMapping.Rules = domain.RedirectRules{ios}
+
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		rules, err := svc.GetURLRules(ctx, slug)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RedirectRules{ios}, rules)
+	})
+
+	t.Run("hides rules of other users", func(t *testing.T) {
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", domain.NewUserID())
+
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		_, err := svc.GetURLRules(ctx, slug)
+		require.ErrorIs(t, err, e.ErrSlugNotFound)
+	})
+
+	t.Run("sets valid rules", func(t *testing.T) {
+		rules := domain.RedirectRules{ios, android}
+
+		repo.EXPECT().UpdateURLMappingRules(gomock.Any(), owner, rules).Return(nil, nil)
+
+		require.NoError(t, svc.SetURLRules(ctx, slug, rules))
+	})
+
+	t.Run("rejects invalid rules", func(t *testing.T) {
+		rules := domain.RedirectRules{{Target: "https://example.com"}}
+
+		require.ErrorIs(t, svc.SetURLRules(ctx, slug, rules), e.ErrRedirectRuleInvalid)
+	})
+
+	t.Run("appends rule", func(t *testing.T) {
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+		urlMapping.Rules = domain.RedirectRules{ios}
+
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+		repo.EXPECT().UpdateURLMappingRules(gomock.Any(), owner, domain.RedirectRules{ios, android}).Return(nil, nil)
+
+		require.NoError(t, svc.AddURLRule(ctx, slug, &android))
+		assert.Equal(t, domain.RedirectRules{ios}, urlMapping.Rules)
+	})
+
+	t.Run("deletes rule", func(t *testing.T) {
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+		urlMapping.Rules = domain.RedirectRules{ios, android}
+
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+		repo.EXPECT().UpdateURLMappingRules(gomock.Any(), owner, domain.RedirectRules{android}).Return(nil, nil)
+
+		require.NoError(t, svc.DeleteURLRule(ctx, slug, 0))
+	})
+
+	t.Run("rejects unknown rule position", func(t *testing.T) {
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+		urlMapping.Rules = domain.RedirectRules{ios}
+
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+
+		require.ErrorIs(t, svc.DeleteURLRule(ctx, slug, 1), e.ErrRedirectRuleNotFound)
+	})
+
+	t.Run("fails on repository error", func(t *testing.T) {
+		repo.EXPECT().UpdateURLMappingRules(gomock.Any(), owner, gomock.Any()).Return(nil, e.ErrTestGeneral)
+
+		require.ErrorIs(t, svc.SetURLRules(ctx, slug, nil), e.ErrShortenerInternal)
+	})
+
+	t.Run("follows matching rule with passthrough", func(t *testing.T) {
+		urlMapping := domain.NewURLMapping(slug, "http://example.com", userID, domain.WithPassthrough(true, true))
+		urlMapping.Rules = domain.RedirectRules{ios, android}
+
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil).Times(3)
+		repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(urlMapping, nil).Times(3)
+
+		tests := []struct {
+			client   *domain.Client
+			location domain.OriginalURL
+		}{
+			{&domain.Client{Platform: domain.PlatformIOS}, "https://apps.apple.com/app/docs?a=1"},
+			{&domain.Client{Platform: domain.PlatformLinux}, "http://example.com/docs?a=1"},
+			{nil, "http://example.com/docs?a=1"},
+		}
+
+		for _, tt := range tests {
+			visit := &dto.Visit{Slug: slug, Probe: false, Query: "a=1", SubPath: "docs", Client: tt.client}
+
+			redirect, err := svc.FollowURL(ctx, visit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.location, redirect.Location)
+			assert.Len(t, redirect.Rules, 2)
+		}
+	})
+}
+
+func TestShortenerAudit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	auditor := mock.NewMockAuditor(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		auditor,
+		webhooks.NopNotifier{},
+		config,
+		&log,
+	)
+	userID := domain.NewUserID()
+	source := domain.RequestSource{Protocol: domain.ProtocolGRPC, ClientIP: "10.0.0.1"}
+	ctx := middleware.WithSource(context.WithValue(context.Background(), middleware.UserIDKey, userID), source)
+	slug := domain.Slug("slug1")
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+
+	recorded := func(action domain.AuditAction, details string, slugs ...domain.Slug) any {
+		return gomock.Cond(func(events []domain.AuditEvent) bool {
+			if len(events) != len(slugs) {
+				return false
+			}
+
+			for i, event := range events {
+				if event.Action != action || event.Slug != slugs[i] || event.Details != details ||
+					event.UserID != userID || event.Protocol != source.Protocol || event.ClientIP != source.ClientIP {
+					return false
+				}
+			}
+
+			return true
+		})
+	}
+
+	t.Run("records created urls", func(t *testing.T) {
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), urlMapping.OriginalURL).Return(slug)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(nil, e.ErrSlugNotFound)
+		repo.EXPECT().AddURLMapping(gomock.Any(), gomock.Any()).Return(urlMapping, nil)
+		auditor.EXPECT().Record(gomock.Any(), recorded(domain.AuditCreated, "", slug))
+
+		_, err := svc.ShortenURL(ctx, urlMapping.OriginalURL)
+		require.NoError(t, err)
+	})
+
+	t.Run("skips existing urls", func(t *testing.T) {
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), urlMapping.OriginalURL).Return(domain.Slug("slug2"))
+		repo.EXPECT().GetURLMapping(gomock.Any(), domain.Slug("slug2")).Return(nil, e.ErrSlugNotFound)
+		repo.EXPECT().AddURLMapping(gomock.Any(), gomock.Any()).Return(urlMapping, e.ErrOriginalExists)
+
+		_, err := svc.ShortenURL(ctx, urlMapping.OriginalURL)
+		require.ErrorIs(t, err, e.ErrOriginalExists)
+	})
+
+	t.Run("records created batch urls", func(t *testing.T) {
+		originals := dto.OriginalURLBatch{
+			{CorrelationID: "1", OriginalURL: "http://example1.com"},
+			{CorrelationID: "2", OriginalURL: "http://example2.com"},
+		}
+		slugs := []domain.Slug{"short1", "short2"}
+
+		urlGen.EXPECT().GenerateSlugs(gomock.Any(), originals.Originals()).Return(slugs, nil)
+		repo.EXPECT().AddURLMappingBatch(gomock.Any(), gomock.Any()).Return(nil)
+		auditor.EXPECT().Record(gomock.Any(), recorded(domain.AuditCreated, "batch", slugs...))
+
+		_, err := svc.ShortenURLBatch(ctx, &originals)
+		require.NoError(t, err)
+	})
+
+	t.Run("records updated urls", func(t *testing.T) {
+		urlGen.EXPECT().IsValidSlug(slug).Return(true).Times(2)
+		repo.EXPECT().UpdateURLMappingSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Return(urlMapping, nil)
+		repo.EXPECT().UpdateURLMappingRules(gomock.Any(), gomock.Any(), gomock.Any()).Return(urlMapping, nil)
+		auditor.EXPECT().Record(gomock.Any(), recorded(domain.AuditUpdated, "schedule", slug))
+		auditor.EXPECT().Record(gomock.Any(), recorded(domain.AuditUpdated, "rules", slug))
+
+		require.NoError(t, svc.ScheduleURL(ctx, slug, &dto.URLSchedule{}))
+		require.NoError(t, svc.SetURLRules(ctx, slug, domain.RedirectRules{}))
+	})
+}
+
+func TestShortenerWebhooks(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockURLRepository(ctrl)
+	urlGen := mock.NewMockURLGenerator(ctrl)
+	notifier := mock.NewMockNotifier(ctrl)
+	config := config.DefaultConfig()
+	log := zerolog.New(nil)
+	bans := repository.NewInMemoryURLRepository()
+	svc := shortener.NewInsistentShortener(
+		repo,
+		bans,
+		urlGen,
+		urlpolicy.NopPolicy{},
+		audit.NopAuditor{},
+		notifier,
+		config,
+		&log,
+	)
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	slug := domain.Slug("slug1")
+	urlMapping := domain.NewURLMapping(slug, "http://example.com", userID)
+
+	notified := func(eventType domain.WebhookEventType, clicks int64, slugs ...domain.Slug) any {
+		return gomock.Cond(func(events []domain.WebhookEvent) bool {
+			if len(events) != len(slugs) {
+				return false
+			}
+
+			for i, event := range events {
+				if event.Type != eventType || event.Slug != slugs[i] || event.UserID != userID || event.Clicks != clicks {
+					return false
+				}
+			}
+
+			return true
+		})
+	}
+
+	t.Run("notifies created urls", func(t *testing.T) {
+		urlGen.EXPECT().GenerateSlug(gomock.Any(), urlMapping.OriginalURL).Return(slug)
+		repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(nil, e.ErrSlugNotFound)
+		repo.EXPECT().AddURLMapping(gomock.Any(), gomock.Any()).Return(urlMapping, nil)
+		notifier.EXPECT().Notify(gomock.Any(), notified(domain.WebhookLinkCreated, 0, slug))
+
+		_, err := svc.ShortenURL(ctx, urlMapping.OriginalURL)
+		require.NoError(t, err)
+	})
+
+	t.Run("notifies created batch urls", func(t *testing.T) {
+		originals := dto.OriginalURLBatch{
+			{CorrelationID: "1", OriginalURL: "http://example1.com"},
+			{CorrelationID: "2", OriginalURL: "http://example2.com"},
+		}
+		slugs := []domain.Slug{"short1", "short2"}
+
+		urlGen.EXPECT().GenerateSlugs(gomock.Any(), originals.Originals()).Return(slugs, nil)
+		repo.EXPECT().AddURLMappingBatch(gomock.Any(), gomock.Any()).Return(nil)
+		notifier.EXPECT().Notify(gomock.Any(), notified(domain.WebhookLinkCreated, 0, slugs...))
+
+		_, err := svc.ShortenURLBatch(ctx, &originals)
+		require.NoError(t, err)
+	})
+
+	t.Run("notifies click milestones only", func(t *testing.T) {
+		milestone := config.WebhookMilestones[0]
+		follow := func(clicks int64) {
+			clicked := *urlMapping
+			clicked.Clicks = clicks
+
+			urlGen.EXPECT().IsValidSlug(slug).Return(true)
+			repo.EXPECT().GetURLMapping(gomock.Any(), slug).Return(urlMapping, nil)
+			repo.EXPECT().RegisterClick(gomock.Any(), slug, 0).Return(&clicked, nil)
+
+			_, err := svc.GetOriginalURL(ctx, slug)
+			require.NoError(t, err)
+		}
+
+		follow(milestone - 1)
+
+		notifier.EXPECT().Notify(gomock.Any(), notified(domain.WebhookLinkMilestone, milestone, slug))
+		follow(milestone)
+
+		follow(milestone + 1)
+	})
+}
@@ -11,10 +11,18 @@ const errLabel = "shortener"
 
 // URLShortener defines the interface for a URL shortener service.
 // It includes methods for shortening individual URLs, handling batches of URLs,
-// retrieving original URLs by slug, and fetching a user's URL mappings.
+// retrieving original URLs by slug, following shortened URLs, inspecting a shortened URL,
+// and fetching a user's URL mappings.
 type URLShortener interface {
-	ShortenURL(ctx context.Context, original domain.OriginalURL) (domain.Slug, error)
+	ShortenURL(ctx context.Context, original domain.OriginalURL, opts ...domain.URLMappingOption) (domain.Slug, error)
 	ShortenURLBatch(ctx context.Context, batch *dto.OriginalURLBatch) (*dto.SlugBatch, error)
 	GetOriginalURL(ctx context.Context, slug domain.Slug) (domain.OriginalURL, error)
+	FollowURL(ctx context.Context, visit *dto.Visit) (*dto.Redirect, error)
+	GetURLInfo(ctx context.Context, slug domain.Slug) (*dto.URLInfo, error)
 	GetUserURLs(ctx context.Context) (*dto.URLPairBatch, error)
+	ScheduleURL(ctx context.Context, slug domain.Slug, schedule *dto.URLSchedule) error
+	GetURLRules(ctx context.Context, slug domain.Slug) (domain.RedirectRules, error)
+	SetURLRules(ctx context.Context, slug domain.Slug, rules domain.RedirectRules) error
+	AddURLRule(ctx context.Context, slug domain.Slug, rule *domain.RedirectRule) error
+	DeleteURLRule(ctx context.Context, slug domain.Slug, index int) error
 }
@@ -0,0 +1,69 @@
+package shortener
+
+import (
+	"sync"
+	"time"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+type reservedAttempts struct {
+	count   int
+	resetAt time.Time
+}
+
+// attemptThrottler limits password attempts per slug within a fixed time window.
+// Attempts are reserved before passwords are checked, so that concurrent attempts are limited as well,
+// and are forgotten once the password matches.
+type attemptThrottler struct {
+	mu       sync.Mutex
+	attempts map[domain.Slug]*reservedAttempts
+	limit    int
+	window   time.Duration
+}
+
+func newAttemptThrottler(limit int, window time.Duration) *attemptThrottler {
+	return &attemptThrottler{
+		mu:       sync.Mutex{},
+		attempts: make(map[domain.Slug]*reservedAttempts),
+		limit:    limit,
+		window:   window,
+	}
+}
+
+// Allow reserves an attempt for the slug, it reports whether the attempt is allowed.
+func (t *attemptThrottler) Allow(slug domain.Slug) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+
+	// drop expired windows so that the map does not grow with abandoned slugs.
+	for s, reserved := range t.attempts {
+		if now.After(reserved.resetAt) {
+			delete(t.attempts, s)
+		}
+	}
+
+	reserved, ok := t.attempts[slug]
+	if !ok {
+		reserved = &reservedAttempts{count: 0, resetAt: now.Add(t.window)}
+		t.attempts[slug] = reserved
+	}
+
+	if reserved.count >= t.limit {
+		return false
+	}
+
+	reserved.count++
+
+	return true
+}
+
+// Reset forgets attempts for the slug.
+func (t *attemptThrottler) Reset(slug domain.Slug) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.attempts, slug)
+}
@@ -2,9 +2,11 @@ package statsprovider
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog"
 
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
@@ -12,21 +14,45 @@ import (
 
 // RepoStatsProvider provides statistical data related to URL shortener service.
 type RepoStatsProvider struct {
-	repo repository.URLRepository
-	log  *zerolog.Logger
+	repo   repository.URLRepository
+	config *config.Config
+	log    *zerolog.Logger
 }
 
 // NewRepoStatsProvider creates a new instance of RepoStatsProvider.
-func NewRepoStatsProvider(repo repository.URLRepository, log *zerolog.Logger) *RepoStatsProvider {
+func NewRepoStatsProvider(
+	repo repository.URLRepository,
+	config *config.Config,
+	log *zerolog.Logger,
+) *RepoStatsProvider {
 	return &RepoStatsProvider{
-		repo: repo,
-		log:  log,
+		repo:   repo,
+		config: config,
+		log:    log,
 	}
 }
 
-// GetStats retrieves repo statistics.
-func (srv *RepoStatsProvider) GetStats(ctx context.Context) (*dto.RepoStats, error) {
-	stats, err := srv.repo.GetStats(ctx)
+// GetStats retrieves repo statistics of the last days and top users, at most config.MaxStatsDays
+// and config.MaxStatsTopUsers.
+func (srv *RepoStatsProvider) GetStats(ctx context.Context, params dto.StatsParams) (*dto.RepoStats, error) {
+	if params.Days == 0 {
+		params.Days = srv.config.StatsDays
+	}
+
+	if params.TopUsers == 0 {
+		params.TopUsers = srv.config.StatsTopUsers
+	}
+
+	if params.Days < 0 || params.Days > config.MaxStatsDays ||
+		params.TopUsers < 0 || params.TopUsers > config.MaxStatsTopUsers {
+		return nil, e.ErrStatsProviderParams
+	}
+
+	if params.Now.IsZero() {
+		params.Now = time.Now()
+	}
+
+	stats, err := srv.repo.GetStats(ctx, params)
 	if err != nil {
 		srv.log.Error().Err(err).
 			Msg("Failed to get statistics from repo")
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
 	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
 	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
@@ -25,7 +26,7 @@ func setupRepoStatsProviderTest(t *testing.T) (
 	ctrl := gomock.NewController(t)
 	repo := mock.NewMockURLRepository(ctrl)
 	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
-	svc := statsprovider.NewRepoStatsProvider(repo, log)
+	svc := statsprovider.NewRepoStatsProvider(repo, config.DefaultConfig(), log)
 
 	return ctrl, repo, svc
 }
@@ -44,9 +45,9 @@ func TestStatsProvider(t *testing.T) {
 			CountUsers: int64(5),
 		}
 
-		repo.EXPECT().GetStats(gomock.Any()).Return(expectedStats, nil)
+		repo.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(expectedStats, nil)
 
-		actualStats, err := svc.GetStats(ctx)
+		actualStats, err := svc.GetStats(ctx, dto.StatsParams{})
 		require.NoError(t, err)
 
 		assert.Equal(t, expectedStats.CountSlugs, actualStats.CountSlugs)
@@ -54,11 +55,43 @@ func TestStatsProvider(t *testing.T) {
 	})
 
 	t.Run("Failure test", func(t *testing.T) {
-		repo.EXPECT().GetStats(gomock.Any()).Return(nil, e.ErrTestGeneral)
+		repo.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, e.ErrTestGeneral)
 
-		actualStats, err := svc.GetStats(ctx)
+		actualStats, err := svc.GetStats(ctx, dto.StatsParams{})
 		require.ErrorIs(t, err, e.ErrStatsProviderInternal)
 
 		require.Nil(t, actualStats)
 	})
+
+	t.Run("Default params test", func(t *testing.T) {
+		repo.EXPECT().
+			GetStats(gomock.Any(), gomock.Cond(func(params dto.StatsParams) bool {
+				return params.Days == 30 && params.TopUsers == 10 && !params.Now.IsZero()
+			})).
+			Return(&dto.RepoStats{}, nil)
+
+		_, err := svc.GetStats(ctx, dto.StatsParams{})
+		require.NoError(t, err)
+
+		repo.EXPECT().
+			GetStats(gomock.Any(), gomock.Cond(func(params dto.StatsParams) bool {
+				return params.Days == 7 && params.TopUsers == 3
+			})).
+			Return(&dto.RepoStats{}, nil)
+
+		_, err = svc.GetStats(ctx, dto.StatsParams{Days: 7, TopUsers: 3})
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid params test", func(t *testing.T) {
+		for _, params := range []dto.StatsParams{
+			{Days: -1},
+			{Days: config.MaxStatsDays + 1},
+			{TopUsers: -1},
+			{TopUsers: config.MaxStatsTopUsers + 1},
+		} {
+			_, err := svc.GetStats(ctx, params)
+			require.ErrorIs(t, err, e.ErrStatsProviderParams)
+		}
+	})
 }
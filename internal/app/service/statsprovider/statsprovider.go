@@ -7,6 +7,7 @@ import (
 )
 
 // StatsProvider is an interface that collects variaous repo statistics.
+// Zero days and top users of params stand for the configured defaults.
 type StatsProvider interface {
-	GetStats(ctx context.Context) (*dto.RepoStats, error)
+	GetStats(ctx context.Context, params dto.StatsParams) (*dto.RepoStats, error)
 }
@@ -0,0 +1,106 @@
+package urlpolicy
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// Resolver looks up IP addresses of a host, net.Resolver satisfies it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// AddressPolicy is a URLPolicy which rejects URLs pointing to loopback, private,
+// link-local and other non-public addresses, either literally or through host name resolution.
+//
+// Hosts which fail to resolve are allowed, as they might only be resolvable for visitors.
+type AddressPolicy struct {
+	resolver Resolver
+	timeout  time.Duration
+	log      *zerolog.Logger
+}
+
+// NewAddressPolicy creates an AddressPolicy resolving host names with the given resolver.
+func NewAddressPolicy(resolver Resolver, timeout time.Duration, log *zerolog.Logger) *AddressPolicy {
+	return &AddressPolicy{
+		resolver: resolver,
+		timeout:  timeout,
+		log:      log,
+	}
+}
+
+// Check rejects URLs with hosts having any non-public address.
+func (p *AddressPolicy) Check(ctx context.Context, original domain.OriginalURL) error {
+	host := hostname(original)
+	if host == "" {
+		return nil
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return e.ErrURLPrivateAddress
+	}
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !isPublic(ip) {
+			return e.ErrURLPrivateAddress
+		}
+
+		return nil
+	}
+
+	ctxLookup, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	ips, err := p.resolver.LookupNetIP(ctxLookup, "ip", host)
+	if err != nil {
+		p.log.Debug().Err(err).Str("host", host).Msg("failed to resolve destination host")
+
+		return nil
+	}
+
+	for _, ip := range ips {
+		if !isPublic(ip) {
+			return e.ErrURLPrivateAddress
+		}
+	}
+
+	return nil
+}
+
+// DialControl is a net.Dialer control function refusing connections to non-public addresses.
+// Unlike AddressPolicy, it checks the address actually connected to, so hosts resolving
+// to public addresses when their URLs are checked can't be rebound to private ones afterwards.
+func DialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return e.Wrap("invalid dial address", err, errLabel)
+	}
+
+	if !isPublic(addrPort.Addr()) {
+		return e.ErrURLPrivateAddress
+	}
+
+	return nil
+}
+
+// isPublic checks whether an IP address is globally routable.
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast()
+}
@@ -0,0 +1,172 @@
+package urlpolicy
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// Blocklist is a URLPolicy which rejects URLs of blocked domains and their subdomains.
+//
+// Domains are loaded from a file with one domain per line, blank lines and lines starting with '#' are ignored.
+// The file is checked for changes periodically and reloaded while the Blocklist is watched.
+type Blocklist struct {
+	sync.RWMutex
+	domains  map[string]struct{}
+	path     string
+	interval time.Duration
+	modTime  time.Time
+	size     int64
+	log      *zerolog.Logger
+}
+
+// NewBlocklist creates a Blocklist from the file configured in URLBlocklistPath.
+// Without a configured file the Blocklist is empty.
+func NewBlocklist(config *config.Config, log *zerolog.Logger) (*Blocklist, error) {
+	b := &Blocklist{
+		RWMutex:  sync.RWMutex{},
+		domains:  make(map[string]struct{}),
+		path:     config.URLBlocklistPath,
+		interval: config.URLBlocklistReload,
+		modTime:  time.Time{},
+		size:     0,
+		log:      log,
+	}
+
+	if b.path == "" {
+		return b, nil
+	}
+
+	if _, err := b.reload(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ParseBlocklist reads blocked domains from r.
+func ParseBlocklist(r io.Reader) (map[string]struct{}, error) {
+	domains := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(strings.TrimPrefix(line, "*"), ".")
+		domains[strings.TrimSuffix(strings.ToLower(line), ".")] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, e.Wrap("failed to read blocklist", err, errLabel)
+	}
+
+	return domains, nil
+}
+
+// Check rejects URLs with a blocked host or a subdomain of a blocked host.
+func (b *Blocklist) Check(_ context.Context, original domain.OriginalURL) error {
+	host := hostname(original)
+
+	b.RLock()
+	defer b.RUnlock()
+
+	for host != "" {
+		if _, ok := b.domains[host]; ok {
+			return e.ErrURLBlocked
+		}
+
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			break
+		}
+
+		host = parent
+	}
+
+	return nil
+}
+
+// Len returns the number of blocked domains.
+func (b *Blocklist) Len() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return len(b.domains)
+}
+
+// Watch reloads the blocklist file whenever it changes until the context is done.
+// Failed reloads are logged and the previously loaded domains stay in effect.
+func (b *Blocklist) Watch(ctx context.Context) {
+	if b.path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := b.reload()
+			if err != nil {
+				b.log.Error().Err(err).Str("path", b.path).Msg("failed to reload url blocklist")
+
+				continue
+			}
+
+			if reloaded {
+				b.log.Info().Str("path", b.path).Int("domains", b.Len()).Msg("url blocklist reloaded")
+			}
+		}
+	}
+}
+
+// reload loads the blocklist file if it changed since the last load, it reports whether it did.
+func (b *Blocklist) reload() (bool, error) {
+	info, err := os.Stat(b.path)
+	if err != nil {
+		return false, e.Wrap("failed to stat blocklist", err, errLabel)
+	}
+
+	b.RLock()
+	unchanged := info.ModTime().Equal(b.modTime) && info.Size() == b.size
+	b.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	file, err := os.Open(b.path)
+	if err != nil {
+		return false, e.Wrap("failed to open blocklist", err, errLabel)
+	}
+	defer file.Close()
+
+	domains, err := ParseBlocklist(file)
+	if err != nil {
+		return false, err
+	}
+
+	b.Lock()
+	b.domains = domains
+	b.modTime = info.ModTime()
+	b.size = info.Size()
+	b.Unlock()
+
+	return true, nil
+}
@@ -0,0 +1,117 @@
+package urlpolicy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+const errLabel = "urlpolicy"
+
+// Reason codes of rejected destination URLs.
+const (
+	ReasonSchemeNotAllowed = "scheme_not_allowed"
+	ReasonPrivateAddress   = "private_address"
+	ReasonBlockedDomain    = "blocked_domain"
+)
+
+// URLPolicy decides whether a destination URL is safe to be shortened.
+// Rejections are reported with one of the urlpolicy static errors.
+type URLPolicy interface {
+	Check(ctx context.Context, original domain.OriginalURL) error
+}
+
+// NopPolicy is a URLPolicy which allows any URL.
+type NopPolicy struct{}
+
+// Check always allows the URL.
+func (NopPolicy) Check(_ context.Context, _ domain.OriginalURL) error {
+	return nil
+}
+
+// Chain is a URLPolicy which checks URLs against all of its policies in order.
+type Chain []URLPolicy
+
+// Check returns the first rejection of the chained policies.
+func (c Chain) Check(ctx context.Context, original domain.OriginalURL) error {
+	for _, policy := range c {
+		if err := policy.Check(ctx, original); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// New creates the URLPolicy configured in the app config from a scheme allowlist,
+// the domain blocklist and, unless private destinations are allowed, private address rejection.
+func New(config *config.Config, blocklist *Blocklist, log *zerolog.Logger) URLPolicy {
+	chain := Chain{NewSchemePolicy(config.URLAllowedSchemes), blocklist}
+
+	if !config.URLAllowPrivate {
+		chain = append(chain, NewAddressPolicy(net.DefaultResolver, config.URLResolveTimeout, log))
+	}
+
+	return chain
+}
+
+// Reason returns the reason code of a URLPolicy rejection error.
+func Reason(err error) (string, bool) {
+	switch {
+	case errors.Is(err, e.ErrURLSchemeNotAllowed):
+		return ReasonSchemeNotAllowed, true
+	case errors.Is(err, e.ErrURLPrivateAddress):
+		return ReasonPrivateAddress, true
+	case errors.Is(err, e.ErrURLBlocked):
+		return ReasonBlockedDomain, true
+	default:
+		return "", false
+	}
+}
+
+// SchemePolicy is a URLPolicy which only allows URLs with listed schemes.
+type SchemePolicy struct {
+	schemes map[string]struct{}
+}
+
+// NewSchemePolicy creates a SchemePolicy for case insensitive schemes.
+func NewSchemePolicy(schemes []string) *SchemePolicy {
+	allowed := make(map[string]struct{}, len(schemes))
+	for _, scheme := range schemes {
+		allowed[strings.ToLower(strings.TrimSpace(scheme))] = struct{}{}
+	}
+
+	return &SchemePolicy{schemes: allowed}
+}
+
+// Check rejects URLs with schemes out of the allowlist.
+func (p *SchemePolicy) Check(_ context.Context, original domain.OriginalURL) error {
+	parsedURL, err := url.Parse(original.String())
+	if err != nil {
+		return e.Wrap("failed to parse url", e.ErrURLSchemeNotAllowed, errLabel)
+	}
+
+	if _, ok := p.schemes[strings.ToLower(parsedURL.Scheme)]; !ok {
+		return e.ErrURLSchemeNotAllowed
+	}
+
+	return nil
+}
+
+// hostname returns the normalized host name of a URL without port and trailing dot.
+func hostname(original domain.OriginalURL) string {
+	parsedURL, err := url.Parse(original.String())
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(strings.ToLower(parsedURL.Hostname()), ".")
+}
@@ -0,0 +1,208 @@
+package urlpolicy_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+)
+
+type fakeResolver map[string][]netip.Addr
+
+func (r fakeResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, e.ErrTestGeneral
+	}
+
+	return ips, nil
+}
+
+func TestSchemePolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := urlpolicy.NewSchemePolicy([]string{"http", "HTTPS"})
+	ctx := context.Background()
+
+	require.NoError(t, policy.Check(ctx, "http://example.com"))
+	require.NoError(t, policy.Check(ctx, "HTTPS://example.com"))
+	require.ErrorIs(t, policy.Check(ctx, "ftp://example.com"), e.ErrURLSchemeNotAllowed)
+	require.ErrorIs(t, policy.Check(ctx, "javascript://example.com/%0Aalert(1)"), e.ErrURLSchemeNotAllowed)
+}
+
+func TestAddressPolicy(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	resolver := fakeResolver{
+		"example.com":      {netip.MustParseAddr("93.184.215.14")},
+		"intranet.example": {netip.MustParseAddr("10.0.0.1")},
+		"mixed.example":    {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("::1")},
+	}
+	policy := urlpolicy.NewAddressPolicy(resolver, time.Second, &log)
+
+	tests := []struct {
+		url     domain.OriginalURL
+		wantErr bool
+	}{
+		{"https://example.com/path", false},
+		{"https://unresolvable.example", false},
+		{"http://93.184.215.14:8080", false},
+		{"http://127.0.0.1", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://192.168.1.1", true},
+		{"http://[::1]:8080", true},
+		{"http://[::ffff:10.0.0.1]", true},
+		{"http://0.0.0.0", true},
+		{"http://localhost:3000", true},
+		{"http://app.localhost", true},
+		{"https://intranet.example", true},
+		{"https://mixed.example", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url.String(), func(t *testing.T) {
+			t.Parallel()
+
+			err := policy.Check(context.Background(), tt.url)
+			if tt.wantErr {
+				require.ErrorIs(t, err, e.ErrURLPrivateAddress)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{"93.184.215.14:443", false},
+		{"[2606:2800:21f:cb07:6820:80da:af6b:8b2c]:443", false},
+		{"127.0.0.1:80", true},
+		{"10.0.0.1:8080", true},
+		{"[::1]:80", true},
+		{"[::ffff:169.254.169.254]:80", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			t.Parallel()
+
+			err := urlpolicy.DialControl("tcp", tt.address, nil)
+			if tt.wantErr {
+				require.ErrorIs(t, err, e.ErrURLPrivateAddress)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseBlocklist(t *testing.T) {
+	t.Parallel()
+
+	domains, err := urlpolicy.ParseBlocklist(strings.NewReader("# phishing\nEvil.example\n\n*.bad.example\n.worse.example.\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"evil.example": {}, "bad.example": {}, "worse.example": {}}, domains)
+}
+
+func TestBlocklist(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	cfg := config.DefaultConfig()
+	ctx := context.Background()
+
+	blocklist, err := urlpolicy.NewBlocklist(cfg, &log)
+	require.NoError(t, err)
+	require.NoError(t, blocklist.Check(ctx, "https://evil.example"))
+
+	cfg.URLBlocklistPath = filepath.Join(t.TempDir(), "blocklist.txt")
+	cfg.URLBlocklistReload = 10 * time.Millisecond
+
+	_, err = urlpolicy.NewBlocklist(cfg, &log)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(cfg.URLBlocklistPath, []byte("evil.example\n"), 0o600))
+
+	blocklist, err = urlpolicy.NewBlocklist(cfg, &log)
+	require.NoError(t, err)
+	require.ErrorIs(t, blocklist.Check(ctx, "https://evil.example/login"), e.ErrURLBlocked)
+	require.ErrorIs(t, blocklist.Check(ctx, "https://login.EVIL.example./"), e.ErrURLBlocked)
+	require.NoError(t, blocklist.Check(ctx, "https://notevil.example"))
+	require.NoError(t, blocklist.Check(ctx, "https://bad.example"))
+
+	ctxWatch, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go blocklist.Watch(ctxWatch)
+
+	require.NoError(t, os.WriteFile(cfg.URLBlocklistPath, []byte("evil.example\nbad.example\n"), 0o600))
+	require.Eventually(t, func() bool {
+		return blocklist.Check(ctx, "https://bad.example") != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// broken reloads keep the blocklist in effect.
+	require.NoError(t, os.Remove(cfg.URLBlocklistPath))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 2, blocklist.Len())
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	cfg := config.DefaultConfig()
+
+	blocklist, err := urlpolicy.NewBlocklist(cfg, &log)
+	require.NoError(t, err)
+
+	policy := urlpolicy.New(cfg, blocklist, &log)
+	ctx := context.Background()
+
+	require.ErrorIs(t, policy.Check(ctx, "ftp://127.0.0.1"), e.ErrURLSchemeNotAllowed)
+	require.ErrorIs(t, policy.Check(ctx, "http://127.0.0.1"), e.ErrURLPrivateAddress)
+
+	cfg.URLAllowPrivate = true
+	policy = urlpolicy.New(cfg, blocklist, &log)
+	require.NoError(t, policy.Check(ctx, "http://127.0.0.1"))
+}
+
+func TestReason(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err    error
+		reason string
+		ok     bool
+	}{
+		{e.ErrURLSchemeNotAllowed, urlpolicy.ReasonSchemeNotAllowed, true},
+		{e.Wrap("wrapped", e.ErrURLPrivateAddress, "test"), urlpolicy.ReasonPrivateAddress, true},
+		{e.ErrURLBlocked, urlpolicy.ReasonBlockedDomain, true},
+		{e.ErrTestGeneral, "", false},
+		{nil, "", false},
+	}
+
+	for _, tt := range tests {
+		reason, ok := urlpolicy.Reason(tt.err)
+		assert.Equal(t, tt.reason, reason)
+		assert.Equal(t, tt.ok, ok)
+	}
+}
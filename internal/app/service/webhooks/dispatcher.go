@@ -0,0 +1,227 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+const (
+	dispatchBatchSize = 100
+	leaseFactor       = 2                // Claimed deliveries are leased for this many delivery timeouts.
+	maxResponseBody   = 1 << 16          // Bytes of receiver responses drained to reuse connections.
+	dialTimeout       = 30 * time.Second // Same as the dialer of http.DefaultTransport.
+	dialKeepAlive     = 30 * time.Second // Same as the dialer of http.DefaultTransport.
+)
+
+// Dispatcher delivers webhook events queued in the repository outbox.
+// Failed deliveries are retried with exponential backoff and dead-lettered after the configured attempts.
+// Deliveries are claimed for a lease, so a delivery interrupted by a crash or a shutdown is retried
+// once its lease expires: receivers should deduplicate events by their ID.
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	config *config.Config
+	log    *zerolog.Logger
+	wg     *sync.WaitGroup
+}
+
+// NewDispatcher creates a new instance of Dispatcher.
+// Redirects of receivers are not followed and count as failed deliveries.
+// Unless private URLs are allowed, connections to non-public addresses are refused,
+// since receiver hosts may resolve to other addresses than when their webhooks were registered.
+func NewDispatcher(repo repository.WebhookRepository, config *config.Config, log *zerolog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo: repo,
+		client: &http.Client{
+			Transport: newTransport(config),
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+			Jar:     nil,
+			Timeout: config.WebhookTimeout,
+		},
+		config: config,
+		log:    log,
+		wg:     &sync.WaitGroup{},
+	}
+}
+
+// newTransport returns the transport of deliveries, dialing only public addresses unless private URLs are allowed.
+// Deliveries are not sent through proxies, as the addresses of receivers could not be checked then.
+func newTransport(config *config.Config) http.RoundTripper {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok || config.URLAllowPrivate {
+		return http.DefaultTransport
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control:   urlpolicy.DialControl,
+	}
+
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+// Start initiates polling of the outbox in a separate goroutine until the context is done.
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.wg.Add(1)
+
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.config.WebhookPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.Dispatch(ctx)
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the dispatcher, waiting for deliveries in flight to complete.
+func (d *Dispatcher) Stop(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.log.Info().
+			Msg("webhooks: dispatcher stopped gracefully")
+	case <-ctx.Done():
+		d.log.Error().
+			Msg("webhooks: dispatcher shutdown timed out")
+	}
+}
+
+// Dispatch claims due deliveries and attempts them concurrently, it returns the number of attempted deliveries.
+func (d *Dispatcher) Dispatch(ctx context.Context) int {
+	lease := leaseFactor * d.config.WebhookTimeout
+
+	deliveries, err := d.repo.ClaimWebhookDeliveries(ctx, lease, dispatchBatchSize)
+	if err != nil {
+		d.log.Error().Err(err).Msg("webhooks: failed to claim deliveries")
+
+		return 0
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			d.deliver(ctx, &deliveries[i])
+		}()
+	}
+
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// deliver attempts a delivery and records its outcome.
+// Deliveries interrupted by cancellation are left to be retried once their lease expires.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) {
+	err := d.post(ctx, delivery)
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err == nil {
+		delivery.Succeed()
+	} else {
+		delivery.Fail(err.Error(), d.retryDelay(delivery.Attempts+1), d.config.WebhookMaxAttempts)
+	}
+
+	if err := d.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		d.log.Error().Err(err).
+			Int64("delivery_id", delivery.ID).
+			Msg("webhooks: failed to update delivery")
+
+		return
+	}
+
+	if delivery.Status == domain.DeliveryDead {
+		d.log.Warn().
+			Int64("delivery_id", delivery.ID).
+			Str("webhook_id", delivery.Webhook.ID).
+			Str("last_error", delivery.LastError).
+			Msg("webhooks: delivery dead-lettered")
+	}
+}
+
+// post sends the signed delivery payload, any non 2xx response is a failure.
+func (d *Dispatcher) post(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		delivery.Webhook.URL.String(),
+		bytes.NewReader(delivery.Payload),
+	)
+	if err != nil {
+		return e.Wrap("failed to create request", err, errLabel)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderSignature, delivery.Webhook.Sign(delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return e.Wrap("failed to post event", err, errLabel)
+	}
+
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody)); err != nil {
+		d.log.Debug().Err(err).Msg("webhooks: failed to drain response")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return e.Wrap(fmt.Sprintf("status %d", resp.StatusCode), e.ErrWebhookRejected, errLabel)
+	}
+
+	return nil
+}
+
+// retryDelay returns the delay after the failed attempt: the retry interval doubled for each previous failure.
+func (d *Dispatcher) retryDelay(attempt int) time.Duration {
+	boff := utils.ExponentialBackoff(d.config.WebhookRetryInterval, d.config.WebhookRetryMaxInterval)
+
+	var delay time.Duration
+	for range attempt {
+		delay = boff.NextBackOff()
+	}
+
+	return delay
+}
@@ -0,0 +1,208 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
+)
+
+func setupDispatcherTest(
+	t *testing.T,
+	receiver http.HandlerFunc,
+) (*repository.InMemoryWebhookRepository, *webhooks.RepoWebhooks, *webhooks.Dispatcher, *domain.Webhook) {
+	t.Helper()
+
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	cfg := config.DefaultConfig()
+	cfg.WebhookMaxAttempts = 3
+	cfg.WebhookRetryInterval = 0
+	cfg.URLAllowPrivate = true
+
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+	repo := repository.NewInMemoryWebhookRepository()
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), domain.OriginalURL(server.URL+"/hooks"), "")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWebhook(context.Background(), hook))
+
+	svc := webhooks.NewRepoWebhooks(repo, urlpolicy.NopPolicy{}, cfg, log)
+
+	return repo, svc, webhooks.NewDispatcher(repo, cfg, log), hook
+}
+
+func TestDispatcherDelivers(t *testing.T) {
+	t.Parallel()
+
+	var hook *domain.Webhook
+
+	received := make(chan *http.Request, 1)
+	_, svc, dispatcher, hook := setupDispatcherTest(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		// receivers verify the signature of the body with the shared secret
+		assert.Equal(t, hook.Sign(body), r.Header.Get(webhooks.HeaderSignature))
+		received <- r
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkMilestone, "slug1", hook.UserID)
+	event.Clicks = 100
+	svc.Notify(context.Background(), event)
+
+	require.Equal(t, 1, dispatcher.Dispatch(context.Background()))
+
+	r := <-received
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "/hooks", r.URL.Path)
+	assert.Equal(t, string(domain.WebhookLinkMilestone), r.Header.Get(webhooks.HeaderEvent))
+	assert.Equal(t, event.ID, r.Header.Get(webhooks.HeaderEventID))
+
+	// delivered events are not delivered again
+	require.Equal(t, 0, dispatcher.Dispatch(context.Background()))
+}
+
+func TestDispatcherDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	repo, svc, dispatcher, hook := setupDispatcherTest(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkDeleted, "slug1", hook.UserID)
+	svc.Notify(context.Background(), event)
+
+	for range 3 {
+		require.Equal(t, 1, dispatcher.Dispatch(context.Background()))
+	}
+
+	require.Equal(t, 0, dispatcher.Dispatch(context.Background()))
+	assert.Equal(t, int32(3), attempts.Load())
+
+	dead, err := repo.GetWebhookDeliveries(context.Background(), hook, domain.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Contains(t, dead[0].LastError, "status 503")
+}
+
+func TestDispatcherBacksOff(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.URLAllowPrivate = true
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+	repo := repository.NewInMemoryWebhookRepository()
+	dispatcher := webhooks.NewDispatcher(repo, cfg, log)
+
+	hook, err := domain.NewWebhook(domain.NewUserID(), domain.OriginalURL(server.URL), "")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWebhook(context.Background(), hook))
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", hook.UserID)
+	delivery := domain.NewWebhookDelivery(hook, &event, []byte(`{}`))
+	require.NoError(t, repo.AddWebhookDeliveries(context.Background(), []domain.WebhookDelivery{delivery}))
+
+	require.Equal(t, 1, dispatcher.Dispatch(context.Background()))
+
+	// the failed delivery waits for the retry interval before the next attempt
+	require.Equal(t, 0, dispatcher.Dispatch(context.Background()))
+
+	pending, err := repo.GetWebhookDeliveries(context.Background(), hook, domain.DeliveryPending, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.WithinDuration(t, time.Now().Add(cfg.WebhookRetryInterval), pending[0].NextAttemptAt, time.Second)
+}
+
+func TestDispatcherRefusesPrivateAddresses(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WebhookMaxAttempts = 1
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+	repo := repository.NewInMemoryWebhookRepository()
+	dispatcher := webhooks.NewDispatcher(repo, cfg, log)
+
+	// the receiver host resolved to a public address on registration and is rebound to loopback since
+	hook, err := domain.NewWebhook(domain.NewUserID(), domain.OriginalURL(server.URL+"/hooks"), "")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWebhook(context.Background(), hook))
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", hook.UserID)
+	delivery := domain.NewWebhookDelivery(hook, &event, []byte(`{}`))
+	require.NoError(t, repo.AddWebhookDeliveries(context.Background(), []domain.WebhookDelivery{delivery}))
+
+	require.Equal(t, 1, dispatcher.Dispatch(context.Background()))
+	assert.Equal(t, int32(0), attempts.Load())
+
+	dead, err := repo.GetWebhookDeliveries(context.Background(), hook, domain.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Contains(t, dead[0].LastError, e.ErrURLPrivateAddress.Error())
+}
+
+func TestDispatcherStartStop(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan struct{}, 1)
+	_, svc, dispatcher, hook := setupDispatcherTest(t, func(w http.ResponseWriter, _ *http.Request) {
+		received <- struct{}{}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher.Start(ctx)
+
+	svc.Notify(context.Background(), domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", hook.UserID))
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	cancel()
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+	defer stopCancel()
+
+	dispatcher.Stop(stopCtx)
+	require.NoError(t, stopCtx.Err())
+}
@@ -0,0 +1,225 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/repository"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/urlpolicy"
+)
+
+// RepoWebhooks is a webhooks service and notifier backed by the webhook repository.
+// Events are queued in the repository outbox and delivered by the Dispatcher.
+type RepoWebhooks struct {
+	repo   repository.WebhookRepository
+	policy urlpolicy.URLPolicy
+	config *config.Config
+	log    *zerolog.Logger
+}
+
+// NewRepoWebhooks creates a new instance of RepoWebhooks.
+// Webhook URLs must comply with the destination URL policy.
+func NewRepoWebhooks(
+	repo repository.WebhookRepository,
+	policy urlpolicy.URLPolicy,
+	config *config.Config,
+	log *zerolog.Logger,
+) *RepoWebhooks {
+	return &RepoWebhooks{
+		repo:   repo,
+		policy: policy,
+		config: config,
+		log:    log,
+	}
+}
+
+// Subscribe creates a webhook of the current user.
+// A random secret is generated unless one is given.
+func (s *RepoWebhooks) Subscribe(
+	ctx context.Context,
+	target domain.OriginalURL,
+	secret string,
+) (*domain.Webhook, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	hook, err := domain.NewWebhook(userID, target, secret)
+	if errors.Is(err, e.ErrWebhookInvalid) {
+		return nil, err
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to generate webhook")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	if err := s.policy.Check(ctx, target); err != nil {
+		if _, rejected := urlpolicy.Reason(err); rejected {
+			return nil, err
+		}
+
+		s.log.Error().Err(err).Msg("failed to check webhook url")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	if err := s.repo.AddWebhook(ctx, hook); err != nil {
+		s.log.Error().Err(err).Msg("failed to add webhook")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	s.log.Info().
+		Str("user_id", userID.String()).
+		Str("webhook_id", hook.ID).
+		Msg("webhook subscribed")
+
+	return hook, nil
+}
+
+// GetUserWebhooks returns all webhooks of the current user.
+func (s *RepoWebhooks) GetUserWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	hooks, err := s.repo.GetUserWebhooks(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get user webhooks")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	return hooks, nil
+}
+
+// Unsubscribe deletes a webhook of the current user along with its pending deliveries.
+func (s *RepoWebhooks) Unsubscribe(ctx context.Context, id string) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return e.ErrWebhooksInternal
+	}
+
+	err := s.repo.DelWebhook(ctx, userID, id)
+	if errors.Is(err, e.ErrWebhookNotFound) {
+		return err
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to delete webhook")
+
+		return e.ErrWebhooksInternal
+	}
+
+	s.log.Info().
+		Str("user_id", userID.String()).
+		Str("webhook_id", id).
+		Msg("webhook unsubscribed")
+
+	return nil
+}
+
+// GetDeadDeliveries returns the latest dead-lettered deliveries of a webhook of the current user, newest first.
+func (s *RepoWebhooks) GetDeadDeliveries(ctx context.Context, id string) ([]domain.WebhookDelivery, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		s.log.Error().Msg("failed to get userID from context")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	hooks, err := s.repo.GetUserWebhooks(ctx, userID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get user webhooks")
+
+		return nil, e.ErrWebhooksInternal
+	}
+
+	for i := range hooks {
+		if hooks[i].ID != id {
+			continue
+		}
+
+		deliveries, err := s.repo.GetWebhookDeliveries(ctx, &hooks[i], domain.DeliveryDead, MaxDeadDeliveries)
+		if err != nil {
+			s.log.Error().Err(err).Msg("failed to get webhook deliveries")
+
+			return nil, e.ErrWebhooksInternal
+		}
+
+		return deliveries, nil
+	}
+
+	return nil, e.ErrWebhookNotFound
+}
+
+// Notify queues deliveries of the events to every webhook of the link owners.
+func (s *RepoWebhooks) Notify(ctx context.Context, events ...domain.WebhookEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	owners := make(map[domain.UserID][]domain.Webhook)
+	deliveries := make([]domain.WebhookDelivery, 0, len(events))
+
+	for i := range events {
+		event := &events[i]
+
+		hooks, known := owners[event.UserID]
+		if !known {
+			var err error
+
+			hooks, err = s.repo.GetUserWebhooks(ctx, event.UserID)
+			if err != nil {
+				s.log.Error().Err(err).Msg("webhooks: failed to get user webhooks")
+
+				return
+			}
+
+			owners[event.UserID] = hooks
+		}
+
+		if len(hooks) == 0 {
+			continue
+		}
+
+		payload, err := easyjson.Marshal(dto.NewWebhookEvent(event, s.config.BaseURL))
+		if err != nil {
+			s.log.Error().Err(err).Msg("webhooks: failed to marshal event")
+
+			continue
+		}
+
+		for j := range hooks {
+			deliveries = append(deliveries, domain.NewWebhookDelivery(&hooks[j], event, payload))
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return
+	}
+
+	if err := s.repo.AddWebhookDeliveries(ctx, deliveries); err != nil {
+		s.log.Error().Err(err).
+			Int("size", len(deliveries)).
+			Msg("webhooks: failed to queue deliveries")
+	}
+}
@@ -0,0 +1,161 @@
+package webhooks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mailru/easyjson"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/config"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/dto"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/logger"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/middleware"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/mock"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/service/webhooks"
+)
+
+func setupRepoWebhooksTest(t *testing.T) (
+	*mock.MockWebhookRepository,
+	*mock.MockURLPolicy,
+	*webhooks.RepoWebhooks,
+) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockWebhookRepository(ctrl)
+	policy := mock.NewMockURLPolicy(ctrl)
+	log := logger.NewLogger(zerolog.DebugLevel).GetLogger()
+
+	return repo, policy, webhooks.NewRepoWebhooks(repo, policy, config.DefaultConfig(), log)
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	repo, policy, svc := setupRepoWebhooksTest(t)
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+	target := domain.OriginalURL("https://crm.example.com/hooks")
+
+	policy.EXPECT().Check(gomock.Any(), target).Return(nil)
+	repo.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).Return(nil)
+
+	hook, err := svc.Subscribe(ctx, target, "")
+	require.NoError(t, err)
+	assert.Equal(t, userID, hook.UserID)
+	assert.NotEmpty(t, hook.Secret)
+
+	_, err = svc.Subscribe(ctx, "ftp://crm.example.com/hooks", "")
+	require.ErrorIs(t, err, e.ErrWebhookInvalid)
+
+	policy.EXPECT().Check(gomock.Any(), target).Return(e.ErrURLPrivateAddress)
+
+	_, err = svc.Subscribe(ctx, target, "")
+	require.ErrorIs(t, err, e.ErrURLPrivateAddress)
+
+	policy.EXPECT().Check(gomock.Any(), target).Return(nil)
+	repo.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).Return(e.ErrTestGeneral)
+
+	_, err = svc.Subscribe(ctx, target, "")
+	require.ErrorIs(t, err, e.ErrWebhooksInternal)
+
+	_, err = svc.Subscribe(context.Background(), target, "")
+	require.ErrorIs(t, err, e.ErrWebhooksInternal)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	repo, _, svc := setupRepoWebhooksTest(t)
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+
+	repo.EXPECT().DelWebhook(gomock.Any(), userID, "id").Return(nil)
+	require.NoError(t, svc.Unsubscribe(ctx, "id"))
+
+	repo.EXPECT().DelWebhook(gomock.Any(), userID, "id").Return(e.ErrWebhookNotFound)
+	require.ErrorIs(t, svc.Unsubscribe(ctx, "id"), e.ErrWebhookNotFound)
+
+	repo.EXPECT().DelWebhook(gomock.Any(), userID, "id").Return(e.ErrTestGeneral)
+	require.ErrorIs(t, svc.Unsubscribe(ctx, "id"), e.ErrWebhooksInternal)
+}
+
+func TestGetDeadDeliveries(t *testing.T) {
+	t.Parallel()
+
+	repo, _, svc := setupRepoWebhooksTest(t)
+	userID := domain.NewUserID()
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID)
+
+	hook, err := domain.NewWebhook(userID, "https://crm.example.com/hooks", "")
+	require.NoError(t, err)
+
+	event := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", userID)
+	dead := domain.NewWebhookDelivery(hook, &event, []byte(`{}`))
+	dead.Status = domain.DeliveryDead
+
+	repo.EXPECT().GetUserWebhooks(gomock.Any(), userID).Return([]domain.Webhook{*hook}, nil).Times(2)
+	repo.EXPECT().
+		GetWebhookDeliveries(gomock.Any(), hook, domain.DeliveryDead, webhooks.MaxDeadDeliveries).
+		Return([]domain.WebhookDelivery{dead}, nil)
+
+	deliveries, err := svc.GetDeadDeliveries(ctx, hook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{dead}, deliveries)
+
+	_, err = svc.GetDeadDeliveries(ctx, "unknown")
+	require.ErrorIs(t, err, e.ErrWebhookNotFound)
+
+	repo.EXPECT().GetUserWebhooks(gomock.Any(), userID).Return(nil, e.ErrTestGeneral)
+
+	_, err = svc.GetDeadDeliveries(ctx, hook.ID)
+	require.ErrorIs(t, err, e.ErrWebhooksInternal)
+}
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	repo, _, svc := setupRepoWebhooksTest(t)
+	owner, stranger := domain.NewUserID(), domain.NewUserID()
+
+	hook1, err := domain.NewWebhook(owner, "https://crm.example.com/first", "")
+	require.NoError(t, err)
+	hook2, err := domain.NewWebhook(owner, "https://crm.example.com/second", "")
+	require.NoError(t, err)
+
+	created := domain.NewWebhookEvent(domain.WebhookLinkCreated, "slug1", owner)
+	created.OriginalURL = "https://example.com/"
+	deleted := domain.NewWebhookEvent(domain.WebhookLinkDeleted, "slug2", owner)
+	ignored := domain.NewWebhookEvent(domain.WebhookLinkDeleted, "slug3", stranger)
+
+	repo.EXPECT().GetUserWebhooks(gomock.Any(), owner).Return([]domain.Webhook{*hook1, *hook2}, nil)
+	repo.EXPECT().GetUserWebhooks(gomock.Any(), stranger).Return([]domain.Webhook{}, nil)
+	repo.EXPECT().
+		AddWebhookDeliveries(gomock.Any(), gomock.Len(4)).
+		DoAndReturn(func(_ context.Context, deliveries []domain.WebhookDelivery) error {
+			assert.Equal(t, hook1.ID, deliveries[0].Webhook.ID)
+			assert.Equal(t, hook2.ID, deliveries[1].Webhook.ID)
+			assert.Equal(t, deleted.ID, deliveries[3].EventID)
+
+			var payload dto.WebhookEvent
+			require.NoError(t, easyjson.Unmarshal(deliveries[0].Payload, &payload))
+			assert.Equal(t, domain.WebhookLinkCreated, payload.Type)
+			assert.Equal(t, created.OriginalURL, payload.OriginalURL)
+			assert.Equal(t, "http://localhost:8080/slug1", payload.ShortURL)
+			assert.Equal(t, owner.String(), payload.UserID)
+
+			return nil
+		})
+
+	svc.Notify(context.Background(), created, ignored, deleted)
+
+	// nothing is queued when webhooks fail to load
+	repo.EXPECT().GetUserWebhooks(gomock.Any(), stranger).Return(nil, e.ErrTestGeneral)
+	svc.Notify(context.Background(), ignored)
+	svc.Notify(context.Background())
+}
@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/domain"
+)
+
+const errLabel = "webhooks"
+
+// MaxDeadDeliveries is the maximum number of latest dead-lettered deliveries reported per webhook.
+const MaxDeadDeliveries = 100
+
+// Headers of webhook deliveries.
+const (
+	HeaderEvent     = "X-Webhook-Event"     // The event type.
+	HeaderEventID   = "X-Webhook-Event-Id"  // The event ID, the same for every retry of a delivery.
+	HeaderSignature = "X-Webhook-Signature" // The sha256= prefixed HMAC-SHA256 of the body.
+)
+
+// Webhooks defines the interface for a webhooks service.
+// It includes methods for subscribing, listing and unsubscribing webhooks of the current user
+// and inspecting their dead-lettered deliveries.
+type Webhooks interface {
+	Subscribe(ctx context.Context, target domain.OriginalURL, secret string) (*domain.Webhook, error)
+	GetUserWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	Unsubscribe(ctx context.Context, id string) error
+	GetDeadDeliveries(ctx context.Context, id string) ([]domain.WebhookDelivery, error)
+}
+
+// Notifier queues link events for delivery to webhooks of the link owners.
+// Notifying is best effort and never fails the operation the events originate from.
+type Notifier interface {
+	Notify(ctx context.Context, events ...domain.WebhookEvent)
+}
+
+// NopNotifier is a Notifier which drops all events.
+type NopNotifier struct{}
+
+// Notify drops the events.
+func (NopNotifier) Notify(_ context.Context, _ ...domain.WebhookEvent) {}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/x509"
+	"os"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+// LoadCertPool loads PEM encoded certificates of CA bundles into a certificate pool, empty paths are skipped.
+func LoadCertPool(paths ...string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		bundle, err := os.ReadFile(path)
+		if err != nil {
+			return nil, e.Wrap("failed to read ca bundle", err, errLabel)
+		}
+
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, e.Wrap("failed to load ca bundle "+path, e.ErrUtilsCertPool, errLabel)
+		}
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,60 @@
+package utils_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+func TestLoadCertPool(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	garbage := filepath.Join(dir, "garbage.pem")
+
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(garbage, []byte("not a certificate"), 0o600))
+
+	pool, err := utils.LoadCertPool("", bundle)
+	require.NoError(t, err)
+	assert.False(t, pool.Equal(x509.NewCertPool()))
+
+	pool, err = utils.LoadCertPool()
+	require.NoError(t, err)
+	assert.True(t, pool.Equal(x509.NewCertPool()))
+
+	_, err = utils.LoadCertPool(bundle, garbage)
+	require.ErrorIs(t, err, e.ErrUtilsCertPool)
+
+	_, err = utils.LoadCertPool(filepath.Join(dir, "missing.pem"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
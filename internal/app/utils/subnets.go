@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"net"
+	"strings"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+)
+
+const bitsPerByte = 8
+
+// ParseSubnets parses IPv4 and IPv6 subnets in CIDR notation, single addresses stand for subnets of one address.
+// Empty values are skipped.
+func ParseSubnets(values ...string) ([]*net.IPNet, error) {
+	subnets := make([]*net.IPNet, 0, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, e.Wrap("failed to parse subnet "+value, e.ErrUtilsSubnet, errLabel)
+			}
+
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+
+			bits := len(ip) * bitsPerByte
+			subnets = append(subnets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, subnet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, e.Wrap("failed to parse subnet "+value, e.ErrUtilsSubnet, errLabel)
+		}
+
+		subnets = append(subnets, subnet)
+	}
+
+	return subnets, nil
+}
+
+// SubnetsContain reports whether any of subnets contains the ip.
+func SubnetsContain(subnets []*net.IPNet, ip net.IP) bool {
+	for _, subnet := range subnets {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
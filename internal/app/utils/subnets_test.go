@@ -0,0 +1,34 @@
+package utils_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	e "github.com/patraden/ya-practicum-go-shortly/internal/app/domain/errors"
+	"github.com/patraden/ya-practicum-go-shortly/internal/app/utils"
+)
+
+func TestParseSubnets(t *testing.T) {
+	t.Parallel()
+
+	subnets, err := utils.ParseSubnets("10.0.0.0/8", "", " 192.168.1.1 ", "2001:db8::/32", "::1")
+	require.NoError(t, err)
+	require.Len(t, subnets, 4)
+
+	assert.Equal(t, "192.168.1.1/32", subnets[1].String())
+	assert.Equal(t, "::1/128", subnets[3].String())
+
+	assert.True(t, utils.SubnetsContain(subnets, net.ParseIP("10.20.30.40")))
+	assert.True(t, utils.SubnetsContain(subnets, net.ParseIP("::ffff:192.168.1.1")))
+	assert.True(t, utils.SubnetsContain(subnets, net.ParseIP("2001:db8::1")))
+	assert.False(t, utils.SubnetsContain(subnets, net.ParseIP("192.168.1.2")))
+	assert.False(t, utils.SubnetsContain(nil, net.ParseIP("10.0.0.1")))
+
+	for _, value := range []string{"111", "10.0.0.0/33", "localhost"} {
+		_, err := utils.ParseSubnets(value)
+		require.ErrorIs(t, err, e.ErrUtilsSubnet, value)
+	}
+}
@@ -11,8 +11,9 @@ import (
 )
 
 const (
-	errLabel = "utils"
-	alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	errLabel          = "utils"
+	backoffMultiplier = 2
+	alphabet          = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 )
 
 // IsServerAddress checks if the provided address is a valid server address with the format "server:port".
@@ -30,6 +31,17 @@ func LinearBackoff(maxElapsedTime time.Duration, interval time.Duration) *backof
 	)
 }
 
+// ExponentialBackoff creates a backoff policy that doubles intervals up to maxInterval and never stops retrying.
+func ExponentialBackoff(interval time.Duration, maxInterval time.Duration) *backoff.ExponentialBackOff {
+	return backoff.NewExponentialBackOff(
+		backoff.WithMaxElapsedTime(0),
+		backoff.WithMultiplier(backoffMultiplier),
+		backoff.WithRandomizationFactor(0),
+		backoff.WithInitialInterval(interval),
+		backoff.WithMaxInterval(maxInterval),
+	)
+}
+
 // RandomString generates a random string of length n consisting of uppercase and lowercase letters.
 func RandString(n int) string {
 	bytes := make([]byte, n)
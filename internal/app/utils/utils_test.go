@@ -34,6 +34,18 @@ func TestLinearBackoff(t *testing.T) {
 	assert.Equal(t, interval, bo.InitialInterval)
 }
 
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	bo := utils.ExponentialBackoff(time.Second, 3*time.Second)
+	bo.Reset()
+
+	assert.Equal(t, time.Second, bo.NextBackOff())
+	assert.Equal(t, 2*time.Second, bo.NextBackOff())
+	assert.Equal(t, 3*time.Second, bo.NextBackOff())
+	assert.Equal(t, 3*time.Second, bo.NextBackOff())
+}
+
 func TestRandString(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  ADD COLUMN pass_query BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN pass_path  BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  DROP COLUMN IF EXISTS pass_query,
+  DROP COLUMN IF EXISTS pass_path;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  DROP COLUMN IF EXISTS password_hash;
+-- +goose StatementEnd
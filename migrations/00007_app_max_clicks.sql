@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  ADD COLUMN max_clicks BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  DROP COLUMN IF EXISTS max_clicks;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  ADD COLUMN canonical VARCHAR(2048) NULL;
+-- existing urls keep their original form as canonical one.
+UPDATE shortener.urlmapping
+SET canonical = original;
+ALTER TABLE shortener.urlmapping
+  ALTER COLUMN canonical SET NOT NULL,
+  DROP CONSTRAINT IF EXISTS urlmapping_original_key,
+  ADD CONSTRAINT urlmapping_canonical_key UNIQUE (canonical);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  DROP CONSTRAINT IF EXISTS urlmapping_canonical_key,
+  ADD CONSTRAINT urlmapping_original_key UNIQUE (original);
+ALTER TABLE shortener.urlmapping
+  DROP COLUMN IF EXISTS canonical;
+-- +goose StatementEnd
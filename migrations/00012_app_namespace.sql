@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  ADD COLUMN namespace UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE shortener.urlmapping
+  ALTER COLUMN namespace DROP DEFAULT,
+  DROP CONSTRAINT IF EXISTS urlmapping_canonical_key,
+  ADD CONSTRAINT urlmapping_namespace_canonical_key UNIQUE (namespace, canonical);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  DROP CONSTRAINT IF EXISTS urlmapping_namespace_canonical_key,
+  ADD CONSTRAINT urlmapping_canonical_key UNIQUE (canonical);
+ALTER TABLE shortener.urlmapping
+  DROP COLUMN IF EXISTS namespace;
+-- +goose StatementEnd
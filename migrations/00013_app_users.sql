@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE shortener.users (
+  user_id       UUID          PRIMARY KEY,
+  email         VARCHAR(254)  UNIQUE NOT NULL,
+  password_hash VARCHAR(60)   NOT NULL,
+  created_at    TIMESTAMP     NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shortener.users;
+-- +goose StatementEnd
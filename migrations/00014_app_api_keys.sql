@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE shortener.api_keys (
+  key_id      VARCHAR(16)   PRIMARY KEY,
+  user_id     UUID          NOT NULL,
+  name        VARCHAR(100)  NOT NULL,
+  key_hash    VARCHAR(64)   NOT NULL,
+  scopes      JSONB         NOT NULL,
+  created_at  TIMESTAMP     NOT NULL,
+  revoked     BOOLEAN       NOT NULL DEFAULT false
+);
+CREATE INDEX idx_api_keys_user_id ON shortener.api_keys (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS shortener.idx_api_keys_user_id;
+DROP TABLE IF EXISTS shortener.api_keys;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE shortener.revoked_tokens (
+  jti         VARCHAR(36)   PRIMARY KEY,
+  expires_at  TIMESTAMP     NOT NULL
+);
+CREATE INDEX idx_revoked_tokens_expires_at ON shortener.revoked_tokens (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS shortener.idx_revoked_tokens_expires_at;
+DROP TABLE IF EXISTS shortener.revoked_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE shortener.stats_daily (
+  day      DATE    PRIMARY KEY,
+  links    BIGINT  NOT NULL,
+  deleted  BIGINT  NOT NULL
+);
+CREATE TABLE shortener.stats_users (
+  user_id  UUID    PRIMARY KEY,
+  links    BIGINT  NOT NULL
+);
+CREATE INDEX idx_stats_users_links ON shortener.stats_users (links DESC);
+CREATE INDEX idx_urlmapping_expires_at ON shortener.urlmapping (expires_at)
+  WHERE expires_at IS NOT NULL AND NOT deleted;
+
+INSERT INTO shortener.stats_daily (day, links, deleted)
+SELECT created_at::DATE, COUNT(1), COUNT(1) FILTER (WHERE deleted)
+FROM shortener.urlmapping
+GROUP BY created_at::DATE;
+
+INSERT INTO shortener.stats_users (user_id, links)
+SELECT user_id, COUNT(1)
+FROM shortener.urlmapping
+WHERE user_id IS NOT NULL
+GROUP BY user_id;
+
+-- stats counters are maintained on every change of url mappings instead of scanning them.
+CREATE FUNCTION shortener.urlmapping_stats() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    UPDATE shortener.stats_daily
+    SET links = links - 1,
+        deleted = deleted - OLD.deleted::INT
+    WHERE day = OLD.created_at::DATE;
+
+    UPDATE shortener.stats_users
+    SET links = links - 1
+    WHERE user_id = OLD.user_id;
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    INSERT INTO shortener.stats_daily AS s (day, links, deleted)
+    VALUES (NEW.created_at::DATE, 1, NEW.deleted::INT)
+    ON CONFLICT (day) DO UPDATE
+    SET links = s.links + 1,
+        deleted = s.deleted + EXCLUDED.deleted;
+
+    IF NEW.user_id IS NOT NULL THEN
+      INSERT INTO shortener.stats_users AS s (user_id, links)
+      VALUES (NEW.user_id, 1)
+      ON CONFLICT (user_id) DO UPDATE
+      SET links = s.links + 1;
+    END IF;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER urlmapping_stats_insert_delete
+AFTER INSERT OR DELETE ON shortener.urlmapping
+FOR EACH ROW EXECUTE FUNCTION shortener.urlmapping_stats();
+
+CREATE TRIGGER urlmapping_stats_update
+AFTER UPDATE OF user_id, created_at, deleted ON shortener.urlmapping
+FOR EACH ROW
+WHEN (OLD.user_id IS DISTINCT FROM NEW.user_id
+  OR OLD.created_at IS DISTINCT FROM NEW.created_at
+  OR OLD.deleted IS DISTINCT FROM NEW.deleted)
+EXECUTE FUNCTION shortener.urlmapping_stats();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS urlmapping_stats_update ON shortener.urlmapping;
+DROP TRIGGER IF EXISTS urlmapping_stats_insert_delete ON shortener.urlmapping;
+DROP FUNCTION IF EXISTS shortener.urlmapping_stats();
+DROP INDEX IF EXISTS shortener.idx_urlmapping_expires_at;
+DROP INDEX IF EXISTS shortener.idx_stats_users_links;
+DROP TABLE IF EXISTS shortener.stats_users;
+DROP TABLE IF EXISTS shortener.stats_daily;
+-- +goose StatementEnd
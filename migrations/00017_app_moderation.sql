@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shortener.urlmapping
+  ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN disabled_reason TEXT NOT NULL DEFAULT '';
+CREATE TABLE shortener.user_bans (
+  user_id     UUID          PRIMARY KEY,
+  reason      TEXT          NOT NULL,
+  created_at  TIMESTAMP     NOT NULL
+);
+CREATE TABLE shortener.moderation_log (
+  id          BIGSERIAL     PRIMARY KEY,
+  action      VARCHAR(16)   NOT NULL,
+  slug        VARCHAR(8)    NOT NULL,
+  user_id     UUID          NOT NULL,
+  actor       VARCHAR(64)   NOT NULL,
+  reason      TEXT          NOT NULL,
+  created_at  TIMESTAMP     NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shortener.moderation_log;
+DROP TABLE IF EXISTS shortener.user_bans;
+ALTER TABLE shortener.urlmapping
+  DROP COLUMN IF EXISTS disabled_reason,
+  DROP COLUMN IF EXISTS disabled;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE shortener.audit_log (
+  id          BIGSERIAL     PRIMARY KEY,
+  action      VARCHAR(16)   NOT NULL,
+  slug        VARCHAR(8)    NOT NULL,
+  user_id     UUID          NOT NULL,
+  protocol    VARCHAR(8)    NOT NULL,
+  client_ip   VARCHAR(45)   NOT NULL,
+  details     TEXT          NOT NULL,
+  created_at  TIMESTAMP     NOT NULL
+);
+CREATE INDEX idx_audit_log_slug ON shortener.audit_log (slug, id DESC);
+CREATE INDEX idx_audit_log_user_id ON shortener.audit_log (user_id, id DESC);
+
+-- audit log records are append-only.
+CREATE FUNCTION shortener.audit_log_immutable() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_immutable
+BEFORE UPDATE OR DELETE ON shortener.audit_log
+FOR EACH ROW EXECUTE FUNCTION shortener.audit_log_immutable();
+
+CREATE TRIGGER audit_log_immutable_truncate
+BEFORE TRUNCATE ON shortener.audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION shortener.audit_log_immutable();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shortener.audit_log;
+DROP FUNCTION IF EXISTS shortener.audit_log_immutable();
+-- +goose StatementEnd
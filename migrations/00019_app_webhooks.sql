@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE shortener.webhooks (
+  webhook_id  VARCHAR(16)   PRIMARY KEY,
+  user_id     UUID          NOT NULL,
+  url         TEXT          NOT NULL,
+  secret      VARCHAR(256)  NOT NULL,
+  created_at  TIMESTAMP     NOT NULL
+);
+CREATE INDEX idx_webhooks_user_id ON shortener.webhooks (user_id);
+
+-- outbox of webhook deliveries, dead-lettered deliveries are kept for inspection.
+CREATE TABLE shortener.webhook_deliveries (
+  id               BIGSERIAL     PRIMARY KEY,
+  webhook_id       VARCHAR(16)   NOT NULL REFERENCES shortener.webhooks (webhook_id) ON DELETE CASCADE,
+  event_id         VARCHAR(36)   NOT NULL,
+  event_type       VARCHAR(32)   NOT NULL,
+  payload          JSONB         NOT NULL,
+  status           VARCHAR(16)   NOT NULL,
+  attempts         INTEGER       NOT NULL,
+  next_attempt_at  TIMESTAMP     NOT NULL,
+  last_error       TEXT          NOT NULL,
+  created_at       TIMESTAMP     NOT NULL
+);
+CREATE INDEX idx_webhook_deliveries_due ON shortener.webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook_id ON shortener.webhook_deliveries (webhook_id, status, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shortener.webhook_deliveries;
+DROP INDEX IF EXISTS shortener.idx_webhooks_user_id;
+DROP TABLE IF EXISTS shortener.webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- transactional outbox of URL mapping events, events are deleted once published.
+CREATE TABLE shortener.outbox (
+  id            BIGSERIAL     PRIMARY KEY,
+  event_id      VARCHAR(36)   NOT NULL,
+  event_type    VARCHAR(32)   NOT NULL,
+  slug          VARCHAR(8)    NOT NULL,
+  user_id       UUID          NOT NULL,
+  original      TEXT          NOT NULL,
+  created_at    TIMESTAMP     NOT NULL,
+  available_at  TIMESTAMP     NOT NULL
+);
+CREATE INDEX idx_outbox_available_at ON shortener.outbox (available_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS shortener.idx_outbox_available_at;
+DROP TABLE IF EXISTS shortener.outbox;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- urls backfilled by 00011 kept their original form as canonical one.
+-- canonicalization lives in the app, which canonicalizes them on start
+-- and removes them from this table one by one.
+CREATE TABLE shortener.canonical_backfill (
+  slug  VARCHAR(8)  PRIMARY KEY REFERENCES shortener.urlmapping (slug) ON DELETE CASCADE
+);
+INSERT INTO shortener.canonical_backfill (slug)
+SELECT slug
+FROM shortener.urlmapping
+WHERE canonical = original;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shortener.canonical_backfill;
+-- +goose StatementEnd
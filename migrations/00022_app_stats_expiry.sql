@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+-- not deleted url mappings by the day they expire on, so expired ones are counted
+-- from the aggregate for past days and with the expires_at index for the current one.
+CREATE TABLE shortener.stats_expiry (
+  day    DATE    PRIMARY KEY,
+  links  BIGINT  NOT NULL
+);
+
+INSERT INTO shortener.stats_expiry (day, links)
+SELECT expires_at::DATE, COUNT(1)
+FROM shortener.urlmapping
+WHERE expires_at IS NOT NULL AND NOT deleted
+GROUP BY expires_at::DATE;
+
+CREATE FUNCTION shortener.urlmapping_stats_expiry() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.expires_at IS NOT NULL AND NOT OLD.deleted THEN
+    UPDATE shortener.stats_expiry
+    SET links = links - 1
+    WHERE day = OLD.expires_at::DATE;
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.expires_at IS NOT NULL AND NOT NEW.deleted THEN
+    INSERT INTO shortener.stats_expiry AS s (day, links)
+    VALUES (NEW.expires_at::DATE, 1)
+    ON CONFLICT (day) DO UPDATE
+    SET links = s.links + 1;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER urlmapping_stats_expiry_insert_delete
+AFTER INSERT OR DELETE ON shortener.urlmapping
+FOR EACH ROW EXECUTE FUNCTION shortener.urlmapping_stats_expiry();
+
+CREATE TRIGGER urlmapping_stats_expiry_update
+AFTER UPDATE OF expires_at, deleted ON shortener.urlmapping
+FOR EACH ROW
+WHEN (OLD.expires_at IS DISTINCT FROM NEW.expires_at
+  OR OLD.deleted IS DISTINCT FROM NEW.deleted)
+EXECUTE FUNCTION shortener.urlmapping_stats_expiry();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS urlmapping_stats_expiry_update ON shortener.urlmapping;
+DROP TRIGGER IF EXISTS urlmapping_stats_expiry_insert_delete ON shortener.urlmapping;
+DROP FUNCTION IF EXISTS shortener.urlmapping_stats_expiry();
+DROP TABLE IF EXISTS shortener.stats_expiry;
+-- +goose StatementEnd
@@ -1,27 +1,37 @@
 -- name: GetURLMapping :one
-SELECT slug, original, user_id, created_at, expires_at, deleted
+SELECT slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
 FROM shortener.urlmapping
 WHERE slug = $1;
 
 -- name: GetUserURLMappings :many
-SELECT slug, original, user_id, created_at, expires_at, deleted
+SELECT slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
 FROM shortener.urlmapping
 WHERE user_id =$1;
 
 -- name: AddURLMapping :one
-INSERT INTO shortener.urlmapping (slug, original, user_id, created_at, expires_at, deleted)
-VALUES ($1, $2, $3, $4, $5, $6)
-ON CONFLICT (original) DO UPDATE
+INSERT INTO shortener.urlmapping (slug, original, user_id, created_at, expires_at, deleted, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT (namespace, canonical) DO UPDATE
 SET slug = shortener.urlmapping.slug,
     user_id = shortener.urlmapping.user_id,
     created_at = shortener.urlmapping.created_at,
     expires_at = shortener.urlmapping.expires_at,
-    deleted = shortener.urlmapping.deleted
-RETURNING slug, original, user_id, created_at, expires_at, deleted;
+    deleted = shortener.urlmapping.deleted,
+    redirect_type = shortener.urlmapping.redirect_type,
+    pass_query = shortener.urlmapping.pass_query,
+    pass_path = shortener.urlmapping.pass_path,
+    password_hash = shortener.urlmapping.password_hash,
+    max_clicks = shortener.urlmapping.max_clicks,
+    active_from = shortener.urlmapping.active_from,
+    rules = shortener.urlmapping.rules,
+    variants = shortener.urlmapping.variants,
+    canonical = shortener.urlmapping.canonical,
+    namespace = shortener.urlmapping.namespace
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason;
 
 -- name: AddURLMappingBatchCopy :copyfrom
-INSERT INTO shortener.urlmapping (slug, original, user_id, created_at, expires_at, deleted)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO shortener.urlmapping (slug, original, user_id, created_at, expires_at, deleted, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
 
 -- name: CreateDeletedSlugTempTable :exec
 CREATE TEMP TABLE urlmapping_tmp (
@@ -33,15 +43,301 @@ CREATE TEMP TABLE urlmapping_tmp (
 INSERT INTO urlmapping_tmp (slug, user_id)
 VALUES ($1, $2);
 
--- name: DeleteSlugsInTarget :exec
+-- name: DeleteSlugsInTarget :many
 UPDATE shortener.urlmapping
 SET deleted = true
 FROM urlmapping_tmp
 WHERE shortener.urlmapping.slug = urlmapping_tmp.slug
-  AND shortener.urlmapping.user_id = urlmapping_tmp.user_id;
+  AND shortener.urlmapping.user_id = urlmapping_tmp.user_id
+  AND NOT shortener.urlmapping.deleted
+RETURNING shortener.urlmapping.slug, shortener.urlmapping.user_id;
+
+-- name: RegisterClick :one
+UPDATE shortener.urlmapping
+SET clicks = clicks + 1,
+    variants = CASE
+      WHEN sqlc.arg(variant)::INT > 0 THEN jsonb_set(
+        variants,
+        ARRAY[(sqlc.arg(variant)::INT - 1)::TEXT, 'clicks'],
+        to_jsonb(COALESCE((variants -> (sqlc.arg(variant)::INT - 1) ->> 'clicks')::BIGINT, 0) + 1))
+      ELSE variants
+    END
+WHERE slug = $1
+  AND (max_clicks = 0 OR clicks < max_clicks)
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason;
 
 -- name: GetStats :one
-SELECT 
-  COUNT(1)::BIGINT AS CountSlugs,
-  COUNT(DISTINCT user_id)::BIGINT AS CountUsers
-FROM shortener.urlmapping;
\ No newline at end of file
+SELECT
+  COALESCE(SUM(d.links), 0)::BIGINT AS CountSlugs,
+  COALESCE(SUM(d.deleted), 0)::BIGINT AS CountDeleted,
+  (
+    SELECT COUNT(1)
+    FROM shortener.stats_users AS u
+    WHERE u.links > 0
+  )::BIGINT AS CountUsers,
+  (
+    SELECT COALESCE(SUM(x.links), 0)
+    FROM shortener.stats_expiry AS x
+    WHERE x.day < sqlc.arg(now)::TIMESTAMP::DATE
+  )::BIGINT + (
+    SELECT COUNT(1)
+    FROM shortener.urlmapping AS m
+    WHERE m.expires_at >= sqlc.arg(now)::TIMESTAMP::DATE
+      AND m.expires_at <= sqlc.arg(now)::TIMESTAMP
+      AND NOT m.deleted
+  )::BIGINT AS CountExpired,
+  pg_total_relation_size('shortener.urlmapping')::BIGINT AS StorageBytes
+FROM shortener.stats_daily AS d;
+
+-- name: GetStatsDaily :many
+SELECT day, links, deleted
+FROM shortener.stats_daily
+WHERE day >= $1
+ORDER BY day;
+
+-- name: GetStatsTopUsers :many
+SELECT user_id, links
+FROM shortener.stats_users
+WHERE links > 0
+ORDER BY links DESC, user_id
+LIMIT $1;
+
+-- name: UpdateURLMappingSchedule :one
+UPDATE shortener.urlmapping
+SET active_from = $3,
+    expires_at = $4
+WHERE slug = $1
+  AND user_id = $2
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason;
+
+-- name: UpdateURLMappingRules :one
+UPDATE shortener.urlmapping
+SET rules = $3
+WHERE slug = $1
+  AND user_id = $2
+RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason;
+
+-- name: ReassignUserURLMappings :execrows
+UPDATE shortener.urlmapping AS m
+SET user_id = sqlc.arg(to_user),
+    namespace = CASE WHEN m.namespace = sqlc.arg(from_user) THEN sqlc.arg(to_user) ELSE m.namespace END
+WHERE m.user_id = sqlc.arg(from_user)
+  AND NOT (m.namespace = sqlc.arg(from_user) AND EXISTS (
+    SELECT 1
+    FROM shortener.urlmapping AS o
+    WHERE o.namespace = sqlc.arg(to_user)
+      AND o.canonical = m.canonical
+  ));
+
+-- name: ModerateURLMapping :one
+WITH moderated AS (
+  UPDATE shortener.urlmapping
+  SET disabled = sqlc.arg(disabled),
+      disabled_reason = sqlc.arg(disabled_reason)
+  WHERE slug = sqlc.arg(slug)
+  RETURNING slug, original, user_id, created_at, expires_at, deleted, clicks, redirect_type, pass_query, pass_path, password_hash, max_clicks, active_from, rules, variants, canonical, namespace, disabled, disabled_reason
+), logged AS (
+  INSERT INTO shortener.moderation_log (action, slug, user_id, actor, reason, created_at)
+  SELECT sqlc.arg(action)::VARCHAR, m.slug, m.user_id, sqlc.arg(actor)::VARCHAR, sqlc.arg(reason)::TEXT, sqlc.arg(created_at)::TIMESTAMP
+  FROM moderated AS m
+  RETURNING id
+)
+SELECT m.slug, m.original, m.user_id, m.created_at, m.expires_at, m.deleted, m.clicks, m.redirect_type, m.pass_query, m.pass_path, m.password_hash, m.max_clicks, m.active_from, m.rules, m.variants, m.canonical, m.namespace, m.disabled, m.disabled_reason, l.id AS log_id
+FROM moderated AS m, logged AS l;
+
+-- name: BanUser :one
+WITH banned AS (
+  INSERT INTO shortener.user_bans (user_id, reason, created_at)
+  VALUES (sqlc.arg(user_id), sqlc.arg(reason), sqlc.arg(created_at))
+  ON CONFLICT (user_id) DO UPDATE
+  SET reason = EXCLUDED.reason,
+      created_at = EXCLUDED.created_at
+)
+INSERT INTO shortener.moderation_log (action, slug, user_id, actor, reason, created_at)
+VALUES (sqlc.arg(action), '', sqlc.arg(user_id), sqlc.arg(actor), sqlc.arg(reason), sqlc.arg(created_at))
+RETURNING id;
+
+-- name: UnbanUser :one
+WITH unbanned AS (
+  DELETE FROM shortener.user_bans
+  WHERE user_id = sqlc.arg(user_id)
+)
+INSERT INTO shortener.moderation_log (action, slug, user_id, actor, reason, created_at)
+VALUES (sqlc.arg(action), '', sqlc.arg(user_id), sqlc.arg(actor), sqlc.arg(reason), sqlc.arg(created_at))
+RETURNING id;
+
+-- name: IsUserBanned :one
+SELECT EXISTS (
+  SELECT 1
+  FROM shortener.user_bans
+  WHERE user_id = $1
+);
+
+-- name: GetModerationLog :many
+SELECT id, action, slug, user_id, actor, reason, created_at
+FROM shortener.moderation_log
+ORDER BY id DESC
+LIMIT $1;
+
+-- name: AddAuditEvents :copyfrom
+INSERT INTO shortener.audit_log (action, slug, user_id, protocol, client_ip, details, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetAuditEvents :many
+SELECT id, action, slug, user_id, protocol, client_ip, details, created_at
+FROM shortener.audit_log
+WHERE (sqlc.arg(slug)::VARCHAR = '' OR slug = sqlc.arg(slug))
+  AND (sqlc.arg(any_user)::BOOLEAN OR user_id = sqlc.arg(user_id))
+ORDER BY id DESC
+LIMIT sqlc.arg(max_events);
+
+-- name: AddUser :exec
+INSERT INTO shortener.users (user_id, email, password_hash, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetUser :one
+SELECT user_id, email, password_hash, created_at
+FROM shortener.users
+WHERE user_id = $1;
+
+-- name: GetUserByEmail :one
+SELECT user_id, email, password_hash, created_at
+FROM shortener.users
+WHERE email = $1;
+
+-- name: AddAPIKey :exec
+INSERT INTO shortener.api_keys (key_id, user_id, name, key_hash, scopes, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetAPIKey :one
+SELECT key_id, user_id, name, key_hash, scopes, created_at, revoked
+FROM shortener.api_keys
+WHERE key_id = $1;
+
+-- name: GetUserAPIKeys :many
+SELECT key_id, user_id, name, key_hash, scopes, created_at, revoked
+FROM shortener.api_keys
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: RevokeAPIKey :execrows
+UPDATE shortener.api_keys
+SET revoked = true
+WHERE key_id = $1
+  AND user_id = $2;
+
+-- name: AddWebhook :exec
+INSERT INTO shortener.webhooks (webhook_id, user_id, url, secret, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetUserWebhooks :many
+SELECT webhook_id, user_id, url, secret, created_at
+FROM shortener.webhooks
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: DelWebhook :execrows
+DELETE FROM shortener.webhooks
+WHERE webhook_id = $1
+  AND user_id = $2;
+
+-- name: AddWebhookDeliveries :copyfrom
+INSERT INTO shortener.webhook_deliveries (
+  webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE shortener.webhook_deliveries AS d
+SET next_attempt_at = sqlc.arg(lease_until)
+FROM shortener.webhooks AS w
+WHERE w.webhook_id = d.webhook_id
+  AND d.id IN (
+    SELECT id
+    FROM shortener.webhook_deliveries
+    WHERE status = 'pending'
+      AND next_attempt_at <= sqlc.arg(now)
+    ORDER BY next_attempt_at, id
+    LIMIT sqlc.arg(max_deliveries)
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+  d.last_error, d.created_at, w.user_id, w.url, w.secret, w.created_at AS webhook_created_at;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE shortener.webhook_deliveries
+SET status = $2,
+    attempts = $3,
+    next_attempt_at = $4,
+    last_error = $5
+WHERE id = $1;
+
+-- name: GetWebhookDeliveries :many
+SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+  d.last_error, d.created_at, w.user_id, w.url, w.secret, w.created_at AS webhook_created_at
+FROM shortener.webhook_deliveries AS d
+JOIN shortener.webhooks AS w ON w.webhook_id = d.webhook_id
+WHERE d.webhook_id = $1
+  AND w.user_id = $2
+  AND d.status = $3
+ORDER BY d.id DESC
+LIMIT $4;
+
+-- name: AddOutboxEvents :copyfrom
+INSERT INTO shortener.outbox (event_id, event_type, slug, user_id, original, created_at, available_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ClaimOutboxEvents :many
+UPDATE shortener.outbox
+SET available_at = sqlc.arg(lease_until)
+WHERE id IN (
+  SELECT id
+  FROM shortener.outbox
+  WHERE available_at <= sqlc.arg(now)
+  ORDER BY id
+  LIMIT sqlc.arg(max_events)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event_id, event_type, slug, user_id, original, created_at, available_at;
+
+-- name: DelOutboxEvents :exec
+DELETE FROM shortener.outbox
+WHERE id = ANY(sqlc.arg(ids)::BIGINT[]);
+
+-- name: RevokeToken :exec
+INSERT INTO shortener.revoked_tokens (jti, expires_at)
+VALUES ($1, $2)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS (
+  SELECT 1
+  FROM shortener.revoked_tokens
+  WHERE jti = $1
+);
+
+-- name: DeleteExpiredTokens :execrows
+DELETE FROM shortener.revoked_tokens
+WHERE expires_at < $1;
+
+-- name: GetCanonicalBackfill :many
+SELECT u.slug, u.original, u.canonical, u.namespace, u.created_at
+FROM shortener.canonical_backfill b
+JOIN shortener.urlmapping u ON u.slug = b.slug
+ORDER BY u.created_at, u.slug
+LIMIT $1;
+
+-- name: GetCanonicalHolder :one
+SELECT slug, created_at
+FROM shortener.urlmapping
+WHERE namespace = $1
+  AND canonical = $2;
+
+-- name: UpdateURLMappingCanonical :exec
+UPDATE shortener.urlmapping
+SET canonical = $2
+WHERE slug = $1;
+
+-- name: DelCanonicalBackfill :exec
+DELETE FROM shortener.canonical_backfill
+WHERE slug = $1;